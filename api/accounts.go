@@ -0,0 +1,159 @@
+package api
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/db"
+)
+
+// MaxAccountsInBatch limits the number of addresses and descriptors in one batch query
+const MaxAccountsInBatch = 200
+
+// maxAccountsBatchWork limits the work of one batch query, an address costs 1, an XPUB descriptor xpubBatchWork
+const maxAccountsBatchWork = 400
+
+// xpubBatchWork is the cost of an XPUB descriptor in a batch query, the descriptor derives and loads at least gap addresses
+const xpubBatchWork = 20
+
+// accountsBatchConcurrency limits the number of the entries of one batch query processed concurrently
+const accountsBatchConcurrency = 8
+
+// accountsBatchPlan is the plan of a batch query: the unique entries, the addresses read from the index
+// by one MultiGet and the work of the query
+type accountsBatchPlan struct {
+	// first maps the descriptor to the index of its first occurrence, the duplicates are resolved once
+	first map[string]int
+	// addrIndexes are the indexes of the entries which are plain addresses, addrDescs are their descriptors
+	addrIndexes []int
+	addrDescs   []bchain.AddressDescriptor
+	work        int
+}
+
+// planAccountsBatch splits the batch query to the plain addresses and the XPUB descriptors (Bitcoin type) or names
+// resolved by the backend (ENS) and computes the work of the query
+func (w *Worker) planAccountsBatch(descriptors []string) *accountsBatchPlan {
+	p := &accountsBatchPlan{first: make(map[string]int, len(descriptors))}
+	for i, d := range descriptors {
+		if _, found := p.first[d]; found {
+			continue
+		}
+		p.first[d] = i
+		if addrDesc, _, err := w.getAddrDescAndNormalizeAddress(d); err == nil {
+			p.addrIndexes = append(p.addrIndexes, i)
+			p.addrDescs = append(p.addrDescs, addrDesc)
+			p.work++
+		} else if w.chainType == bchain.ChainBitcoinType {
+			p.work += xpubBatchWork
+		} else {
+			p.work++
+		}
+	}
+	return p
+}
+
+// prefetchAccountsBatch reads the index data of the plain addresses of the batch query by one MultiGet
+func (w *Worker) prefetchAccountsBatch(p *accountsBatchPlan) (map[int]*addressPrefetch, error) {
+	prefetch := make(map[int]*addressPrefetch, len(p.addrIndexes))
+	if len(p.addrDescs) == 0 {
+		return prefetch, nil
+	}
+	if w.chainType == bchain.ChainEthereumType {
+		w.work.addDBReads(len(p.addrDescs))
+		contracts, err := w.db.MultiGetAddrDescContracts(p.addrDescs)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range p.addrIndexes {
+			prefetch[i] = &addressPrefetch{contracts: contracts[j]}
+		}
+	} else {
+		w.work.addDBReads(len(p.addrDescs))
+		balances, err := w.db.MultiGetAddrDescBalance(p.addrDescs, db.AddressBalanceDetailNoUTXO)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range p.addrIndexes {
+			prefetch[i] = &addressPrefetch{balance: balances[j]}
+		}
+	}
+	return prefetch, nil
+}
+
+// GetAccountsInfo returns the balances, transaction counts and tokens of the addresses and XPUB descriptors,
+// the entries which cannot be loaded have their own errors. Transaction history is not supported.
+func (w *Worker) GetAccountsInfo(descriptors []string, option AccountDetails, filter *AddressFilter, gap int, secondaryCoin string) (*AccountsInfo, error) {
+	start := time.Now()
+	if len(descriptors) == 0 {
+		return nil, NewAPIError("Missing addresses", true)
+	}
+	if len(descriptors) > MaxAccountsInBatch {
+		return nil, NewAPIError(fmt.Sprintf("Too many addresses, max %d", MaxAccountsInBatch), true)
+	}
+	if option > AccountDetailsTokenBalances {
+		return nil, NewAPIError("Transaction history is not supported by batch queries, use details basic, tokens or tokenBalances", true)
+	}
+	p := w.planAccountsBatch(descriptors)
+	if p.work > maxAccountsBatchWork {
+		return nil, NewAPIError(fmt.Sprintf("Too many addresses, an XPUB descriptor counts as %d addresses, max %d", xpubBatchWork, maxAccountsBatchWork), true)
+	}
+	prefetch, err := w.prefetchAccountsBatch(p)
+	if err != nil {
+		return nil, err
+	}
+	r := &AccountsInfo{Accounts: make([]AccountsInfoEntry, len(descriptors))}
+	sem := make(chan struct{}, accountsBatchConcurrency)
+	var wg sync.WaitGroup
+	for _, i := range p.first {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(e *AccountsInfoEntry, descriptor string, prefetch *addressPrefetch) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					glog.Error("GetAccountsInfo ", descriptor, " recovered from panic: ", rec)
+					debug.PrintStack()
+					e.Error = "Internal server error"
+				}
+				<-sem
+				wg.Done()
+			}()
+			// the address functions may modify the filter
+			f := *filter
+			// the diagnostics need several backend calls per address, they are not part of the batch queries
+			f.WithDiagnostics = false
+			var a *Address
+			var err error
+			if prefetch != nil {
+				a, err = w.getAddress(descriptor, 0, 1, option, &f, secondaryCoin, prefetch)
+			} else if w.chainType == bchain.ChainBitcoinType {
+				a, err = w.GetXpubAddress(descriptor, 0, 1, option, &f, gap, secondaryCoin)
+			} else {
+				a, err = w.GetAddress(descriptor, 0, 1, option, &f, secondaryCoin)
+			}
+			if err != nil {
+				if apiErr, ok := err.(*APIError); ok && apiErr.Public {
+					e.Error = apiErr.Error()
+				} else {
+					glog.Warning("GetAccountsInfo ", descriptor, ": ", err)
+					e.Error = "Internal server error"
+				}
+				return
+			}
+			e.Account = a
+		}(&r.Accounts[i], descriptors[i], prefetch[i])
+	}
+	wg.Wait()
+	for i, d := range descriptors {
+		r.Accounts[i].Descriptor = d
+		if j := p.first[d]; j != i {
+			r.Accounts[i].Account = r.Accounts[j].Account
+			r.Accounts[i].Error = r.Accounts[j].Error
+		}
+	}
+	glog.V(1).Info("GetAccountsInfo ", len(descriptors), " entries, work ", p.work, ", ", time.Since(start))
+	return r, nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/btc"
+)
+
+func Test_planAccountsBatch(t *testing.T) {
+	w := &Worker{
+		chainParser: btc.NewBitcoinParser(btc.GetChainParams("main"), &btc.Configuration{}),
+		chainType:   bchain.ChainBitcoinType,
+	}
+	xpub := "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj"
+	p := w.planAccountsBatch([]string{
+		"1JKgN43B9SyLuZH19H5ECvr4KcfrbVHzZ6",
+		xpub,
+		"1JKgN43B9SyLuZH19H5ECvr4KcfrbVHzZ6",
+		"1HY6bKYhFH7HF3F48ikvziPHLrEWPGwXcE",
+	})
+	if len(p.first) != 3 || p.first["1JKgN43B9SyLuZH19H5ECvr4KcfrbVHzZ6"] != 0 || p.first[xpub] != 1 || p.first["1HY6bKYhFH7HF3F48ikvziPHLrEWPGwXcE"] != 3 {
+		t.Errorf("first = %v", p.first)
+	}
+	if len(p.addrIndexes) != 2 || p.addrIndexes[0] != 0 || p.addrIndexes[1] != 3 || len(p.addrDescs) != 2 {
+		t.Errorf("addrIndexes = %v, addrDescs = %v", p.addrIndexes, p.addrDescs)
+	}
+	if want := 2 + xpubBatchWork; p.work != want {
+		t.Errorf("work = %d, want %d", p.work, want)
+	}
+
+	tooMuchWork := make([]string, 0, MaxAccountsInBatch)
+	for i := 0; i <= maxAccountsBatchWork/xpubBatchWork; i++ {
+		tooMuchWork = append(tooMuchWork, xpub[:len(xpub)-1]+string(rune('a'+i)))
+	}
+	tests := []struct {
+		name        string
+		descriptors []string
+		option      AccountDetails
+		wantErr     string
+	}{
+		{name: "empty", wantErr: "Missing addresses"},
+		{name: "too many entries", descriptors: make([]string, MaxAccountsInBatch+1), wantErr: "Too many addresses"},
+		{name: "transaction history", descriptors: []string{xpub}, option: AccountDetailsTxidHistory, wantErr: "Transaction history is not supported"},
+		{name: "too much work", descriptors: tooMuchWork, wantErr: "an XPUB descriptor counts as"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := w.GetAccountsInfo(tt.descriptors, tt.option, &AddressFilter{}, 0, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("GetAccountsInfo() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
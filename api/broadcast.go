@@ -0,0 +1,319 @@
+package api
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/common"
+	"github.com/trezor/blockbook/db"
+)
+
+// broadcastCheckPeriod is the minimal period of the lookups of the confirmation of a transaction missing from the mempool,
+// it is also the time the backend has to add a newly broadcast transaction to the mempool
+var broadcastCheckPeriod = time.Minute
+
+// maxBroadcastTrackedTxs limits the number of the tracked transactions
+const maxBroadcastTrackedTxs = 10000
+
+// maxRebroadcastFailures is the number of consecutive rejected rebroadcasts after which the transaction
+// is considered invalid and is not rebroadcast anymore
+const maxRebroadcastFailures = 3
+
+// alternativeSendTxChecker is implemented by the Ethereum type chains which send the transactions through the alternative
+// providers (private relays), the transactions sent this way may be unknown to the mempool of the backend until they are mined
+type alternativeSendTxChecker interface {
+	AlternativeSendTxKnown(txid string) (bool, error)
+}
+
+type trackedBroadcast struct {
+	BroadcastTx
+	hex                   string
+	disableAlternativeRPC bool
+	firstBroadcast        time.Time
+	lastBroadcast         time.Time
+	nextCheck             time.Time
+	failures              int
+	// finished transactions do not change their status anymore
+	finished bool
+}
+
+// BroadcastTracker tracks the transactions broadcast through Blockbook and rebroadcasts them while they are missing from the mempool
+type BroadcastTracker struct {
+	db                *db.RocksDB
+	chain             bchain.BlockChain
+	chainType         bchain.ChainType
+	mempool           bchain.Mempool
+	is                *common.InternalState
+	alternative       alternativeSendTxChecker
+	retention         time.Duration
+	rebroadcastPeriod time.Duration
+	mux               sync.Mutex
+	txs               map[string]*trackedBroadcast
+	// resync signals the processing goroutine that the mempool was resynchronized
+	resync chan struct{}
+	// lookupTx returns the height of the block containing the transaction (0 if it is not confirmed)
+	// and whether the backend knows the transaction
+	lookupTx func(txid string) (uint32, bool, error)
+}
+
+// NewBroadcastTracker creates the tracker of the broadcast transactions. The transactions are tracked for the retention period
+// after their first broadcast, the missing ones are rebroadcast each rebroadcastPeriod, zero rebroadcastPeriod disables the rebroadcasting.
+func NewBroadcastTracker(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, is *common.InternalState, retention, rebroadcastPeriod time.Duration) *BroadcastTracker {
+	t := &BroadcastTracker{
+		db:                db,
+		chain:             chain,
+		chainType:         chain.GetChainParser().GetChainType(),
+		mempool:           mempool,
+		is:                is,
+		retention:         retention,
+		rebroadcastPeriod: rebroadcastPeriod,
+		txs:               make(map[string]*trackedBroadcast),
+		resync:            make(chan struct{}, 1),
+	}
+	if a, ok := chain.(alternativeSendTxChecker); ok && t.chainType == bchain.ChainEthereumType {
+		t.alternative = a
+	}
+	t.lookupTx = t.getTxState
+	go t.processLoop()
+	return t
+}
+
+// SendRawTransaction broadcasts the transaction and starts tracking it if the backend accepted it
+func (t *BroadcastTracker) SendRawTransaction(hex string, disableAlternativeRPC bool) (string, error) {
+	txid, err := t.chain.SendRawTransaction(hex, disableAlternativeRPC)
+	if err != nil {
+		return txid, err
+	}
+	t.track(txid, hex, disableAlternativeRPC, time.Now())
+	return txid, nil
+}
+
+func (t *BroadcastTracker) track(txid, hex string, disableAlternativeRPC bool, now time.Time) {
+	if txid == "" {
+		return
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if b, found := t.txs[txid]; found {
+		// the transaction was broadcast again by the user, track it as a rebroadcast
+		b.lastBroadcast = now
+		b.LastBroadcast = now.Unix()
+		b.Broadcasts++
+		return
+	}
+	if len(t.txs) >= maxBroadcastTrackedTxs {
+		t.evictFinishedLocked(now)
+	}
+	if len(t.txs) >= maxBroadcastTrackedTxs {
+		glog.Warning("BroadcastTracker: the limit of ", maxBroadcastTrackedTxs, " tracked transactions reached, tx ", txid, " is not tracked")
+		return
+	}
+	t.txs[txid] = &trackedBroadcast{
+		BroadcastTx: BroadcastTx{
+			Txid:           txid,
+			Status:         BroadcastAccepted,
+			FirstBroadcast: now.Unix(),
+			LastBroadcast:  now.Unix(),
+			Broadcasts:     1,
+			Rebroadcasting: t.rebroadcastPeriod > 0,
+		},
+		hex:                   hex,
+		disableAlternativeRPC: disableAlternativeRPC,
+		firstBroadcast:        now,
+		lastBroadcast:         now,
+		nextCheck:             now.Add(broadcastCheckPeriod),
+	}
+}
+
+// GetBroadcastTx returns the status of the tracked transaction
+func (t *BroadcastTracker) GetBroadcastTx(txid string) (*BroadcastTx, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	b, found := t.txs[txid]
+	if !found {
+		return nil, NewAPIError("Transaction '"+txid+"' is not tracked", true)
+	}
+	r := b.BroadcastTx
+	return &r, nil
+}
+
+// evictFinishedLocked removes the expired and finished transactions to make room for new ones
+func (t *BroadcastTracker) evictFinishedLocked(now time.Time) {
+	for txid, b := range t.txs {
+		if b.finished || now.Sub(b.firstBroadcast) > t.retention {
+			delete(t.txs, txid)
+		}
+	}
+}
+
+// OnMempoolResync schedules the update of the status of the tracked transactions and the rebroadcast of the missing ones,
+// the lookups in the backend run in a separate goroutine so that they do not delay the mempool synchronization
+func (t *BroadcastTracker) OnMempoolResync() {
+	select {
+	case t.resync <- struct{}{}:
+	default:
+		// the processing is already scheduled
+	}
+}
+
+func (t *BroadcastTracker) processLoop() {
+	for range t.resync {
+		t.process(time.Now())
+	}
+}
+
+func (b *trackedBroadcast) finish(status BroadcastStatus) {
+	b.Status = status
+	b.Rebroadcasting = false
+	b.finished = true
+	b.hex = ""
+}
+
+func (t *BroadcastTracker) process(now time.Time) {
+	var missing []*trackedBroadcast
+	t.mux.Lock()
+	for txid, b := range t.txs {
+		if now.Sub(b.firstBroadcast) > t.retention {
+			delete(t.txs, txid)
+			continue
+		}
+		if b.finished {
+			continue
+		}
+		if t.mempool != nil {
+			if t.mempool.GetTransactionTime(txid) != 0 {
+				b.Status = BroadcastInMempool
+				b.LastSeenInMempool = now.Unix()
+				b.failures = 0
+				continue
+			}
+			if r := t.mempool.GetTxReplacement(txid); r != nil && r.ReplacedBy != "" {
+				b.ReplacedBy = r.ReplacedBy
+				b.finish(BroadcastReplaced)
+				continue
+			}
+			if d := t.mempool.GetTxDropped(txid); d != nil && d.ConflictingTxid != "" {
+				b.ReplacedBy = d.ConflictingTxid
+				b.finish(BroadcastReplaced)
+				continue
+			}
+		}
+		if now.Before(b.nextCheck) {
+			continue
+		}
+		missing = append(missing, b)
+	}
+	t.mux.Unlock()
+	if len(missing) == 0 {
+		return
+	}
+	// the transaction missing from the mempool may be in a block which is not yet indexed
+	if t.is != nil {
+		if synchronized, _, _, _ := t.is.GetSyncState(); !synchronized {
+			return
+		}
+	}
+	// rebroadcast the transactions in the order of their first broadcast, the dependent transactions follow their parents
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].firstBroadcast.Before(missing[j].firstBroadcast)
+	})
+	for _, b := range missing {
+		t.checkMissing(b, now)
+	}
+}
+
+// checkMissing checks if the transaction missing from the mempool was confirmed, if not, rebroadcasts it
+func (t *BroadcastTracker) checkMissing(b *trackedBroadcast, now time.Time) {
+	txid := b.Txid
+	height, known, err := t.lookupTx(txid)
+	if err != nil {
+		glog.Warning("BroadcastTracker: confirmation of ", txid, ": ", err)
+		return
+	}
+	t.mux.Lock()
+	b.nextCheck = now.Add(broadcastCheckPeriod)
+	if height > 0 {
+		b.BlockHeight = height
+		b.finish(BroadcastConfirmed)
+		t.mux.Unlock()
+		return
+	}
+	hex, disableAlternativeRPC := b.hex, b.disableAlternativeRPC
+	rebroadcast := b.Rebroadcasting && now.Sub(b.lastBroadcast) >= t.rebroadcastPeriod
+	t.mux.Unlock()
+
+	if !known && t.alternative != nil && !disableAlternativeRPC {
+		// the transaction sent through the alternative providers may be known only to them
+		if known, err = t.alternative.AlternativeSendTxKnown(txid); err != nil {
+			glog.V(1).Info("BroadcastTracker: alternative providers lookup of ", txid, ": ", err)
+		}
+	}
+	if known {
+		// pending in the backend but not (yet) in the mempool of Blockbook
+		t.mux.Lock()
+		b.Status = BroadcastInMempool
+		b.LastSeenInMempool = now.Unix()
+		b.failures = 0
+		t.mux.Unlock()
+		return
+	}
+	if !rebroadcast {
+		t.mux.Lock()
+		b.Status = BroadcastDropped
+		t.mux.Unlock()
+		return
+	}
+	_, err = t.chain.SendRawTransaction(hex, disableAlternativeRPC)
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	b.lastBroadcast = now
+	b.LastBroadcast = now.Unix()
+	b.Broadcasts++
+	if err != nil {
+		glog.Info("BroadcastTracker: rebroadcast of ", txid, " failed: ", err)
+		b.LastError = err.Error()
+		b.Status = BroadcastDropped
+		b.failures++
+		if b.failures >= maxRebroadcastFailures {
+			// the backend keeps rejecting the transaction, it is most likely invalid
+			b.finish(BroadcastDropped)
+		}
+		return
+	}
+	glog.Info("BroadcastTracker: rebroadcast ", txid)
+	b.LastError = ""
+	b.Status = BroadcastAccepted
+	b.failures = 0
+}
+
+// getTxState looks up the confirmed transaction in the index (Bitcoin type) or the transaction in the backend (other chains)
+func (t *BroadcastTracker) getTxState(txid string) (uint32, bool, error) {
+	if t.chainType == bchain.ChainBitcoinType {
+		ta, err := t.db.GetTxAddresses(txid)
+		if err != nil || ta == nil {
+			return 0, false, err
+		}
+		return ta.Height, true, nil
+	}
+	tx, err := t.chain.GetTransaction(txid)
+	if err != nil {
+		if err == bchain.ErrTxNotFound {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if tx.Confirmations == 0 {
+		return 0, true, nil
+	}
+	if tx.BlockHeight != 0 {
+		return tx.BlockHeight, true, nil
+	}
+	bestHeight, err := t.chain.GetBestBlockHeight()
+	if err != nil {
+		return 0, true, err
+	}
+	return bestHeight - tx.Confirmations + 1, true, nil
+}
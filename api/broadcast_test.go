@@ -0,0 +1,160 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+type broadcastTestChain struct {
+	bchain.BlockChain
+	sent []string
+	err  error
+}
+
+func (c *broadcastTestChain) SendRawTransaction(hex string, disableAlternativeRPC bool) (string, error) {
+	c.sent = append(c.sent, hex)
+	return hex, c.err
+}
+
+type broadcastTestMempool struct {
+	bchain.Mempool
+	txs      map[string]uint32
+	replaced map[string]string
+}
+
+func (m *broadcastTestMempool) GetTransactionTime(txid string) uint32 {
+	return m.txs[txid]
+}
+
+func (m *broadcastTestMempool) GetTxReplacement(txid string) *bchain.MempoolTxReplacement {
+	if r, found := m.replaced[txid]; found {
+		return &bchain.MempoolTxReplacement{ReplacedBy: r}
+	}
+	return nil
+}
+
+func (m *broadcastTestMempool) GetTxDropped(txid string) *bchain.MempoolTxDropped {
+	return nil
+}
+
+func TestBroadcastTracker(t *testing.T) {
+	chain := &broadcastTestChain{}
+	mempool := &broadcastTestMempool{txs: map[string]uint32{"inmempool": 1}, replaced: map[string]string{"replaced": "replacement"}}
+	confirmed := map[string]uint32{"confirmed": 100}
+	tracker := &BroadcastTracker{
+		chain:             chain,
+		mempool:           mempool,
+		retention:         time.Hour,
+		rebroadcastPeriod: 10 * time.Minute,
+		txs:               make(map[string]*trackedBroadcast),
+		lookupTx: func(txid string) (uint32, bool, error) {
+			h, found := confirmed[txid]
+			return h, found, nil
+		},
+	}
+	t0 := time.Unix(1700000000, 0)
+	for _, txid := range []string{"inmempool", "confirmed", "replaced", "missing"} {
+		if _, err := tracker.SendRawTransaction(txid, false); err != nil {
+			t.Fatal(err)
+		}
+		tracker.txs[txid].firstBroadcast = t0
+		tracker.txs[txid].lastBroadcast = t0
+		tracker.txs[txid].nextCheck = t0.Add(broadcastCheckPeriod)
+	}
+	chain.sent = nil
+	status := func(txid string) BroadcastTx {
+		t.Helper()
+		b, err := tracker.GetBroadcastTx(txid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *b
+	}
+
+	// the missing transaction is not checked within broadcastCheckPeriod after the broadcast
+	tracker.process(t0.Add(time.Second))
+	if s := status("missing"); s.Status != BroadcastAccepted {
+		t.Errorf("missing status = %v, want %v", s.Status, BroadcastAccepted)
+	}
+	tracker.process(t0.Add(2 * time.Minute))
+	if s := status("inmempool"); s.Status != BroadcastInMempool || s.LastSeenInMempool != t0.Add(2*time.Minute).Unix() {
+		t.Errorf("inmempool = %+v", s)
+	}
+	if s := status("confirmed"); s.Status != BroadcastConfirmed || s.BlockHeight != 100 || s.Rebroadcasting {
+		t.Errorf("confirmed = %+v", s)
+	}
+	if s := status("replaced"); s.Status != BroadcastReplaced || s.ReplacedBy != "replacement" {
+		t.Errorf("replaced = %+v", s)
+	}
+	// the rebroadcast period has not passed yet
+	if s := status("missing"); s.Status != BroadcastDropped || s.Broadcasts != 1 || !s.Rebroadcasting || len(chain.sent) != 0 {
+		t.Errorf("missing = %+v, sent %v", s, chain.sent)
+	}
+
+	tracker.process(t0.Add(11 * time.Minute))
+	if s := status("missing"); s.Status != BroadcastAccepted || s.Broadcasts != 2 || len(chain.sent) != 1 || chain.sent[0] != "missing" {
+		t.Errorf("missing after rebroadcast = %+v, sent %v", s, chain.sent)
+	}
+
+	// the transaction repeatedly rejected by the backend is not rebroadcast anymore
+	chain.err = errors.New("bad-txns-inputs-missingorspent")
+	for i := 1; i <= maxRebroadcastFailures; i++ {
+		tracker.process(t0.Add(time.Duration(11+10*i) * time.Minute))
+	}
+	s := status("missing")
+	if s.Status != BroadcastDropped || s.Rebroadcasting || s.LastError != chain.err.Error() || s.Broadcasts != 2+maxRebroadcastFailures {
+		t.Errorf("missing after rejected rebroadcasts = %+v", s)
+	}
+	tracker.process(t0.Add(55 * time.Minute))
+	if len(chain.sent) != 1+maxRebroadcastFailures {
+		t.Errorf("rebroadcasts = %d, want %d", len(chain.sent), 1+maxRebroadcastFailures)
+	}
+
+	// the transactions are forgotten after the retention period
+	tracker.process(t0.Add(61 * time.Minute))
+	if _, err := tracker.GetBroadcastTx("inmempool"); err == nil {
+		t.Error("GetBroadcastTx() of a transaction after the retention period did not fail")
+	}
+}
+
+func TestBroadcastTrackerEvictsFinished(t *testing.T) {
+	tracker := &BroadcastTracker{
+		retention: time.Hour,
+		txs:       make(map[string]*trackedBroadcast),
+	}
+	now := time.Unix(1700000000, 0)
+	for i := 0; i < maxBroadcastTrackedTxs; i++ {
+		tracker.track(strconv.Itoa(i), "", false, now)
+	}
+	tracker.track("over", "", false, now)
+	if _, err := tracker.GetBroadcastTx("over"); err == nil {
+		t.Fatal("transaction over the limit tracked")
+	}
+	// the confirmed transactions make room for the new ones
+	tracker.txs["0"].finish(BroadcastConfirmed)
+	tracker.track("new", "", false, now)
+	if _, err := tracker.GetBroadcastTx("new"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tracker.GetBroadcastTx("0"); err == nil {
+		t.Error("finished transaction not evicted")
+	}
+	if len(tracker.txs) != maxBroadcastTrackedTxs {
+		t.Errorf("%d tracked transactions, want %d", len(tracker.txs), maxBroadcastTrackedTxs)
+	}
+}
+
+func TestReplacedTx(t *testing.T) {
+	w := &Worker{mempool: &broadcastTestMempool{replaced: map[string]string{"replaced": "replacement"}}}
+	tx := w.replacedTx("replaced")
+	if tx == nil || tx.Txid != "replaced" || tx.ReplacedBy != "replacement" || tx.Vin == nil || tx.Vout == nil || tx.Blockheight != -1 {
+		t.Fatalf("replacedTx(replaced) = %+v", tx)
+	}
+	if tx = w.replacedTx("missing"); tx != nil {
+		t.Fatalf("replacedTx(missing) = %+v, want nil", tx)
+	}
+}
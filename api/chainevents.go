@@ -0,0 +1,124 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/db"
+)
+
+// maxChainEvents limits the number of the kept disconnections of the blocks and the number of the events in one response
+const maxChainEvents = 1000
+
+// ChainEventLog keeps the blocks disconnected by the reorgs, so that the clients which cached them can learn which
+// blocks and transactions they must forget. The connected blocks are read from the index.
+type ChainEventLog struct {
+	mux          sync.Mutex
+	disconnected []ChainEvent
+	// bestHeight and blockInfo read the index, the block info is nil if there is no block at the height
+	bestHeight func() (uint32, error)
+	blockInfo  func(height uint32) (*db.BlockInfo, error)
+}
+
+// NewChainEventLog creates the log of the changes of the indexed chain
+func NewChainEventLog(d *db.RocksDB) *ChainEventLog {
+	return &ChainEventLog{
+		bestHeight: func() (uint32, error) {
+			height, _, err := d.GetBestBlock()
+			return height, err
+		},
+		blockInfo: d.GetBlockInfo,
+	}
+}
+
+// OnDisconnectBlocks logs the blocks disconnected by a reorg, ordered from the highest
+func (l *ChainEventLog) OnDisconnectBlocks(blocks []bchain.DisconnectedBlock) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	for i := range blocks {
+		b := &blocks[i]
+		e := ChainEvent{
+			Type:   ChainEventDisconnect,
+			Height: b.Height,
+			Hash:   b.Hash,
+			Txids:  make([]string, len(b.Txs)),
+		}
+		for j := range b.Txs {
+			e.Txids[j] = b.Txs[j].Txid
+		}
+		l.disconnected = append(l.disconnected, e)
+	}
+	if len(l.disconnected) > maxChainEvents {
+		l.disconnected = append([]ChainEvent(nil), l.disconnected[len(l.disconnected)-maxChainEvents:]...)
+	}
+}
+
+// GetChainEvents returns the changes of the chain which the client with the block at the height since must apply to get
+// to the current chain. If the block of the client identified by the hash was disconnected, the events start with
+// the disconnections of the blocks of the client, followed by the connections of the blocks of the current chain,
+// at most maxChainEvents of them. Without the hash the block of the client is assumed to be in the current chain.
+func (l *ChainEventLog) GetChainEvents(since uint32, hash string) (*ChainEvents, error) {
+	bestHeight, err := l.bestHeight()
+	if err != nil {
+		return nil, err
+	}
+	r := &ChainEvents{BestHeight: bestHeight, Events: []ChainEvent{}}
+	if since > bestHeight {
+		// the client is ahead of the index, its blocks may have been disconnected
+		if hash == "" {
+			return r, nil
+		}
+	} else {
+		bi, err := l.blockInfo(since)
+		if err != nil {
+			return nil, err
+		}
+		if bi == nil {
+			return nil, NewAPIError("Block not found", true)
+		}
+		if hash == "" || bi.Hash == hash {
+			return l.appendConnected(r, since+1)
+		}
+	}
+	// the block of the client was disconnected, find the disconnections of it and of the lower blocks
+	l.mux.Lock()
+	found := false
+	fork := since
+	for _, e := range l.disconnected {
+		if !found && e.Height == since && e.Hash == hash {
+			found = true
+		}
+		if found && e.Height <= since {
+			r.Events = append(r.Events, e)
+			fork = min(fork, e.Height)
+		}
+	}
+	l.mux.Unlock()
+	if !found {
+		r.Events = r.Events[:0]
+		r.Resync = true
+		return r, nil
+	}
+	return l.appendConnected(r, fork)
+}
+
+// appendConnected adds the connections of the blocks of the current chain from the height
+func (l *ChainEventLog) appendConnected(r *ChainEvents, from uint32) (*ChainEvents, error) {
+	for height := from; height <= r.BestHeight && len(r.Events) < maxChainEvents; height++ {
+		bi, err := l.blockInfo(height)
+		if err != nil {
+			return nil, err
+		}
+		if bi == nil {
+			// the block was disconnected meanwhile, the client continues by the next request
+			break
+		}
+		r.Events = append(r.Events, ChainEvent{
+			Type:   ChainEventConnect,
+			Height: height,
+			Hash:   bi.Hash,
+			Time:   bi.Time,
+		})
+	}
+	return r, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/db"
+)
+
+func newTestChainEventLog(hashes map[uint32]string, best uint32) *ChainEventLog {
+	return &ChainEventLog{
+		bestHeight: func() (uint32, error) { return best, nil },
+		blockInfo: func(height uint32) (*db.BlockInfo, error) {
+			if h, ok := hashes[height]; ok && height <= best {
+				return &db.BlockInfo{Hash: h, Time: int64(height)}, nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+func chainEventKeys(events []ChainEvent) []string {
+	keys := make([]string, len(events))
+	for i := range events {
+		keys[i] = string(events[i].Type) + " " + events[i].Hash
+	}
+	return keys
+}
+
+func TestChainEventLog(t *testing.T) {
+	l := newTestChainEventLog(map[uint32]string{100: "a100", 101: "b101", 102: "b102", 103: "b103"}, 103)
+	// reorg replaced a101, a102 by b101, b102, b103
+	l.OnDisconnectBlocks([]bchain.DisconnectedBlock{
+		{Height: 102, Hash: "a102", Txs: []bchain.DisconnectedTx{{Txid: "tx2"}}},
+		{Height: 101, Hash: "a101", Txs: []bchain.DisconnectedTx{{Txid: "tx1"}}},
+	})
+	tests := []struct {
+		name   string
+		since  uint32
+		hash   string
+		want   []string
+		resync bool
+	}{
+		{name: "current chain", since: 101, hash: "b101", want: []string{"connect b102", "connect b103"}},
+		{name: "without hash", since: 102, want: []string{"connect b103"}},
+		{name: "disconnected tip", since: 102, hash: "a102", want: []string{"disconnect a102", "disconnect a101", "connect b101", "connect b102", "connect b103"}},
+		{name: "disconnected block", since: 101, hash: "a101", want: []string{"disconnect a101", "connect b101", "connect b102", "connect b103"}},
+		{name: "best block", since: 103, hash: "b103", want: []string{}},
+		{name: "unknown block", since: 101, hash: "c101", want: []string{}, resync: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.GetChainEvents(tt.since, tt.hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.BestHeight != 103 || got.Resync != tt.resync || !reflect.DeepEqual(chainEventKeys(got.Events), tt.want) {
+				t.Errorf("GetChainEvents() = %+v, want %v, resync %v", got, tt.want, tt.resync)
+			}
+		})
+	}
+	got, _ := l.GetChainEvents(102, "a102")
+	if !reflect.DeepEqual(got.Events[0].Txids, []string{"tx2"}) {
+		t.Errorf("disconnected txids %v", got.Events[0].Txids)
+	}
+	// the client ahead of the index after the reorg to a shorter chain
+	l = newTestChainEventLog(map[uint32]string{100: "a100", 101: "b101"}, 101)
+	l.OnDisconnectBlocks([]bchain.DisconnectedBlock{{Height: 102, Hash: "a102"}, {Height: 101, Hash: "a101"}})
+	got, err := l.GetChainEvents(102, "a102")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"disconnect a102", "disconnect a101", "connect b101"}; !reflect.DeepEqual(chainEventKeys(got.Events), want) {
+		t.Errorf("GetChainEvents() ahead = %v, want %v", chainEventKeys(got.Events), want)
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+// addressCursorVersion is stored in the cursors to be able to change their format
+const addressCursorVersion = 1
+
+// maxCursorNewerItems bounds the items read for a backward cursor, the clients with more newer items
+// than that must reload the history from the first page
+const maxCursorNewerItems = 100000
+
+// addressCursor points to an item of the transaction history of an address or XPUB. The item is identified by the height
+// of its block and by its position among the items of the account in the block, which do not change when new blocks arrive.
+// The forward cursor returns the items older than the item, the backward cursor the newer ones.
+type addressCursor struct {
+	height   uint32
+	index    int
+	backward bool
+}
+
+func (c *addressCursor) String() string {
+	b := make([]byte, 0, 1+2*binary.MaxVarintLen32)
+	flags := byte(addressCursorVersion << 1)
+	if c.backward {
+		flags |= 1
+	}
+	b = append(b, flags)
+	b = binary.AppendUvarint(b, uint64(c.height))
+	b = binary.AppendUvarint(b, uint64(c.index))
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// parseAddressCursor decodes the opaque cursor passed by the client, empty cursor returns nil
+func parseAddressCursor(s string) (*addressCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	errInvalid := NewAPIError("Invalid cursor", true)
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) < 3 || b[0]>>1 != addressCursorVersion {
+		return nil, errInvalid
+	}
+	c := &addressCursor{backward: b[0]&1 == 1}
+	height, l := binary.Uvarint(b[1:])
+	if l <= 0 || height > math.MaxUint32 {
+		return nil, errInvalid
+	}
+	index, l2 := binary.Uvarint(b[1+l:])
+	if l2 <= 0 || 1+l+l2 != len(b) || index > math.MaxInt32 {
+		return nil, errInvalid
+	}
+	c.height = uint32(height)
+	c.index = int(index)
+	return c, nil
+}
+
+// historyHeights are the block heights of the items of the transaction history ordered from the newest,
+// the list must contain all items of the block of its first item
+type historyHeights func(i int) uint32
+
+// blockStart returns the index of the first item in the block at the height
+func (h historyHeights) blockStart(n int, height uint32) int {
+	return sort.Search(n, func(i int) bool { return h(i) <= height })
+}
+
+// cursorAt returns the cursor pointing to the i-th item of the history
+func (h historyHeights) cursorAt(n, i int, backward bool) *addressCursor {
+	height := h(i)
+	return &addressCursor{height: height, index: i - h.blockStart(n, height), backward: backward}
+}
+
+// cursorPage returns the range [from, to) of the n items of the history which forms the page following the cursor in its direction
+func (h historyHeights) cursorPage(n int, c *addressCursor, itemsOnPage int) (int, int) {
+	start := h.blockStart(n, c.height)
+	end := sort.Search(n, func(i int) bool { return h(i) < c.height })
+	// the block may contain less items than the cursor expects after a reorg
+	pos := start + c.index
+	if c.backward {
+		to := min(pos, end)
+		return max(to-itemsOnPage, 0), to
+	}
+	from := min(pos+1, end)
+	return from, min(from+itemsOnPage, n)
+}
+
+// pageCursors returns the cursors of the page [from, to) of the n items of the history, the next cursor
+// only if there may be older items, the previous cursor always to allow the clients to continue with the newer items later
+func (h historyHeights) pageCursors(n, from, to int, more bool) (string, string) {
+	var next, prev string
+	if from < to {
+		if more {
+			next = h.cursorAt(n, to-1, false).String()
+		}
+		prev = h.cursorAt(n, from, true).String()
+	}
+	return next, prev
+}
+
+// getAddressHistoryPage returns the confirmed history of the address around the cursor and the range [from, to) of the page in it
+func (w *Worker) getAddressHistoryPage(addrDesc bchain.AddressDescriptor, filter *AddressFilter, cursor *addressCursor, itemsOnPage int) ([]addressHistoryEntry, int, int, error) {
+	f := *filter
+	maxResults := maxInt
+	if cursor.backward {
+		// the history is read from the newest items, all items newer than the cursor must be read
+		if f.FromHeight < cursor.height {
+			f.FromHeight = cursor.height
+		}
+		maxResults = maxCursorNewerItems + 1
+	} else {
+		if f.ToHeight == 0 || f.ToHeight > cursor.height {
+			f.ToHeight = cursor.height
+		}
+		// the items of the cursor block up to the cursor are skipped, one more item tells if there are older items
+		maxResults = cursor.index + 1 + itemsOnPage + 1
+	}
+	entries, _, err := w.getAddressHistory(addrDesc, &f, maxResults)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if cursor.backward && len(entries) > maxCursorNewerItems {
+		return nil, 0, 0, NewAPIError("Cursor too old, reload the history from the first page", true)
+	}
+	from, to := historyHeights(func(i int) uint32 { return entries[i].height }).cursorPage(len(entries), cursor, itemsOnPage)
+	return entries, from, to, nil
+}
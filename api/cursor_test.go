@@ -0,0 +1,113 @@
+package api
+
+import (
+	"testing"
+)
+
+func Test_addressCursor(t *testing.T) {
+	for _, c := range []addressCursor{
+		{height: 0, index: 0},
+		{height: 840000, index: 3, backward: true},
+		{height: 4294967295, index: 2147483647},
+	} {
+		got, err := parseAddressCursor(c.String())
+		if err != nil {
+			t.Fatalf("parseAddressCursor(%v) error %v", c, err)
+		}
+		if *got != c {
+			t.Errorf("parseAddressCursor(%v) = %v", c, *got)
+		}
+	}
+	if c, err := parseAddressCursor(""); c != nil || err != nil {
+		t.Errorf("parseAddressCursor(\"\") = %v, %v", c, err)
+	}
+	valid := (&addressCursor{height: 100, index: 1}).String()
+	for _, s := range []string{"x", "AAAA", valid + "A", valid[:len(valid)-1], "!" + valid[1:]} {
+		if _, err := parseAddressCursor(s); err == nil {
+			t.Errorf("parseAddressCursor(%q) did not fail", s)
+		}
+	}
+}
+
+func Test_historyHeights_cursorPage(t *testing.T) {
+	// items of the history ordered from the newest, block 105 contains 3 items
+	items := []uint32{110, 105, 105, 105, 103, 101, 101}
+	h := historyHeights(func(i int) uint32 { return items[i] })
+	tests := []struct {
+		name     string
+		cursor   addressCursor
+		from, to int
+		next     *addressCursor
+		prev     *addressCursor
+	}{
+		{
+			name:   "forward inside block",
+			cursor: addressCursor{height: 105, index: 0},
+			from:   2,
+			to:     4,
+			next:   &addressCursor{height: 105, index: 2},
+			prev:   &addressCursor{height: 105, index: 1, backward: true},
+		},
+		{
+			name:   "forward to the end",
+			cursor: addressCursor{height: 103, index: 0},
+			from:   5,
+			to:     7,
+			prev:   &addressCursor{height: 101, index: 0, backward: true},
+		},
+		{
+			name:   "forward from the removed block",
+			cursor: addressCursor{height: 104, index: 5},
+			from:   4,
+			to:     6,
+			next:   &addressCursor{height: 101, index: 0},
+			prev:   &addressCursor{height: 103, index: 0, backward: true},
+		},
+		{
+			name:   "forward from the last item",
+			cursor: addressCursor{height: 101, index: 1},
+			from:   7,
+			to:     7,
+		},
+		{
+			name:   "backward",
+			cursor: addressCursor{height: 105, index: 2, backward: true},
+			from:   1,
+			to:     3,
+			next:   &addressCursor{height: 105, index: 1},
+			prev:   &addressCursor{height: 105, index: 0, backward: true},
+		},
+		{
+			name:   "backward from block shrunk by reorg",
+			cursor: addressCursor{height: 103, index: 7, backward: true},
+			from:   3,
+			to:     5,
+			next:   &addressCursor{height: 103, index: 0},
+			prev:   &addressCursor{height: 105, index: 2, backward: true},
+		},
+		{
+			name:   "backward from the newest item",
+			cursor: addressCursor{height: 110, index: 0, backward: true},
+			from:   0,
+			to:     0,
+		},
+	}
+	cursorString := func(c *addressCursor) string {
+		if c == nil {
+			return ""
+		}
+		return c.String()
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := h.cursorPage(len(items), &tt.cursor, 2)
+			if from != tt.from || to != tt.to {
+				t.Fatalf("cursorPage() = [%d, %d), want [%d, %d)", from, to, tt.from, tt.to)
+			}
+			next, prev := h.pageCursors(len(items), from, to, to < len(items))
+			if next != cursorString(tt.next) || prev != cursorString(tt.prev) {
+				t.Errorf("pageCursors() = %q, %q, want %q, %q", next, prev, cursorString(tt.next), cursorString(tt.prev))
+			}
+		})
+	}
+}
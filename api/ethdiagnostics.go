@@ -0,0 +1,123 @@
+package api
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// replacementPriceBumpPercent is the minimal increase of the fees of a replacement transaction
+// required by the backends (txpool.pricebump of geth)
+const replacementPriceBumpPercent = 10
+
+// bumpFee returns the fee increased by the minimal replacement price bump, rounded up
+func bumpFee(fee *big.Int) *big.Int {
+	r := new(big.Int).Mul(fee, big.NewInt(100+replacementPriceBumpPercent))
+	r.Add(r, big.NewInt(99))
+	return r.Div(r, big.NewInt(100))
+}
+
+func maxBigInt(a, b *big.Int) *big.Int {
+	if b != nil && (a == nil || b.Cmp(a) > 0) {
+		return b
+	}
+	return a
+}
+
+// replacementFees suggests the fees of a replacement of the stuck transaction: the fees must be bumped
+// at least by the replacement price bump, the tip should reach the medium estimate and the fee cap
+// should cover a doubled base fee, so that the replacement survives the growth of the base fee
+func replacementFees(tx *bchain.MempoolAccountTx, baseFee *big.Int, fees *bchain.Eip1559Fees) *EthereumReplacementFees {
+	tip := bumpFee(tx.MaxPriorityFeePerGas)
+	feeCap := bumpFee(tx.MaxFeePerGas)
+	if fees != nil && fees.Medium != nil {
+		tip = maxBigInt(tip, fees.Medium.MaxPriorityFeePerGas)
+		feeCap = maxBigInt(feeCap, fees.Medium.MaxFeePerGas)
+	}
+	if baseFee != nil {
+		feeCap = maxBigInt(feeCap, new(big.Int).Add(new(big.Int).Lsh(baseFee, 1), tip))
+	}
+	feeCap = maxBigInt(feeCap, tip)
+	return &EthereumReplacementFees{
+		MaxFeePerGas:         (*Amount)(new(big.Int).Set(feeCap)),
+		MaxPriorityFeePerGas: (*Amount)(new(big.Int).Set(tip)),
+	}
+}
+
+// computeEthereumAccountDiagnostics finds the nonce gaps and the stuck transactions among the pending transactions
+// of an account, the transactions must be ordered by nonce
+func computeEthereumAccountDiagnostics(txs []bchain.MempoolAccountTx, pendingNonce, confirmedNonce uint64, fees *bchain.Eip1559Fees) *EthereumAccountDiagnostics {
+	d := EthereumAccountDiagnostics{
+		ConfirmedNonce: confirmedNonce,
+		PendingNonce:   pendingNonce,
+	}
+	var baseFee, lowTip *big.Int
+	if fees != nil {
+		baseFee = fees.BaseFeePerGas
+		if fees.Low != nil {
+			lowTip = fees.Low.MaxPriorityFeePerGas
+		}
+		d.BaseFeePerGas = (*Amount)(baseFee)
+	}
+	nextNonce := confirmedNonce
+	var gap bool
+	var blockedBy string
+	for i := range txs {
+		tx := &txs[i]
+		p := EthereumPendingTxDiagnostics{
+			Txid:                 tx.Txid,
+			Nonce:                tx.Nonce,
+			FirstSeen:            int64(tx.Time),
+			MaxFeePerGas:         (*Amount)(tx.MaxFeePerGas),
+			MaxPriorityFeePerGas: (*Amount)(tx.MaxPriorityFeePerGas),
+		}
+		if tx.Nonce < nextNonce {
+			// the nonce was used by a confirmed transaction or by another pending transaction of the account
+			p.NonceUsed = tx.Nonce < confirmedNonce
+			d.PendingTxs = append(d.PendingTxs, p)
+			continue
+		}
+		if tx.Nonce > nextNonce {
+			d.NonceGaps = append(d.NonceGaps, EthereumNonceGap{From: nextNonce, To: tx.Nonce - 1})
+			gap = true
+		}
+		nextNonce = tx.Nonce + 1
+		p.BlockedByNonceGap = gap
+		p.BlockedBy = blockedBy
+		if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil {
+			p.BelowBaseFee = baseFee != nil && tx.MaxFeePerGas.Cmp(baseFee) < 0
+			p.LowPriorityFee = lowTip != nil && tx.MaxPriorityFeePerGas.Cmp(lowTip) < 0
+			if p.BelowBaseFee || p.LowPriorityFee {
+				p.Replacement = replacementFees(tx, baseFee, fees)
+				if blockedBy == "" {
+					blockedBy = tx.Txid
+				}
+			}
+		}
+		if p.BlockedByNonceGap || p.BlockedBy != "" || p.BelowBaseFee || p.LowPriorityFee {
+			d.Stuck = true
+		}
+		d.PendingTxs = append(d.PendingTxs, p)
+	}
+	return &d
+}
+
+// getEthereumAccountDiagnostics returns the diagnostics of the pending transactions of the account
+func (w *Worker) getEthereumAccountDiagnostics(addrDesc bchain.AddressDescriptor, pendingNonce, confirmedNonce uint64) *EthereumAccountDiagnostics {
+	var txs []bchain.MempoolAccountTx
+	if w.mempool != nil {
+		txs = w.mempool.GetAccountTxs(addrDesc)
+	}
+	var fees *bchain.Eip1559Fees
+	if len(txs) > 0 {
+		var err error
+		start := time.Now()
+		if fees, err = w.chain.EthereumTypeGetEip1559Fees(); err != nil {
+			glog.Warning("EthereumTypeGetEip1559Fees error ", err)
+		}
+		glog.V(1).Info("getEthereumAccountDiagnostics ", addrDesc, ", ", len(txs), " txs, ", time.Since(start))
+	}
+	return computeEthereumAccountDiagnostics(txs, pendingNonce, confirmedNonce, fees)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func Test_computeEthereumAccountDiagnostics(t *testing.T) {
+	weiAmount := func(i int64) *Amount {
+		return (*Amount)(big.NewInt(i))
+	}
+	fees := &bchain.Eip1559Fees{
+		BaseFeePerGas: big.NewInt(100),
+		Low:           &bchain.Eip1559Fee{MaxFeePerGas: big.NewInt(120), MaxPriorityFeePerGas: big.NewInt(10)},
+		Medium:        &bchain.Eip1559Fee{MaxFeePerGas: big.NewInt(250), MaxPriorityFeePerGas: big.NewInt(20)},
+	}
+	tx := func(txid string, nonce uint64, maxFee, tip int64) bchain.MempoolAccountTx {
+		return bchain.MempoolAccountTx{Txid: txid, Time: 1700000000, Nonce: nonce, MaxFeePerGas: big.NewInt(maxFee), MaxPriorityFeePerGas: big.NewInt(tip)}
+	}
+	ptx := func(txid string, nonce uint64, maxFee, tip int64) EthereumPendingTxDiagnostics {
+		return EthereumPendingTxDiagnostics{Txid: txid, Nonce: nonce, FirstSeen: 1700000000, MaxFeePerGas: weiAmount(maxFee), MaxPriorityFeePerGas: weiAmount(tip)}
+	}
+	tests := []struct {
+		name      string
+		txs       []bchain.MempoolAccountTx
+		pending   uint64
+		confirmed uint64
+		fees      *bchain.Eip1559Fees
+		want      *EthereumAccountDiagnostics
+	}{
+		{
+			name:      "no pending transactions",
+			pending:   5,
+			confirmed: 5,
+			fees:      fees,
+			want:      &EthereumAccountDiagnostics{ConfirmedNonce: 5, PendingNonce: 5, BaseFeePerGas: weiAmount(100)},
+		},
+		{
+			name:      "pending transactions not stuck",
+			txs:       []bchain.MempoolAccountTx{tx("0x1", 5, 300, 20), tx("0x2", 6, 300, 20)},
+			pending:   7,
+			confirmed: 5,
+			fees:      fees,
+			want: &EthereumAccountDiagnostics{ConfirmedNonce: 5, PendingNonce: 7, BaseFeePerGas: weiAmount(100), PendingTxs: []EthereumPendingTxDiagnostics{
+				ptx("0x1", 5, 300, 20), ptx("0x2", 6, 300, 20),
+			}},
+		},
+		{
+			name:      "fee cap below base fee blocks the following transactions",
+			txs:       []bchain.MempoolAccountTx{tx("0x1", 5, 90, 20), tx("0x2", 6, 300, 20)},
+			pending:   7,
+			confirmed: 5,
+			fees:      fees,
+			want: &EthereumAccountDiagnostics{ConfirmedNonce: 5, PendingNonce: 7, BaseFeePerGas: weiAmount(100), Stuck: true, PendingTxs: []EthereumPendingTxDiagnostics{
+				func() EthereumPendingTxDiagnostics {
+					p := ptx("0x1", 5, 90, 20)
+					p.BelowBaseFee = true
+					// tip bumped by 10% (22), fee cap max(99, 2*100+22, 250)
+					p.Replacement = &EthereumReplacementFees{MaxFeePerGas: weiAmount(250), MaxPriorityFeePerGas: weiAmount(22)}
+					return p
+				}(),
+				func() EthereumPendingTxDiagnostics {
+					p := ptx("0x2", 6, 300, 20)
+					p.BlockedBy = "0x1"
+					return p
+				}(),
+			}},
+		},
+		{
+			name:      "low priority fee",
+			txs:       []bchain.MempoolAccountTx{tx("0x1", 5, 1000, 5)},
+			pending:   6,
+			confirmed: 5,
+			fees:      fees,
+			want: &EthereumAccountDiagnostics{ConfirmedNonce: 5, PendingNonce: 6, BaseFeePerGas: weiAmount(100), Stuck: true, PendingTxs: []EthereumPendingTxDiagnostics{
+				func() EthereumPendingTxDiagnostics {
+					p := ptx("0x1", 5, 1000, 5)
+					p.LowPriorityFee = true
+					// tip raised to the medium estimate, fee cap bumped by 10%
+					p.Replacement = &EthereumReplacementFees{MaxFeePerGas: weiAmount(1100), MaxPriorityFeePerGas: weiAmount(20)}
+					return p
+				}(),
+			}},
+		},
+		{
+			name:      "nonce gaps and used nonce",
+			txs:       []bchain.MempoolAccountTx{tx("0x0", 4, 300, 20), tx("0x1", 7, 300, 20), tx("0x2", 8, 300, 20), tx("0x3", 10, 300, 20)},
+			pending:   5,
+			confirmed: 5,
+			want: &EthereumAccountDiagnostics{ConfirmedNonce: 5, PendingNonce: 5, Stuck: true,
+				NonceGaps: []EthereumNonceGap{{From: 5, To: 6}, {From: 9, To: 9}},
+				PendingTxs: []EthereumPendingTxDiagnostics{
+					func() EthereumPendingTxDiagnostics {
+						p := ptx("0x0", 4, 300, 20)
+						p.NonceUsed = true
+						return p
+					}(),
+					func() EthereumPendingTxDiagnostics {
+						p := ptx("0x1", 7, 300, 20)
+						p.BlockedByNonceGap = true
+						return p
+					}(),
+					func() EthereumPendingTxDiagnostics {
+						p := ptx("0x2", 8, 300, 20)
+						p.BlockedByNonceGap = true
+						return p
+					}(),
+					func() EthereumPendingTxDiagnostics {
+						p := ptx("0x3", 10, 300, 20)
+						p.BlockedByNonceGap = true
+						return p
+					}(),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeEthereumAccountDiagnostics(tt.txs, tt.pending, tt.confirmed, tt.fees)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("computeEthereumAccountDiagnostics() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
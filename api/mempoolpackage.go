@@ -0,0 +1,180 @@
+package api
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/golang/glog"
+	"github.com/trezor/blockbook/bchain"
+)
+
+const (
+	// incrementalRelayFeeRate is the default incremental relay fee rate of Bitcoin Core in sat/vB,
+	// a replacement must pay it for its own size on top of the fees of the replaced transactions
+	incrementalRelayFeeRate = 1
+	// the child transaction of CPFP is assumed to be a segwit transaction spending one output to one P2WPKH output
+	cpfpChildOverheadVSize = 11
+	cpfpChildOutputVSize   = 31
+	// cpfpMinChildOutputSat is the dust limit the output of the child must stay above
+	cpfpMinChildOutputSat = 546
+)
+
+// inputVSize estimates the virtual size of an input spending the output script
+func inputVSize(script bchain.AddressDescriptor) int64 {
+	switch {
+	case len(script) == 22 && script[0] == 0x00 && script[1] == 0x14: // P2WPKH
+		return 68
+	case len(script) == 34 && script[0] == 0x51 && script[1] == 0x20: // P2TR key path
+		return 58
+	case len(script) == 34 && script[0] == 0x00 && script[1] == 0x20: // P2WSH, assuming 2-of-3 multisig
+		return 105
+	case len(script) == 25 && script[0] == 0x76: // P2PKH
+		return 148
+	case len(script) == 23 && script[0] == 0xa9: // P2SH, assuming P2SH-P2WPKH
+		return 91
+	}
+	return 68
+}
+
+func feeRate(fee *big.Int, vsize int64) float64 {
+	if vsize <= 0 {
+		return 0
+	}
+	f, _ := new(big.Float).SetInt(fee).Float64()
+	return f / float64(vsize)
+}
+
+// mempoolPackageFromEntry computes the package data from the backend mempool entry.
+// The effective fee rate is an estimate: the transaction is mined with its ancestors at the lower of its own and
+// the ancestor package fee rate, unless its descendants pull the whole package in at a higher fee rate (CPFP).
+func mempoolPackageFromEntry(e *bchain.MempoolEntry) *MempoolPackage {
+	vsize := int64(e.VSize)
+	p := MempoolPackage{
+		VSize:             vsize,
+		Fees:              (*Amount)(new(big.Int).Set(&e.FeeSat)),
+		FeeRate:           feeRate(&e.FeeSat, vsize),
+		AncestorCount:     int(e.AncestorCount),
+		AncestorSize:      int64(e.AncestorSize),
+		AncestorFees:      (*Amount)(new(big.Int).Set(&e.AncestorFeesSat)),
+		AncestorFeeRate:   feeRate(&e.AncestorFeesSat, int64(e.AncestorSize)),
+		DescendantCount:   int(e.DescendantCount),
+		DescendantSize:    int64(e.DescendantSize),
+		DescendantFees:    (*Amount)(new(big.Int).Set(&e.DescendantFeesSat)),
+		DescendantFeeRate: feeRate(&e.DescendantFeesSat, int64(e.DescendantSize)),
+		Depends:           e.Depends,
+		SpentBy:           e.SpentBy,
+		Bip125Replaceable: e.Bip125Replaceable,
+	}
+	p.EffectiveFeeRate = math.Min(p.FeeRate, p.AncestorFeeRate)
+	if p.DescendantCount > 1 {
+		clusterFees := new(big.Int).Add(&e.AncestorFeesSat, &e.DescendantFeesSat)
+		clusterFees.Sub(clusterFees, &e.FeeSat)
+		clusterRate := feeRate(clusterFees, p.AncestorSize+p.DescendantSize-vsize)
+		if clusterRate > p.EffectiveFeeRate {
+			p.EffectiveFeeRate = clusterRate
+		}
+	}
+	return &p
+}
+
+// getMempoolPackage returns the package data of an unconfirmed transaction or nil if they are not available
+func (w *Worker) getMempoolPackage(txid string) *MempoolPackage {
+	if w.chainType != bchain.ChainBitcoinType {
+		return nil
+	}
+	w.work.addBackendCalls(1)
+	e, err := w.chain.GetMempoolEntry(txid)
+	if err != nil || e == nil {
+		glog.V(1).Infof("GetMempoolEntry %v error %v", txid, err)
+		return nil
+	}
+	return mempoolPackageFromEntry(e)
+}
+
+// computeFeeBump computes the CPFP and RBF suggestions for the package to reach the target fee rate.
+// The CPFP child must lift both the transaction alone and its whole ancestor package to the target fee rate.
+// The RBF replacement must pay for the replaced transaction and its descendants and for its own relay.
+func computeFeeBump(p *MempoolPackage, targetFeeRate float64, childVSize int64) (*FeeBumpCpfp, *FeeBumpRbf) {
+	if p.EffectiveFeeRate >= targetFeeRate {
+		return nil, nil
+	}
+	fee := p.Fees.AsInt64()
+	ancestorChildFee := int64(math.Ceil(targetFeeRate*float64(p.AncestorSize+childVSize))) - p.AncestorFees.AsInt64()
+	childFee := int64(math.Ceil(targetFeeRate*float64(p.VSize+childVSize))) - fee
+	if ancestorChildFee > childFee {
+		childFee = ancestorChildFee
+	}
+	cpfp := FeeBumpCpfp{ChildVSize: childVSize, ChildFee: (*Amount)(big.NewInt(childFee))}
+	rbfFee := int64(math.Ceil(targetFeeRate * float64(p.VSize)))
+	if minFee := p.DescendantFees.AsInt64() + incrementalRelayFeeRate*p.VSize; minFee > rbfFee {
+		rbfFee = minFee
+	}
+	rbf := FeeBumpRbf{
+		Fee:           (*Amount)(big.NewInt(rbfFee)),
+		AdditionalFee: (*Amount)(big.NewInt(rbfFee - fee)),
+		Signaled:      p.Bip125Replaceable,
+	}
+	return &cpfp, &rbf
+}
+
+// GetFeeBump returns the suggestions how to get a stuck mempool transaction to the target fee rate (in sat/vB),
+// by a child spending one of the outputs (CPFP) or by a replacement (RBF). The outputs are the indexes
+// of the wallet's outputs which can be spent by the child, all outputs are considered if empty.
+// If childVSize is not positive, it is estimated from the script types of the outputs.
+func (w *Worker) GetFeeBump(txid string, targetFeeRate float64, outputs []int, childVSize int64) (*FeeBump, error) {
+	if w.chainType != bchain.ChainBitcoinType {
+		return nil, NewAPIError("Not supported", true)
+	}
+	if !(targetFeeRate > 0) || math.IsInf(targetFeeRate, 0) {
+		return nil, NewAPIError("Parameter 'feeRate' must be positive", true)
+	}
+	w.work.addBackendCalls(1)
+	e, err := w.chain.GetMempoolEntry(txid)
+	if err != nil || e == nil {
+		return nil, NewAPIError("Transaction '"+txid+"' not found in mempool", true)
+	}
+	p := mempoolPackageFromEntry(e)
+	tx, err := w.getTransaction(txid, false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*Vout
+	if len(outputs) == 0 {
+		for i := range tx.Vout {
+			candidates = append(candidates, &tx.Vout[i])
+		}
+	} else {
+		for _, n := range outputs {
+			if n < 0 || n >= len(tx.Vout) {
+				return nil, NewAPIError("Invalid output index", true)
+			}
+			candidates = append(candidates, &tx.Vout[n])
+		}
+	}
+	if childVSize <= 0 {
+		var maxInput int64
+		for _, o := range candidates {
+			if s := inputVSize(o.AddrDesc); s > maxInput {
+				maxInput = s
+			}
+		}
+		childVSize = cpfpChildOverheadVSize + maxInput + cpfpChildOutputVSize
+	}
+	r := FeeBump{Txid: txid, TargetFeeRate: targetFeeRate, EffectiveFeeRate: p.EffectiveFeeRate}
+	r.Cpfp, r.Rbf = computeFeeBump(p, targetFeeRate, childVSize)
+	if r.Cpfp != nil {
+		r.Cpfp.Outputs = []FeeBumpCpfpOutput{}
+		minValue := r.Cpfp.ChildFee.AsInt64() + cpfpMinChildOutputSat
+		for _, o := range candidates {
+			if o.Spent || !o.IsAddress || o.ValueSat == nil || o.ValueSat.AsInt64() < minValue {
+				continue
+			}
+			out := FeeBumpCpfpOutput{N: o.N, ValueSat: o.ValueSat}
+			if len(o.Addresses) == 1 {
+				out.Address = o.Addresses[0]
+			}
+			r.Cpfp.Outputs = append(r.Cpfp.Outputs, out)
+		}
+	}
+	return &r, nil
+}
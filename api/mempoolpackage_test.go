@@ -0,0 +1,87 @@
+package api
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func mempoolEntry(vsize uint32, fee int64, ancestorCount, ancestorSize uint32, ancestorFees int64, descendantCount, descendantSize uint32, descendantFees int64) *bchain.MempoolEntry {
+	e := bchain.MempoolEntry{
+		VSize:           vsize,
+		AncestorCount:   ancestorCount,
+		AncestorSize:    ancestorSize,
+		DescendantCount: descendantCount,
+		DescendantSize:  descendantSize,
+	}
+	e.FeeSat.SetInt64(fee)
+	e.AncestorFeesSat.SetInt64(ancestorFees)
+	e.DescendantFeesSat.SetInt64(descendantFees)
+	return &e
+}
+
+func Test_computeFeeBump(t *testing.T) {
+	tests := []struct {
+		name             string
+		entry            *bchain.MempoolEntry
+		effectiveFeeRate float64
+		wantChildFee     int64
+		wantRbfFee       int64
+	}{
+		{
+			name:             "single transaction",
+			entry:            mempoolEntry(141, 282, 1, 141, 282, 1, 141, 282),
+			effectiveFeeRate: 2,
+			wantChildFee:     4738,
+			wantRbfFee:       2820,
+		},
+		{
+			name:             "low fee parent",
+			entry:            mempoolEntry(141, 282, 2, 341, 482, 1, 141, 282),
+			effectiveFeeRate: 482.0 / 341,
+			wantChildFee:     8538,
+			wantRbfFee:       2820,
+		},
+		{
+			name:             "replacement pays for descendants",
+			entry:            mempoolEntry(141, 282, 1, 141, 282, 2, 251, 2982),
+			effectiveFeeRate: 2982.0 / 251,
+			wantChildFee:     4738,
+			wantRbfFee:       2982 + 141,
+		},
+		{
+			name:             "already bumped by a child",
+			entry:            mempoolEntry(141, 282, 1, 141, 282, 2, 251, 5282),
+			effectiveFeeRate: 5282.0 / 251,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mempoolPackageFromEntry(tt.entry)
+			if math.Abs(p.EffectiveFeeRate-tt.effectiveFeeRate) > 1e-9 {
+				t.Errorf("EffectiveFeeRate = %v, want %v", p.EffectiveFeeRate, tt.effectiveFeeRate)
+			}
+			cpfp, rbf := computeFeeBump(p, 20, 110)
+			if tt.wantChildFee == 0 {
+				if cpfp != nil || rbf != nil {
+					t.Errorf("computeFeeBump() = %+v, %+v, want nil", cpfp, rbf)
+				}
+				return
+			}
+			if cpfp == nil || rbf == nil {
+				t.Fatalf("computeFeeBump() = %+v, %+v", cpfp, rbf)
+			}
+			if got := (*big.Int)(cpfp.ChildFee).Int64(); got != tt.wantChildFee {
+				t.Errorf("ChildFee = %v, want %v", got, tt.wantChildFee)
+			}
+			if got := (*big.Int)(rbf.Fee).Int64(); got != tt.wantRbfFee {
+				t.Errorf("Rbf.Fee = %v, want %v", got, tt.wantRbfFee)
+			}
+			if got := (*big.Int)(rbf.AdditionalFee).Int64(); got != tt.wantRbfFee-282 {
+				t.Errorf("Rbf.AdditionalFee = %v, want %v", got, tt.wantRbfFee-282)
+			}
+		})
+	}
+}
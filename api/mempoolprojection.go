@@ -0,0 +1,89 @@
+package api
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+// mempoolProjectedBlocks is the number of the projected blocks, the last one contains the rest of the mempool
+const mempoolProjectedBlocks = 8
+
+// bitcoinFeeHistogramBounds are the lower bounds of the fee histogram buckets in sat/vB
+var bitcoinFeeHistogramBounds = []float64{
+	1, 2, 3, 4, 5, 6, 8, 10, 12, 15, 20, 30, 40, 50, 60, 70, 80, 90, 100, 125, 150, 175, 200,
+	250, 300, 350, 400, 500, 600, 700, 800, 900, 1000, 1200, 1400, 1600, 1800, 2000,
+}
+
+// ethereumFeeHistogramBounds are the lower bounds of the fee histogram buckets in Gwei
+var ethereumFeeHistogramBounds = []float64{
+	0.001, 0.002, 0.005, 0.01, 0.02, 0.05, 0.1, 0.2, 0.5, 1, 1.5, 2, 3, 4, 5, 7, 10, 15, 20, 30,
+	50, 75, 100, 150, 200, 300, 500, 1000,
+}
+
+// GetMempoolProjection projects the current mempool into the next blocks and computes the fee rate histogram.
+// The blockSize is the gas limit of the block and the baseFee the base fee of the last block for Ethereum type chains,
+// the mempool transactions are ranked by the effective priority fee at the base fee. Both are ignored for Bitcoin type chains.
+func (w *Worker) GetMempoolProjection(blockSize int64, baseFee *big.Int) (*MempoolBlocks, *MempoolFeeHistogram, error) {
+	if w.mempool == nil {
+		return nil, nil, NewAPIError("Mempool not available", true)
+	}
+	var unit string
+	var bounds []float64
+	// the fee rates of the entries are converted to unit by rateScale, the fees to base units by feeScale
+	rateScale := 1.0
+	feeScale := int64(1)
+	switch w.chainType {
+	case bchain.ChainBitcoinType:
+		unit = "sat/vB"
+		bounds = bitcoinFeeHistogramBounds
+		blockSize = bchain.MaxBitcoinBlockVSize
+	case bchain.ChainEthereumType:
+		unit = "Gwei"
+		// the entries are in Mwei, the histogram bounds must be in the same unit
+		rateScale = 1e9 / bchain.EthereumMempoolFeeUnit
+		feeScale = bchain.EthereumMempoolFeeUnit
+		bounds = make([]float64, len(ethereumFeeHistogramBounds))
+		for i := range ethereumFeeHistogramBounds {
+			bounds[i] = ethereumFeeHistogramBounds[i] * rateScale
+		}
+		if blockSize <= 0 {
+			blockSize = bchain.DefaultEthereumBlockGasLimit
+		}
+	default:
+		return nil, nil, NewAPIError("Not supported", true)
+	}
+	entries := w.mempool.GetFeeEntries()
+	if w.chainType == bchain.ChainEthereumType {
+		bchain.SetEthereumEffectiveFees(entries, baseFee)
+	}
+	now := time.Now().Unix()
+	p := bchain.ProjectMempool(entries, blockSize, mempoolProjectedBlocks, bounds)
+	blocks := &MempoolBlocks{FeeRateUnit: unit, Time: now, Blocks: make([]MempoolBlock, len(p.Blocks))}
+	for i := range p.Blocks {
+		b := &p.Blocks[i]
+		fees := new(big.Int).Mul(big.NewInt(b.TotalFee), big.NewInt(feeScale))
+		feeRange := make([]float64, len(b.FeeRange))
+		for j := range b.FeeRange {
+			feeRange[j] = b.FeeRange[j] / rateScale
+		}
+		blocks.Blocks[i] = MempoolBlock{
+			Size:          b.VSize,
+			TxCount:       b.TxCount,
+			TotalFeesSat:  (*Amount)(fees),
+			MedianFeeRate: b.MedianFeeRate() / rateScale,
+			FeeRange:      feeRange,
+		}
+	}
+	histogram := &MempoolFeeHistogram{FeeRateUnit: unit, Time: now, Buckets: make([]MempoolFeeHistogramBucket, len(p.Histogram))}
+	for i := range p.Histogram {
+		h := &p.Histogram[i]
+		histogram.Buckets[i] = MempoolFeeHistogramBucket{
+			FeeRate: h.FeeRate / rateScale,
+			Size:    h.VSize,
+			TxCount: h.TxCount,
+		}
+	}
+	return blocks, histogram, nil
+}
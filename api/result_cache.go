@@ -0,0 +1,290 @@
+package api
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/common"
+	"golang.org/x/sync/singleflight"
+)
+
+// The expensive lookups (GetAddress, GetXpubAddress, GetBalanceHistory and GetBlock) are coalesced:
+// concurrent requests with the same normalized parameters at the same best block wait for a single
+// computation and share its result. The results are also kept for a short time; the whole cache is
+// dropped on a new or disconnected block and the entries of an address are dropped when a mempool
+// transaction of the address arrives, is replaced or dropped. Other changes (for example the fiat
+// rates or a mempool transaction leaving without notification) are bounded by resultCacheTTL.
+const (
+	resultCacheCapacity = 1024
+	resultCacheTTL      = 5 * time.Second
+	// resultCacheDirtyCapacity bounds the addresses invalidated during the running computations,
+	// over the limit all running computations are considered stale
+	resultCacheDirtyCapacity = 4 * resultCacheCapacity
+	// resultCacheAddrsPerResult bounds the addresses indexed by the cache to this multiple of its capacity,
+	// an xpub result depends on all its derived addresses
+	resultCacheAddrsPerResult = 64
+)
+
+// the status labels of the api_result_cache metric
+const (
+	resultCacheHit       = "hit"
+	resultCacheCoalesced = "coalesced"
+	resultCacheMiss      = "miss"
+)
+
+// resultCache is a TTL-bounded LRU of the results indexed by the address descriptors they depend on,
+// combined with a singleflight group. The methods are nil-safe, a nil cache computes every request.
+type resultCache struct {
+	sf       singleflight.Group
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	items    map[string]*list.Element
+	byAddr   map[string]map[string]struct{}
+	// addrs counts the addresses of the stored results, limited by maxAddrs
+	addrs    int
+	maxAddrs int
+	// gen is increased by each invalidation, computing counts the running computations
+	gen       uint64
+	computing int
+	// dirty holds the generation of the last invalidation of the addresses invalidated while computing,
+	// the results started before dirtyFloor or before the invalidation of any of their addresses are not stored
+	dirty      map[string]uint64
+	dirtyFloor uint64
+}
+
+type resultCacheEntry struct {
+	key       string
+	value     interface{}
+	expires   time.Time
+	addrDescs []string
+}
+
+func newResultCache(capacity int, ttl time.Duration) *resultCache {
+	if capacity <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &resultCache{
+		capacity: capacity,
+		ttl:      ttl,
+		maxAddrs: capacity * resultCacheAddrsPerResult,
+		order:    list.New(),
+		items:    make(map[string]*list.Element, capacity),
+		byAddr:   make(map[string]map[string]struct{}),
+		dirty:    make(map[string]uint64),
+	}
+}
+
+// do returns the cached result for the key or runs compute once for all concurrent callers of the key.
+// compute returns the result together with the address descriptors the result depends on. The returned
+// status is one of resultCacheHit, resultCacheCoalesced or resultCacheMiss. Errors are not cached.
+func (c *resultCache) do(key string, compute func() (interface{}, []bchain.AddressDescriptor, error)) (interface{}, string, error) {
+	if c == nil {
+		v, _, err := compute()
+		return v, resultCacheMiss, err
+	}
+	if v, ok := c.get(key, time.Now()); ok {
+		return v, resultCacheHit, nil
+	}
+	status := resultCacheCoalesced
+	v, err, _ := c.sf.Do(key, func() (interface{}, error) {
+		status = resultCacheMiss
+		// the previous flight of the key may have stored the result in the meantime
+		if v, ok := c.get(key, time.Now()); ok {
+			status = resultCacheHit
+			return v, nil
+		}
+		gen := c.start()
+		v, addrDescs, err := compute()
+		c.finish(key, v, addrDescs, err == nil, gen, time.Now())
+		return v, err
+	})
+	return v, status, err
+}
+
+func (c *resultCache) get(key string, now time.Time) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*resultCacheEntry)
+	if now.After(e.expires) {
+		c.removeLocked(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+func (c *resultCache) start() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.computing++
+	return c.gen
+}
+
+// finish stores the result of a computation started at the generation gen,
+// unless any of its addresses was invalidated in the meantime or it depends on too many addresses
+func (c *resultCache) finish(key string, value interface{}, addrDescs []bchain.AddressDescriptor, store bool, gen uint64, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.computing--
+	if store && gen >= c.dirtyFloor && len(addrDescs) <= c.maxAddrs {
+		ads := make([]string, len(addrDescs))
+		for i := range addrDescs {
+			ads[i] = string(addrDescs[i])
+			if g, found := c.dirty[ads[i]]; found && g > gen {
+				store = false
+				break
+			}
+		}
+		if store {
+			c.addLocked(&resultCacheEntry{key: key, value: value, expires: now.Add(c.ttl), addrDescs: ads})
+		}
+	}
+	if c.computing == 0 && len(c.dirty) > 0 {
+		c.dirty = make(map[string]uint64)
+	}
+}
+
+func (c *resultCache) addLocked(e *resultCacheEntry) {
+	if el, ok := c.items[e.key]; ok {
+		c.removeLocked(el)
+	}
+	c.items[e.key] = c.order.PushFront(e)
+	for _, ad := range e.addrDescs {
+		keys := c.byAddr[ad]
+		if keys == nil {
+			keys = make(map[string]struct{})
+			c.byAddr[ad] = keys
+		}
+		keys[e.key] = struct{}{}
+	}
+	c.addrs += len(e.addrDescs)
+	for c.order.Len() > c.capacity || c.addrs > c.maxAddrs {
+		c.removeLocked(c.order.Back())
+	}
+}
+
+func (c *resultCache) removeLocked(el *list.Element) {
+	e := el.Value.(*resultCacheEntry)
+	c.order.Remove(el)
+	delete(c.items, e.key)
+	c.addrs -= len(e.addrDescs)
+	for _, ad := range e.addrDescs {
+		if keys := c.byAddr[ad]; keys != nil {
+			delete(keys, e.key)
+			if len(keys) == 0 {
+				delete(c.byAddr, ad)
+			}
+		}
+	}
+}
+
+// purge drops all results, the running computations are not stored
+func (c *resultCache) purge() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.dirtyFloor = c.gen
+	c.order.Init()
+	c.items = make(map[string]*list.Element, c.capacity)
+	c.byAddr = make(map[string]map[string]struct{})
+	c.addrs = 0
+	c.dirty = make(map[string]uint64)
+}
+
+// invalidate drops the results depending on any of the addresses
+func (c *resultCache) invalidate(addrDescs []bchain.AddressDescriptor) {
+	if c == nil || len(addrDescs) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	for _, addrDesc := range addrDescs {
+		ad := string(addrDesc)
+		for key := range c.byAddr[ad] {
+			if el, ok := c.items[key]; ok {
+				c.removeLocked(el)
+			}
+		}
+		if c.computing > 0 {
+			c.dirty[ad] = c.gen
+		}
+	}
+	if len(c.dirty) > resultCacheDirtyCapacity {
+		c.dirtyFloor = c.gen
+		c.dirty = make(map[string]uint64)
+	}
+}
+
+// coalesce returns the result of compute shared by the concurrent requests of the method with the same params
+// at the current best block, or a result of the same request cached since the last change of its addresses.
+// The result may be shared by several requests, the callers must return its copy.
+func (w *Worker) coalesce(method string, params []interface{}, compute func() (interface{}, []bchain.AddressDescriptor, error)) (interface{}, error) {
+	if w.results == nil {
+		v, _, err := compute()
+		return v, err
+	}
+	_, bestHeight, _, _ := w.is.GetSyncState()
+	key := fmt.Sprintf("%s:%d:%d:%#v", method, bestHeight, w.db.ReorgGeneration(), params)
+	v, status, err := w.results.do(key, compute)
+	if w.metrics != nil {
+		w.metrics.ApiResultCache.With(common.Labels{"method": method, "status": status}).Inc()
+	}
+	return v, err
+}
+
+// ResetResultCache drops all cached results, it is called on a new or disconnected block
+func (w *Worker) ResetResultCache() {
+	if w != nil {
+		w.results.purge()
+	}
+}
+
+// InvalidateResultCache drops the cached results which depend on any of the addresses
+func (w *Worker) InvalidateResultCache(addrDescs []bchain.AddressDescriptor) {
+	if w != nil {
+		w.results.invalidate(addrDescs)
+	}
+}
+
+// InvalidateResultCacheForTx drops the cached results of the addresses of a new mempool transaction
+func (w *Worker) InvalidateResultCacheForTx(tx *bchain.MempoolTx) {
+	if w == nil || w.results == nil {
+		return
+	}
+	addrDescs := make([]bchain.AddressDescriptor, 0, len(tx.Vin)+len(tx.Vout)+2*len(tx.TokenTransfers))
+	addAddress := func(address string) {
+		if addrDesc, err := w.chainParser.GetAddrDescFromAddress(address); err == nil && len(addrDesc) > 0 {
+			addrDescs = append(addrDescs, addrDesc)
+		}
+	}
+	for i := range tx.Vin {
+		if len(tx.Vin[i].AddrDesc) > 0 {
+			addrDescs = append(addrDescs, tx.Vin[i].AddrDesc)
+		} else if len(tx.Vin[i].Addresses) > 0 {
+			addAddress(tx.Vin[i].Addresses[0])
+		}
+	}
+	for i := range tx.Vout {
+		if addrDesc, err := w.chainParser.GetAddrDescFromVout(&tx.Vout[i]); err == nil && len(addrDesc) > 0 {
+			addrDescs = append(addrDescs, addrDesc)
+		}
+	}
+	for i := range tx.TokenTransfers {
+		addAddress(tx.TokenTransfers[i].From)
+		addAddress(tx.TokenTransfers[i].To)
+	}
+	w.results.invalidate(addrDescs)
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func TestResultCache(t *testing.T) {
+	c := newResultCache(16, time.Minute)
+	addr1 := bchain.AddressDescriptor{1}
+	addr2 := bchain.AddressDescriptor{2}
+	computed := 0
+	compute := func(v string, addrDescs ...bchain.AddressDescriptor) func() (interface{}, []bchain.AddressDescriptor, error) {
+		return func() (interface{}, []bchain.AddressDescriptor, error) {
+			computed++
+			return v, addrDescs, nil
+		}
+	}
+	check := func(key string, fn func() (interface{}, []bchain.AddressDescriptor, error), wantValue, wantStatus string, wantComputed int) {
+		t.Helper()
+		v, status, err := c.do(key, fn)
+		if err != nil || v != wantValue || status != wantStatus || computed != wantComputed {
+			t.Fatalf("do(%q) = %v, %v, %v, computed %d, want %v, %v, computed %d", key, v, status, err, computed, wantValue, wantStatus, wantComputed)
+		}
+	}
+
+	check("a", compute("a1", addr1), "a1", resultCacheMiss, 1)
+	check("a", compute("a2", addr1), "a1", resultCacheHit, 1)
+	check("b", compute("b1", addr1, addr2), "b1", resultCacheMiss, 2)
+
+	// a mempool change of an address drops only the results depending on it
+	check("c", compute("c1"), "c1", resultCacheMiss, 3)
+	c.invalidate([]bchain.AddressDescriptor{addr2})
+	check("b", compute("b2", addr1, addr2), "b2", resultCacheMiss, 4)
+	c.invalidate([]bchain.AddressDescriptor{addr1})
+	check("a", compute("a3", addr1), "a3", resultCacheMiss, 5)
+	if len(c.items) != 2 || len(c.byAddr) != 1 {
+		t.Fatalf("%d items, %d addresses after the invalidation, want 2, 1", len(c.items), len(c.byAddr))
+	}
+
+	// a new block drops everything
+	c.purge()
+	check("a", compute("a4", addr1), "a4", resultCacheMiss, 6)
+
+	// the results computed while their address changed are not stored
+	check("d", func() (interface{}, []bchain.AddressDescriptor, error) {
+		computed++
+		c.invalidate([]bchain.AddressDescriptor{addr2})
+		return "d1", []bchain.AddressDescriptor{addr2}, nil
+	}, "d1", resultCacheMiss, 7)
+	check("d", compute("d2", addr2), "d2", resultCacheMiss, 8)
+	check("d", compute("d3", addr2), "d2", resultCacheHit, 8)
+	check("e", func() (interface{}, []bchain.AddressDescriptor, error) {
+		computed++
+		c.invalidate([]bchain.AddressDescriptor{addr2})
+		return "e1", []bchain.AddressDescriptor{addr1}, nil
+	}, "e1", resultCacheMiss, 9)
+	check("e", compute("e2", addr1), "e1", resultCacheHit, 9)
+	if len(c.dirty) != 0 {
+		t.Fatalf("%d dirty addresses without running computations", len(c.dirty))
+	}
+
+	// errors are not cached
+	if _, status, err := c.do("f", func() (interface{}, []bchain.AddressDescriptor, error) {
+		return nil, nil, errors.New("failed")
+	}); err == nil || status != resultCacheMiss {
+		t.Fatalf("do of a failing computation = %v, %v", status, err)
+	}
+	check("f", compute("f1"), "f1", resultCacheMiss, 10)
+
+	// the expired results are dropped
+	if _, ok := c.get("f", time.Now().Add(2*time.Minute)); ok {
+		t.Fatal("expired result returned")
+	}
+	if _, found := c.items["f"]; found {
+		t.Fatal("expired result not removed")
+	}
+
+	// the capacity evicts the least recently used results
+	small := newResultCache(2, time.Minute)
+	for _, key := range []string{"x", "y", "x", "z"} {
+		small.do(key, compute(key, addr1))
+	}
+	if _, ok := small.get("y", time.Now()); ok {
+		t.Fatal("the least recently used result not evicted")
+	}
+	if len(small.items) != 2 || len(small.byAddr[string(addr1)]) != 2 {
+		t.Fatalf("%d items, %d keys of the address, want 2, 2", len(small.items), len(small.byAddr[string(addr1)]))
+	}
+
+	// the addresses indexed by the results are bounded
+	addrs := make([]bchain.AddressDescriptor, small.maxAddrs+1)
+	for i := range addrs {
+		addrs[i] = bchain.AddressDescriptor{byte(i >> 8), byte(i)}
+	}
+	small.do("xpub1", compute("xpub1", addrs...))
+	if _, ok := small.get("xpub1", time.Now()); ok {
+		t.Fatal("result with too many addresses stored")
+	}
+	half := small.maxAddrs/2 + 1
+	small.do("xpub2", compute("xpub2", addrs[:half]...))
+	small.do("xpub3", compute("xpub3", addrs[half:]...))
+	if _, ok := small.get("xpub2", time.Now()); ok {
+		t.Fatal("the least recently used result not evicted over the address limit")
+	}
+	if len(small.items) != 1 || small.addrs != len(addrs)-half || len(small.byAddr) != len(addrs)-half {
+		t.Fatalf("%d items, %d addresses, %d indexed, want 1, %d", len(small.items), small.addrs, len(small.byAddr), len(addrs)-half)
+	}
+
+	// a nil cache computes each request
+	var none *resultCache
+	for i := 0; i < 2; i++ {
+		if v, status, err := none.do("a", compute("n", addr1)); v != "n" || status != resultCacheMiss || err != nil {
+			t.Fatalf("nil cache do = %v, %v, %v", v, status, err)
+		}
+	}
+	none.invalidate([]bchain.AddressDescriptor{addr1})
+	none.purge()
+}
+
+func TestResultCacheCoalescing(t *testing.T) {
+	c := newResultCache(16, time.Minute)
+	var computed atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	compute := func() (interface{}, []bchain.AddressDescriptor, error) {
+		if computed.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		return &Address{AddrStr: "xpub"}, nil, nil
+	}
+
+	const requests = 10
+	var wg sync.WaitGroup
+	values := make([]interface{}, requests)
+	statuses := make([]string, requests)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		values[0], statuses[0], _ = c.do("xpub", compute)
+	}()
+	<-started
+	for i := 1; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], statuses[i], _ = c.do("xpub", compute)
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if computed.Load() != 1 {
+		t.Fatalf("computed %d times, want once", computed.Load())
+	}
+	if statuses[0] != resultCacheMiss {
+		t.Fatalf("status of the first request %q, want %q", statuses[0], resultCacheMiss)
+	}
+	for i := 1; i < requests; i++ {
+		if values[i] != values[0] {
+			t.Fatalf("request %d got a different result", i)
+		}
+		// the requests arriving after the computation finished are served from the cache
+		if statuses[i] != resultCacheCoalesced && statuses[i] != resultCacheHit {
+			t.Fatalf("status of request %d %q, want coalesced or hit", i, statuses[i])
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/eth"
+)
+
+// SimulateEthereumTx simulates the Ethereum-type transaction given by params against the latest or pending block
+// and returns its outcome together with the resulting balance changes, the transaction is not broadcast
+func (w *Worker) SimulateEthereumTx(params map[string]interface{}, block string) (*SimulatedTx, error) {
+	if w.chainType != bchain.ChainEthereumType {
+		return nil, NewAPIError("Transaction simulation is supported only for Ethereum-type coins", true)
+	}
+	st, err := w.chain.EthereumTypeSimulateTx(params, block)
+	if err != nil {
+		return nil, NewAPIError(err.Error(), true)
+	}
+	r := &SimulatedTx{
+		Traced:          st.Traced,
+		Status:          st.Status,
+		Error:           st.Error,
+		GasUsed:         st.GasUsed,
+		From:            simulatedAddress(st.From),
+		To:              simulatedAddress(st.To),
+		Value:           (*Amount)(&st.Value),
+		CreatedContract: simulatedAddress(st.CreatedContract),
+	}
+	if len(st.InternalTransfers) > 0 {
+		r.InternalTransfers = make([]EthereumInternalTransfer, len(st.InternalTransfers))
+		for i := range st.InternalTransfers {
+			f := &st.InternalTransfers[i]
+			t := &r.InternalTransfers[i]
+			t.Type = f.Type
+			t.From = simulatedAddress(f.From)
+			t.To = simulatedAddress(f.To)
+			t.Value = (*Amount)(&f.Value)
+		}
+	}
+	if len(st.TokenTransfers) > 0 {
+		r.TokenTransfers = w.getEthereumTokensTransfers(st.TokenTransfers, map[string]struct{}{})
+	}
+	r.BalanceChanges = simulatedBalanceChanges(r, st.TokenTransfers, w.chainParser.AmountDecimals())
+	return r, nil
+}
+
+// simulatedAddress returns the address in the EIP55 checksum format, empty address stays empty
+func simulatedAddress(address string) string {
+	if address == "" {
+		return ""
+	}
+	return eth.EIP55AddressFromAddress(address)
+}
+
+type simulatedBalanceKey struct {
+	address  string
+	contract string
+}
+
+// simulatedBalanceChanges sums the native coin and fungible token movements of a successful simulated transaction per address,
+// the token transfers are the source transfers of tx.TokenTransfers, used to identify the fungible ones
+func simulatedBalanceChanges(tx *SimulatedTx, transfers bchain.TokenTransfers, decimals int) []SimulatedBalanceChange {
+	if tx.Status != bchain.TxStatusOK {
+		return nil
+	}
+	deltas := make(map[simulatedBalanceKey]*big.Int)
+	move := func(from, to, contract string, value *big.Int) {
+		if value == nil || value.Sign() == 0 {
+			return
+		}
+		for _, m := range []struct {
+			address string
+			neg     bool
+		}{{from, true}, {to, false}} {
+			if m.address == "" {
+				continue
+			}
+			k := simulatedBalanceKey{address: m.address, contract: contract}
+			d, ok := deltas[k]
+			if !ok {
+				d = new(big.Int)
+				deltas[k] = d
+			}
+			if m.neg {
+				d.Sub(d, value)
+			} else {
+				d.Add(d, value)
+			}
+		}
+	}
+	to := tx.To
+	if to == "" {
+		to = tx.CreatedContract
+	}
+	move(tx.From, to, "", (*big.Int)(tx.Value))
+	for i := range tx.InternalTransfers {
+		t := &tx.InternalTransfers[i]
+		move(t.From, t.To, "", (*big.Int)(t.Value))
+	}
+	tokens := make(map[string]*TokenTransfer)
+	for i := range tx.TokenTransfers {
+		t := &tx.TokenTransfers[i]
+		if t.Contract != "" {
+			tokens[t.Contract] = t
+		}
+	}
+	for _, t := range transfers {
+		if t.Standard == bchain.FungibleToken {
+			move(t.From, t.To, t.Contract, &t.Value)
+		}
+	}
+	changes := make([]SimulatedBalanceChange, 0, len(deltas))
+	for k, d := range deltas {
+		if d.Sign() == 0 {
+			continue
+		}
+		c := SimulatedBalanceChange{
+			Address:  k.address,
+			Contract: k.contract,
+			Decimals: decimals,
+			Delta:    (*Amount)(d),
+		}
+		if k.contract != "" {
+			if t, ok := tokens[k.contract]; ok {
+				c.Symbol = t.Symbol
+				c.Decimals = t.Decimals
+			}
+		}
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Address != changes[j].Address {
+			return changes[i].Address < changes[j].Address
+		}
+		return changes[i].Contract < changes[j].Contract
+	})
+	return changes
+}
@@ -0,0 +1,68 @@
+//go:build unittest
+
+package api
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func amount(v int64) *Amount {
+	return (*Amount)(big.NewInt(v))
+}
+
+func Test_simulatedBalanceChanges(t *testing.T) {
+	const (
+		sender   = "0x1111111111111111111111111111111111111111"
+		contract = "0x2222222222222222222222222222222222222222"
+		receiver = "0x3333333333333333333333333333333333333333"
+		nft      = "0x4444444444444444444444444444444444444444"
+	)
+	transfers := bchain.TokenTransfers{
+		{Standard: bchain.FungibleToken, Contract: contract, From: sender, To: receiver, Value: *big.NewInt(1000)},
+		{Standard: bchain.FungibleToken, Contract: contract, From: receiver, To: sender, Value: *big.NewInt(1000)},
+		{Standard: bchain.FungibleToken, Contract: contract, From: sender, To: receiver, Value: *big.NewInt(300)},
+		{Standard: bchain.NonFungibleToken, Contract: nft, From: sender, To: receiver, Value: *big.NewInt(7)},
+	}
+	tx := &SimulatedTx{
+		Status: bchain.TxStatusOK,
+		From:   sender,
+		To:     contract,
+		Value:  amount(50),
+		InternalTransfers: []EthereumInternalTransfer{
+			{From: contract, To: receiver, Value: amount(20)},
+		},
+		TokenTransfers: []TokenTransfer{
+			{Contract: contract, Symbol: "TST", Decimals: 6},
+		},
+	}
+	want := []SimulatedBalanceChange{
+		{Address: sender, Decimals: 18, Delta: amount(-50)},
+		{Address: sender, Contract: contract, Symbol: "TST", Decimals: 6, Delta: amount(-300)},
+		{Address: contract, Decimals: 18, Delta: amount(30)},
+		{Address: receiver, Decimals: 18, Delta: amount(20)},
+		{Address: receiver, Contract: contract, Symbol: "TST", Decimals: 6, Delta: amount(300)},
+	}
+	if got := simulatedBalanceChanges(tx, transfers, 18); !reflect.DeepEqual(got, want) {
+		t.Errorf("simulatedBalanceChanges() = %+v, want %+v", got, want)
+	}
+
+	// contract creation credits the created contract
+	tx = &SimulatedTx{Status: bchain.TxStatusOK, From: sender, Value: amount(5), CreatedContract: nft}
+	want = []SimulatedBalanceChange{
+		{Address: sender, Decimals: 18, Delta: amount(-5)},
+		{Address: nft, Decimals: 18, Delta: amount(5)},
+	}
+	if got := simulatedBalanceChanges(tx, nil, 18); !reflect.DeepEqual(got, want) {
+		t.Errorf("simulatedBalanceChanges() = %+v, want %+v", got, want)
+	}
+
+	// failed transaction does not change balances
+	tx.Status = bchain.TxStatusFailure
+	if got := simulatedBalanceChanges(tx, transfers, 18); got != nil {
+		t.Errorf("simulatedBalanceChanges() = %+v, want nil", got)
+	}
+}
@@ -315,9 +315,61 @@ type EthereumSpecific struct {
 	L1FeeScalar          string                                 `json:"l1FeeScalar,omitempty" ts_doc:"Scaling factor for L1 fees in certain Layer 2 solutions."`
 	L1GasPrice           *Amount                                `json:"l1GasPrice,omitempty" ts_doc:"Gas price for L1 component, if applicable."`
 	L1GasUsed            *big.Int                               `json:"l1GasUsed,omitempty" ts_doc:"Amount of gas used in L1 for this tx, if applicable."`
+	L1BaseFeeScalar      *big.Int                               `json:"l1BaseFeeScalar,omitempty" ts_doc:"Scalar applied to the L1 base fee on OP stack networks."`
+	L1BlobBaseFee        *Amount                                `json:"l1BlobBaseFee,omitempty" ts_doc:"L1 blob base fee used for the L1 data fee on OP stack networks."`
+	L1BlobBaseFeeScalar  *big.Int                               `json:"l1BlobBaseFeeScalar,omitempty" ts_doc:"Scalar applied to the L1 blob base fee on OP stack networks."`
+	GasUsedForL1         *big.Int                               `json:"gasUsedForL1,omitempty" ts_doc:"Part of gasUsed paying for the L1 data posting on Arbitrum, already included in the fee of gasUsed."`
 	Data                 string                                 `json:"data,omitempty" ts_doc:"Hex-encoded input data for the transaction."`
 	ParsedData           *bchain.EthereumParsedInputData        `json:"parsedData,omitempty" ts_doc:"Decoded transaction data (function name, params, etc.)."`
 	InternalTransfers    []EthereumInternalTransfer             `json:"internalTransfers,omitempty" ts_doc:"List of internal (sub-call) transfers."`
+	UserOperations       []EthereumUserOperation                `json:"userOperations,omitempty" ts_doc:"ERC-4337 UserOperations executed by the transaction (bundled by an EntryPoint contract)."`
+	AuthorizationList    []EthereumAuthorization                `json:"authorizationList,omitempty" ts_doc:"EIP-7702 authorizations of a set-code (type 4) transaction."`
+}
+
+// EthereumAuthorization is an EIP-7702 authorization of a set-code transaction
+type EthereumAuthorization struct {
+	ChainID   *Amount `json:"chainId" ts_doc:"Chain ID the authorization is valid for, 0 for any chain."`
+	Address   string  `json:"address" ts_doc:"Address of the code the authority delegates to, the zero address clears the delegation."`
+	Nonce     uint64  `json:"nonce" ts_doc:"Nonce of the authority account."`
+	Authority string  `json:"authority,omitempty" ts_doc:"Account which signed the authorization, omitted if the signature is invalid."`
+}
+
+// EthereumUserOperation is an ERC-4337 UserOperation executed in an Ethereum-type transaction
+type EthereumUserOperation struct {
+	UserOpHash    string                          `json:"userOpHash" ts_doc:"Hash of the UserOperation."`
+	EntryPoint    string                          `json:"entryPoint" ts_doc:"EntryPoint contract which executed the UserOperation."`
+	Sender        string                          `json:"sender" ts_doc:"Smart account which sent the UserOperation."`
+	Paymaster     string                          `json:"paymaster,omitempty" ts_doc:"Paymaster which paid for the UserOperation, omitted if the sender paid."`
+	Nonce         *Amount                         `json:"nonce" ts_doc:"Nonce of the UserOperation."`
+	Success       bool                            `json:"success" ts_doc:"Whether the execution of the UserOperation succeeded."`
+	ActualGasCost *Amount                         `json:"actualGasCost" ts_doc:"Actual gas cost paid for the UserOperation (in Wei)."`
+	ActualGasUsed *Amount                         `json:"actualGasUsed" ts_doc:"Actual gas used by the UserOperation."`
+	CallData      string                          `json:"callData,omitempty" ts_doc:"Call data executed by the smart account, if the transaction calls the EntryPoint directly."`
+	ParsedData    *bchain.EthereumParsedInputData `json:"parsedData,omitempty" ts_doc:"Decoded call data of the UserOperation."`
+}
+
+// SimulatedBalanceChange is a change of the balance of an address caused by a simulated transaction
+type SimulatedBalanceChange struct {
+	Address  string  `json:"address" ts_doc:"Address whose balance changes."`
+	Contract string  `json:"contract,omitempty" ts_doc:"Contract of the token, empty for the native coin."`
+	Symbol   string  `json:"symbol,omitempty" ts_doc:"Token symbol."`
+	Decimals int     `json:"decimals" ts_doc:"Number of decimals of the coin or token."`
+	Delta    *Amount `json:"delta" ts_doc:"Signed change of the balance (in base units), excluding the transaction fee."`
+}
+
+// SimulatedTx contains the result of an Ethereum-type transaction simulated without broadcasting it
+type SimulatedTx struct {
+	Traced            bool                       `json:"traced" ts_doc:"True if the simulation was traced; if false, the backend supports only eth_call and transfers are not available."`
+	Status            bchain.TxStatus            `json:"status" ts_doc:"Execution status of the simulated transaction (1: success, 0: fail)."`
+	Error             string                     `json:"error,omitempty" ts_doc:"Revert reason or execution error of a failed simulation."`
+	GasUsed           uint64                     `json:"gasUsed" ts_doc:"Gas used by the simulated execution (an eth_estimateGas estimate if not traced)."`
+	From              string                     `json:"from" ts_doc:"Sender of the simulated transaction."`
+	To                string                     `json:"to,omitempty" ts_doc:"Recipient of the simulated transaction."`
+	Value             *Amount                    `json:"value" ts_doc:"Value (in base units) sent by the simulated transaction."`
+	CreatedContract   string                     `json:"createdContract,omitempty" ts_doc:"Address of the contract the simulated transaction would create."`
+	InternalTransfers []EthereumInternalTransfer `json:"internalTransfers,omitempty" ts_doc:"Internal transfers of the simulated execution."`
+	TokenTransfers    []TokenTransfer            `json:"tokenTransfers,omitempty" ts_doc:"Token transfers of the simulated execution."`
+	BalanceChanges    []SimulatedBalanceChange   `json:"balanceChanges,omitempty" ts_doc:"Balance changes of the native coin and fungible tokens caused by the simulated transaction."`
 }
 
 // AddressAlias holds a specialized alias for an address
@@ -354,6 +406,9 @@ type Tx struct {
 	TokenTransfers         []TokenTransfer   `json:"tokenTransfers,omitempty" ts_doc:"List of token transfers that occurred in this transaction."`
 	EthereumSpecific       *EthereumSpecific `json:"ethereumSpecific,omitempty" ts_doc:"Ethereum-like blockchain specific data (if applicable)."`
 	AddressAliases         AddressAliasesMap `json:"addressAliases,omitempty" ts_doc:"Aliases for addresses involved in this transaction."`
+	ReplacedBy             string            `json:"replacedBy,omitempty" ts_doc:"Transaction which replaced this mempool transaction (RBF or the same nonce). If the backend no longer knows the replaced transaction, only txid and replacedBy are set."`
+	Replaces               []string          `json:"replaces,omitempty" ts_doc:"Mempool transactions replaced by this transaction."`
+	MempoolPackage         *MempoolPackage   `json:"mempoolPackage,omitempty" ts_doc:"Ancestor and descendant package data of an unconfirmed transaction (Bitcoin-type), returned if requested by mempoolPackage."`
 }
 
 // FeeStats contains detailed block fee statistics
@@ -364,6 +419,122 @@ type FeeStats struct {
 	DecilesFeePerKb [11]int64 `json:"decilesFeePerKb" ts_doc:"Fee distribution deciles (0%..100%) in satoshi or base units per kB."`
 }
 
+// MempoolPackage contains the data of the packages of a mempool transaction with its in-mempool ancestors and descendants
+type MempoolPackage struct {
+	VSize             int64    `json:"vsize" ts_doc:"Virtual size of the transaction."`
+	Fees              *Amount  `json:"fees" ts_doc:"Fee of the transaction in satoshi."`
+	FeeRate           float64  `json:"feeRate" ts_doc:"Fee rate of the transaction in sat/vB."`
+	AncestorCount     int      `json:"ancestorCount" ts_doc:"Number of in-mempool ancestors including the transaction."`
+	AncestorSize      int64    `json:"ancestorSize" ts_doc:"Virtual size of the in-mempool ancestors including the transaction."`
+	AncestorFees      *Amount  `json:"ancestorFees" ts_doc:"Fees of the in-mempool ancestors including the transaction in satoshi."`
+	AncestorFeeRate   float64  `json:"ancestorFeeRate" ts_doc:"Package fee rate of the transaction with its ancestors in sat/vB."`
+	DescendantCount   int      `json:"descendantCount" ts_doc:"Number of in-mempool descendants including the transaction."`
+	DescendantSize    int64    `json:"descendantSize" ts_doc:"Virtual size of the in-mempool descendants including the transaction."`
+	DescendantFees    *Amount  `json:"descendantFees" ts_doc:"Fees of the in-mempool descendants including the transaction in satoshi."`
+	DescendantFeeRate float64  `json:"descendantFeeRate" ts_doc:"Fee rate of the transaction with its descendants in sat/vB."`
+	EffectiveFeeRate  float64  `json:"effectiveFeeRate" ts_doc:"Fee rate at which the transaction is expected to be mined, taking into account the ancestors and the descendants (CPFP) in sat/vB."`
+	Depends           []string `json:"depends,omitempty" ts_doc:"Unconfirmed parent transactions."`
+	SpentBy           []string `json:"spentBy,omitempty" ts_doc:"Unconfirmed child transactions."`
+	Bip125Replaceable bool     `json:"bip125Replaceable" ts_doc:"True if the transaction signals replaceability (BIP125)."`
+}
+
+// FeeBumpCpfpOutput is an output of the transaction which can be spent by the CPFP child
+type FeeBumpCpfpOutput struct {
+	N        int     `json:"n" ts_doc:"Index of the output."`
+	ValueSat *Amount `json:"value" ts_doc:"Value of the output in satoshi."`
+	Address  string  `json:"address,omitempty" ts_doc:"Address of the output."`
+}
+
+// FeeBumpCpfp is the suggestion of a child transaction paying for the parent
+type FeeBumpCpfp struct {
+	ChildVSize int64               `json:"childVSize" ts_doc:"Assumed virtual size of the child transaction spending one output to one output."`
+	ChildFee   *Amount             `json:"childFee" ts_doc:"Fee of the child transaction in satoshi needed to reach the target fee rate of the package."`
+	Outputs    []FeeBumpCpfpOutput `json:"outputs" ts_doc:"Outputs of the transaction whose value covers the child fee."`
+}
+
+// FeeBumpRbf is the suggestion of a replacement transaction
+type FeeBumpRbf struct {
+	Fee           *Amount `json:"fee" ts_doc:"Minimum fee of the replacement of the same size in satoshi."`
+	AdditionalFee *Amount `json:"additionalFee" ts_doc:"Fee to add to the original fee in satoshi."`
+	Signaled      bool    `json:"signaled" ts_doc:"True if the transaction signals replaceability (BIP125), otherwise the replacement relies on the full RBF policy of the nodes."`
+}
+
+// FeeBump contains the suggestions how to speed up a stuck mempool transaction
+type FeeBump struct {
+	Txid             string       `json:"txid" ts_doc:"Stuck transaction."`
+	TargetFeeRate    float64      `json:"targetFeeRate" ts_doc:"Requested fee rate in sat/vB."`
+	EffectiveFeeRate float64      `json:"effectiveFeeRate" ts_doc:"Current effective fee rate of the transaction in sat/vB."`
+	Cpfp             *FeeBumpCpfp `json:"cpfp,omitempty" ts_doc:"Child-pays-for-parent suggestion, omitted if the package already pays the target fee rate."`
+	Rbf              *FeeBumpRbf  `json:"rbf,omitempty" ts_doc:"Replace-by-fee suggestion, omitted if the transaction already pays the target fee rate."`
+}
+
+// DroppedTx is a transaction which left the mempool without being confirmed
+type DroppedTx struct {
+	Txid            string `json:"txid" ts_doc:"Dropped transaction."`
+	DroppedTime     int64  `json:"droppedTime" ts_doc:"Unix timestamp when the transaction was found dropped."`
+	ConflictingTxid string `json:"conflictingTxid,omitempty" ts_doc:"Confirmed transaction spending the same inputs, if known."`
+}
+
+// BroadcastStatus is the state of a transaction broadcast through Blockbook
+type BroadcastStatus string
+
+const (
+	// BroadcastAccepted - the backend accepted the transaction, it was not yet seen in the mempool
+	BroadcastAccepted BroadcastStatus = "accepted"
+	// BroadcastInMempool - the transaction is in the mempool
+	BroadcastInMempool BroadcastStatus = "mempool"
+	// BroadcastConfirmed - the transaction was included in a block
+	BroadcastConfirmed BroadcastStatus = "confirmed"
+	// BroadcastDropped - the transaction is missing from the mempool and is not confirmed
+	BroadcastDropped BroadcastStatus = "dropped"
+	// BroadcastReplaced - the transaction was replaced or double spent by another transaction
+	BroadcastReplaced BroadcastStatus = "replaced"
+)
+
+// BroadcastTx is the status of a transaction broadcast through Blockbook
+type BroadcastTx struct {
+	Txid              string          `json:"txid"`
+	Status            BroadcastStatus `json:"status" ts_type:"'accepted' | 'mempool' | 'confirmed' | 'dropped' | 'replaced'" ts_doc:"Broadcast status of the transaction."`
+	FirstBroadcast    int64           `json:"firstBroadcast" ts_doc:"Unix timestamp of the first broadcast."`
+	LastBroadcast     int64           `json:"lastBroadcast" ts_doc:"Unix timestamp of the last broadcast or rebroadcast."`
+	Broadcasts        int             `json:"broadcasts" ts_doc:"Number of broadcasts including the rebroadcasts."`
+	LastSeenInMempool int64           `json:"lastSeenInMempool,omitempty" ts_doc:"Unix timestamp when the transaction was last seen in the mempool."`
+	BlockHeight       uint32          `json:"blockHeight,omitempty" ts_doc:"Height of the block containing the confirmed transaction."`
+	ReplacedBy        string          `json:"replacedBy,omitempty" ts_doc:"Transaction which replaced or double spent the transaction."`
+	LastError         string          `json:"lastError,omitempty" ts_doc:"Error returned by the backend for the last rebroadcast."`
+	Rebroadcasting    bool            `json:"rebroadcasting" ts_doc:"True while Blockbook rebroadcasts the transaction if it is missing from the mempool."`
+}
+
+// MempoolBlock is a block projected from the mempool transactions
+type MempoolBlock struct {
+	Size          int64     `json:"size" ts_doc:"Virtual size (Bitcoin-type) or the sum of gas limits (Ethereum-type) of the transactions in the block."`
+	TxCount       int       `json:"txCount" ts_doc:"Number of transactions in the block."`
+	TotalFeesSat  *Amount   `json:"totalFeesSat" ts_doc:"Sum of the fees (maximum fees for Ethereum-type) in satoshi or base units."`
+	MedianFeeRate float64   `json:"medianFeeRate" ts_doc:"Median effective fee rate of the block in feeRateUnit."`
+	FeeRange      []float64 `json:"feeRange" ts_doc:"Minimum, 10th, 25th, 50th, 75th, 90th percentile and maximum of the effective fee rates in feeRateUnit."`
+}
+
+// MempoolBlocks contains the next blocks projected from the mempool
+type MempoolBlocks struct {
+	FeeRateUnit string         `json:"feeRateUnit" ts_doc:"Unit of the fee rates, sat/vB for Bitcoin-type or Gwei for Ethereum-type chains."`
+	Time        int64          `json:"time" ts_doc:"Unix timestamp of the projection."`
+	Blocks      []MempoolBlock `json:"blocks" ts_doc:"Projected blocks from the next one, the last block contains all remaining transactions."`
+}
+
+// MempoolFeeHistogramBucket holds the mempool transactions in a fee rate range
+type MempoolFeeHistogramBucket struct {
+	FeeRate float64 `json:"feeRate" ts_doc:"Lower bound of the effective fee rate of the bucket in feeRateUnit."`
+	Size    int64   `json:"size" ts_doc:"Virtual size or gas limit of the transactions in the bucket."`
+	TxCount int     `json:"txCount" ts_doc:"Number of transactions in the bucket."`
+}
+
+// MempoolFeeHistogram is the histogram of the effective fee rates of the mempool transactions
+type MempoolFeeHistogram struct {
+	FeeRateUnit string                      `json:"feeRateUnit" ts_doc:"Unit of the fee rates, sat/vB for Bitcoin-type or Gwei for Ethereum-type chains."`
+	Time        int64                       `json:"time" ts_doc:"Unix timestamp of the histogram."`
+	Buckets     []MempoolFeeHistogramBucket `json:"buckets" ts_doc:"Non-empty buckets ordered from the highest fee rate."`
+}
+
 // Paging contains information about paging for address, blocks and block
 type Paging struct {
 	Page        int `json:"page,omitempty" ts_doc:"Current page index."`
@@ -405,6 +576,14 @@ type AddressFilter struct {
 	// WithConfirmedNonce set to true makes the Ethereum-like address response include the confirmed nonce,
 	// which requires an extra eth_getTransactionCount("latest") backend call; off by default to avoid that cost.
 	WithConfirmedNonce bool `ts_doc:"If true, additionally fetch and return the confirmed nonce for Ethereum-like addresses (extra backend call)."`
+	// WithDiagnostics set to true makes the Ethereum-like address response include the diagnostics of the pending transactions,
+	// which requires the confirmed nonce and the current fees from the backend
+	WithDiagnostics bool `ts_doc:"If true, additionally return the diagnostics of stuck pending transactions for Ethereum-like addresses (extra backend calls)."`
+	// WithDelegation set to true makes the Ethereum-like address response include the EIP-7702 delegation of the account,
+	// which requires an extra eth_getCode backend call; off by default to avoid that cost.
+	WithDelegation bool `ts_doc:"If true, additionally fetch and return the EIP-7702 delegation of Ethereum-like addresses (extra backend call)."`
+	// Cursor is the opaque cursor returned in the previous response, it selects the page of the transaction history instead of the page number
+	Cursor string `ts_doc:"Opaque cursor of the page of the transaction history, used instead of the page number."`
 }
 
 // StakingPool holds data about address participation in a staking pool contract
@@ -420,43 +599,108 @@ type StakingPool struct {
 	AutocompoundBalance     *Amount `json:"autocompoundBalance" ts_doc:"Any balance automatically reinvested into the pool."`
 }
 
+// EthereumWithdrawal is an EIP-4895 beacon chain withdrawal crediting an address without a transaction
+type EthereumWithdrawal struct {
+	Index          uint64  `json:"index" ts_doc:"Global index of the withdrawal."`
+	ValidatorIndex uint64  `json:"validatorIndex" ts_doc:"Index of the validator the withdrawal comes from."`
+	Address        string  `json:"address" ts_doc:"Address credited by the withdrawal."`
+	AmountSat      *Amount `json:"amount" ts_doc:"Withdrawn amount (in Wei)."`
+	BlockHeight    uint32  `json:"blockHeight" ts_doc:"Height of the block containing the withdrawal."`
+	BlockTime      int64   `json:"blockTime" ts_doc:"Unix timestamp of the block containing the withdrawal."`
+	Confirmations  uint32  `json:"confirmations" ts_doc:"Number of confirmations of the withdrawal."`
+}
+
+// EthereumNonceGap is a range of nonces missing between the confirmed nonce and the pending transactions of an account
+type EthereumNonceGap struct {
+	From uint64 `json:"from" ts_doc:"First missing nonce."`
+	To   uint64 `json:"to" ts_doc:"Last missing nonce (inclusive)."`
+}
+
+// EthereumReplacementFees are the suggested fees of a transaction replacing a stuck one with the same nonce
+type EthereumReplacementFees struct {
+	MaxFeePerGas         *Amount `json:"maxFeePerGas" ts_doc:"Suggested fee cap per gas (in Wei)."`
+	MaxPriorityFeePerGas *Amount `json:"maxPriorityFeePerGas" ts_doc:"Suggested priority fee per gas (in Wei)."`
+}
+
+// EthereumPendingTxDiagnostics describes the state of a pending transaction of an account
+type EthereumPendingTxDiagnostics struct {
+	Txid                 string                   `json:"txid"`
+	Nonce                uint64                   `json:"nonce"`
+	FirstSeen            int64                    `json:"firstSeen" ts_doc:"Unix timestamp when the transaction was first seen in the mempool."`
+	MaxFeePerGas         *Amount                  `json:"maxFeePerGas,omitempty" ts_doc:"Fee cap per gas of the transaction (gas price of legacy transactions)."`
+	MaxPriorityFeePerGas *Amount                  `json:"maxPriorityFeePerGas,omitempty" ts_doc:"Priority fee cap per gas of the transaction (gas price of legacy transactions)."`
+	NonceUsed            bool                     `json:"nonceUsed,omitempty" ts_doc:"The nonce was already used by a confirmed transaction, this transaction cannot be mined."`
+	BelowBaseFee         bool                     `json:"belowBaseFee,omitempty" ts_doc:"The fee cap is below the current base fee."`
+	LowPriorityFee       bool                     `json:"lowPriorityFee,omitempty" ts_doc:"The priority fee is below the current low priority fee estimate."`
+	BlockedByNonceGap    bool                     `json:"blockedByNonceGap,omitempty" ts_doc:"A lower nonce is missing, the transaction waits until the gap is filled."`
+	BlockedBy            string                   `json:"blockedBy,omitempty" ts_doc:"Stuck pending transaction with a lower nonce which blocks this transaction."`
+	Replacement          *EthereumReplacementFees `json:"replacement,omitempty" ts_doc:"Suggested fees of a replacement with the same nonce, if the transaction is stuck because of low fees."`
+}
+
+// EthereumAccountDiagnostics describes the pending transactions of an account and the reasons why they are stuck
+type EthereumAccountDiagnostics struct {
+	ConfirmedNonce uint64                         `json:"confirmedNonce" ts_doc:"Nonce of the next transaction to be mined."`
+	PendingNonce   uint64                         `json:"pendingNonce" ts_doc:"Next nonce reported by the backend including its pending transactions."`
+	BaseFeePerGas  *Amount                        `json:"baseFeePerGas,omitempty" ts_doc:"Current base fee per gas (in Wei), if known."`
+	Stuck          bool                           `json:"stuck" ts_doc:"True if any pending transaction is stuck."`
+	NonceGaps      []EthereumNonceGap             `json:"nonceGaps,omitempty" ts_doc:"Nonces missing before the pending transactions."`
+	PendingTxs     []EthereumPendingTxDiagnostics `json:"pendingTxs,omitempty" ts_doc:"Pending transactions of the account ordered by nonce."`
+}
+
 // Address holds information about an address and its transactions
 type Address struct {
 	Paging
-	AddrStr               string              `json:"address" ts_doc:"The address string in standard format."`
-	BalanceSat            *Amount             `json:"balance" ts_doc:"Current confirmed balance (in satoshi or base units)."`
-	TotalReceivedSat      *Amount             `json:"totalReceived,omitempty" ts_doc:"Total amount ever received by this address."`
-	TotalSentSat          *Amount             `json:"totalSent,omitempty" ts_doc:"Total amount ever sent by this address."`
-	UnconfirmedBalanceSat *Amount             `json:"unconfirmedBalance,omitempty" ts_doc:"Unconfirmed balance for this address. Omitted for AccountDetailsBasic, where mempool transactions are not aggregated."`
-	UnconfirmedTxs        int                 `json:"unconfirmedTxs" ts_doc:"Number of unconfirmed transactions for this address."`
-	UnconfirmedSending    *Amount             `json:"unconfirmedSending,omitempty" ts_doc:"Unconfirmed outgoing balance for this address."`
-	UnconfirmedReceiving  *Amount             `json:"unconfirmedReceiving,omitempty" ts_doc:"Unconfirmed incoming balance for this address."`
-	Txs                   int                 `json:"txs" ts_doc:"Number of transactions for this address (including confirmed)."`
-	AddrTxCount           int                 `json:"addrTxCount,omitempty" ts_doc:"Historical total count of transactions, if known."`
-	NonTokenTxs           int                 `json:"nonTokenTxs,omitempty" ts_doc:"Number of transactions not involving tokens (pure coin transfers)."`
-	InternalTxs           int                 `json:"internalTxs,omitempty" ts_doc:"Number of internal transactions (e.g., Ethereum calls)."`
-	Transactions          []*Tx               `json:"transactions,omitempty" ts_doc:"List of transaction details (if requested)."`
-	Txids                 []string            `json:"txids,omitempty" ts_doc:"List of transaction IDs (if detailed data is not requested)."`
-	Nonce                 string              `json:"nonce,omitempty" ts_doc:"Current (pending) transaction nonce for Ethereum-like addresses, including mempool transactions. This is the next nonce the account will use."`
-	ConfirmedNonce        string              `json:"confirmedNonce,omitempty" ts_doc:"Confirmed transaction nonce for Ethereum-like addresses, reflecting only mined transactions (eth_getTransactionCount at the latest block). Equals nonce when the account has no pending transactions."`
-	UsedTokens            int                 `json:"usedTokens,omitempty" ts_doc:"Number of tokens with any historical usage at this address."`
-	Tokens                Tokens              `json:"tokens,omitempty" ts_doc:"List of tokens associated with this address."`
-	SecondaryValue        float64             `json:"secondaryValue,omitempty" ts_doc:"Total value of the address in secondary currency (e.g. fiat)."`
-	TokensBaseValue       float64             `json:"tokensBaseValue,omitempty" ts_doc:"Sum of token values in base currency."`
-	TokensSecondaryValue  float64             `json:"tokensSecondaryValue,omitempty" ts_doc:"Sum of token values in secondary currency (fiat)."`
-	TotalBaseValue        float64             `json:"totalBaseValue,omitempty" ts_doc:"Address's entire value in base currency, including tokens."`
-	TotalSecondaryValue   float64             `json:"totalSecondaryValue,omitempty" ts_doc:"Address's entire value in secondary currency, including tokens."`
-	ContractInfo          *ContractInfoResult `json:"contractInfo,omitempty" ts_doc:"Extra info if the address is a contract. Shape matches getContractInfo; rates and protocols are populated only when explicitly requested via getContractInfo."`
+	AddrStr               string               `json:"address" ts_doc:"The address string in standard format."`
+	BalanceSat            *Amount              `json:"balance" ts_doc:"Current confirmed balance (in satoshi or base units)."`
+	TotalReceivedSat      *Amount              `json:"totalReceived,omitempty" ts_doc:"Total amount ever received by this address."`
+	TotalSentSat          *Amount              `json:"totalSent,omitempty" ts_doc:"Total amount ever sent by this address."`
+	UnconfirmedBalanceSat *Amount              `json:"unconfirmedBalance,omitempty" ts_doc:"Unconfirmed balance for this address. Omitted for AccountDetailsBasic, where mempool transactions are not aggregated."`
+	UnconfirmedTxs        int                  `json:"unconfirmedTxs" ts_doc:"Number of unconfirmed transactions for this address."`
+	UnconfirmedSending    *Amount              `json:"unconfirmedSending,omitempty" ts_doc:"Unconfirmed outgoing balance for this address."`
+	UnconfirmedReceiving  *Amount              `json:"unconfirmedReceiving,omitempty" ts_doc:"Unconfirmed incoming balance for this address."`
+	Txs                   int                  `json:"txs" ts_doc:"Number of transactions for this address (including confirmed)."`
+	AddrTxCount           int                  `json:"addrTxCount,omitempty" ts_doc:"Historical total count of transactions, if known."`
+	NonTokenTxs           int                  `json:"nonTokenTxs,omitempty" ts_doc:"Number of transactions not involving tokens (pure coin transfers)."`
+	InternalTxs           int                  `json:"internalTxs,omitempty" ts_doc:"Number of internal transactions (e.g., Ethereum calls)."`
+	Transactions          []*Tx                `json:"transactions,omitempty" ts_doc:"List of transaction details (if requested)."`
+	Txids                 []string             `json:"txids,omitempty" ts_doc:"List of transaction IDs (if detailed data is not requested)."`
+	Withdrawals           []EthereumWithdrawal `json:"withdrawals,omitempty" ts_doc:"EIP-4895 beacon chain withdrawals credited to the address, ordered by block height together with the transactions of the page and counted in its paging."`
+	NextCursor            string               `json:"nextCursor,omitempty" ts_doc:"Cursor of the page of the older confirmed transactions, omitted if there are none."`
+	PrevCursor            string               `json:"prevCursor,omitempty" ts_doc:"Cursor of the page of the newer confirmed transactions, keep it to fetch the transactions confirmed later."`
+	Nonce                 string               `json:"nonce,omitempty" ts_doc:"Current (pending) transaction nonce for Ethereum-like addresses, including mempool transactions. This is the next nonce the account will use."`
+	ConfirmedNonce        string               `json:"confirmedNonce,omitempty" ts_doc:"Confirmed transaction nonce for Ethereum-like addresses, reflecting only mined transactions (eth_getTransactionCount at the latest block). Equals nonce when the account has no pending transactions."`
+	Delegation            string               `json:"delegation,omitempty" ts_doc:"Address the account currently delegates its code to using EIP-7702, if any. Returned only if requested (extra backend call)."`
+	UsedTokens            int                  `json:"usedTokens,omitempty" ts_doc:"Number of tokens with any historical usage at this address."`
+	Tokens                Tokens               `json:"tokens,omitempty" ts_doc:"List of tokens associated with this address."`
+	SecondaryValue        float64              `json:"secondaryValue,omitempty" ts_doc:"Total value of the address in secondary currency (e.g. fiat)."`
+	TokensBaseValue       float64              `json:"tokensBaseValue,omitempty" ts_doc:"Sum of token values in base currency."`
+	TokensSecondaryValue  float64              `json:"tokensSecondaryValue,omitempty" ts_doc:"Sum of token values in secondary currency (fiat)."`
+	TotalBaseValue        float64              `json:"totalBaseValue,omitempty" ts_doc:"Address's entire value in base currency, including tokens."`
+	TotalSecondaryValue   float64              `json:"totalSecondaryValue,omitempty" ts_doc:"Address's entire value in secondary currency, including tokens."`
+	ContractInfo          *ContractInfoResult  `json:"contractInfo,omitempty" ts_doc:"Extra info if the address is a contract. Shape matches getContractInfo; rates and protocols are populated only when explicitly requested via getContractInfo."`
 	// Deprecated: replaced by ContractInfo
-	Erc20Contract  *ContractInfoResult    `json:"erc20Contract,omitempty" ts_doc:"@deprecated: replaced by contractInfo"`
-	AddressAliases AddressAliasesMap      `json:"addressAliases,omitempty" ts_doc:"Aliases assigned to this address."`
-	StakingPools   []StakingPool          `json:"stakingPools,omitempty" ts_doc:"List of staking pool data if address interacts with staking."`
-	ChainExtraData *AccountChainExtraData `json:"chainExtraData,omitempty" ts_type:"{ payloadType: 'tron'; payload?: TronAccountExtraData } | { payloadType: string; payload?: any }" ts_doc:"Additional normalized chain-specific account/address data. Use payloadType as discriminator for payload."`
+	Erc20Contract  *ContractInfoResult         `json:"erc20Contract,omitempty" ts_doc:"@deprecated: replaced by contractInfo"`
+	AddressAliases AddressAliasesMap           `json:"addressAliases,omitempty" ts_doc:"Aliases assigned to this address."`
+	StakingPools   []StakingPool               `json:"stakingPools,omitempty" ts_doc:"List of staking pool data if address interacts with staking."`
+	Diagnostics    *EthereumAccountDiagnostics `json:"diagnostics,omitempty" ts_doc:"Diagnostics of the pending transactions for Ethereum-like addresses, if requested."`
+	ChainExtraData *AccountChainExtraData      `json:"chainExtraData,omitempty" ts_type:"{ payloadType: 'tron'; payload?: TronAccountExtraData } | { payloadType: string; payload?: any }" ts_doc:"Additional normalized chain-specific account/address data. Use payloadType as discriminator for payload."`
 	// helpers for explorer
 	Filter        string              `json:"-" ts_doc:"Filter used internally for data retrieval."`
 	XPubAddresses map[string]struct{} `json:"-" ts_doc:"Set of derived XPUB addresses (internal usage)."`
 }
 
+// AccountsInfoEntry is the result of one address or XPUB descriptor of a batch query
+type AccountsInfoEntry struct {
+	Descriptor string   `json:"descriptor" ts_doc:"Address or XPUB descriptor from the request."`
+	Account    *Address `json:"account,omitempty" ts_doc:"Account data, omitted if the entry failed."`
+	Error      string   `json:"error,omitempty" ts_doc:"Error of the entry, the other entries are not affected."`
+}
+
+// AccountsInfo is the result of a batch query of addresses and XPUB descriptors
+type AccountsInfo struct {
+	Accounts []AccountsInfoEntry `json:"accounts" ts_doc:"Results in the order of the request."`
+}
+
 // Utxo is one unspent transaction output
 type Utxo struct {
 	Txid          string  `json:"txid" ts_doc:"Transaction ID in which this UTXO was created."`
@@ -492,6 +736,7 @@ func (a Utxos) Less(i, j int) bool {
 type BalanceHistory struct {
 	Time          uint32             `json:"time" ts_doc:"Unix timestamp for this point in the balance history."`
 	Txs           uint32             `json:"txs" ts_doc:"Number of transactions in this interval."`
+	Withdrawals   uint32             `json:"withdrawals,omitempty" ts_doc:"Number of beacon chain withdrawals in this interval, their amount is included in received."`
 	ReceivedSat   *Amount            `json:"received" ts_doc:"Amount received in this interval (in satoshi or base units)."`
 	SentSat       *Amount            `json:"sent" ts_doc:"Amount sent in this interval (in satoshi or base units)."`
 	SentToSelfSat *Amount            `json:"sentToSelf" ts_doc:"Amount sent to the same address (self-transfer)."`
@@ -543,11 +788,12 @@ func (a BalanceHistories) SortAndAggregate(groupByTime uint32) BalanceHistories
 				bha.Txs += bh.Txs
 				bha.Txid = bh.Txid
 			}
+			bha.Withdrawals += bh.Withdrawals
 			(*big.Int)(bha.ReceivedSat).Add((*big.Int)(bha.ReceivedSat), (*big.Int)(bh.ReceivedSat))
 			(*big.Int)(bha.SentSat).Add((*big.Int)(bha.SentSat), (*big.Int)(bh.SentSat))
 			(*big.Int)(bha.SentToSelfSat).Add((*big.Int)(bha.SentToSelfSat), (*big.Int)(bh.SentToSelfSat))
 		}
-		if bha.Txs > 0 {
+		if bha.Txs > 0 || bha.Withdrawals > 0 {
 			bha.Txid = ""
 			bhs = append(bhs, bha)
 		}
@@ -592,33 +838,60 @@ type BlockRaw struct {
 	Hex string `json:"hex" ts_doc:"Hex-encoded block data."`
 }
 
+// ChainEventType is the kind of the change of the indexed chain
+type ChainEventType string
+
+const (
+	// ChainEventConnect - the block was connected to the chain
+	ChainEventConnect ChainEventType = "connect"
+	// ChainEventDisconnect - the block was disconnected from the chain by a reorg
+	ChainEventDisconnect ChainEventType = "disconnect"
+)
+
+// ChainEvent is a connection or a disconnection of a block
+type ChainEvent struct {
+	Type   ChainEventType `json:"type" ts_type:"'connect' | 'disconnect'" ts_doc:"Kind of the change of the chain."`
+	Height uint32         `json:"height" ts_doc:"Height of the block."`
+	Hash   string         `json:"hash" ts_doc:"Hash of the block."`
+	Time   int64          `json:"time,omitempty" ts_doc:"Timestamp of the connected block."`
+	Txids  []string       `json:"txids,omitempty" ts_doc:"Transactions of the disconnected block, they lost their confirmation."`
+}
+
+// ChainEvents is the list of the changes of the chain since a block
+type ChainEvents struct {
+	BestHeight uint32       `json:"bestHeight" ts_doc:"Height of the best indexed block."`
+	Events     []ChainEvent `json:"events" ts_doc:"Disconnections of the blocks of the client ordered from the highest followed by the connections of the blocks of the current chain, at most 1000 events."`
+	Resync     bool         `json:"resync,omitempty" ts_doc:"True if the disconnection of the given block is no longer known, the client must resync its data."`
+}
+
 // BlockbookInfo contains information about the running blockbook instance
 type BlockbookInfo struct {
-	Coin                         string                       `json:"coin" ts_doc:"Coin name, e.g. 'Bitcoin'."`
-	Network                      string                       `json:"network" ts_doc:"Network shortcut, e.g. 'BTC'."`
-	Host                         string                       `json:"host" ts_doc:"Hostname of the blockbook instance, e.g. 'backend5'."`
-	Version                      string                       `json:"version" ts_doc:"Running blockbook version, e.g. '0.4.0'."`
-	GitCommit                    string                       `json:"gitCommit" ts_doc:"Git commit hash of the running blockbook, e.g. 'a0960c8e'."`
-	BuildTime                    string                       `json:"buildTime" ts_doc:"Build time of running blockbook, e.g. '2024-08-08T12:32:50+00:00'."`
-	SyncMode                     bool                         `json:"syncMode" ts_doc:"If true, blockbook is syncing from scratch or in a special sync mode."`
-	InitialSync                  bool                         `json:"initialSync" ts_doc:"Indicates if blockbook is in its initial sync phase."`
-	InSync                       bool                         `json:"inSync" ts_doc:"Indicates if the backend is fully synced with the blockchain."`
-	BestHeight                   uint32                       `json:"bestHeight" ts_doc:"Best (latest) block height according to this instance."`
-	LastBlockTime                time.Time                    `json:"lastBlockTime" ts_doc:"Timestamp of the latest block in the chain."`
-	InSyncMempool                bool                         `json:"inSyncMempool" ts_doc:"Indicates if mempool info is synced as well."`
-	LastMempoolTime              time.Time                    `json:"lastMempoolTime" ts_doc:"Timestamp of the last mempool update."`
-	MempoolSize                  int                          `json:"mempoolSize" ts_doc:"Number of unconfirmed transactions in the mempool."`
-	Decimals                     int                          `json:"decimals" ts_doc:"Number of decimals for this coin's base unit."`
-	DbSize                       int64                        `json:"dbSize" ts_doc:"Size of the underlying database in bytes."`
-	HasFiatRates                 bool                         `json:"hasFiatRates,omitempty" ts_doc:"Whether this instance provides fiat exchange rates."`
-	HasTokenFiatRates            bool                         `json:"hasTokenFiatRates,omitempty" ts_doc:"Whether this instance provides fiat exchange rates for tokens."`
-	CurrentFiatRatesTime         *time.Time                   `json:"currentFiatRatesTime,omitempty" ts_doc:"Timestamp of the latest fiat rates update."`
-	HistoricalFiatRatesTime      *time.Time                   `json:"historicalFiatRatesTime,omitempty" ts_doc:"Timestamp of the latest historical fiat rates update."`
-	HistoricalTokenFiatRatesTime *time.Time                   `json:"historicalTokenFiatRatesTime,omitempty" ts_doc:"Timestamp of the latest historical token fiat rates update."`
-	SupportedStakingPools        []string                     `json:"supportedStakingPools,omitempty" ts_doc:"List of contract addresses supported for staking."`
-	DbSizeFromColumns            int64                        `json:"dbSizeFromColumns,omitempty" ts_doc:"Optional calculated DB size from columns."`
-	DbColumns                    []common.InternalStateColumn `json:"dbColumns,omitempty" ts_doc:"List of columns/tables in the DB for internal state."`
-	About                        string                       `json:"about" ts_doc:"Additional human-readable info about this blockbook instance."`
+	Coin                         string                          `json:"coin" ts_doc:"Coin name, e.g. 'Bitcoin'."`
+	Network                      string                          `json:"network" ts_doc:"Network shortcut, e.g. 'BTC'."`
+	Host                         string                          `json:"host" ts_doc:"Hostname of the blockbook instance, e.g. 'backend5'."`
+	Version                      string                          `json:"version" ts_doc:"Running blockbook version, e.g. '0.4.0'."`
+	GitCommit                    string                          `json:"gitCommit" ts_doc:"Git commit hash of the running blockbook, e.g. 'a0960c8e'."`
+	BuildTime                    string                          `json:"buildTime" ts_doc:"Build time of running blockbook, e.g. '2024-08-08T12:32:50+00:00'."`
+	SyncMode                     bool                            `json:"syncMode" ts_doc:"If true, blockbook is syncing from scratch or in a special sync mode."`
+	InitialSync                  bool                            `json:"initialSync" ts_doc:"Indicates if blockbook is in its initial sync phase."`
+	InSync                       bool                            `json:"inSync" ts_doc:"Indicates if the backend is fully synced with the blockchain."`
+	BestHeight                   uint32                          `json:"bestHeight" ts_doc:"Best (latest) block height according to this instance."`
+	LastBlockTime                time.Time                       `json:"lastBlockTime" ts_doc:"Timestamp of the latest block in the chain."`
+	InSyncMempool                bool                            `json:"inSyncMempool" ts_doc:"Indicates if mempool info is synced as well."`
+	LastMempoolTime              time.Time                       `json:"lastMempoolTime" ts_doc:"Timestamp of the last mempool update."`
+	MempoolSize                  int                             `json:"mempoolSize" ts_doc:"Number of unconfirmed transactions in the mempool."`
+	Decimals                     int                             `json:"decimals" ts_doc:"Number of decimals for this coin's base unit."`
+	DbSize                       int64                           `json:"dbSize" ts_doc:"Size of the underlying database in bytes."`
+	HasFiatRates                 bool                            `json:"hasFiatRates,omitempty" ts_doc:"Whether this instance provides fiat exchange rates."`
+	HasTokenFiatRates            bool                            `json:"hasTokenFiatRates,omitempty" ts_doc:"Whether this instance provides fiat exchange rates for tokens."`
+	CurrentFiatRatesTime         *time.Time                      `json:"currentFiatRatesTime,omitempty" ts_doc:"Timestamp of the latest fiat rates update."`
+	HistoricalFiatRatesTime      *time.Time                      `json:"historicalFiatRatesTime,omitempty" ts_doc:"Timestamp of the latest historical fiat rates update."`
+	HistoricalTokenFiatRatesTime *time.Time                      `json:"historicalTokenFiatRatesTime,omitempty" ts_doc:"Timestamp of the latest historical token fiat rates update."`
+	SupportedStakingPools        []string                        `json:"supportedStakingPools,omitempty" ts_doc:"List of contract addresses supported for staking."`
+	DbSizeFromColumns            int64                           `json:"dbSizeFromColumns,omitempty" ts_doc:"Optional calculated DB size from columns."`
+	DbColumns                    []common.InternalStateColumn    `json:"dbColumns,omitempty" ts_doc:"List of columns/tables in the DB for internal state."`
+	FourByteSignatures           *bchain.FourByteSignaturesState `json:"fourByteSignatures,omitempty" ts_doc:"State of the 4byte signatures import (internal status only)."`
+	About                        string                          `json:"about" ts_doc:"Additional human-readable info about this blockbook instance."`
 }
 
 // SystemInfo contains information about the running blockbook and backend instance
@@ -665,6 +938,7 @@ type Eip1559Fee struct {
 	MaxPriorityFeePerGas *Amount `json:"maxPriorityFeePerGas"`
 	MinWaitTimeEstimate  int     `json:"minWaitTimeEstimate,omitempty"`
 	MaxWaitTimeEstimate  int     `json:"maxWaitTimeEstimate,omitempty"`
+	MaxFeePerBlobGas     *Amount `json:"maxFeePerBlobGas,omitempty" ts_doc:"Max fee per blob gas for blob (type 3) transactions."`
 }
 
 // Eip1559Fees
@@ -680,6 +954,7 @@ type Eip1559Fees struct {
 	HistoricalBaseFeeRange     []*Amount   `json:"historicalBaseFeeRange,omitempty"`
 	PriorityFeeTrend           string      `json:"priorityFeeTrend,omitempty" ts_type:"'up' | 'down'"`
 	BaseFeeTrend               string      `json:"baseFeeTrend,omitempty" ts_type:"'up' | 'down'"`
+	BaseFeePerBlobGas          *Amount     `json:"baseFeePerBlobGas,omitempty" ts_doc:"Estimated blob base fee of the next block, on chains with blob transactions."`
 }
 
 type LongTermFeeRate struct {
@@ -173,6 +173,58 @@ func TestBalanceHistories_SortAndAggregate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "withdrawals",
+			a: []BalanceHistory{
+				{
+					ReceivedSat:   (*Amount)(big.NewInt(1)),
+					SentSat:       (*Amount)(big.NewInt(2)),
+					SentToSelfSat: (*Amount)(big.NewInt(0)),
+					Time:          1521504812,
+					Txid:          "00b2c06055e5e90e9c82bd4181fde310104391a7fa4f289b1704e5d90caa3840",
+					Txs:           1,
+				},
+				{
+					ReceivedSat:   (*Amount)(big.NewInt(100)),
+					SentSat:       (*Amount)(big.NewInt(0)),
+					SentToSelfSat: (*Amount)(big.NewInt(0)),
+					Time:          1521504812,
+					Withdrawals:   1,
+				},
+				{
+					ReceivedSat:   (*Amount)(big.NewInt(50)),
+					SentSat:       (*Amount)(big.NewInt(0)),
+					SentToSelfSat: (*Amount)(big.NewInt(0)),
+					Time:          1521514812,
+					Withdrawals:   1,
+				},
+				{
+					ReceivedSat:   (*Amount)(big.NewInt(60)),
+					SentSat:       (*Amount)(big.NewInt(0)),
+					SentToSelfSat: (*Amount)(big.NewInt(0)),
+					Time:          1521514824,
+					Withdrawals:   1,
+				},
+			},
+			groupByTime: 3600,
+			want: []BalanceHistory{
+				{
+					ReceivedSat:   (*Amount)(big.NewInt(101)),
+					SentSat:       (*Amount)(big.NewInt(2)),
+					SentToSelfSat: (*Amount)(big.NewInt(0)),
+					Time:          1521504000,
+					Txs:           1,
+					Withdrawals:   1,
+				},
+				{
+					ReceivedSat:   (*Amount)(big.NewInt(110)),
+					SentSat:       (*Amount)(big.NewInt(0)),
+					SentToSelfSat: (*Amount)(big.NewInt(0)),
+					Time:          1521514800,
+					Withdrawals:   2,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
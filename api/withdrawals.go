@@ -0,0 +1,103 @@
+package api
+
+import (
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/db"
+)
+
+// addressHistoryEntry is an item of the address history, either a transaction or a beacon chain withdrawal
+type addressHistoryEntry struct {
+	txid       string
+	height     uint32
+	withdrawal *db.Withdrawal
+}
+
+// withdrawalsInAddressHistory returns true if the withdrawals are part of the address history for the filter,
+// withdrawals only credit the native coin and therefore match only an unfiltered or an outputs query
+func (w *Worker) withdrawalsInAddressHistory(filter *AddressFilter) bool {
+	return w.chainType == bchain.ChainEthereumType && filter.Contract == "" &&
+		(filter.Vout == AddressFilterVoutOff || filter.Vout == AddressFilterVoutOutputs)
+}
+
+// getAddressWithdrawals returns up to maxResults newest withdrawals of the address in the filter height range
+// and the total number of the withdrawals in the range, -1 if there are more than maxResults of them.
+// The reading stops after maxResults withdrawals, a validator address may have a very long history.
+func (w *Worker) getAddressWithdrawals(addrDesc bchain.AddressDescriptor, filter *AddressFilter, maxResults int) ([]db.Withdrawal, int, error) {
+	to := filter.ToHeight
+	if to == 0 {
+		to = maxUint32
+	}
+	var withdrawals []db.Withdrawal
+	count := 0
+	err := w.db.GetAddrDescWithdrawals(addrDesc, filter.FromHeight, to, func(wd *db.Withdrawal) error {
+		if len(withdrawals) >= maxResults {
+			count = -1
+			return &db.StopIteration{}
+		}
+		w.work.addDBReads(1)
+		withdrawals = append(withdrawals, *wd)
+		count++
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return withdrawals, count, nil
+}
+
+// mergeAddressHistory merges the txids and withdrawals, both ordered from the newest, into at most maxResults entries,
+// the withdrawals of a block are placed before its transactions
+func mergeAddressHistory(txids []string, heights []uint32, withdrawals []db.Withdrawal, maxResults int) []addressHistoryEntry {
+	n := len(txids) + len(withdrawals)
+	if n > maxResults {
+		n = maxResults
+	}
+	entries := make([]addressHistoryEntry, 0, n)
+	i, j := 0, 0
+	for len(entries) < n {
+		if j < len(withdrawals) && (i >= len(txids) || withdrawals[j].Height >= heights[i]) {
+			entries = append(entries, addressHistoryEntry{height: withdrawals[j].Height, withdrawal: &withdrawals[j]})
+			j++
+		} else {
+			entries = append(entries, addressHistoryEntry{txid: txids[i], height: heights[i]})
+			i++
+		}
+	}
+	return entries
+}
+
+// ethereumWithdrawalFromDb converts the indexed withdrawal credited to the address to the api type
+func (w *Worker) ethereumWithdrawalFromDb(wd *db.Withdrawal, address string, bestheight uint32) EthereumWithdrawal {
+	amount := wd.Amount
+	return EthereumWithdrawal{
+		Index:          wd.Index,
+		ValidatorIndex: wd.ValidatorIndex,
+		Address:        address,
+		AmountSat:      (*Amount)(&amount),
+		BlockHeight:    wd.Height,
+		BlockTime:      int64(w.is.GetBlockTime(wd.Height)),
+		Confirmations:  bestheight - wd.Height + 1,
+	}
+}
+
+// balanceHistoryForWithdrawals returns the balance history entries of the withdrawals credited to the address
+// in the blocks [fromHeight, toHeight] with block time in [fromUnix, toUnix)
+func (w *Worker) balanceHistoryForWithdrawals(addrDesc bchain.AddressDescriptor, fromHeight, toHeight, fromUnix, toUnix uint32) (BalanceHistories, error) {
+	var bhs BalanceHistories
+	err := w.db.GetAddrDescWithdrawals(addrDesc, fromHeight, toHeight, func(wd *db.Withdrawal) error {
+		time := w.is.GetBlockTime(wd.Height)
+		if time < fromUnix || time >= toUnix {
+			return nil
+		}
+		received := wd.Amount
+		bhs = append(bhs, BalanceHistory{
+			Time:          time,
+			Withdrawals:   1,
+			ReceivedSat:   (*Amount)(&received),
+			SentSat:       &Amount{},
+			SentToSelfSat: &Amount{},
+		})
+		return nil
+	})
+	return bhs, err
+}
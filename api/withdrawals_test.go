@@ -0,0 +1,85 @@
+//go:build unittest
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/db"
+)
+
+func Test_mergeAddressHistory(t *testing.T) {
+	withdrawals := []db.Withdrawal{{Height: 30, Index: 7}, {Height: 20, Index: 6}, {Height: 20, Index: 5}, {Height: 5, Index: 4}}
+	txids := []string{"tx31", "tx20", "tx10"}
+	heights := []uint32{31, 20, 10}
+	type entry struct {
+		txid   string
+		height uint32
+		index  uint64
+	}
+	flatten := func(entries []addressHistoryEntry) []entry {
+		r := make([]entry, len(entries))
+		for i, e := range entries {
+			r[i] = entry{txid: e.txid, height: e.height}
+			if e.withdrawal != nil {
+				r[i].index = e.withdrawal.Index
+			}
+		}
+		return r
+	}
+	tests := []struct {
+		name        string
+		txids       []string
+		heights     []uint32
+		withdrawals []db.Withdrawal
+		maxResults  int
+		want        []entry
+	}{
+		{
+			name:       "no withdrawals",
+			txids:      txids,
+			heights:    heights,
+			maxResults: 10,
+			want:       []entry{{txid: "tx31", height: 31}, {txid: "tx20", height: 20}, {txid: "tx10", height: 10}},
+		},
+		{
+			name:        "only withdrawals",
+			withdrawals: withdrawals[:2],
+			maxResults:  10,
+			want:        []entry{{height: 30, index: 7}, {height: 20, index: 6}},
+		},
+		{
+			name:        "withdrawals before txs of the same block",
+			txids:       txids,
+			heights:     heights,
+			withdrawals: withdrawals,
+			maxResults:  10,
+			want: []entry{
+				{txid: "tx31", height: 31},
+				{height: 30, index: 7},
+				{height: 20, index: 6},
+				{height: 20, index: 5},
+				{txid: "tx20", height: 20},
+				{txid: "tx10", height: 10},
+				{height: 5, index: 4},
+			},
+		},
+		{
+			name:        "maxResults",
+			txids:       txids,
+			heights:     heights,
+			withdrawals: withdrawals,
+			maxResults:  3,
+			want:        []entry{{txid: "tx31", height: 31}, {height: 30, index: 7}, {height: 20, index: 6}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flatten(mergeAddressHistory(tt.txids, tt.heights, tt.withdrawals, tt.maxResults))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeAddressHistory() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
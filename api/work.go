@@ -0,0 +1,80 @@
+package api
+
+import (
+	"sync/atomic"
+
+	"github.com/trezor/blockbook/db"
+)
+
+// RequestWork counts the work done by the Worker for one request, so that the client can be charged
+// by the actual cost of the request. The counters are safe for concurrent use and nil-safe,
+// a nil RequestWork counts nothing.
+type RequestWork struct {
+	dbReads          atomic.Int64
+	derivedAddresses atomic.Int64
+	backendCalls     atomic.Int64
+}
+
+// DBReads returns the number of the database reads: index rows, balances and cached transactions
+func (rw *RequestWork) DBReads() int64 {
+	if rw == nil {
+		return 0
+	}
+	return rw.dbReads.Load()
+}
+
+// DerivedAddresses returns the number of the addresses derived from xpubs
+func (rw *RequestWork) DerivedAddresses() int64 {
+	if rw == nil {
+		return 0
+	}
+	return rw.derivedAddresses.Load()
+}
+
+// BackendCalls returns the number of the calls to the backend
+func (rw *RequestWork) BackendCalls() int64 {
+	if rw == nil {
+		return 0
+	}
+	return rw.backendCalls.Load()
+}
+
+func (rw *RequestWork) addDBReads(n int) {
+	if rw != nil {
+		rw.dbReads.Add(int64(n))
+	}
+}
+
+func (rw *RequestWork) addDerivedAddresses(n int) {
+	if rw != nil {
+		rw.derivedAddresses.Add(int64(n))
+	}
+}
+
+func (rw *RequestWork) addBackendCalls(n int) {
+	if rw != nil {
+		rw.backendCalls.Add(int64(n))
+	}
+}
+
+// WithWork returns a copy of the worker which counts its work to the given RequestWork,
+// the copy shares all data with the original worker and is meant to serve one request
+func (w *Worker) WithWork(work *RequestWork) *Worker {
+	if work == nil || w == nil {
+		return w
+	}
+	c := *w
+	c.work = work
+	return &c
+}
+
+// countRows wraps the callback of an index iteration so that each visited row counts as a database read
+func (w *Worker) countRows(fn db.GetTransactionsCallback) db.GetTransactionsCallback {
+	if w.work == nil {
+		return fn
+	}
+	return func(txid string, height uint32, indexes []int32) error {
+		w.work.addDBReads(1)
+		return fn(txid, height, indexes)
+	}
+}
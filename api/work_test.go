@@ -0,0 +1,48 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestRequestWork(t *testing.T) {
+	var none *RequestWork
+	none.addDBReads(1)
+	none.addDerivedAddresses(1)
+	none.addBackendCalls(1)
+	if none.DBReads() != 0 || none.DerivedAddresses() != 0 || none.BackendCalls() != 0 {
+		t.Fatal("nil RequestWork counted work")
+	}
+
+	w := &Worker{}
+	if w.WithWork(nil) != w {
+		t.Fatal("WithWork(nil) copied the worker")
+	}
+	work := &RequestWork{}
+	c := w.WithWork(work)
+	if c == w || c.work != work || w.work != nil {
+		t.Fatal("WithWork did not return a copy counting to the work")
+	}
+
+	rows := 0
+	fn := c.countRows(func(txid string, height uint32, indexes []int32) error {
+		rows++
+		return nil
+	})
+	for i := 0; i < 3; i++ {
+		if err := fn("txid", 1, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.work.addDerivedAddresses(40)
+	c.work.addBackendCalls(2)
+	if rows != 3 || work.DBReads() != 3 || work.DerivedAddresses() != 40 || work.BackendCalls() != 2 {
+		t.Fatalf("rows %d, work %d/%d/%d, want 3, 3/40/2", rows, work.DBReads(), work.DerivedAddresses(), work.BackendCalls())
+	}
+	// the original worker does not count
+	if err := w.countRows(func(string, uint32, []int32) error { return nil })("txid", 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if work.DBReads() != 3 {
+		t.Fatalf("DBReads() = %d after a read of the original worker, want 3", work.DBReads())
+	}
+}
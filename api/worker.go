@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/glog"
 	"github.com/juju/errors"
 	"github.com/trezor/blockbook/bchain"
@@ -35,6 +36,10 @@ type Worker struct {
 	fiatRates         *fiat.FiatRates
 	metrics           *common.Metrics
 	xpubConfig        XpubConfig
+	// work counts the work of the request served by a copy of the worker made by WithWork, nil otherwise
+	work *RequestWork
+	// results coalesces and caches the expensive requests, shared by the copies of the worker
+	results *resultCache
 }
 
 var getTickersForTimestamps = func(fr *fiat.FiatRates, timestamps []int64, vsCurrency string, token string) (*[]*common.CurrencyRatesTicker, error) {
@@ -73,6 +78,7 @@ func NewWorker(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool,
 		fiatRates:         fiatRates,
 		metrics:           metrics,
 		xpubConfig:        xpubCfg,
+		results:           newResultCache(resultCacheCapacity, resultCacheTTL),
 	}
 	if w.chainType == bchain.ChainBitcoinType {
 		w.initXpubCache()
@@ -92,7 +98,7 @@ func (w *Worker) getAddressesFromVout(vout *bchain.Vout) (bchain.AddressDescript
 // setSpendingTxToVout is helper function, that finds transaction that spent given output and sets it to the output
 // there is no direct index for the operation, it must be found using addresses -> txaddresses -> tx
 func (w *Worker) setSpendingTxToVout(vout *Vout, txid string, height uint32) error {
-	err := w.db.GetAddrDescTransactions(vout.AddrDesc, height, maxUint32, func(t string, height uint32, indexes []int32) error {
+	err := w.db.GetAddrDescTransactions(vout.AddrDesc, height, maxUint32, w.countRows(func(t string, height uint32, indexes []int32) error {
 		for _, index := range indexes {
 			// take only inputs
 			if index < 0 {
@@ -104,6 +110,7 @@ func (w *Worker) setSpendingTxToVout(vout *Vout, txid string, height uint32) err
 					glog.Warning("DB inconsistency:  tx ", t, ": not found in txAddresses")
 				} else if len(tsp.Inputs) > int(index) {
 					if tsp.Inputs[index].ValueSat.Cmp((*big.Int)(vout.ValueSat)) == 0 {
+						w.work.addDBReads(1)
 						spentTx, spentHeight, err := w.txCache.GetTransaction(t)
 						if err != nil {
 							glog.Warning("Tx ", t, ": not found")
@@ -122,7 +129,7 @@ func (w *Worker) setSpendingTxToVout(vout *Vout, txid string, height uint32) err
 			}
 		}
 		return nil
-	})
+	}))
 	return err
 }
 
@@ -198,6 +205,7 @@ func (w *Worker) getTxChainExtraData(tx *bchain.Tx) (*TxChainExtraData, error) {
 }
 
 func (w *Worker) getAccountChainExtraData(addrDesc bchain.AddressDescriptor) (*AccountChainExtraData, error) {
+	w.work.addBackendCalls(1)
 	payload, err := w.chain.GetAddressChainExtraData(addrDesc)
 	if err != nil {
 		return nil, err
@@ -247,24 +255,34 @@ func (w *Worker) getAddressAliases(addresses map[string]struct{}) AddressAliases
 	return nil
 }
 
-// GetTransaction reads transaction data from txid
-func (w *Worker) GetTransaction(txid string, spendingTxs bool, specificJSON bool) (*Tx, error) {
+// GetTransaction reads transaction data from txid, the package data of an unconfirmed transaction
+// are returned only if mempoolPackage is set as they cost a backend call. A replaced mempool transaction
+// which the backend no longer knows is returned without its data, only with the replacing transaction.
+func (w *Worker) GetTransaction(txid string, spendingTxs bool, specificJSON bool, mempoolPackage bool) (*Tx, error) {
 	addresses := w.newAddressesMapForAliases()
 	tx, err := w.getTransaction(txid, spendingTxs, specificJSON, addresses)
 	if err != nil {
+		if tx := w.replacedTx(txid); tx != nil {
+			return tx, nil
+		}
 		return nil, err
 	}
+	if mempoolPackage && tx.Confirmations == 0 && tx.ReplacedBy == "" {
+		tx.MempoolPackage = w.getMempoolPackage(txid)
+	}
 	tx.AddressAliases = w.getAddressAliases(addresses)
 	return tx, nil
 }
 
 // GetRawTransaction gets raw transaction data in hex format from txid
 func (w *Worker) GetRawTransaction(txid string) (string, error) {
+	w.work.addBackendCalls(1)
 	return w.chain.EthereumTypeGetRawTransaction(txid)
 }
 
 // getTransaction reads transaction data from txid
 func (w *Worker) getTransaction(txid string, spendingTxs bool, specificJSON bool, addresses map[string]struct{}) (*Tx, error) {
+	w.work.addDBReads(1)
 	bchainTx, height, err := w.txCache.GetTransaction(txid)
 	if err != nil {
 		if err == bchain.ErrTxNotFound {
@@ -275,6 +293,47 @@ func (w *Worker) getTransaction(txid string, spendingTxs bool, specificJSON bool
 	return w.GetTransactionFromBchainTx(bchainTx, height, spendingTxs, specificJSON, addresses)
 }
 
+// replacedTx returns the replaced mempool transaction without its data, which are no longer available,
+// and with the replacing transaction, nil if the transaction was not replaced
+func (w *Worker) replacedTx(txid string) *Tx {
+	replacedBy := w.getTxReplacedBy(txid)
+	if replacedBy == "" {
+		return nil
+	}
+	return &Tx{
+		Txid:        txid,
+		Vin:         []Vin{},
+		Vout:        []Vout{},
+		Blockheight: -1,
+		ValueOutSat: &Amount{},
+		ReplacedBy:  replacedBy,
+	}
+}
+
+// getTxReplacedBy returns the transaction which replaced the mempool transaction, empty string if it was not replaced
+func (w *Worker) getTxReplacedBy(txid string) string {
+	if w.mempool == nil {
+		return ""
+	}
+	if r := w.mempool.GetTxReplacement(txid); r != nil {
+		return r.ReplacedBy
+	}
+	return ""
+}
+
+// GetDroppedTx returns the transaction which left the mempool without being confirmed,
+// the dropped transactions are kept only for a limited time
+func (w *Worker) GetDroppedTx(txid string) (*DroppedTx, error) {
+	var d *bchain.MempoolTxDropped
+	if w.mempool != nil {
+		d = w.mempool.GetTxDropped(txid)
+	}
+	if d == nil {
+		return nil, NewAPIError(fmt.Sprintf("Dropped transaction '%v' not found", txid), true)
+	}
+	return &DroppedTx{Txid: txid, DroppedTime: d.Time, ConflictingTxid: d.ConflictingTxid}, nil
+}
+
 func (w *Worker) getParsedEthereumInputData(data string) *bchain.EthereumParsedInputData {
 	var err error
 	var signatures *[]bchain.FourByteSignature
@@ -382,6 +441,7 @@ func (w *Worker) GetTransactionFromBchainTx(bchainTx *bchain.Tx, height int, spe
 				}
 				if output == nil {
 					// try to load from backend
+					w.work.addDBReads(1)
 					otx, _, err := w.txCache.GetTransaction(bchainVin.Txid)
 					if err != nil {
 						if err == bchain.ErrTxNotFound {
@@ -505,11 +565,17 @@ func (w *Worker) GetTransactionFromBchainTx(bchainTx *bchain.Tx, height int, spe
 			L1FeeScalar:          ethTxData.L1FeeScalar,
 			L1GasPrice:           (*Amount)(ethTxData.L1GasPrice),
 			L1GasUsed:            ethTxData.L1GasUsed,
+			L1BaseFeeScalar:      ethTxData.L1BaseFeeScalar,
+			L1BlobBaseFee:        (*Amount)(ethTxData.L1BlobBaseFee),
+			L1BlobBaseFeeScalar:  ethTxData.L1BlobBaseFeeScalar,
+			GasUsedForL1:         ethTxData.GasUsedForL1,
 			Nonce:                ethTxData.Nonce,
 			Status:               ethTxData.Status,
 			Data:                 ethTxData.Data,
 			ParsedData:           parsedInputData,
 		}
+		ethSpecific.UserOperations = w.getEthereumUserOperations(bchainTx, addresses)
+		ethSpecific.AuthorizationList = getEthereumAuthorizations(bchainTx, addresses)
 		if internalData != nil {
 			ethSpecific.Type = internalData.Type
 			ethSpecific.CreatedContract = internalData.Contract
@@ -532,6 +598,7 @@ func (w *Worker) GetTransactionFromBchainTx(bchainTx *bchain.Tx, height int, spe
 	var chainExtraData *TxChainExtraData
 	// return CoinSpecificData for all mempool transactions or if requested
 	if specificJSON || bchainTx.Confirmations == 0 {
+		w.work.addBackendCalls(1)
 		sj, err = w.chain.GetTransactionSpecific(bchainTx)
 		if err != nil {
 			return nil, err
@@ -564,6 +631,11 @@ func (w *Worker) GetTransactionFromBchainTx(bchainTx *bchain.Tx, height int, spe
 		EthereumSpecific: ethSpecific,
 	}
 	if bchainTx.Confirmations == 0 {
+		// only the mempool transactions can be replaced, the confirmed ones are not looked up
+		if replacement := w.mempool.GetTxReplacement(bchainTx.Txid); replacement != nil {
+			r.ReplacedBy = replacement.ReplacedBy
+			r.Replaces = replacement.Replaces
+		}
 		r.Blocktime = int64(w.mempool.GetTransactionTime(bchainTx.Txid))
 		r.ConfirmationETASeconds, r.ConfirmationETABlocks = w.getConfirmationETA(r)
 	}
@@ -710,6 +782,7 @@ func (w *Worker) getContractDescriptorInfo(cd bchain.AddressDescriptor, standard
 		if bchain.ProcessInternalTransactions {
 			glog.Warningf("Contract %v %v not found in DB", cd, standardFromContext)
 		}
+		w.work.addBackendCalls(1)
 		contractInfo, err = w.chain.GetContractInfo(cd)
 		if err != nil {
 			glog.Errorf("GetContractInfo from chain error %v, contract %v", err, cd)
@@ -733,6 +806,7 @@ func (w *Worker) getContractDescriptorInfo(cd bchain.AddressDescriptor, standard
 		}
 	} else if (contractInfo.Standard == bchain.UnhandledTokenStandard || len(contractInfo.Name) > 0 && contractInfo.Name[0] == 0) || (len(contractInfo.Symbol) > 0 && contractInfo.Symbol[0] == 0) {
 		// fix contract name/symbol that was parsed as a string consisting of zeroes
+		w.work.addBackendCalls(1)
 		blockchainContractInfo, err := w.chain.GetContractInfo(cd)
 		if err != nil {
 			glog.Errorf("GetContractInfo from chain error %v, contract %v", err, cd)
@@ -825,6 +899,64 @@ func (w *Worker) getEthereumTokensTransfers(transfers bchain.TokenTransfers, add
 	return tokens
 }
 
+// getEthereumAuthorizations returns the EIP-7702 authorization list of a set-code transaction
+func getEthereumAuthorizations(bchainTx *bchain.Tx, addresses map[string]struct{}) []EthereumAuthorization {
+	csd, ok := bchainTx.CoinSpecificData.(bchain.EthereumSpecificData)
+	if !ok || csd.Tx == nil || len(csd.Tx.AuthorizationList) == 0 {
+		return nil
+	}
+	r := make([]EthereumAuthorization, len(csd.Tx.AuthorizationList))
+	for i := range csd.Tx.AuthorizationList {
+		a := &csd.Tx.AuthorizationList[i]
+		ea := &r[i]
+		if chainID, err := hexutil.DecodeBig(a.ChainID); err == nil {
+			ea.ChainID = (*Amount)(chainID)
+		}
+		ea.Nonce, _ = hexutil.DecodeUint64(a.Nonce)
+		ea.Address = a.Address
+		aggregateAddress(addresses, ea.Address)
+		if a.Authority != "" {
+			ea.Authority = a.Authority
+			aggregateAddress(addresses, ea.Authority)
+		}
+	}
+	return r
+}
+
+// getEthereumUserOperations returns the ERC-4337 UserOperations executed in the transaction
+func (w *Worker) getEthereumUserOperations(bchainTx *bchain.Tx, addresses map[string]struct{}) []EthereumUserOperation {
+	userOps, err := w.chainParser.EthereumTypeGetUserOperationsFromTx(bchainTx)
+	if err != nil {
+		glog.Errorf("EthereumTypeGetUserOperationsFromTx error %v, %v", err, bchainTx.Txid)
+		return nil
+	}
+	if len(userOps) == 0 {
+		return nil
+	}
+	r := make([]EthereumUserOperation, len(userOps))
+	for i := range userOps {
+		op := &userOps[i]
+		u := &r[i]
+		u.UserOpHash = op.UserOpHash
+		u.EntryPoint = op.EntryPoint
+		u.Sender = op.Sender
+		aggregateAddress(addresses, u.Sender)
+		if op.Paymaster != eth.EthereumZeroAddress {
+			u.Paymaster = op.Paymaster
+			aggregateAddress(addresses, u.Paymaster)
+		}
+		u.Nonce = (*Amount)(&op.Nonce)
+		u.Success = op.Success
+		u.ActualGasCost = (*Amount)(&op.ActualGasCost)
+		u.ActualGasUsed = (*Amount)(&op.ActualGasUsed)
+		if op.CallData != "" {
+			u.CallData = op.CallData
+			u.ParsedData = w.getParsedEthereumInputData(op.CallData)
+		}
+	}
+	return r
+}
+
 func (w *Worker) GetEthereumTokenURI(contract string, id string) (string, *bchain.ContractInfo, error) {
 	cd, err := w.chainParser.GetAddrDescFromAddress(contract)
 	if err != nil {
@@ -834,6 +966,7 @@ func (w *Worker) GetEthereumTokenURI(contract string, id string) (string, *bchai
 	if !ok {
 		return "", nil, errors.New("Invalid token id")
 	}
+	w.work.addBackendCalls(1)
 	uri, err := w.chain.GetTokenURI(cd, tokenId)
 	if err != nil {
 		return "", nil, err
@@ -846,12 +979,20 @@ func (w *Worker) GetEthereumTokenURI(contract string, id string) (string, *bchai
 }
 
 func (w *Worker) getAddressTxids(addrDesc bchain.AddressDescriptor, mempool bool, filter *AddressFilter, maxResults int) ([]string, error) {
+	txids, _, err := w.getAddressTxidsAndHeights(addrDesc, mempool, filter, maxResults)
+	return txids, err
+}
+
+// getAddressTxidsAndHeights returns the txids of the address together with the heights of their blocks, 0 for mempool txs
+func (w *Worker) getAddressTxidsAndHeights(addrDesc bchain.AddressDescriptor, mempool bool, filter *AddressFilter, maxResults int) ([]string, []uint32, error) {
 	var err error
 	txids := make([]string, 0, 4)
+	heights := make([]uint32, 0, 4)
 	var callback db.GetTransactionsCallback
 	if filter.Vout == AddressFilterVoutOff {
 		callback = func(txid string, height uint32, indexes []int32) error {
 			txids = append(txids, txid)
+			heights = append(heights, height)
 			if len(txids) >= maxResults {
 				return &db.StopIteration{}
 			}
@@ -868,6 +1009,7 @@ func (w *Worker) getAddressTxids(addrDesc bchain.AddressDescriptor, mempool bool
 					(filter.Vout == AddressFilterVoutOutputs && index >= 0) ||
 					(vout == int32(filter.Vout)) {
 					txids = append(txids, txid)
+					heights = append(heights, height)
 					if len(txids) >= maxResults {
 						return &db.StopIteration{}
 					}
@@ -881,7 +1023,7 @@ func (w *Worker) getAddressTxids(addrDesc bchain.AddressDescriptor, mempool bool
 		uniqueTxs := make(map[string]struct{})
 		o, err := w.mempool.GetAddrDescTransactions(addrDesc)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		for _, m := range o {
 			if _, found := uniqueTxs[m.Txid]; !found {
@@ -897,12 +1039,30 @@ func (w *Worker) getAddressTxids(addrDesc bchain.AddressDescriptor, mempool bool
 		if to == 0 {
 			to = maxUint32
 		}
-		err = w.db.GetAddrDescTransactions(addrDesc, filter.FromHeight, to, callback)
+		err = w.db.GetAddrDescTransactions(addrDesc, filter.FromHeight, to, w.countRows(callback))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+	}
+	return txids, heights, nil
+}
+
+// getAddressHistory returns up to maxResults newest items of the confirmed history of the address, beacon chain withdrawals
+// are part of the history together with the transactions, and the total number of the withdrawals, -1 if it is not known
+func (w *Worker) getAddressHistory(addrDesc bchain.AddressDescriptor, filter *AddressFilter, maxResults int) ([]addressHistoryEntry, int, error) {
+	txc, heights, err := w.getAddressTxidsAndHeights(addrDesc, false, filter, maxResults)
+	if err != nil {
+		return nil, 0, errors.Annotatef(err, "getAddressTxids %v false", addrDesc)
+	}
+	var wds []db.Withdrawal
+	var wdsCount int
+	if w.withdrawalsInAddressHistory(filter) {
+		wds, wdsCount, err = w.getAddressWithdrawals(addrDesc, filter, maxResults)
+		if err != nil {
+			return nil, 0, errors.Annotatef(err, "getAddressWithdrawals %v", addrDesc)
 		}
 	}
-	return txids, nil
+	return mergeAddressHistory(txc, heights, wds, maxResults), wdsCount, nil
 }
 
 func (t *Tx) getAddrVoutValue(addrDesc bchain.AddressDescriptor) *big.Int {
@@ -1091,6 +1251,7 @@ func (w *Worker) getEthereumContractBalance(addrDesc bchain.AddressDescriptor, i
 			// retrying as a single call would only amplify RPC load without changing the outcome.
 			b := erc20Balance
 			if b == nil && !erc20Batched {
+				w.work.addBackendCalls(1)
 				b, err = w.chain.EthereumTypeGetErc20ContractBalance(addrDesc, c.Contract)
 				if err != nil {
 					// return nil, nil, nil, errors.Annotatef(err, "EthereumTypeGetErc20ContractBalance %v %v", addrDesc, c.Contract)
@@ -1165,6 +1326,7 @@ func (w *Worker) getEthereumContractBalanceFromBlockchain(addrDesc, contract bch
 	}
 	// do not read contract balances etc in case of Basic option
 	if details >= AccountDetailsTokenBalances && validContract {
+		w.work.addBackendCalls(1)
 		b, err = w.chain.EthereumTypeGetErc20ContractBalance(addrDesc, contract)
 		if err != nil {
 			// return nil, nil, nil, errors.Annotatef(err, "EthereumTypeGetErc20ContractBalance %v %v", addrDesc, c.Contract)
@@ -1221,12 +1383,14 @@ type ethereumTypeAddressData struct {
 	contractInfo         *bchain.ContractInfo
 	nonce                string
 	confirmedNonce       string
+	delegation           string
 	nonContractTxs       int
 	internalTxs          int
 	totalResults         int
 	tokensBaseValue      float64
 	tokensSecondaryValue float64
 	stakingPools         []StakingPool
+	diagnostics          *EthereumAccountDiagnostics
 }
 
 func (w *Worker) getSecondaryTicker(secondaryCoin string) *common.CurrencyRatesTicker {
@@ -1238,18 +1402,25 @@ func (w *Worker) getSecondaryTicker(secondaryCoin string) *common.CurrencyRatesT
 	return getCurrentTicker(w.fiatRates, "", "")
 }
 
-func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescriptor, details AccountDetails, filter *AddressFilter, secondaryCoin string) (*db.AddrBalance, *ethereumTypeAddressData, error) {
+func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescriptor, details AccountDetails, filter *AddressFilter, secondaryCoin string, prefetch *addressPrefetch) (*db.AddrBalance, *ethereumTypeAddressData, error) {
 	var ba *db.AddrBalance
 	var nPending, nConfirmed uint64
 	var confirmedNonceOK bool
 	// unknown number of results for paging initially
 	d := ethereumTypeAddressData{totalResults: -1}
 	// Load cached contract list and totals from the index; this drives token lookups.
-	ca, err := w.db.GetAddrDescContracts(addrDesc)
-	if err != nil {
-		return nil, nil, NewAPIError(fmt.Sprintf("Address not found, %v", err), true)
+	var ca *db.AddrContracts
+	var err error
+	if prefetch != nil {
+		ca = prefetch.contracts
+	} else {
+		w.work.addDBReads(1)
+		if ca, err = w.db.GetAddrDescContracts(addrDesc); err != nil {
+			return nil, nil, NewAPIError(fmt.Sprintf("Address not found, %v", err), true)
+		}
 	}
 	// Always fetch the native balance from the backend.
+	w.work.addBackendCalls(1)
 	b, err := w.chain.EthereumTypeGetBalance(addrDesc)
 	if err != nil {
 		return nil, nil, errors.Annotatef(err, "EthereumTypeGetBalance %v", addrDesc)
@@ -1270,7 +1441,8 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 		if b != nil {
 			ba.BalanceSat = *b
 		}
-		nPending, nConfirmed, confirmedNonceOK, err = w.chain.EthereumTypeGetNonces(addrDesc, filter.WithConfirmedNonce)
+		w.work.addBackendCalls(1)
+		nPending, nConfirmed, confirmedNonceOK, err = w.chain.EthereumTypeGetNonces(addrDesc, filter.WithConfirmedNonce || filter.WithDiagnostics)
 		if err != nil {
 			return nil, nil, errors.Annotatef(err, "EthereumTypeGetNonces %v", addrDesc)
 		}
@@ -1291,6 +1463,7 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 				erc20Contracts = append(erc20Contracts, c.Contract)
 			}
 			if len(erc20Contracts) > 1 {
+				w.work.addBackendCalls(1)
 				balances, err := w.chain.EthereumTypeGetErc20ContractBalances(addrDesc, erc20Contracts)
 				if err != nil {
 					glog.Warningf("EthereumTypeGetErc20ContractBalances addr %v: %v", addrDesc, err)
@@ -1353,6 +1526,13 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 				return nil, nil, err
 			}
 		}
+		// only an account without contract code can delegate using EIP-7702; the delegation is best effort
+		if filter.WithDelegation && d.contractInfo == nil {
+			w.work.addBackendCalls(1)
+			if d.delegation, err = w.chain.EthereumTypeGetDelegation(addrDesc); err != nil {
+				glog.Warningf("EthereumTypeGetDelegation addr %v: %v", addrDesc, err)
+			}
+		}
 		if filter.FromHeight == 0 && filter.ToHeight == 0 {
 			// compute total results for paging
 			if filter.Vout == AddressFilterVoutOff {
@@ -1381,14 +1561,20 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 		// value the indexed path would fetch, without a backend call. When the caller opted in,
 		// surface the confirmed nonce as "0" for symmetry with the indexed path; omitting it would be
 		// indistinguishable from the feature not being deployed. nPending/nConfirmed are already 0.
-		confirmedNonceOK = filter.WithConfirmedNonce
+		confirmedNonceOK = filter.WithConfirmedNonce || filter.WithDiagnostics
 	}
 	// returns 0 for unknown address
 	d.nonce = strconv.Itoa(int(nPending))
 	// confirmed nonce is gated and best-effort: surfaced only when the caller opted in
 	// and the backend lookup succeeded; otherwise it is left empty and omitted
 	if confirmedNonceOK {
-		d.confirmedNonce = strconv.Itoa(int(nConfirmed))
+		if filter.WithConfirmedNonce {
+			d.confirmedNonce = strconv.Itoa(int(nConfirmed))
+		}
+		// the diagnostics of the pending transactions are relative to the confirmed nonce
+		if filter.WithDiagnostics {
+			d.diagnostics = w.getEthereumAccountDiagnostics(addrDesc, nPending, nConfirmed)
+		}
 	}
 	// special handling if filtering for a contract, return the contract details even though the address had no transactions with it
 	if len(d.tokens) == 0 && len(filterDesc) > 0 && details >= AccountDetailsTokens {
@@ -1418,6 +1604,7 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 func (w *Worker) getStakingPoolsData(addrDesc bchain.AddressDescriptor) ([]StakingPool, error) {
 	var pools []StakingPool
 	if len(w.chain.EthereumTypeGetSupportedStakingPools()) > 0 {
+		w.work.addBackendCalls(1)
 		sp, err := w.chain.EthereumTypeGetStakingPoolsData(addrDesc)
 		if err != nil {
 			return nil, err
@@ -1522,8 +1709,43 @@ func setIsOwnAddress(tx *Tx, address string) {
 	}
 }
 
-// GetAddress computes address value and gets transactions for given address
+// GetAddress computes address value and gets transactions for given address,
+// the identical concurrent requests are coalesced and their result is cached for a short time
 func (w *Worker) GetAddress(address string, page int, txsOnPage int, option AccountDetails, filter *AddressFilter, secondaryCoin string) (*Address, error) {
+	addrDesc, normalized, err := w.getAddrDescAndNormalizeAddress(address)
+	if err != nil || filter == nil {
+		// for example an ENS name, resolved by getAddress
+		return w.getAddress(address, page, txsOnPage, option, filter, secondaryCoin, nil)
+	}
+	// getAddress may modify the filter, the modified filter is returned to all callers
+	type addressResult struct {
+		address *Address
+		filter  AddressFilter
+	}
+	params := []interface{}{normalized, max(page, 1), txsOnPage, option, *filter, secondaryCoin}
+	v, err := w.coalesce("GetAddress", params, func() (interface{}, []bchain.AddressDescriptor, error) {
+		a, err := w.getAddress(address, page, txsOnPage, option, filter, secondaryCoin, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &addressResult{address: a, filter: *filter}, []bchain.AddressDescriptor{addrDesc}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := v.(*addressResult)
+	*filter = r.filter
+	a := *r.address
+	return &a, nil
+}
+
+// addressPrefetch holds the index data of an address read in advance, for example by a batch query
+type addressPrefetch struct {
+	balance   *db.AddrBalance
+	contracts *db.AddrContracts
+}
+
+func (w *Worker) getAddress(address string, page int, txsOnPage int, option AccountDetails, filter *AddressFilter, secondaryCoin string, prefetch *addressPrefetch) (*Address, error) {
 	if w.chainType == bchain.ChainEthereumType && strings.HasSuffix(strings.ToLower(address), ".eth") {
 		ensResolver, ok := w.chain.(interface {
 			ResolveENS(string) (*bchain.ENSResolution, error)
@@ -1561,13 +1783,19 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 	if page < 0 {
 		page = 0
 	}
+	cursor, err := parseAddressCursor(filter.Cursor)
+	if err != nil {
+		return nil, err
+	}
 	var (
 		ba                       *db.AddrBalance
 		txm                      []string
 		txs                      []*Tx
 		txids                    []string
+		withdrawals              []EthereumWithdrawal
 		accountChainExtraData    *AccountChainExtraData
 		pg                       Paging
+		nextCursor, prevCursor   string
 		uBalSat                  big.Int
 		uBalSending              big.Int
 		uBalReceiving            big.Int
@@ -1585,16 +1813,20 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 		glog.Warningf("GetAccountChainExtraData error %v, %v", err, address)
 	}
 	if w.chainType == bchain.ChainEthereumType {
-		ba, ed, err = w.getEthereumTypeAddressBalances(addrDesc, option, filter, secondaryCoin)
+		ba, ed, err = w.getEthereumTypeAddressBalances(addrDesc, option, filter, secondaryCoin, prefetch)
 		if err != nil {
 			return nil, err
 		}
 		totalResults = ed.totalResults
 	} else {
 		// ba can be nil if the address is only in mempool!
-		ba, err = w.db.GetAddrDescBalance(addrDesc, db.AddressBalanceDetailNoUTXO)
-		if err != nil {
-			return nil, NewAPIError(fmt.Sprintf("Address not found, %v", err), true)
+		if prefetch != nil {
+			ba = prefetch.balance
+		} else {
+			w.work.addDBReads(1)
+			if ba, err = w.db.GetAddrDescBalance(addrDesc, db.AddressBalanceDetailNoUTXO); err != nil {
+				return nil, NewAPIError(fmt.Sprintf("Address not found, %v", err), true)
+			}
 		}
 		if ba != nil {
 			// totalResults is known only if there is no filter
@@ -1640,7 +1872,8 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 						} else {
 							uBalSending.Add(&uBalSending, tx.getAddrVinValue(addrDesc))
 						}
-						if page == 0 {
+						// unconfirmed transactions are returned only on the first page, they are not part of the cursor pages
+						if page == 0 && cursor == nil {
 							if option == AccountDetailsTxidHistory {
 								txids = append(txids, tx.Txid)
 							} else if option >= AccountDetailsTxHistoryLight {
@@ -1655,25 +1888,59 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 	}
 	// get tx history if requested by option or check mempool if there are some transactions for a new address
 	if option >= AccountDetailsTxidHistory && filter.Vout != AddressFilterVoutQueryNotNecessary {
-		txc, err := w.getAddressTxids(addrDesc, false, filter, (page+1)*txsOnPage)
-		if err != nil {
-			return nil, errors.Annotatef(err, "getAddressTxids %v false", addrDesc)
+		var (
+			entries  []addressHistoryEntry
+			from, to int
+		)
+		if cursor == nil {
+			maxResults := (page + 1) * txsOnPage
+			var wdsCount int
+			entries, wdsCount, err = w.getAddressHistory(addrDesc, filter, maxResults)
+			if err != nil {
+				return nil, err
+			}
+			if wdsCount < 0 {
+				totalResults = -1
+			} else if totalResults >= 0 {
+				totalResults += wdsCount
+			}
+			pg, from, to, page = computePaging(len(entries), page, txsOnPage)
+			if len(entries) >= txsOnPage {
+				if totalResults < 0 {
+					pg.TotalPages = -1
+				} else {
+					pg, _, _, _ = computePaging(totalResults, page, txsOnPage)
+				}
+			}
+			// on page 1 the mempool items already take a part of the page
+			if page == 0 && txsOnPage > 0 {
+				if limit := from + txsOnPage - len(txids) - len(txs); to > limit {
+					to = limit
+				}
+			}
+			nextCursor, prevCursor = historyHeights(func(i int) uint32 { return entries[i].height }).pageCursors(len(entries), from, to, to < len(entries) || len(entries) >= maxResults)
+		} else {
+			entries, from, to, err = w.getAddressHistoryPage(addrDesc, filter, cursor, txsOnPage)
+			if err != nil {
+				return nil, err
+			}
+			pg = Paging{ItemsOnPage: txsOnPage}
+			nextCursor, prevCursor = historyHeights(func(i int) uint32 { return entries[i].height }).pageCursors(len(entries), from, to, to < len(entries))
+			if from == to && cursor.backward {
+				// no newer items yet, the client continues from the same cursor
+				prevCursor = filter.Cursor
+			}
 		}
 		bestheight, _, err := w.db.GetBestBlock()
 		if err != nil {
 			return nil, errors.Annotatef(err, "GetBestBlock")
 		}
-		var from, to int
-		pg, from, to, page = computePaging(len(txc), page, txsOnPage)
-		if len(txc) >= txsOnPage {
-			if totalResults < 0 {
-				pg.TotalPages = -1
-			} else {
-				pg, _, _, _ = computePaging(totalResults, page, txsOnPage)
-			}
-		}
 		for i := from; i < to; i++ {
-			txid := txc[i]
+			if wd := entries[i].withdrawal; wd != nil {
+				withdrawals = append(withdrawals, w.ethereumWithdrawalFromDb(wd, address, bestheight))
+				continue
+			}
+			txid := entries[i].txid
 			if option == AccountDetailsTxidHistory {
 				txids = append(txids, txid)
 			} else {
@@ -1743,6 +2010,9 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 		UnconfirmedReceiving:  amountOrNil(&uBalReceiving),
 		Transactions:          txs,
 		Txids:                 txids,
+		Withdrawals:           withdrawals,
+		NextCursor:            nextCursor,
+		PrevCursor:            prevCursor,
 		Tokens:                ed.tokens,
 		SecondaryValue:        secondaryValue,
 		TokensBaseValue:       ed.tokensBaseValue,
@@ -1752,8 +2022,10 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 		ContractInfo:          contractInfoResultFromBchain(ed.contractInfo, contractInfoBestHeight),
 		Nonce:                 ed.nonce,
 		ConfirmedNonce:        ed.confirmedNonce,
+		Delegation:            ed.delegation,
 		AddressAliases:        w.getAddressAliases(addresses),
 		StakingPools:          ed.stakingPools,
+		Diagnostics:           ed.diagnostics,
 		ChainExtraData:        accountChainExtraData,
 	}
 	// keep address backward compatible, set deprecated Erc20Contract value if ERC20 token
@@ -1830,8 +2102,9 @@ func (w *Worker) processInternalTransactionsForBalanceHistory(addrDesc bchain.Ad
 // unit of the chain. On L2 networks (Arbitrum, Optimism, Base, ...) the transaction
 // gasPrice is only the bid price, while the receipt's effectiveGasPrice is the price
 // actually charged, so it is preferred when available. Rollups that report a separate
-// L1 data fee (l1Fee, e.g. the OP stack) have it added on top. Mempool transactions
-// have no gasUsed yet and therefore no fee.
+// L1 data fee (l1Fee, e.g. the OP stack) have it added on top. Arbitrum charges the L1
+// data fee as a part of the L2 gas (gasUsedForL1 is included in gasUsed), so nothing is
+// added there. Mempool transactions have no gasUsed yet and therefore no fee.
 func getEthereumFeesSat(ethTxData *bchain.EthereumTxData) *big.Int {
 	feesSat := new(big.Int)
 	if ethTxData.GasUsed == nil {
@@ -2013,6 +2286,7 @@ func (w *Worker) balanceHistoryForTxid(addrDesc bchain.AddressDescriptor, txid s
 		height = ta.Height
 	} else if w.chainType == bchain.ChainEthereumType {
 		var h int
+		w.work.addDBReads(1)
 		bchainTx, h, err = w.txCache.GetTransaction(txid)
 		if err != nil {
 			return nil, err
@@ -2067,8 +2341,30 @@ const (
 // GetBalanceHistory returns history of balance for given address. maxTxs bounds
 // how many transactions in the requested range may be aggregated (0 = unlimited);
 // the caller supplies the transport-specific cap (WS vs REST). transport labels
-// the emitted metrics with the serving surface.
+// the emitted metrics with the serving surface. The identical concurrent requests
+// are coalesced and their result is cached for a short time.
 func (w *Worker) GetBalanceHistory(address string, fromTimestamp, toTimestamp int64, currencies []string, groupBy uint32, maxTxs int, transport string) (BalanceHistories, error) {
+	normalizedCurrencies, err := normalizeFiatCurrencies(currencies)
+	if err != nil {
+		return nil, err
+	}
+	addrDesc, normalized, err := w.getAddrDescAndNormalizeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	params := []interface{}{normalized, fromTimestamp, toTimestamp, normalizedCurrencies, groupBy, maxTxs, transport}
+	v, err := w.coalesce("GetBalanceHistory", params, func() (interface{}, []bchain.AddressDescriptor, error) {
+		bha, err := w.getBalanceHistory(address, fromTimestamp, toTimestamp, currencies, groupBy, maxTxs, transport)
+		return bha, []bchain.AddressDescriptor{addrDesc}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	bha := v.(BalanceHistories)
+	return append(make(BalanceHistories, 0, len(bha)), bha...), nil
+}
+
+func (w *Worker) getBalanceHistory(address string, fromTimestamp, toTimestamp int64, currencies []string, groupBy uint32, maxTxs int, transport string) (BalanceHistories, error) {
 	var err error
 	currencies, err = normalizeFiatCurrencies(currencies)
 	if err != nil {
@@ -2126,6 +2422,13 @@ func (w *Worker) GetBalanceHistory(address string, fromTimestamp, toTimestamp in
 			bhs = append(bhs, *bh)
 		}
 	}
+	if w.chainType == bchain.ChainEthereumType {
+		wbhs, err := w.balanceHistoryForWithdrawals(addrDesc, fromHeight, toHeight, fromUnix, toUnix)
+		if err != nil {
+			return nil, err
+		}
+		bhs = append(bhs, wbhs...)
+	}
 	bha := bhs.SortAndAggregate(groupBy)
 	if w.metrics != nil {
 		w.metrics.BalanceHistoryPoints.With(common.Labels{"path": "address"}).Observe(float64(len(bha)))
@@ -2165,6 +2468,7 @@ func (w *Worker) getAddrDescUtxo(addrDesc bchain.AddressDescriptor, ba *db.AddrB
 			mc := make([]*bchain.Tx, len(txm))
 			for i, txid := range txm {
 				// get mempool txs and process their inputs to detect spends between mempool txs
+				w.work.addDBReads(1)
 				bchainTx, _, err := w.txCache.GetTransaction(txid)
 				// mempool transaction may fail
 				if err != nil {
@@ -2209,6 +2513,7 @@ func (w *Worker) getAddrDescUtxo(addrDesc bchain.AddressDescriptor, ba *db.AddrB
 	if !onlyMempool {
 		// get utxo from index
 		if ba == nil {
+			w.work.addDBReads(1)
 			ba, err = w.db.GetAddrDescBalance(addrDesc, db.AddressBalanceDetailUTXO)
 			if err != nil {
 				return nil, NewAPIError(fmt.Sprintf("Address not found, %v", err), true)
@@ -2342,6 +2647,7 @@ func (w *Worker) getBlockInfoFromBlockID(bid string) (*bchain.BlockInfo, error)
 	if hash == "" {
 		return nil, NewAPIError("Block not found", true)
 	}
+	w.work.addBackendCalls(1)
 	bi, err := w.chain.GetBlockInfo(hash)
 	return bi, err
 }
@@ -2370,6 +2676,7 @@ func (w *Worker) GetFeeStats(bid string) (*FeeStats, error) {
 
 	for _, txid := range bi.Txids {
 		// Get a raw JSON with transaction details, including size, vsize, hex
+		w.work.addBackendCalls(1)
 		txSpecificJSON, err := w.chain.GetTransactionSpecific(&bchain.Tx{Txid: txid})
 		if err != nil {
 			return nil, errors.Annotatef(err, "GetTransactionSpecific")
@@ -2452,8 +2759,21 @@ func (w *Worker) GetFeeStats(bid string) (*FeeStats, error) {
 	}, nil
 }
 
-// GetBlock returns paged data about block
+// GetBlock returns paged data about block,
+// the identical concurrent requests are coalesced and their result is cached for a short time
 func (w *Worker) GetBlock(bid string, page int, txsOnPage int) (*Block, error) {
+	v, err := w.coalesce("GetBlock", []interface{}{bid, max(page, 1), txsOnPage}, func() (interface{}, []bchain.AddressDescriptor, error) {
+		b, err := w.getBlock(bid, page, txsOnPage)
+		return b, nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	b := *v.(*Block)
+	return &b, nil
+}
+
+func (w *Worker) getBlock(bid string, page int, txsOnPage int) (*Block, error) {
 	start := time.Now()
 	page--
 	if page < 0 {
@@ -2525,6 +2845,7 @@ func (w *Worker) GetBlockRaw(bid string) (*BlockRaw, error) {
 	if hash == "" {
 		return nil, NewAPIError("Block not found", true)
 	}
+	w.work.addBackendCalls(1)
 	hex, err := w.chain.GetBlockRaw(hash)
 	if err != nil {
 		if err == bchain.ErrBlockNotFound {
@@ -2546,6 +2867,7 @@ func (w *Worker) GetBlockFiltersBatch(bestKnownBlockHash string, pageSize int) (
 	if pageSize <= 0 {
 		pageSize = 1000
 	}
+	w.work.addBackendCalls(1)
 	bi, err := w.chain.GetBlockInfo(bestKnownBlockHash)
 	if err != nil {
 		return nil, err
@@ -2588,6 +2910,7 @@ func (w *Worker) ComputeFeeStats(blockFrom, blockTo int, stopCompute chan os.Sig
 		if err != nil {
 			return err
 		}
+		w.work.addBackendCalls(1)
 		bi, err := w.chain.GetBlockInfo(hash)
 		if err != nil {
 			return err
@@ -2707,6 +3030,7 @@ func (w *Worker) GetSystemInfo(internal bool) (*SystemInfo, error) {
 		}
 	}
 	inSyncMempool, lastMempoolTime, mempoolSize := w.is.GetMempoolSyncState()
+	w.work.addBackendCalls(1)
 	ci, err := w.chain.GetChainInfo()
 	var backendError string
 	if err != nil {
@@ -2721,9 +3045,15 @@ func (w *Worker) GetSystemInfo(internal bool) (*SystemInfo, error) {
 	}
 	var columnStats []common.InternalStateColumn
 	var internalDBSize int64
+	var fourByteSignatures *bchain.FourByteSignaturesState
 	if internal {
 		columnStats = w.is.GetAllDBColumnStats()
 		internalDBSize = w.is.DBSizeTotal()
+		if w.chainType == bchain.ChainEthereumType {
+			if fourByteSignatures, err = w.db.GetFourByteSignaturesState(); err != nil {
+				glog.Error("GetFourByteSignaturesState error ", err)
+			}
+		}
 	}
 	var currentFiatRatesTime time.Time
 	ct := w.fiatRates.GetCurrentTicker("", "")
@@ -2755,6 +3085,7 @@ func (w *Worker) GetSystemInfo(internal bool) (*SystemInfo, error) {
 		DbSize:                       w.db.DatabaseSizeOnDisk(),
 		DbSizeFromColumns:            internalDBSize,
 		DbColumns:                    columnStats,
+		FourByteSignatures:           fourByteSignatures,
 		About:                        Text.BlockbookAbout,
 	}
 	backendInfo := &common.BackendInfo{
@@ -2826,6 +3157,7 @@ func (w *Worker) cachedEstimateFee(blocks int, conservative bool) (big.Int, erro
 	if s.timestamp >= threshold {
 		return s.fee, nil
 	}
+	w.work.addBackendCalls(1)
 	fee, err := w.chain.EstimateSmartFee(blocks, conservative)
 	if err == nil {
 		s.timestamp = time.Now().Unix()
@@ -2845,6 +3177,7 @@ func (w *Worker) cachedEstimateFee(blocks int, conservative bool) (big.Int, erro
 // it uses 10 second cache to reduce calls to the backend
 func (w *Worker) EstimateFee(blocks int, conservative bool) (big.Int, error) {
 	if blocks >= estimatedFeeCacheSize {
+		w.work.addBackendCalls(1)
 		return w.chain.EstimateSmartFee(blocks, conservative)
 	}
 	return w.cachedEstimateFee(blocks, conservative)
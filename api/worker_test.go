@@ -349,6 +349,11 @@ func TestGetEthereumFeesSat(t *testing.T) {
 			txData:  &bchain.EthereumTxData{GasUsed: bi(21000), GasPrice: bi(1200), EffectiveGasPrice: bi(10), L1Fee: bi(5000)},
 			wantFee: "215000", // 21000 * 10 + 5000
 		},
+		{
+			name:    "Arbitrum gasUsedForL1 is part of gasUsed and not added again",
+			txData:  &bchain.EthereumTxData{GasUsed: bi(21000), GasPrice: bi(1200), EffectiveGasPrice: bi(10), GasUsedForL1: bi(2500)},
+			wantFee: "210000", // 21000 * 10, the 2500 L1 gas is already in gasUsed
+		},
 		{
 			name:    "legacy tx without effectiveGasPrice falls back to gasPrice",
 			txData:  &bchain.EthereumTxData{GasUsed: bi(21000), GasPrice: bi(1200)},
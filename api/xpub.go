@@ -238,7 +238,7 @@ func (w *Worker) xpubGetAddressTxids(addrDesc bchain.AddressDescriptor, mempool
 			}
 		}
 	} else {
-		err = w.db.GetAddrDescTransactions(addrDesc, fromHeight, toHeight, callback)
+		err = w.db.GetAddrDescTransactions(addrDesc, fromHeight, toHeight, w.countRows(callback))
 		if err != nil {
 			return nil, false, err
 		}
@@ -308,6 +308,7 @@ func (w *Worker) xpubCheckAndLoadTxids(ad *xpubAddress, maxHeight uint32, maxRes
 
 func (w *Worker) xpubDerivedAddressBalance(data *xpubData, ad *xpubAddress) (bool, error) {
 	var err error
+	w.work.addDBReads(1)
 	if ad.balance, err = w.db.GetAddrDescBalance(ad.addrDesc, db.AddressBalanceDetailUTXO); err != nil {
 		return false, err
 	}
@@ -358,6 +359,7 @@ func (w *Worker) xpubScanAddresses(xd *bchain.XpubDescriptor, data *xpubData, ad
 		if err != nil {
 			return 0, nil, err
 		}
+		w.work.addDerivedAddresses(len(descriptors))
 		for i, a := range descriptors {
 			ad := xpubAddress{addrDesc: a}
 			used, err := w.xpubDerivedAddressBalance(data, &ad)
@@ -534,8 +536,36 @@ func (w *Worker) getXpubData(xd *bchain.XpubDescriptor, page int, txsOnPage int,
 	return &data, bestheight, inCache, nil
 }
 
-// GetXpubAddress computes address value and gets transactions for given address
+// GetXpubAddress computes address value and gets transactions for given address,
+// the identical concurrent requests are coalesced and their result is cached for a short time
 func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option AccountDetails, filter *AddressFilter, gap int, secondaryCoin string) (*Address, error) {
+	xd, err := w.chainParser.ParseXpub(xpub)
+	if err != nil {
+		return nil, err
+	}
+	params := []interface{}{xd.XpubDescriptor, max(page, 1), txsOnPage, option, *filter, gap, secondaryCoin}
+	v, err := w.coalesce("GetXpubAddress", params, func() (interface{}, []bchain.AddressDescriptor, error) {
+		a, data, err := w.getXpubAddress(xpub, xd, page, txsOnPage, option, filter, gap, secondaryCoin)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the result depends on all derived addresses, including the unused ones in the gap
+		var addrDescs []bchain.AddressDescriptor
+		for _, da := range data.addresses {
+			for i := range da {
+				addrDescs = append(addrDescs, da[i].addrDesc)
+			}
+		}
+		return a, addrDescs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	a := *v.(*Address)
+	return &a, nil
+}
+
+func (w *Worker) getXpubAddress(xpub string, xd *bchain.XpubDescriptor, page int, txsOnPage int, option AccountDetails, filter *AddressFilter, gap int, secondaryCoin string) (*Address, *xpubData, error) {
 	start := time.Now()
 	page--
 	if page < 0 {
@@ -552,17 +582,19 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 		txs            []*Tx
 		txids          []string
 		pg             Paging
+		nextCursor     string
+		prevCursor     string
 		filtered       bool
 		uBalSat        big.Int
 		unconfirmedTxs int
 	)
-	xd, err := w.chainParser.ParseXpub(xpub)
+	cursor, err := parseAddressCursor(filter.Cursor)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	data, bestheight, inCache, err := w.getXpubData(xd, page, txsOnPage, option, filter, gap)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// setup filtering of txids
 	var txidFilter func(txid *xpubTxid, ad *xpubAddress) bool
@@ -595,7 +627,7 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 				ad := &da[i]
 				newTxids, _, err := w.xpubGetAddressTxids(ad.addrDesc, true, 0, 0, maxInt)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				for _, txid := range newTxids {
 					// the same tx can have multiple addresses from the same xpub, get it from backend it only once
@@ -626,8 +658,8 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 						}
 						uBalSat.Add(&uBalSat, tx.getAddrVoutValue(ad.addrDesc))
 						uBalSat.Sub(&uBalSat, tx.getAddrVinValue(ad.addrDesc))
-						// mempool txs are returned only on the first page, uniquely and filtered
-						if page == 0 && !foundTx && (txidFilter == nil || txidFilter(&txid, ad)) {
+						// mempool txs are returned only on the first page, uniquely and filtered, they are not part of the cursor pages
+						if page == 0 && cursor == nil && !foundTx && (txidFilter == nil || txidFilter(&txid, ad)) {
 							mempoolEntries = append(mempoolEntries, bchain.MempoolTxidEntry{Txid: txid.txid, Time: uint32(tx.Blocktime)})
 						}
 					}
@@ -679,13 +711,24 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 			totalResults = -1
 		}
 		var from, to int
-		pg, from, to, page = computePaging(len(txc), page, txsOnPage)
-		if len(txc) >= txsOnPage {
-			if totalResults < 0 {
-				pg.TotalPages = -1
-			} else {
-				pg, _, _, _ = computePaging(totalResults, page, txsOnPage)
+		heights := historyHeights(func(i int) uint32 { return txc[i].height })
+		if cursor == nil {
+			pg, from, to, page = computePaging(len(txc), page, txsOnPage)
+			if len(txc) >= txsOnPage {
+				if totalResults < 0 {
+					pg.TotalPages = -1
+				} else {
+					pg, _, _, _ = computePaging(totalResults, page, txsOnPage)
+				}
 			}
+		} else {
+			from, to = heights.cursorPage(len(txc), cursor, txsOnPage)
+			pg = Paging{ItemsOnPage: txsOnPage}
+		}
+		nextCursor, prevCursor = heights.pageCursors(len(txc), from, to, to < len(txc))
+		if from == to && cursor != nil && cursor.backward {
+			// no newer items yet, the client continues from the same cursor
+			prevCursor = filter.Cursor
 		}
 		// get confirmed transactions
 		for i := from; i < to; i++ {
@@ -695,7 +738,7 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 			} else {
 				tx, err := w.txFromTxid(xpubTxid.txid, bestheight, option, nil, addresses)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				txs = append(txs, tx)
 			}
@@ -761,6 +804,8 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 		UnconfirmedTxs:        unconfirmedTxs,
 		Transactions:          txs,
 		Txids:                 txids,
+		NextCursor:            nextCursor,
+		PrevCursor:            prevCursor,
 		UsedTokens:            usedTokens,
 		Tokens:                tokens,
 		SecondaryValue:        secondaryValue,
@@ -768,7 +813,7 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 		AddressAliases:        w.getAddressAliases(addresses),
 	}
 	glog.V(1).Info("GetXpubAddress ", xpub[:xpubLogPrefix], ", cache ", inCache, ", ", txCount, " txs, ", time.Since(start))
-	return &addr, nil
+	return &addr, data, nil
 }
 
 // GetXpubUtxo returns unspent outputs for given xpub
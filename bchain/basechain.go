@@ -50,6 +50,11 @@ func (b *BaseChain) LongTermFeeRate() (*LongTermFeeRate, error) {
 	return nil, errors.New("not supported")
 }
 
+// EstimateMempoolFee is not supported
+func (b *BaseChain) EstimateMempoolFee(blocks int) (*MempoolFeeEstimate, error) {
+	return nil, errors.New("not supported")
+}
+
 // EthereumTypeGetBalance is not supported
 func (b *BaseChain) EthereumTypeGetBalance(addrDesc AddressDescriptor) (*big.Int, error) {
 	return nil, errors.New("not supported")
@@ -60,11 +65,21 @@ func (b *BaseChain) EthereumTypeGetNonces(addrDesc AddressDescriptor, withConfir
 	return 0, 0, false, errors.New("not supported")
 }
 
+// EthereumTypeGetDelegation is not supported
+func (b *BaseChain) EthereumTypeGetDelegation(addrDesc AddressDescriptor) (string, error) {
+	return "", errors.New("not supported")
+}
+
 // EthereumTypeEstimateGas is not supported
 func (b *BaseChain) EthereumTypeEstimateGas(params map[string]interface{}) (uint64, error) {
 	return 0, errors.New("not supported")
 }
 
+// EthereumTypeEstimateL1Fee is not supported
+func (b *BaseChain) EthereumTypeEstimateL1Fee(params map[string]interface{}) (*EthereumL1FeeEstimate, error) {
+	return nil, errors.New("not supported")
+}
+
 // EthereumTypeGetEip1559Fees is not supported
 func (b *BaseChain) EthereumTypeGetEip1559Fees() (*Eip1559Fees, error) {
 	return nil, errors.New("not supported")
@@ -120,3 +135,8 @@ func (b *BaseChain) EthereumTypeGetRawTransaction(txid string) (string, error) {
 func (b *BaseChain) EthereumTypeGetTransactionReceipt(txid string) (*RpcReceipt, error) {
 	return nil, errors.New("not supported")
 }
+
+// EthereumTypeSimulateTx is not supported
+func (b *BaseChain) EthereumTypeSimulateTx(params map[string]interface{}, block string) (*EthereumSimulatedTx, error) {
+	return nil, errors.New("not supported")
+}
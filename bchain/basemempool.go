@@ -1,6 +1,7 @@
 package bchain
 
 import (
+	"math/big"
 	"sort"
 	"sync"
 	"time"
@@ -15,12 +16,30 @@ type txEntry struct {
 	addrIndexes []addrIndex
 	time        uint32
 	filter      string
+	fees        *txFeeData
+	// spends are the outpoints spent by a Bitcoin type transaction, set only if the replacements or the dropped transactions are tracked
+	spends []Outpoint
+}
+
+// txFeeData holds the data of a mempool transaction needed for the fee estimation
+type txFeeData struct {
+	fee     int64
+	vsize   int64
+	parents []string
+	// sender and nonce of Ethereum type transactions, the parents are resolved from them
+	sender string
+	nonce  uint64
+	// fee cap and tip cap per gas of Ethereum type transactions, both equal to the gas price for legacy transactions
+	maxFeePerGas         *big.Int
+	maxPriorityFeePerGas *big.Int
 }
 
 type txidio struct {
 	txid   string
 	io     []addrIndex
 	filter string
+	fees   *txFeeData
+	spends []Outpoint
 }
 
 // BaseMempool is mempool base handle
@@ -30,6 +49,12 @@ type BaseMempool struct {
 	txEntries    map[string]txEntry
 	addrDescToTx map[string][]Outpoint
 	OnNewTx      OnNewTxFunc
+	replacements mempoolReplacements
+	dropped      mempoolDropped
+	// spentOutpoints maps the outpoints to the spending mempool transactions (Bitcoin type)
+	spentOutpoints map[Outpoint]string
+	// nonceTxs maps the sender and nonce to the mempool transaction (Ethereum type)
+	nonceTxs map[senderNonce]string
 }
 
 // GetTransactions returns slice of mempool transactions for given address
@@ -68,9 +93,25 @@ func (a MempoolTxidEntries) Less(i, j int) bool {
 	return hi > hj
 }
 
+// spendsTracked returns true if the spent outpoints of the transactions must be kept
+func (m *BaseMempool) spendsTracked() bool {
+	return m.replacements.enabled() || m.dropped.enabled()
+}
+
 // removeEntryFromMempool removes entry from mempool structs. The caller is responsible for locking!
 func (m *BaseMempool) removeEntryFromMempool(txid string, entry txEntry) {
 	delete(m.txEntries, txid)
+	for _, o := range entry.spends {
+		if m.spentOutpoints[o] == txid {
+			delete(m.spentOutpoints, o)
+		}
+	}
+	if m.nonceTxs != nil && entry.fees != nil && entry.fees.sender != "" {
+		sn := senderNonce{entry.fees.sender, entry.fees.nonce}
+		if m.nonceTxs[sn] == txid {
+			delete(m.nonceTxs, sn)
+		}
+	}
 	// store already processed addrDesc - it can appear multiple times as a different outpoint
 	processedAddrDesc := make(map[string]struct{})
 	for _, si := range entry.addrIndexes {
@@ -97,6 +138,32 @@ func (m *BaseMempool) removeEntryFromMempool(txid string, entry txEntry) {
 	}
 }
 
+// GetAccountTxs returns the mempool transactions sent by the account, ordered by nonce
+func (m *BaseMempool) GetAccountTxs(addrDesc AddressDescriptor) []MempoolAccountTx {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	var txs []MempoolAccountTx
+	for _, o := range m.addrDescToTx[string(addrDesc)] {
+		// the sender is the first address of the first input
+		if o.Vout != ^0 {
+			continue
+		}
+		entry, found := m.txEntries[o.Txid]
+		if !found || entry.fees == nil || entry.fees.sender == "" {
+			continue
+		}
+		txs = append(txs, MempoolAccountTx{
+			Txid:                 o.Txid,
+			Time:                 entry.time,
+			Nonce:                entry.fees.nonce,
+			MaxFeePerGas:         entry.fees.maxFeePerGas,
+			MaxPriorityFeePerGas: entry.fees.maxPriorityFeePerGas,
+		})
+	}
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+	return txs
+}
+
 // GetAllEntries returns all mempool entries sorted by fist seen time in descending order
 func (m *BaseMempool) GetAllEntries() MempoolTxidEntries {
 	i := 0
@@ -408,6 +408,11 @@ func (p *BaseParser) EthereumTypeGetTokenTransfersFromTx(tx *Tx) (TokenTransfers
 	return nil, errors.New("Not supported")
 }
 
+// EthereumTypeGetUserOperationsFromTx is unsupported
+func (p *BaseParser) EthereumTypeGetUserOperationsFromTx(tx *Tx) ([]EthereumUserOperation, error) {
+	return nil, errors.New("Not supported")
+}
+
 // GetEthereumTxData returns default pending status for non-Ethereum-like chains.
 func (p *BaseParser) GetEthereumTxData(tx *Tx) *EthereumTxData {
 	return &EthereumTxData{Status: TxStatusPending}
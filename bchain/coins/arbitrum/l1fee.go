@@ -0,0 +1,87 @@
+package arbitrum
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/eth"
+)
+
+// NodeInterfaceAddress is the virtual contract of the Arbitrum node exposing the gas estimation helpers
+const NodeInterfaceAddress = "0x00000000000000000000000000000000000000C8"
+
+const gasEstimateL1ComponentSignature = "0x77d488a2" // gasEstimateL1Component(address,bool,bytes)
+
+var gasEstimateL1ComponentArguments abi.Arguments
+
+func init() {
+	addressType, err := abi.NewType("address", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	boolType, err := abi.NewType("bool", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	gasEstimateL1ComponentArguments = abi.Arguments{{Type: addressType}, {Type: boolType}, {Type: bytesType}}
+}
+
+// gasEstimateL1ComponentCallData returns the NodeInterface gasEstimateL1Component call data
+// for the transaction described by the params
+func gasEstimateL1ComponentCallData(params map[string]interface{}) (string, error) {
+	var to ethcommon.Address
+	contractCreation := true
+	if s, ok := eth.GetStringFromMap("to", params); ok && len(s) > 0 {
+		if !ethcommon.IsHexAddress(s) {
+			return "", errors.Errorf("Invalid address %v", s)
+		}
+		to = ethcommon.HexToAddress(s)
+		contractCreation = false
+	}
+	var data []byte
+	if s, ok := eth.GetStringFromMap("data", params); ok && len(s) > 0 {
+		data = ethcommon.FromHex(s)
+	}
+	packed, err := gasEstimateL1ComponentArguments.Pack(to, contractCreation, data)
+	if err != nil {
+		return "", err
+	}
+	return gasEstimateL1ComponentSignature + hexutil.Encode(packed)[2:], nil
+}
+
+// parseGasEstimateL1Component parses the (uint64 gasEstimateForL1, uint256 baseFee, uint256 l1BaseFeeEstimate)
+// result of the gasEstimateL1Component call, the L1 data fee is paid as gasEstimateForL1 of L2 gas at the L2 base fee
+func parseGasEstimateL1Component(result string) (*bchain.EthereumL1FeeEstimate, error) {
+	b, err := hexutil.Decode(result)
+	if err != nil || len(b) != 3*32 {
+		return nil, errors.Errorf("Invalid gasEstimateL1Component result %v", result)
+	}
+	gasForL1 := new(big.Int).SetBytes(b[:32])
+	baseFee := new(big.Int).SetBytes(b[32:64])
+	return &bchain.EthereumL1FeeEstimate{
+		L1Fee:        new(big.Int).Mul(gasForL1, baseFee),
+		GasUsedForL1: gasForL1,
+	}, nil
+}
+
+// EthereumTypeEstimateL1Fee returns estimation of the L1 data fee, on Arbitrum it is charged as a part of the L2 gas
+// and therefore already included in the gas estimate
+func (b *ArbitrumRPC) EthereumTypeEstimateL1Fee(params map[string]interface{}) (*bchain.EthereumL1FeeEstimate, error) {
+	data, err := gasEstimateL1ComponentCallData(params)
+	if err != nil {
+		return nil, err
+	}
+	result, err := b.EthereumTypeRpcCall(data, NodeInterfaceAddress, "")
+	if err != nil {
+		return nil, err
+	}
+	return parseGasEstimateL1Component(result)
+}
@@ -0,0 +1,76 @@
+package arbitrum
+
+import (
+	"strings"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestGasEstimateL1ComponentCallData(t *testing.T) {
+	tests := []struct {
+		name             string
+		params           map[string]interface{}
+		to               string
+		contractCreation bool
+		data             string
+	}{
+		{
+			name:   "transfer",
+			params: map[string]interface{}{"to": "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f", "data": "0xa9059cbb"},
+			to:     "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f",
+			data:   "0xa9059cbb",
+		},
+		{
+			name:             "contract creation",
+			params:           map[string]interface{}{"data": "0x6080604052"},
+			to:               "0x0000000000000000000000000000000000000000",
+			contractCreation: true,
+			data:             "0x6080604052",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := gasEstimateL1ComponentCallData(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(data, gasEstimateL1ComponentSignature) {
+				t.Fatalf("gasEstimateL1ComponentCallData() = %v, expected prefix %v", data, gasEstimateL1ComponentSignature)
+			}
+			unpacked, err := gasEstimateL1ComponentArguments.Unpack(hexutil.MustDecode("0x" + data[len(gasEstimateL1ComponentSignature):]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := unpacked[0].(ethcommon.Address).Hex(); got != tt.to {
+				t.Errorf("to = %v, want %v", got, tt.to)
+			}
+			if got := unpacked[1].(bool); got != tt.contractCreation {
+				t.Errorf("contractCreation = %v, want %v", got, tt.contractCreation)
+			}
+			if got := hexutil.Encode(unpacked[2].([]byte)); got != tt.data {
+				t.Errorf("data = %v, want %v", got, tt.data)
+			}
+		})
+	}
+	if _, err := gasEstimateL1ComponentCallData(map[string]interface{}{"to": "0x1234"}); err == nil {
+		t.Error("gasEstimateL1ComponentCallData() with invalid address, expected error")
+	}
+}
+
+func TestParseGasEstimateL1Component(t *testing.T) {
+	got, err := parseGasEstimateL1Component("0x" +
+		"00000000000000000000000000000000000000000000000000000000000009c4" +
+		"0000000000000000000000000000000000000000000000000000000000989680" +
+		"00000000000000000000000000000000000000000000000000000000b2d05e00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GasUsedForL1.String() != "2500" || got.L1Fee.String() != "25000000000" {
+		t.Errorf("parseGasEstimateL1Component() = %v %v, want 2500 25000000000", got.GasUsedForL1, got.L1Fee)
+	}
+	if _, err := parseGasEstimateL1Component("0x00000000000000000000000000000000000000000000000000000000000009c4"); err == nil {
+		t.Error("parseGasEstimateL1Component() of short result, expected error")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/trezor/blockbook/bchain"
 	"github.com/trezor/blockbook/bchain/coins/eth"
+	"github.com/trezor/blockbook/bchain/coins/optimism"
 )
 
 const (
@@ -79,3 +80,8 @@ func (b *BaseRPC) Initialize() error {
 func (b *BaseRPC) ResolveENS(name string) (*bchain.ENSResolution, error) {
 	return b.EthereumRPC.ResolveENS(name)
 }
+
+// EthereumTypeEstimateL1Fee returns estimation of the L1 data fee charged on top of the L2 execution fee
+func (b *BaseRPC) EthereumTypeEstimateL1Fee(params map[string]interface{}) (*bchain.EthereumL1FeeEstimate, error) {
+	return optimism.EstimateL1Fee(b.EthereumRPC, params)
+}
@@ -320,6 +320,11 @@ func (c *blockChainWithMetrics) LongTermFeeRate() (v *bchain.LongTermFeeRate, er
 	return c.b.LongTermFeeRate()
 }
 
+func (c *blockChainWithMetrics) EstimateMempoolFee(blocks int) (v *bchain.MempoolFeeEstimate, err error) {
+	defer func(s time.Time) { c.observeRPCLatency("EstimateMempoolFee", s, err) }(time.Now())
+	return c.b.EstimateMempoolFee(blocks)
+}
+
 func (c *blockChainWithMetrics) SendRawTransaction(tx string, disableAlternativeRPC bool) (v string, err error) {
 	defer func(s time.Time) { c.observeRPCLatency("SendRawTransaction", s, err) }(time.Now())
 	return c.b.SendRawTransaction(tx, disableAlternativeRPC)
@@ -344,11 +349,21 @@ func (c *blockChainWithMetrics) EthereumTypeGetNonces(addrDesc bchain.AddressDes
 	return c.b.EthereumTypeGetNonces(addrDesc, withConfirmed)
 }
 
+func (c *blockChainWithMetrics) EthereumTypeGetDelegation(addrDesc bchain.AddressDescriptor) (v string, err error) {
+	defer func(s time.Time) { c.observeRPCLatency("EthereumTypeGetDelegation", s, err) }(time.Now())
+	return c.b.EthereumTypeGetDelegation(addrDesc)
+}
+
 func (c *blockChainWithMetrics) EthereumTypeEstimateGas(params map[string]interface{}) (v uint64, err error) {
 	defer func(s time.Time) { c.observeRPCLatency("EthereumTypeEstimateGas", s, err) }(time.Now())
 	return c.b.EthereumTypeEstimateGas(params)
 }
 
+func (c *blockChainWithMetrics) EthereumTypeEstimateL1Fee(params map[string]interface{}) (v *bchain.EthereumL1FeeEstimate, err error) {
+	defer func(s time.Time) { c.observeRPCLatency("EthereumTypeEstimateL1Fee", s, err) }(time.Now())
+	return c.b.EthereumTypeEstimateL1Fee(params)
+}
+
 func (c *blockChainWithMetrics) EthereumTypeGetEip1559Fees() (v *bchain.Eip1559Fees, err error) {
 	defer func(s time.Time) { c.observeRPCLatency("EthereumTypeGetEip1559Fees", s, err) }(time.Now())
 	return c.b.EthereumTypeGetEip1559Fees()
@@ -422,6 +437,11 @@ func (c *blockChainWithMetrics) EthereumTypeGetTransactionReceipt(txid string) (
 	return c.b.EthereumTypeGetTransactionReceipt(txid)
 }
 
+func (c *blockChainWithMetrics) EthereumTypeSimulateTx(params map[string]interface{}, block string) (v *bchain.EthereumSimulatedTx, err error) {
+	defer func(s time.Time) { c.observeRPCLatency("EthereumTypeSimulateTx", s, err) }(time.Now())
+	return c.b.EthereumTypeSimulateTx(params, block)
+}
+
 type mempoolWithMetrics struct {
 	mempool bchain.Mempool
 	m       *common.Metrics
@@ -496,6 +516,39 @@ func (c *mempoolWithMetrics) GetTxidFilterEntries(filterScripts string, fromTime
 	return c.mempool.GetTxidFilterEntries(filterScripts, fromTimestamp)
 }
 
+func (c *mempoolWithMetrics) GetFeeEntries() (v []bchain.MempoolFeeEntry) {
+	defer func(s time.Time) { c.observeRPCLatency("GetFeeEntries", s, nil) }(time.Now())
+	return c.mempool.GetFeeEntries()
+}
+
+func (c *mempoolWithMetrics) SetReplacementTracking(retention time.Duration, onTxReplaced bchain.OnTxReplacedFunc) {
+	c.mempool.SetReplacementTracking(retention, onTxReplaced)
+}
+
+func (c *mempoolWithMetrics) GetTxReplacement(txid string) *bchain.MempoolTxReplacement {
+	return c.mempool.GetTxReplacement(txid)
+}
+
+func (c *mempoolWithMetrics) SetDroppedTracking(retention time.Duration, check bchain.TxDroppedCheckFunc, onTxDropped bchain.OnTxDroppedFunc) {
+	c.mempool.SetDroppedTracking(retention, check, onTxDropped)
+}
+
+func (c *mempoolWithMetrics) GetTxDropped(txid string) *bchain.MempoolTxDropped {
+	return c.mempool.GetTxDropped(txid)
+}
+
+func (c *mempoolWithMetrics) GetAccountTxs(addrDesc bchain.AddressDescriptor) []bchain.MempoolAccountTx {
+	return c.mempool.GetAccountTxs(addrDesc)
+}
+
+func (c *mempoolWithMetrics) GetSnapshot() *bchain.MempoolSnapshot {
+	return c.mempool.GetSnapshot()
+}
+
+func (c *mempoolWithMetrics) LoadSnapshot(snapshot *bchain.MempoolSnapshot) int {
+	return c.mempool.LoadSnapshot(snapshot)
+}
+
 func (c *blockChainWithMetrics) ResolveENS(name string) (*bchain.ENSResolution, error) {
 	if ensResolver, ok := c.b.(interface {
 		ResolveENS(string) (*bchain.ENSResolution, error)
@@ -537,6 +590,17 @@ func (c *blockChainWithMetrics) MissingBlockRetryOverride() *bchain.MissingBlock
 	return nil
 }
 
+// AlternativeSendTxKnown forwards the lookup of the transaction in the alternative send tx
+// providers of the wrapped chain; returns an error when the underlying chain doesn't have them.
+func (c *blockChainWithMetrics) AlternativeSendTxKnown(txid string) (bool, error) {
+	if p, ok := c.b.(interface {
+		AlternativeSendTxKnown(string) (bool, error)
+	}); ok {
+		return p.AlternativeSendTxKnown(txid)
+	}
+	return false, errors.New("alternative send tx providers not supported by underlying chain")
+}
+
 // XpubConfigOverride forwards the wrapped chain's per-chain xpub config
 // override through the metrics wrapper; nil when none is provided.
 func (c *blockChainWithMetrics) XpubConfigOverride() *bchain.XpubConfig {
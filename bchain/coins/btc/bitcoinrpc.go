@@ -39,6 +39,7 @@ type BitcoinRPC struct {
 	mempoolFilterScripts   string
 	mempoolUseZeroedKey    bool
 	alternativeFeeProvider alternativeFeeProviderInterface
+	mempoolFeeProvider     *mempoolFeeProvider
 	metrics                *common.Metrics
 }
 
@@ -99,6 +100,7 @@ type Configuration struct {
 	Slip44                       uint32 `json:"slip44,omitempty"`
 	AlternativeEstimateFee       string `json:"alternative_estimate_fee,omitempty"`
 	AlternativeEstimateFeeParams string `json:"alternative_estimate_fee_params,omitempty"`
+	MempoolFeeEstimatorParams    string `json:"mempool_fee_estimator_params,omitempty"`
 	MinimumCoinbaseConfirmations int    `json:"minimumCoinbaseConfirmations,omitempty"`
 	MempoolGolombFilterP         uint8  `json:"mempool_golomb_filter_p,omitempty"`
 	MempoolFilterScripts         string `json:"mempool_filter_scripts,omitempty"`
@@ -250,11 +252,28 @@ func (b *BitcoinRPC) Initialize() error {
 			// disable AlternativeEstimateFee logic
 			b.alternativeFeeProvider = nil
 		}
+	} else if b.ChainConfig.AlternativeEstimateFee == "mempool" {
+		glog.Info("Using MempoolFee")
+		if b.mempoolFeeProvider, err = NewMempoolFee(b, b.ChainConfig.AlternativeEstimateFeeParams, b.metrics); err != nil {
+			glog.Error("MempoolFee error ", err, " Reverting to default estimateFee functionality")
+			// disable AlternativeEstimateFee logic
+			b.mempoolFeeProvider = nil
+		} else {
+			b.alternativeFeeProvider = b.mempoolFeeProvider
+		}
 	} else if len(b.ChainConfig.AlternativeEstimateFee) > 0 {
 		glog.Error("AlternativeEstimateFee ", b.ChainConfig.AlternativeEstimateFee, " not supported")
 	} else {
 		glog.Info("Using default estimateFee")
 	}
+	// the mempool fee estimator can be enabled only for the explicit requests, keeping the default estimateFee
+	if b.mempoolFeeProvider == nil && len(b.ChainConfig.MempoolFeeEstimatorParams) > 0 {
+		glog.Info("Enabling MempoolFee estimator")
+		if b.mempoolFeeProvider, err = NewMempoolFee(b, b.ChainConfig.MempoolFeeEstimatorParams, b.metrics); err != nil {
+			glog.Error("MempoolFee error ", err)
+			b.mempoolFeeProvider = nil
+		}
+	}
 
 	return nil
 }
@@ -263,6 +282,9 @@ func (b *BitcoinRPC) Initialize() error {
 func (b *BitcoinRPC) CreateMempool(chain bchain.BlockChain) (bchain.Mempool, error) {
 	if b.Mempool == nil {
 		b.Mempool = bchain.NewMempoolBitcoinType(chain, b.ChainConfig.MempoolWorkers, b.ChainConfig.MempoolSubWorkers, b.mempoolGolombFilterP, b.mempoolFilterScripts, b.mempoolUseZeroedKey, b.ChainConfig.MempoolResyncBatchSize)
+		if b.mempoolFeeProvider != nil {
+			b.mempoolFeeProvider.setupMempool(b.Mempool)
+		}
 	}
 	return b.Mempool, nil
 }
@@ -511,6 +533,23 @@ type ResEstimateSmartFee struct {
 	} `json:"result"`
 }
 
+// getblockstats
+
+type CmdGetBlockStats struct {
+	Method string `json:"method"`
+	Params struct {
+		HashOrHeight string   `json:"hash_or_height"`
+		Stats        []string `json:"stats"`
+	} `json:"params"`
+}
+
+type ResGetBlockStats struct {
+	Error  *bchain.RPCError `json:"error"`
+	Result struct {
+		FeeratePercentiles []float64 `json:"feerate_percentiles"`
+	} `json:"result"`
+}
+
 // estimatefee
 
 type CmdEstimateFee struct {
@@ -1087,6 +1126,33 @@ func (b *BitcoinRPC) EstimateFee(blocks int) (big.Int, error) {
 	return r, nil
 }
 
+// EstimateMempoolFee returns fee estimation with confidence levels computed by the built-in mempool fee estimator
+func (b *BitcoinRPC) EstimateMempoolFee(blocks int) (*bchain.MempoolFeeEstimate, error) {
+	if b.mempoolFeeProvider == nil {
+		return nil, errors.New("Mempool fee estimator not enabled")
+	}
+	return b.mempoolFeeProvider.estimateLevels(blocks)
+}
+
+// getBlockFeeRatePercentiles returns the 10th, 25th, 50th, 75th and 90th percentile of the fee rates
+// of the transactions in the block in sat/vB
+func (b *BitcoinRPC) getBlockFeeRatePercentiles(hash string) ([]float64, error) {
+	glog.V(1).Info("rpc: getblockstats ", hash)
+
+	res := ResGetBlockStats{}
+	req := CmdGetBlockStats{Method: "getblockstats"}
+	req.Params.HashOrHeight = hash
+	req.Params.Stats = []string{"feerate_percentiles"}
+	err := b.Call(&req, &res)
+	if err != nil {
+		return nil, errors.Annotatef(err, "hash %v", hash)
+	}
+	if res.Error != nil {
+		return nil, errors.Annotatef(res.Error, "hash %v", hash)
+	}
+	return res.Result.FeeratePercentiles, nil
+}
+
 // LongTermFeeRate returns smallest fee rate from historic blocks.
 func (b *BitcoinRPC) LongTermFeeRate() (*bchain.LongTermFeeRate, error) {
 	blocks := 1008 // ~7 days of blocks, highest number estimatesmartfee supports
@@ -1136,15 +1202,38 @@ func (b *BitcoinRPC) GetMempoolEntry(txid string) (*bchain.MempoolEntry, error)
 	if res.Error != nil {
 		return nil, res.Error
 	}
-	res.Result.FeeSat, err = b.Parser.AmountToBigInt(res.Result.Fee)
-	if err != nil {
-		return nil, err
+	if res.Result == nil {
+		return nil, bchain.ErrTxNotFound
 	}
-	res.Result.ModifiedFeeSat, err = b.Parser.AmountToBigInt(res.Result.ModifiedFee)
-	if err != nil {
-		return nil, err
+	e := res.Result
+	if e.Fees != nil {
+		// the deprecated top level fee fields are not returned since Bitcoin Core 0.21
+		if e.FeeSat, err = b.Parser.AmountToBigInt(e.Fees.Base); err != nil {
+			return nil, err
+		}
+		if e.ModifiedFeeSat, err = b.Parser.AmountToBigInt(e.Fees.Modified); err != nil {
+			return nil, err
+		}
+		if e.AncestorFeesSat, err = b.Parser.AmountToBigInt(e.Fees.Ancestor); err != nil {
+			return nil, err
+		}
+		if e.DescendantFeesSat, err = b.Parser.AmountToBigInt(e.Fees.Descendant); err != nil {
+			return nil, err
+		}
+	} else {
+		if e.FeeSat, err = b.Parser.AmountToBigInt(e.Fee); err != nil {
+			return nil, err
+		}
+		if e.ModifiedFeeSat, err = b.Parser.AmountToBigInt(e.ModifiedFee); err != nil {
+			return nil, err
+		}
+		e.AncestorFeesSat.SetUint64(uint64(e.AncestorFees))
+		e.DescendantFeesSat.SetUint64(uint64(e.DescendantFees))
 	}
-	return res.Result, nil
+	if e.VSize == 0 {
+		e.VSize = e.Size
+	}
+	return e, nil
 }
 
 // callBatch sends a JSON-RPC batch request and decodes responses.
@@ -0,0 +1,255 @@
+package btc
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/common"
+)
+
+// The built-in mempool fee estimator projects the next blocks from the transactions in the mempool
+// (selected by the fee rate of their ancestor packages) and combines them with the fee rates
+// of the recently confirmed blocks, it does not need any external service.
+// The fee rates of the confirmed blocks are the feerate_percentiles of getblockstats, the same distribution
+// as the one logged by ComputeFeeStats, computed by the backend per vbyte instead of the absolute fees
+// of the transactions, which ComputeFeeStats loads one by one from the index.
+
+type mempoolFeeParams struct {
+	PeriodSeconds int `json:"periodSeconds"`
+	// ProjectedBlocks is the number of projected blocks, the last one contains the rest of the mempool
+	ProjectedBlocks int `json:"projectedBlocks,omitempty"`
+	// ConfirmedBlocks is the number of the recent blocks whose fee rates are taken into account, 0 for default
+	ConfirmedBlocks  int   `json:"confirmedBlocks,omitempty"`
+	MaxBlockVSize    int64 `json:"maxBlockVSize,omitempty"`
+	MinFeePerKB      int   `json:"minFeePerKB,omitempty"`
+	FallbackFeePerKB int   `json:"fallbackFeePerKB,omitempty"`
+}
+
+const (
+	mempoolFeeDefaultProjectedBlocks = 8
+	mempoolFeeDefaultConfirmedBlocks = 6
+	mempoolFeeDefaultMinFeePerKB     = 1000
+	// projected block filled to this percentage is considered full
+	mempoolFeeFullBlockPercent = 95
+)
+
+type mempoolFeeLevels struct {
+	blocks int
+	low    int
+	medium int
+	high   int
+}
+
+type mempoolFeeProvider struct {
+	*alternativeFeeProvider
+	params mempoolFeeParams
+	rpc    *BitcoinRPC
+	// mempool is set by setupMempool when the mempool is created, after the estimator is started
+	mempool atomic.Pointer[bchain.MempoolBitcoinType]
+	levels  []mempoolFeeLevels
+	// fee rate percentiles of the recently confirmed blocks by block hash, the blocks replaced by a reorg are not reused
+	confirmed map[string][]float64
+}
+
+// NewMempoolFee initializes the built-in mempool fee estimator
+func NewMempoolFee(rpc *BitcoinRPC, params string, metrics *common.Metrics) (*mempoolFeeProvider, error) {
+	p := &mempoolFeeProvider{
+		alternativeFeeProvider: &alternativeFeeProvider{metrics: metrics, name: "mempool"},
+		rpc:                    rpc,
+		confirmed:              make(map[string][]float64),
+	}
+	err := json.Unmarshal([]byte(params), &p.params)
+	if err != nil {
+		return nil, err
+	}
+	if p.params.PeriodSeconds <= 0 {
+		return nil, errors.New("NewMempoolFee: Missing periodSeconds")
+	}
+	if p.params.ProjectedBlocks <= 0 {
+		p.params.ProjectedBlocks = mempoolFeeDefaultProjectedBlocks
+	}
+	if p.params.ConfirmedBlocks <= 0 {
+		p.params.ConfirmedBlocks = mempoolFeeDefaultConfirmedBlocks
+	}
+	if p.params.MaxBlockVSize <= 0 {
+		p.params.MaxBlockVSize = bchain.MaxBitcoinBlockVSize
+	}
+	if p.params.MinFeePerKB <= 0 {
+		p.params.MinFeePerKB = mempoolFeeDefaultMinFeePerKB
+	}
+	p.fallbackFeePerKBIfNotAvailable = p.params.FallbackFeePerKB
+	p.chain = rpc
+	go p.estimator()
+	return p, nil
+}
+
+// setupMempool passes the mempool to the estimator
+func (p *mempoolFeeProvider) setupMempool(mempool *bchain.MempoolBitcoinType) {
+	p.mempool.Store(mempool)
+}
+
+func (p *mempoolFeeProvider) estimator() {
+	period := time.Duration(p.params.PeriodSeconds) * time.Second
+	timer := time.NewTimer(period)
+	counter := 0
+	for {
+		if err := p.update(); err != nil {
+			glog.Error("mempoolFeeProvider ", err)
+		} else {
+			if counter%60 == 0 {
+				p.compareToDefault()
+			}
+			counter++
+		}
+		<-timer.C
+		timer.Reset(period)
+	}
+}
+
+func (p *mempoolFeeProvider) update() error {
+	mempool := p.mempool.Load()
+	if mempool == nil {
+		return errors.New("mempool not created")
+	}
+	entries := mempool.GetFeeEntries()
+	if len(entries) == 0 {
+		return errors.New("no mempool transactions with known fee")
+	}
+	blocks := bchain.ProjectBlocks(entries, p.params.MaxBlockVSize, p.params.ProjectedBlocks)
+	// the estimate can be computed from the mempool only if the confirmed blocks are not available
+	confirmed, err := p.confirmedFeeRates()
+	if err != nil {
+		glog.Warning("mempoolFeeProvider confirmed blocks ", err)
+	}
+	levels := computeMempoolFeeLevels(blocks, confirmed, &p.params)
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.levels = levels
+	p.fees = make([]alternativeFeeProviderFee, len(levels))
+	for i := range levels {
+		p.fees[i] = alternativeFeeProviderFee{blocks: levels[i].blocks, feePerKB: levels[i].medium}
+	}
+	p.lastSync = time.Now()
+	return nil
+}
+
+// confirmedFeeRates returns the fee rate percentiles of the recently confirmed blocks
+func (p *mempoolFeeProvider) confirmedFeeRates() ([][]float64, error) {
+	best, err := p.rpc.GetBestBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	r := make([][]float64, 0, p.params.ConfirmedBlocks)
+	var lower uint32
+	if best >= uint32(p.params.ConfirmedBlocks) {
+		lower = best - uint32(p.params.ConfirmedBlocks) + 1
+	}
+	hashes := make(map[string]struct{}, p.params.ConfirmedBlocks)
+	for height := lower; height <= best; height++ {
+		hash, err := p.rpc.GetBlockHash(height)
+		if err != nil {
+			return nil, err
+		}
+		hashes[hash] = struct{}{}
+		percentiles, found := p.confirmed[hash]
+		if !found {
+			if percentiles, err = p.rpc.getBlockFeeRatePercentiles(hash); err != nil {
+				return nil, err
+			}
+			p.confirmed[hash] = percentiles
+		}
+		if len(percentiles) > 0 {
+			r = append(r, percentiles)
+		}
+	}
+	for hash := range p.confirmed {
+		if _, found := hashes[hash]; !found {
+			delete(p.confirmed, hash)
+		}
+	}
+	return r, nil
+}
+
+// feeRateToFeePerKB converts the fee rate in sat/vB to fee per kB rounded to 3 significant digits
+func feeRateToFeePerKB(rate float64) int {
+	return int(math.Round(common.RoundToSignificantDigits(rate, 3) * 1000))
+}
+
+// computeMempoolFeeLevels computes the fees for the confirmation targets from the projected blocks and the fee rate
+// percentiles (10th, 25th, 50th, 75th, 90th) of the confirmed blocks
+func computeMempoolFeeLevels(blocks []bchain.ProjectedBlock, confirmed [][]float64, params *mempoolFeeParams) []mempoolFeeLevels {
+	// the median of the 10th percentiles of the confirmed blocks is the fee rate which reliably got into the recent blocks
+	var confirmedFloor int
+	if len(confirmed) > 0 {
+		lows := make([]float64, len(confirmed))
+		for i := range confirmed {
+			lows[i] = confirmed[i][0]
+		}
+		sort.Float64s(lows)
+		confirmedFloor = feeRateToFeePerKB(lows[len(lows)/2])
+	}
+	fullBlock := params.MaxBlockVSize * mempoolFeeFullBlockPercent / 100
+	levels := make([]mempoolFeeLevels, params.ProjectedBlocks)
+	for i := range levels {
+		l := &levels[i]
+		l.blocks = i + 1
+		// if the projected block is not full, there is space for any fee above the minimum
+		if i < len(blocks) && blocks[i].VSize >= fullBlock {
+			b := &blocks[i]
+			l.low = feeRateToFeePerKB(b.FeeRange[0])
+			l.medium = feeRateToFeePerKB(b.FeeRange[2])
+			l.high = feeRateToFeePerKB(b.MedianFeeRate())
+		}
+		if l.high < confirmedFloor {
+			l.high = confirmedFloor
+		}
+		if l.low < params.MinFeePerKB {
+			l.low = params.MinFeePerKB
+		}
+		if l.medium < l.low {
+			l.medium = l.low
+		}
+		if l.high < l.medium {
+			l.high = l.medium
+		}
+		// the fee for a later confirmation cannot be higher than for a sooner one
+		if i > 0 {
+			prev := &levels[i-1]
+			l.low = min(l.low, prev.low)
+			l.medium = min(l.medium, prev.medium)
+			l.high = min(l.high, prev.high)
+		}
+	}
+	return levels
+}
+
+// estimateLevels returns the fee estimate with the confidence levels for the number of blocks
+func (p *mempoolFeeProvider) estimateLevels(blocks int) (*bchain.MempoolFeeEstimate, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if len(p.levels) == 0 {
+		return nil, errors.New("mempoolFeeProvider: no fees")
+	}
+	if p.lastSync.Before(time.Now().Add(time.Duration(-10) * time.Minute)) {
+		return nil, errors.Errorf("mempoolFeeProvider: Missing recent value, last sync at %v", p.lastSync)
+	}
+	l := &p.levels[len(p.levels)-1]
+	for i := range p.levels {
+		if p.levels[i].blocks >= blocks {
+			l = &p.levels[i]
+			break
+		}
+	}
+	r := bchain.MempoolFeeEstimate{Blocks: l.blocks}
+	r.Low = *big.NewInt(int64(l.low))
+	r.Medium = *big.NewInt(int64(l.medium))
+	r.High = *big.NewInt(int64(l.high))
+	return &r, nil
+}
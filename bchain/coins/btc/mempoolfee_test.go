@@ -0,0 +1,61 @@
+//go:build unittest
+
+package btc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func Test_computeMempoolFeeLevels(t *testing.T) {
+	params := mempoolFeeParams{
+		ProjectedBlocks: 4,
+		MaxBlockVSize:   1000000,
+		MinFeePerKB:     1000,
+	}
+	blocks := []bchain.ProjectedBlock{
+		{VSize: 999000, FeeRange: [7]float64{12.1, 13, 15.55, 20, 30, 50, 300}},
+		{VSize: 990000, FeeRange: [7]float64{5, 6, 7, 8, 9, 10, 20}},
+		// the block is not full, it is not possible to guess the fee from it
+		{VSize: 400000, FeeRange: [7]float64{2, 2, 2, 2, 3, 3, 4}},
+	}
+	tests := []struct {
+		name      string
+		confirmed [][]float64
+		want      []mempoolFeeLevels
+	}{
+		{
+			name: "mempool only",
+			want: []mempoolFeeLevels{
+				{blocks: 1, low: 12100, medium: 15600, high: 20000},
+				{blocks: 2, low: 5000, medium: 7000, high: 8000},
+				{blocks: 3, low: 1000, medium: 1000, high: 1000},
+				{blocks: 4, low: 1000, medium: 1000, high: 1000},
+			},
+		},
+		{
+			name: "confirmed blocks raise high confidence",
+			confirmed: [][]float64{
+				{10, 12, 15, 20, 25},
+				{3, 5, 8, 10, 12},
+				{9, 10, 11, 12, 13},
+			},
+			want: []mempoolFeeLevels{
+				{blocks: 1, low: 12100, medium: 15600, high: 20000},
+				{blocks: 2, low: 5000, medium: 7000, high: 9000},
+				{blocks: 3, low: 1000, medium: 1000, high: 9000},
+				{blocks: 4, low: 1000, medium: 1000, high: 9000},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeMempoolFeeLevels(blocks, tt.confirmed, &params)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("computeMempoolFeeLevels() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
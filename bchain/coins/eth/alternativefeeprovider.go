@@ -1,9 +1,17 @@
 package eth
 
 import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/glog"
+	"github.com/juju/errors"
 	"github.com/trezor/blockbook/bchain"
 	"github.com/trezor/blockbook/common"
 )
@@ -50,3 +58,322 @@ func (p *alternativeFeeProvider) GetEip1559Fees() (*bchain.Eip1559Fees, error) {
 	}
 	return p.eip1559Fees, nil
 }
+
+// feeHistoryTier describes one fee level of the feehistory provider: the reward percentile of the recent blocks,
+// the number of the pending blocks the mempool transactions with the higher tip would fill
+// and the expected inclusion time in blocks
+type feeHistoryTier struct {
+	percentile    int
+	pendingBlocks int64
+	minWaitBlocks int
+	maxWaitBlocks int
+}
+
+var feeHistoryTiers = []feeHistoryTier{
+	{percentile: 10, pendingBlocks: 4, minWaitBlocks: 1, maxWaitBlocks: 10}, // low
+	{percentile: 50, pendingBlocks: 2, minWaitBlocks: 1, maxWaitBlocks: 4},  // medium
+	{percentile: 90, pendingBlocks: 1, minWaitBlocks: 1, maxWaitBlocks: 2},  // high
+}
+
+const (
+	feeHistoryDefaultBlocks = 20
+	feeHistoryStalePeriods  = 5
+)
+
+type feeHistoryFeeParams struct {
+	PeriodSeconds int `json:"periodSeconds"`
+	Blocks        int `json:"blocks"`
+}
+
+type feeHistoryResult struct {
+	OldestBlock       string     `json:"oldestBlock"`
+	Reward            [][]string `json:"reward"`
+	BaseFeePerGas     []string   `json:"baseFeePerGas"`
+	GasUsedRatio      []float64  `json:"gasUsedRatio"`
+	BaseFeePerBlobGas []string   `json:"baseFeePerBlobGas"`
+	BlobGasUsedRatio  []float64  `json:"blobGasUsedRatio"`
+}
+
+// feeHistoryFeeProvider estimates the EIP-1559 fees only from the local node,
+// from eth_feeHistory of the recent blocks and from the tips of the pending mempool transactions
+type feeHistoryFeeProvider struct {
+	*alternativeFeeProvider
+	params feeHistoryFeeParams
+	rpc    *EthereumRPC
+	// mempool is set by setupMempool when the mempool is created, after the downloader is started
+	mempool atomic.Pointer[bchain.MempoolEthereumType]
+}
+
+// NewFeeHistoryFeesProvider initializes the local provider using eth_feeHistory and the mempool of the backend
+func NewFeeHistoryFeesProvider(rpc *EthereumRPC, params string, metrics *common.Metrics) (alternativeFeeProviderInterface, error) {
+	p := &feeHistoryFeeProvider{alternativeFeeProvider: &alternativeFeeProvider{metrics: metrics, name: "feehistory"}, rpc: rpc}
+	if params != "" {
+		if err := json.Unmarshal([]byte(params), &p.params); err != nil {
+			return nil, err
+		}
+	}
+	if p.params.PeriodSeconds <= 0 {
+		// by default refresh the fees once per block
+		p.params.PeriodSeconds = (rpc.ChainConfig.AverageBlockTimeMs + 999) / 1000
+		if p.params.PeriodSeconds <= 0 {
+			return nil, errors.New("NewFeeHistoryFeesProvider: missing config parameter 'periodSeconds'.")
+		}
+	}
+	if p.params.Blocks <= 0 {
+		p.params.Blocks = feeHistoryDefaultBlocks
+	}
+	p.chain = rpc
+	p.staleSyncDuration = time.Duration(p.params.PeriodSeconds*feeHistoryStalePeriods) * time.Second
+	go p.FeeDownloader()
+	return p, nil
+}
+
+// setupMempool passes the mempool to the downloader
+func (p *feeHistoryFeeProvider) setupMempool(mempool *bchain.MempoolEthereumType) {
+	p.mempool.Store(mempool)
+}
+
+func (p *feeHistoryFeeProvider) FeeDownloader() {
+	period := time.Duration(p.params.PeriodSeconds) * time.Second
+	timer := time.NewTimer(period)
+	for {
+		if err := p.downloadFees(); err != nil {
+			glog.Error("feeHistoryFeeProvider.FeeDownloader ", err)
+		}
+		<-timer.C
+		timer.Reset(period)
+	}
+}
+
+func (p *feeHistoryFeeProvider) downloadFees() error {
+	percentiles := make([]int, len(feeHistoryTiers))
+	for i := range feeHistoryTiers {
+		percentiles[i] = feeHistoryTiers[i].percentile
+	}
+	var h feeHistoryResult
+	ctx, cancel := context.WithTimeout(context.Background(), p.rpc.Timeout)
+	defer cancel()
+	if err := p.rpc.RPC.CallContext(ctx, &h, "eth_feeHistory", p.params.Blocks, "latest", percentiles); err != nil {
+		p.observeRequest("rpc_error")
+		return err
+	}
+	p.observeRequest("ok")
+	history, err := decodeFeeHistory(&h)
+	if err != nil {
+		return err
+	}
+	var pending []bchain.MempoolPriorityFee
+	if mempool := p.mempool.Load(); mempool != nil {
+		pending = mempool.GetPriorityFees(history.nextBaseFee())
+	}
+	var gasLimit int64
+	if header, err := p.rpc.getBestHeader(); err == nil {
+		if eh, ok := header.(*EthereumHeader); ok && eh.Header != nil {
+			gasLimit = int64(eh.GasLimit)
+		}
+	}
+	fees := computeFeeHistoryFees(history, pending, gasLimit, p.rpc.ChainConfig.AverageBlockTimeMs)
+	if fees == nil {
+		return nil
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.observeSync(time.Now())
+	p.eip1559Fees = fees
+	return nil
+}
+
+// feeHistory is the decoded eth_feeHistory result
+type feeHistory struct {
+	reward           [][]*big.Int
+	baseFees         []*big.Int
+	gasUsedRatio     []float64
+	blobBaseFees     []*big.Int
+	blobGasUsedRatio []float64
+}
+
+func decodeHexBigInts(s []string) ([]*big.Int, error) {
+	r := make([]*big.Int, len(s))
+	for i := range s {
+		v, err := hexutil.DecodeBig(s[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "eth_feeHistory value %v", s[i])
+		}
+		r[i] = v
+	}
+	return r, nil
+}
+
+func decodeFeeHistory(h *feeHistoryResult) (*feeHistory, error) {
+	var err error
+	r := feeHistory{gasUsedRatio: h.GasUsedRatio, blobGasUsedRatio: h.BlobGasUsedRatio, reward: make([][]*big.Int, len(h.Reward))}
+	if r.baseFees, err = decodeHexBigInts(h.BaseFeePerGas); err != nil {
+		return nil, err
+	}
+	if r.blobBaseFees, err = decodeHexBigInts(h.BaseFeePerBlobGas); err != nil {
+		return nil, err
+	}
+	for i := range h.Reward {
+		if r.reward[i], err = decodeHexBigInts(h.Reward[i]); err != nil {
+			return nil, err
+		}
+	}
+	return &r, nil
+}
+
+// nextFee returns the fee of the block following the history, eth_feeHistory returns it as the extra element,
+// otherwise it is computed from the last block by the EIP-1559 update rule. The blob fees use the same linear rule
+// as an approximation, the exact EIP-4844 exponential rule needs the excess blob gas, which eth_feeHistory does not return.
+func nextFee(fees []*big.Int, usedRatio []float64) *big.Int {
+	if len(fees) == 0 {
+		return nil
+	}
+	if len(fees) > len(usedRatio) {
+		return fees[len(usedRatio)]
+	}
+	last := fees[len(fees)-1]
+	// the fee changes at most by 1/8 when the block is full or empty, the target is a half of the block
+	delta := new(big.Float).Mul(new(big.Float).SetInt(last), big.NewFloat((usedRatio[len(fees)-1]-0.5)/4))
+	d, _ := delta.Int(nil)
+	return d.Add(d, last)
+}
+
+func (h *feeHistory) nextBaseFee() *big.Int {
+	if f := nextFee(h.baseFees, h.gasUsedRatio); f != nil {
+		return f
+	}
+	return new(big.Int)
+}
+
+func medianBigInt(v []*big.Int) *big.Int {
+	if len(v) == 0 {
+		return new(big.Int)
+	}
+	sort.Slice(v, func(i, j int) bool { return v[i].Cmp(v[j]) < 0 })
+	return new(big.Int).Set(v[len(v)/2])
+}
+
+func minMaxBigInt(v []*big.Int) []*big.Int {
+	if len(v) == 0 {
+		return nil
+	}
+	lo, hi := v[0], v[0]
+	for _, x := range v[1:] {
+		if x.Cmp(lo) < 0 {
+			lo = x
+		}
+		if x.Cmp(hi) > 0 {
+			hi = x
+		}
+	}
+	return []*big.Int{new(big.Int).Set(lo), new(big.Int).Set(hi)}
+}
+
+// trendBigInt compares the average of the second half of the values with the average of the first half
+func trendBigInt(v []*big.Int) string {
+	if len(v) < 2 {
+		return ""
+	}
+	first, second := new(big.Int), new(big.Int)
+	half := len(v) / 2
+	for i := range v {
+		if i < half {
+			first.Add(first, v[i])
+		} else {
+			second.Add(second, v[i])
+		}
+	}
+	// compare the sums weighted by the size of the other half
+	first.Mul(first, big.NewInt(int64(len(v)-half)))
+	second.Mul(second, big.NewInt(int64(half)))
+	if second.Cmp(first) > 0 {
+		return "up"
+	}
+	return "down"
+}
+
+// pendingTip returns the tip at which the pending transactions with a higher or equal tip fill the given gas,
+// zero if the mempool does not contain enough transactions. The pending slice is sorted by the function.
+func pendingTip(pending []bchain.MempoolPriorityFee, gas int64) *big.Int {
+	sort.Slice(pending, func(i, j int) bool { return pending[i].PriorityFee.Cmp(pending[j].PriorityFee) > 0 })
+	var cumulative int64
+	for i := range pending {
+		cumulative += pending[i].Gas
+		if cumulative >= gas {
+			return new(big.Int).Set(pending[i].PriorityFee)
+		}
+	}
+	return new(big.Int)
+}
+
+// computeFeeHistoryFees derives the low, medium and high fees from the fee history and the pending transactions.
+// The tip of each level is the larger of the median of the reward percentile over the recent non-empty blocks
+// and the tip needed to get ahead of the pending transactions filling the level's number of blocks.
+func computeFeeHistoryFees(h *feeHistory, pending []bchain.MempoolPriorityFee, gasLimit int64, averageBlockTimeMs int) *bchain.Eip1559Fees {
+	if len(h.baseFees) == 0 || len(h.gasUsedRatio) == 0 {
+		return nil
+	}
+	if gasLimit <= 0 {
+		gasLimit = bchain.DefaultEthereumBlockGasLimit
+	}
+	baseFee := h.nextBaseFee()
+	fees := bchain.Eip1559Fees{BaseFeePerGas: baseFee}
+	var blobFee *big.Int
+	if f := nextFee(h.blobBaseFees, h.blobGasUsedRatio); f != nil && f.Sign() > 0 {
+		blobFee = f
+		fees.BaseFeePerBlobGas = f
+	}
+	levels := []**bchain.Eip1559Fee{&fees.Low, &fees.Medium, &fees.High}
+	prevTip := new(big.Int)
+	for i, tier := range feeHistoryTiers {
+		var rewards []*big.Int
+		for j := range h.reward {
+			// empty blocks report zero rewards, they would push the estimate down
+			if len(h.reward[j]) > i && (j >= len(h.gasUsedRatio) || h.gasUsedRatio[j] > 0) {
+				rewards = append(rewards, h.reward[j][i])
+			}
+		}
+		tip := medianBigInt(rewards)
+		if t := pendingTip(pending, tier.pendingBlocks*gasLimit); t.Cmp(tip) > 0 {
+			tip = t
+		}
+		if tip.Cmp(prevTip) < 0 {
+			tip.Set(prevTip)
+		}
+		prevTip = tip
+		f := bchain.Eip1559Fee{
+			MaxPriorityFeePerGas: tip,
+			MaxFeePerGas:         new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(eip1559BaseFeeMultiplier)), tip),
+			MinWaitTimeEstimate:  tier.minWaitBlocks * averageBlockTimeMs,
+			MaxWaitTimeEstimate:  tier.maxWaitBlocks * averageBlockTimeMs,
+		}
+		if blobFee != nil {
+			f.MaxFeePerBlobGas = new(big.Int).Mul(blobFee, big.NewInt(eip1559BaseFeeMultiplier))
+		}
+		*levels[i] = &f
+	}
+	var congestion float64
+	for _, r := range h.gasUsedRatio {
+		congestion += r
+	}
+	fees.NetworkCongestion = congestion / float64(len(h.gasUsedRatio))
+	var allRewards, medianRewards []*big.Int
+	for j := range h.reward {
+		allRewards = append(allRewards, h.reward[j]...)
+		if len(h.reward[j]) > 1 {
+			medianRewards = append(medianRewards, h.reward[j][1])
+		}
+	}
+	if len(h.reward) > 0 {
+		fees.LatestPriorityFeeRange = minMaxBigInt(h.reward[len(h.reward)-1])
+	}
+	fees.HistoricalPriorityFeeRange = minMaxBigInt(allRewards)
+	historicalBaseFees := h.baseFees
+	if len(historicalBaseFees) > len(h.gasUsedRatio) {
+		historicalBaseFees = historicalBaseFees[:len(h.gasUsedRatio)]
+	}
+	fees.HistoricalBaseFeeRange = minMaxBigInt(historicalBaseFees)
+	fees.PriorityFeeTrend = trendBigInt(medianRewards)
+	fees.BaseFeeTrend = trendBigInt(historicalBaseFees)
+	return &fees
+}
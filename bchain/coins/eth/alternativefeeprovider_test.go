@@ -1,6 +1,12 @@
 package eth
 
-import "testing"
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
 
 // TestInitAlternativeFeeProviderFailFast verifies that when a coin config
 // explicitly selects an EVM alternative fee provider whose required API-key env
@@ -18,6 +24,7 @@ func TestInitAlternativeFeeProviderFailFast(t *testing.T) {
 	}{
 		{name: "infura without INFURA_API_KEY fails fast", feeProvider: "infura", params: infuraParams, wantErr: true},
 		{name: "1inch without ONE_INCH_API_KEY fails fast", feeProvider: "1inch", params: oneInchParams, wantErr: true},
+		{name: "feehistory without period and block time fails fast", feeProvider: "feehistory", params: "", wantErr: true},
 		{name: "no provider configured is a no-op", feeProvider: "", params: "", wantErr: false},
 	}
 
@@ -48,3 +55,88 @@ func TestInitAlternativeFeeProviderFailFast(t *testing.T) {
 		})
 	}
 }
+
+func bigInts(v ...int64) []*big.Int {
+	r := make([]*big.Int, len(v))
+	for i := range v {
+		r[i] = big.NewInt(v[i])
+	}
+	return r
+}
+
+func Test_computeFeeHistoryFees(t *testing.T) {
+	history := &feeHistory{
+		reward: [][]*big.Int{
+			bigInts(1, 2, 5),
+			// empty block, skipped in the tier estimates
+			bigInts(0, 0, 0),
+			bigInts(2, 3, 8),
+			bigInts(3, 4, 10),
+		},
+		baseFees:         bigInts(100, 110, 100, 120, 130),
+		gasUsedRatio:     []float64{0.9, 0, 0.95, 0.6},
+		blobBaseFees:     bigInts(1, 1, 2, 3, 4),
+		blobGasUsedRatio: []float64{0.5, 0.5, 1, 1},
+	}
+	pending := []bchain.MempoolPriorityFee{
+		{PriorityFee: big.NewInt(20), Gas: 600},
+		{PriorityFee: big.NewInt(6), Gas: 600},
+		{PriorityFee: big.NewInt(5), Gas: 5000},
+	}
+	got := computeFeeHistoryFees(history, pending, 1000, 12000)
+	want := &bchain.Eip1559Fees{
+		BaseFeePerGas:     big.NewInt(130),
+		BaseFeePerBlobGas: big.NewInt(4),
+		Low: &bchain.Eip1559Fee{
+			MaxFeePerGas:         big.NewInt(265),
+			MaxPriorityFeePerGas: big.NewInt(5),
+			MinWaitTimeEstimate:  12000,
+			MaxWaitTimeEstimate:  120000,
+			MaxFeePerBlobGas:     big.NewInt(8),
+		},
+		Medium: &bchain.Eip1559Fee{
+			MaxFeePerGas:         big.NewInt(265),
+			MaxPriorityFeePerGas: big.NewInt(5),
+			MinWaitTimeEstimate:  12000,
+			MaxWaitTimeEstimate:  48000,
+			MaxFeePerBlobGas:     big.NewInt(8),
+		},
+		High: &bchain.Eip1559Fee{
+			MaxFeePerGas:         big.NewInt(268),
+			MaxPriorityFeePerGas: big.NewInt(8),
+			MinWaitTimeEstimate:  12000,
+			MaxWaitTimeEstimate:  24000,
+			MaxFeePerBlobGas:     big.NewInt(8),
+		},
+		NetworkCongestion:          0.6125,
+		LatestPriorityFeeRange:     bigInts(3, 10),
+		HistoricalPriorityFeeRange: bigInts(0, 10),
+		HistoricalBaseFeeRange:     bigInts(100, 120),
+		PriorityFeeTrend:           "up",
+		BaseFeeTrend:               "up",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("computeFeeHistoryFees() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_nextFee(t *testing.T) {
+	tests := []struct {
+		name      string
+		fees      []*big.Int
+		usedRatio []float64
+		want      *big.Int
+	}{
+		{name: "next fee returned by the node", fees: bigInts(100, 112), usedRatio: []float64{1}, want: big.NewInt(112)},
+		{name: "full block", fees: bigInts(800), usedRatio: []float64{1}, want: big.NewInt(900)},
+		{name: "empty block", fees: bigInts(800), usedRatio: []float64{0}, want: big.NewInt(700)},
+		{name: "no history", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextFee(tt.fees, tt.usedRatio); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nextFee() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
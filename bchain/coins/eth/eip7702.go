@@ -0,0 +1,159 @@
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// EIP-7702 delegation indicator, the code of a delegated account is the prefix followed by the 20 byte delegate address
+const delegationCodePrefix = "0xef0100"
+
+// setCodeAuthorizationMagic prefixes the RLP encoded authorization tuple when computing the signing hash
+const setCodeAuthorizationMagic = 0x05
+
+// GetDelegationFromCode returns the EIP-55 address of the delegate if the code is an EIP-7702 delegation indicator
+func GetDelegationFromCode(code string) (string, bool) {
+	code = strings.ToLower(code)
+	if len(code) != len(delegationCodePrefix)+2*EthereumTypeAddressDescriptorLen || !strings.HasPrefix(code, delegationCodePrefix) {
+		return "", false
+	}
+	delegate, err := hex.DecodeString(code[len(delegationCodePrefix):])
+	if err != nil {
+		return "", false
+	}
+	return EIP55Address(delegate), true
+}
+
+// authorizationAuthority recovers the account which signed the EIP-7702 authorization
+func authorizationAuthority(a *bchain.RpcAuthorization) (string, error) {
+	chainID, err := hexutil.DecodeBig(a.ChainID)
+	if err != nil {
+		return "", errors.Annotatef(err, "ChainID %v", a.ChainID)
+	}
+	address, err := hexDecode(a.Address)
+	if err != nil || len(address) != EthereumTypeAddressDescriptorLen {
+		return "", errors.Errorf("Invalid address %v", a.Address)
+	}
+	nonce, err := hexutil.DecodeUint64(a.Nonce)
+	if err != nil {
+		return "", errors.Annotatef(err, "Nonce %v", a.Nonce)
+	}
+	v, err := hexutil.DecodeUint64(a.YParity)
+	if err != nil {
+		return "", errors.Annotatef(err, "YParity %v", a.YParity)
+	}
+	r, err := hexutil.DecodeBig(a.R)
+	if err != nil {
+		return "", errors.Annotatef(err, "R %v", a.R)
+	}
+	s, err := hexutil.DecodeBig(a.S)
+	if err != nil {
+		return "", errors.Annotatef(err, "S %v", a.S)
+	}
+	if v > 1 || !crypto.ValidateSignatureValues(byte(v), r, s, true) {
+		return "", errors.New("Invalid signature values")
+	}
+	payload, err := rlp.EncodeToBytes([]interface{}{chainID, common.BytesToAddress(address), nonce})
+	if err != nil {
+		return "", err
+	}
+	sighash := crypto.Keccak256(append([]byte{setCodeAuthorizationMagic}, payload...))
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = byte(v)
+	pub, err := crypto.SigToPub(sighash, sig)
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(*pub).Hex(), nil
+}
+
+// setAuthorizationAuthorities recovers the authorities of the authorizations which do not have them yet,
+// the authority of an authorization with an invalid signature is left empty
+func setAuthorizationAuthorities(tx *bchain.RpcTransaction) {
+	for i := range tx.AuthorizationList {
+		a := &tx.AuthorizationList[i]
+		if a.Authority != "" {
+			continue
+		}
+		authority, err := authorizationAuthority(a)
+		if err != nil {
+			continue
+		}
+		a.Authority = authority
+	}
+}
+
+func packAuthorizationList(authorizations []bchain.RpcAuthorization) ([]*ProtoCompleteTransaction_TxType_AuthorizationType, error) {
+	var err error
+	var n uint64
+	r := make([]*ProtoCompleteTransaction_TxType_AuthorizationType, len(authorizations))
+	for i := range authorizations {
+		a := &authorizations[i]
+		pa := &ProtoCompleteTransaction_TxType_AuthorizationType{}
+		if pa.ChainId, err = hexDecodeBig(a.ChainID); err != nil {
+			return nil, errors.Annotatef(err, "Authorization ChainID %v", a.ChainID)
+		}
+		if pa.Address, err = hexDecode(a.Address); err != nil {
+			return nil, errors.Annotatef(err, "Authorization Address %v", a.Address)
+		}
+		if pa.Nonce, err = hexutil.DecodeUint64(a.Nonce); err != nil {
+			return nil, errors.Annotatef(err, "Authorization Nonce %v", a.Nonce)
+		}
+		if n, err = hexutil.DecodeUint64(a.YParity); err != nil {
+			return nil, errors.Annotatef(err, "Authorization YParity %v", a.YParity)
+		}
+		pa.YParity = uint32(n)
+		if pa.R, err = hexDecodeBig(a.R); err != nil {
+			return nil, errors.Annotatef(err, "Authorization R %v", a.R)
+		}
+		if pa.S, err = hexDecodeBig(a.S); err != nil {
+			return nil, errors.Annotatef(err, "Authorization S %v", a.S)
+		}
+		if pa.Authority, err = hexDecode(a.Authority); err != nil {
+			return nil, errors.Annotatef(err, "Authorization Authority %v", a.Authority)
+		}
+		r[i] = pa
+	}
+	return r, nil
+}
+
+func (p *EthereumParser) unpackAuthorizationList(pas []*ProtoCompleteTransaction_TxType_AuthorizationType) []bchain.RpcAuthorization {
+	r := make([]bchain.RpcAuthorization, len(pas))
+	for i, pa := range pas {
+		r[i] = bchain.RpcAuthorization{
+			ChainID: hexEncodeBig(pa.ChainId),
+			Address: p.FromDescToAddressFunc(pa.Address),
+			Nonce:   hexutil.EncodeUint64(pa.Nonce),
+			YParity: hexutil.EncodeUint64(uint64(pa.YParity)),
+			R:       hexEncodeBig(pa.R),
+			S:       hexEncodeBig(pa.S),
+		}
+		if len(pa.Authority) > 0 {
+			r[i].Authority = p.FromDescToAddressFunc(pa.Authority)
+		}
+	}
+	return r
+}
+
+// EthereumTypeGetDelegation returns the address the account delegates its code to using EIP-7702,
+// empty string if the account is not delegated
+func (b *EthereumRPC) EthereumTypeGetDelegation(addrDesc bchain.AddressDescriptor) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.Timeout)
+	defer cancel()
+	var code string
+	if err := b.RPC.CallContext(ctx, &code, "eth_getCode", hexutil.Encode(addrDesc), "latest"); err != nil {
+		return "", err
+	}
+	delegate, _ := GetDelegationFromCode(code)
+	return delegate, nil
+}
@@ -0,0 +1,127 @@
+package eth
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/trezor/blockbook/bchain"
+)
+
+func signedTestAuthorization(t *testing.T, chainID uint64, nonce uint64) (bchain.RpcAuthorization, string) {
+	t.Helper()
+	key, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := types.SetCodeAuthorization{
+		Address: common.HexToAddress("0x63c7a1c6cbd4eaa40e67cfa3ea1fd3e90b4b6de5"),
+		Nonce:   nonce,
+	}
+	auth.ChainID.SetUint64(chainID)
+	signed, err := types.SignSetCode(key, auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var a bchain.RpcAuthorization
+	if err = json.Unmarshal(b, &a); err != nil {
+		t.Fatal(err)
+	}
+	return a, crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+func TestAuthorizationAuthority(t *testing.T) {
+	for _, chainID := range []uint64{0, 1} {
+		a, want := signedTestAuthorization(t, chainID, 7)
+		got, err := authorizationAuthority(&a)
+		if err != nil {
+			t.Fatalf("chainID %d: authorizationAuthority() error = %v", chainID, err)
+		}
+		if got != want {
+			t.Errorf("chainID %d: authorizationAuthority() = %v, want %v", chainID, got, want)
+		}
+	}
+
+	a, want := signedTestAuthorization(t, 1, 7)
+	// a different nonce recovers a different signer
+	a.Nonce = "0x8"
+	if got, err := authorizationAuthority(&a); err == nil && got == want {
+		t.Errorf("authorizationAuthority() of modified authorization = %v, expected a different address", got)
+	}
+	a.Nonce = "0x7"
+	a.YParity = "0x2"
+	if _, err := authorizationAuthority(&a); err == nil {
+		t.Error("authorizationAuthority() with invalid y parity, expected error")
+	}
+
+	tx := bchain.RpcTransaction{AuthorizationList: []bchain.RpcAuthorization{a}}
+	setAuthorizationAuthorities(&tx)
+	if tx.AuthorizationList[0].Authority != "" {
+		t.Errorf("setAuthorizationAuthorities() of invalid signature = %v, want empty authority", tx.AuthorizationList[0].Authority)
+	}
+}
+
+func TestGetDelegationFromCode(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   string
+		wantOk bool
+	}{
+		{code: "0xef010063c7a1c6cbd4eaa40e67cfa3ea1fd3e90b4b6de5", want: "0x63C7A1c6CBD4eaa40E67Cfa3ea1fd3e90B4B6De5", wantOk: true},
+		{code: "0xEF010063C7A1C6CBD4EAA40E67CFA3EA1FD3E90B4B6DE5", want: "0x63C7A1c6CBD4eaa40E67Cfa3ea1fd3e90B4B6De5", wantOk: true},
+		{code: "0x"},
+		{code: "0x6080604052"},
+		{code: "0xef010063c7a1c6cbd4eaa40e67cfa3ea1fd3e90b4b6de500"},
+	}
+	for _, tt := range tests {
+		got, ok := GetDelegationFromCode(tt.code)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("GetDelegationFromCode(%q) = (%v, %v), want (%v, %v)", tt.code, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestEthereumParser_PackUnpackAuthorizationList(t *testing.T) {
+	p := NewEthereumParser(1, false)
+	a, authority := signedTestAuthorization(t, 1, 7)
+	rt := bchain.RpcTransaction{
+		AccountNonce:      "0x1",
+		GasPrice:          "0x3b9aca00",
+		GasLimit:          "0x186a0",
+		To:                "0x63C7A1c6CBD4eaa40E67Cfa3ea1fd3e90B4B6De5",
+		Value:             "0x0",
+		Payload:           "0x",
+		Hash:              "0xcd647151552b5132b2aef7c9be00dc6f73afc5901dde157aab131335baaa853b",
+		BlockNumber:       "0x41eee8",
+		From:              "0x3E3a3D69dc66bA10737F531ed088954a9EC89d97",
+		TransactionIndex:  "0xa",
+		AuthorizationList: []bchain.RpcAuthorization{a},
+	}
+	tx, err := p.EthTxToTx(&rt, nil, nil, 1521515026, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csd := tx.CoinSpecificData.(bchain.EthereumSpecificData)
+	if got := csd.Tx.AuthorizationList[0].Authority; got != authority {
+		t.Fatalf("EthTxToTx() authority = %v, want %v", got, authority)
+	}
+	packed, err := p.PackTx(tx, 4321000, 1521515026)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpacked, _, err := p.UnpackTx(packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := unpacked.CoinSpecificData.(bchain.EthereumSpecificData).Tx.AuthorizationList
+	if !reflect.DeepEqual(got, csd.Tx.AuthorizationList) {
+		t.Errorf("UnpackTx() authorizationList = %+v, want %+v", got, csd.Tx.AuthorizationList)
+	}
+}
@@ -109,6 +109,7 @@ type rpcLogWithTxHash struct {
 
 type rpcBlockTransactions struct {
 	Transactions []bchain.RpcTransaction `json:"transactions"`
+	Withdrawals  []bchain.RpcWithdrawal  `json:"withdrawals"`
 }
 
 type rpcBlockTxids struct {
@@ -144,6 +145,14 @@ func (p *EthereumParser) EthTxToTx(tx *bchain.RpcTransaction, receipt *bchain.Rp
 		}
 		ta = []string{tx.To}
 	}
+	if len(tx.AuthorizationList) > 0 {
+		if fixEIP55 {
+			for i := range tx.AuthorizationList {
+				tx.AuthorizationList[i].Address = p.FormatAddressFunc(tx.AuthorizationList[i].Address)
+			}
+		}
+		setAuthorizationAuthorities(tx)
+	}
 	if fixEIP55 && receipt != nil && receipt.Logs != nil {
 		for _, l := range receipt.Logs {
 			if len(l.Address) > 2 {
@@ -348,6 +357,11 @@ func (p *EthereumParser) PackTx(tx *bchain.Tx, height uint32, blockTime int64) (
 			return nil, errors.Annotatef(err, "BaseFeePerGas %v", r.Tx.BaseFeePerGas)
 		}
 	}
+	if len(r.Tx.AuthorizationList) > 0 {
+		if pt.Tx.AuthorizationList, err = packAuthorizationList(r.Tx.AuthorizationList); err != nil {
+			return nil, err
+		}
+	}
 	// if pt.R, err = hexDecodeBig(r.R); err != nil {
 	// 	return nil, errors.Annotatef(err, "R %v", r.R)
 	// }
@@ -429,6 +443,26 @@ func (p *EthereumParser) PackTx(tx *bchain.Tx, height uint32, blockTime int64) (
 				return nil, errors.Annotatef(err, "EffectiveGasPrice %v", r.Receipt.EffectiveGasPrice)
 			}
 		}
+		if r.Receipt.L1BaseFeeScalar != "" {
+			if pt.Receipt.L1BaseFeeScalar, err = hexDecodeBig(r.Receipt.L1BaseFeeScalar); err != nil {
+				return nil, errors.Annotatef(err, "L1BaseFeeScalar %v", r.Receipt.L1BaseFeeScalar)
+			}
+		}
+		if r.Receipt.L1BlobBaseFee != "" {
+			if pt.Receipt.L1BlobBaseFee, err = hexDecodeBig(r.Receipt.L1BlobBaseFee); err != nil {
+				return nil, errors.Annotatef(err, "L1BlobBaseFee %v", r.Receipt.L1BlobBaseFee)
+			}
+		}
+		if r.Receipt.L1BlobBaseFeeScalar != "" {
+			if pt.Receipt.L1BlobBaseFeeScalar, err = hexDecodeBig(r.Receipt.L1BlobBaseFeeScalar); err != nil {
+				return nil, errors.Annotatef(err, "L1BlobBaseFeeScalar %v", r.Receipt.L1BlobBaseFeeScalar)
+			}
+		}
+		if r.Receipt.GasUsedForL1 != "" {
+			if pt.Receipt.GasUsedForL1, err = hexDecodeBig(r.Receipt.GasUsedForL1); err != nil {
+				return nil, errors.Annotatef(err, "GasUsedForL1 %v", r.Receipt.GasUsedForL1)
+			}
+		}
 	}
 	if len(r.ChainExtraData) > 0 {
 		pt.ChainExtraData = r.ChainExtraData
@@ -467,6 +501,9 @@ func (p *EthereumParser) UnpackTx(buf []byte) (*bchain.Tx, uint32, error) {
 	if len(pt.Tx.BaseFeePerGas) > 0 {
 		rt.BaseFeePerGas = hexEncodeBig(pt.Tx.BaseFeePerGas)
 	}
+	if len(pt.Tx.AuthorizationList) > 0 {
+		rt.AuthorizationList = p.unpackAuthorizationList(pt.Tx.AuthorizationList)
+	}
 	var rr *bchain.RpcReceipt
 	if pt.Receipt != nil {
 		rr = &bchain.RpcReceipt{
@@ -504,6 +541,18 @@ func (p *EthereumParser) UnpackTx(buf []byte) (*bchain.Tx, uint32, error) {
 		if len(pt.Receipt.EffectiveGasPrice) > 0 {
 			rr.EffectiveGasPrice = hexEncodeBig(pt.Receipt.EffectiveGasPrice)
 		}
+		if len(pt.Receipt.L1BaseFeeScalar) > 0 {
+			rr.L1BaseFeeScalar = hexEncodeBig(pt.Receipt.L1BaseFeeScalar)
+		}
+		if len(pt.Receipt.L1BlobBaseFee) > 0 {
+			rr.L1BlobBaseFee = hexEncodeBig(pt.Receipt.L1BlobBaseFee)
+		}
+		if len(pt.Receipt.L1BlobBaseFeeScalar) > 0 {
+			rr.L1BlobBaseFeeScalar = hexEncodeBig(pt.Receipt.L1BlobBaseFeeScalar)
+		}
+		if len(pt.Receipt.GasUsedForL1) > 0 {
+			rr.GasUsedForL1 = hexEncodeBig(pt.Receipt.GasUsedForL1)
+		}
 	}
 	// TODO handle internal transactions
 	tx, err := p.EthTxToTx(&rt, rr, nil, int64(pt.BlockTime), 0, false)
@@ -624,6 +673,10 @@ func GetEthereumTxDataFromSpecificData(coinSpecificData interface{}) *bchain.Eth
 			etd.L1GasPrice, _ = hexutil.DecodeBig(csd.Receipt.L1GasPrice)
 			etd.L1GasUsed, _ = hexutil.DecodeBig(csd.Receipt.L1GasUsed)
 			etd.L1FeeScalar = csd.Receipt.L1FeeScalar
+			etd.L1BaseFeeScalar, _ = hexutil.DecodeBig(csd.Receipt.L1BaseFeeScalar)
+			etd.L1BlobBaseFee, _ = hexutil.DecodeBig(csd.Receipt.L1BlobBaseFee)
+			etd.L1BlobBaseFeeScalar, _ = hexutil.DecodeBig(csd.Receipt.L1BlobBaseFeeScalar)
+			etd.GasUsedForL1, _ = hexutil.DecodeBig(csd.Receipt.GasUsedForL1)
 		}
 	}
 	return &etd
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/trezor/blockbook/bchain"
 	"github.com/trezor/blockbook/tests/dbtestdata"
 )
@@ -476,6 +477,84 @@ func TestEthereumParser_PackUnpackEffectiveGasPrice(t *testing.T) {
 	}
 }
 
+// TestEthereumParser_PackUnpackL2ReceiptFees verifies that the L1 data fee fields
+// of OP stack (Ecotone) and Arbitrum receipts survive a PackTx/UnpackTx round trip
+// and are exposed by GetEthereumTxData.
+func TestEthereumParser_PackUnpackL2ReceiptFees(t *testing.T) {
+	p := NewEthereumParser(1, false)
+	receipts := []*bchain.RpcReceipt{
+		{
+			GasUsed:             "0x5208",
+			EffectiveGasPrice:   "0xf4240",
+			Status:              "0x1",
+			Logs:                []*bchain.RpcLog{},
+			L1Fee:               "0x2a9f3c1b",
+			L1GasPrice:          "0x3b9aca00",
+			L1GasUsed:           "0x640",
+			L1BaseFeeScalar:     "0x8dd",
+			L1BlobBaseFee:       "0x1",
+			L1BlobBaseFeeScalar: "0x101c12",
+		},
+		{
+			GasUsed:           "0x1e847",
+			EffectiveGasPrice: "0x989680",
+			Status:            "0x1",
+			Logs:              []*bchain.RpcLog{},
+			GasUsedForL1:      "0x9c4",
+		},
+	}
+	for _, receipt := range receipts {
+		original := &bchain.Tx{
+			CoinSpecificData: bchain.EthereumSpecificData{
+				Tx: &bchain.RpcTransaction{
+					AccountNonce:     "0x1",
+					GasPrice:         "0x430e23400",
+					GasLimit:         "0x5208",
+					To:               "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f",
+					Value:            "0x0",
+					Payload:          "0x",
+					Hash:             "0xcd647151552b5132b2aef7c9be00dc6f73afc5901dde157aab131335baaa853b",
+					BlockNumber:      "0x41eee8",
+					From:             "0x3E3a3D69dc66bA10737F531ed088954a9EC89d97",
+					TransactionIndex: "0x0",
+				},
+				Receipt: receipt,
+			},
+		}
+		packed, err := p.PackTx(original, 4321000, 1534858022)
+		if err != nil {
+			t.Fatalf("PackTx error: %v", err)
+		}
+		unpacked, _, err := p.UnpackTx(packed)
+		if err != nil {
+			t.Fatalf("UnpackTx error: %v", err)
+		}
+		csd := unpacked.CoinSpecificData.(bchain.EthereumSpecificData)
+		if !reflect.DeepEqual(csd.Receipt, receipt) {
+			t.Errorf("UnpackTx() receipt = %+v, want %+v", csd.Receipt, receipt)
+		}
+		etd := p.GetEthereumTxData(unpacked)
+		for _, f := range []struct {
+			name string
+			got  *big.Int
+			want string
+		}{
+			{"L1BaseFeeScalar", etd.L1BaseFeeScalar, receipt.L1BaseFeeScalar},
+			{"L1BlobBaseFee", etd.L1BlobBaseFee, receipt.L1BlobBaseFee},
+			{"L1BlobBaseFeeScalar", etd.L1BlobBaseFeeScalar, receipt.L1BlobBaseFeeScalar},
+			{"GasUsedForL1", etd.GasUsedForL1, receipt.GasUsedForL1},
+		} {
+			got := ""
+			if f.got != nil {
+				got = hexutil.EncodeBig(f.got)
+			}
+			if got != f.want {
+				t.Errorf("GetEthereumTxData %s = %v, want %v", f.name, got, f.want)
+			}
+		}
+	}
+}
+
 func TestEthereumParser_GetEthereumTxData(t *testing.T) {
 	p := NewEthereumParser(1, false)
 	tests := []struct {
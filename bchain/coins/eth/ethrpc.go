@@ -713,6 +713,9 @@ func (b *EthereumRPC) CreateMempool(chain bchain.BlockChain) (bchain.Mempool, er
 		if b.alternativeSendTxProvider != nil {
 			b.alternativeSendTxProvider.SetupMempool(b.Mempool, b.removeTransactionFromMempool)
 		}
+		if p, ok := b.alternativeFeeProvider.(*feeHistoryFeeProvider); ok {
+			p.setupMempool(b.Mempool)
+		}
 
 	}
 	return b.Mempool, nil
@@ -900,6 +903,11 @@ func (b *EthereumRPC) initAlternativeFeeProvider() error {
 			b.alternativeFeeProvider = nil
 			return err
 		}
+	} else if b.ChainConfig.AlternativeEstimateFee == "feehistory" {
+		if b.alternativeFeeProvider, err = NewFeeHistoryFeesProvider(b, b.ChainConfig.AlternativeEstimateFeeParams, b.metrics); err != nil {
+			b.alternativeFeeProvider = nil
+			return err
+		}
 	}
 	if b.alternativeFeeProvider != nil {
 		glog.Info("Using alternative fee provider ", b.ChainConfig.AlternativeEstimateFee)
@@ -1441,6 +1449,9 @@ type rpcCallTrace struct {
 	Error  string         `json:"error"`
 	Output string         `json:"output"`
 	Calls  []rpcCallTrace `json:"calls"`
+	// returned only by debug_traceCall used in the transaction simulation
+	GasUsed string           `json:"gasUsed,omitempty"`
+	Logs    []*bchain.RpcLog `json:"logs,omitempty"`
 }
 
 type rpcTraceResult struct {
@@ -1672,6 +1683,17 @@ func (b *EthereumRPC) GetBlock(hash string, height uint32) (*bchain.Block, error
 		}
 	}
 
+	withdrawals, err := ethWithdrawalsToWithdrawals(body.Withdrawals)
+	if err != nil {
+		return nil, errors.Annotatef(err, "hash %v, height %v", hash, height)
+	}
+	if len(withdrawals) > 0 {
+		if blockSpecificData == nil {
+			blockSpecificData = &bchain.EthereumBlockSpecificData{}
+		}
+		blockSpecificData.Withdrawals = withdrawals
+	}
+
 	blockSpecificData = attachBlockGas(&head, blockSpecificData)
 
 	btxs := make([]bchain.Tx, len(body.Transactions))
@@ -1827,7 +1849,7 @@ func (b *EthereumRPC) GetTransaction(txid string) (*bchain.Tx, error) {
 	// recoveredReceipt is set only when the transaction was reconstructed via the pruned-index
 	// fallback below; the mined branch reuses it instead of fetching the receipt again.
 	var recoveredReceipt *bchain.RpcReceipt
-	if *tx == (bchain.RpcTransaction{}) {
+	if tx.Hash == "" {
 		// eth_getTransactionByHash returned null. Some archive backends (observed on
 		// QuikNode Base) prune the transaction-by-hash index beyond a recent window
 		// while still serving block bodies and receipts, so a mined transaction older
@@ -1990,6 +2012,12 @@ func (b *EthereumRPC) EthereumTypeEstimateGas(params map[string]interface{}) (ui
 	return b.Client.EstimateGas(ctx, msg)
 }
 
+// EthereumTypeEstimateL1Fee returns estimation of the L1 data fee for given transaction parameters,
+// nil on networks which do not charge the L1 data fee
+func (b *EthereumRPC) EthereumTypeEstimateL1Fee(params map[string]interface{}) (*bchain.EthereumL1FeeEstimate, error) {
+	return nil, nil
+}
+
 // bigIntToFloat converts a wei amount to float64 for gauge export. float64 holds integers
 // exactly up to 2^53 (~9e15 wei), far above any realistic gas price, so no precision is lost;
 // keeping the metric in raw wei (base units) matches the repo convention and Grafana divides
@@ -2204,6 +2232,19 @@ func (b *EthereumRPC) SendRawTransaction(hex string, disableAlternativeRPC bool)
 	return txid, retErr
 }
 
+// AlternativeSendTxKnown reports whether the alternative send tx providers know the transaction. The transactions
+// sent to private relays may stay unknown to the backend until they are mined.
+func (b *EthereumRPC) AlternativeSendTxKnown(txid string) (bool, error) {
+	if b.alternativeSendTxProvider == nil {
+		return false, nil
+	}
+	if _, found := b.alternativeSendTxProvider.GetTransaction(txid); found {
+		return true, nil
+	}
+	found, _, err := b.alternativeSendTxProvider.providerKnowsTransaction(txid)
+	return found, err
+}
+
 // EthereumTypeGetRawTransaction gets raw transaction in hex format
 func (b *EthereumRPC) EthereumTypeGetRawTransaction(txid string) (string, error) {
 	return b.callRpcStringResult("eth_getRawTransactionByHash", txid)
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.11
 // 	protoc        v3.21.12
 // source: bchain/coins/eth/ethtx.proto
 
@@ -98,19 +98,20 @@ func (x *ProtoCompleteTransaction) GetChainExtraData() []byte {
 }
 
 type ProtoCompleteTransaction_TxType struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	AccountNonce         uint64                 `protobuf:"varint,1,opt,name=AccountNonce,proto3" json:"AccountNonce,omitempty"`
-	GasPrice             []byte                 `protobuf:"bytes,2,opt,name=GasPrice,proto3" json:"GasPrice,omitempty"`
-	GasLimit             uint64                 `protobuf:"varint,3,opt,name=GasLimit,proto3" json:"GasLimit,omitempty"`
-	Value                []byte                 `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
-	Payload              []byte                 `protobuf:"bytes,5,opt,name=Payload,proto3" json:"Payload,omitempty"`
-	Hash                 []byte                 `protobuf:"bytes,6,opt,name=Hash,proto3" json:"Hash,omitempty"`
-	To                   []byte                 `protobuf:"bytes,7,opt,name=To,proto3" json:"To,omitempty"`
-	From                 []byte                 `protobuf:"bytes,8,opt,name=From,proto3" json:"From,omitempty"`
-	TransactionIndex     uint32                 `protobuf:"varint,9,opt,name=TransactionIndex,proto3" json:"TransactionIndex,omitempty"`
-	MaxPriorityFeePerGas []byte                 `protobuf:"bytes,10,opt,name=MaxPriorityFeePerGas,proto3,oneof" json:"MaxPriorityFeePerGas,omitempty"`
-	MaxFeePerGas         []byte                 `protobuf:"bytes,11,opt,name=MaxFeePerGas,proto3,oneof" json:"MaxFeePerGas,omitempty"`
-	BaseFeePerGas        []byte                 `protobuf:"bytes,12,opt,name=BaseFeePerGas,proto3,oneof" json:"BaseFeePerGas,omitempty"`
+	state                protoimpl.MessageState                               `protogen:"open.v1"`
+	AccountNonce         uint64                                               `protobuf:"varint,1,opt,name=AccountNonce,proto3" json:"AccountNonce,omitempty"`
+	GasPrice             []byte                                               `protobuf:"bytes,2,opt,name=GasPrice,proto3" json:"GasPrice,omitempty"`
+	GasLimit             uint64                                               `protobuf:"varint,3,opt,name=GasLimit,proto3" json:"GasLimit,omitempty"`
+	Value                []byte                                               `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	Payload              []byte                                               `protobuf:"bytes,5,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	Hash                 []byte                                               `protobuf:"bytes,6,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	To                   []byte                                               `protobuf:"bytes,7,opt,name=To,proto3" json:"To,omitempty"`
+	From                 []byte                                               `protobuf:"bytes,8,opt,name=From,proto3" json:"From,omitempty"`
+	TransactionIndex     uint32                                               `protobuf:"varint,9,opt,name=TransactionIndex,proto3" json:"TransactionIndex,omitempty"`
+	MaxPriorityFeePerGas []byte                                               `protobuf:"bytes,10,opt,name=MaxPriorityFeePerGas,proto3,oneof" json:"MaxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         []byte                                               `protobuf:"bytes,11,opt,name=MaxFeePerGas,proto3,oneof" json:"MaxFeePerGas,omitempty"`
+	BaseFeePerGas        []byte                                               `protobuf:"bytes,12,opt,name=BaseFeePerGas,proto3,oneof" json:"BaseFeePerGas,omitempty"`
+	AuthorizationList    []*ProtoCompleteTransaction_TxType_AuthorizationType `protobuf:"bytes,13,rep,name=AuthorizationList,proto3" json:"AuthorizationList,omitempty"`
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -229,18 +230,29 @@ func (x *ProtoCompleteTransaction_TxType) GetBaseFeePerGas() []byte {
 	return nil
 }
 
+func (x *ProtoCompleteTransaction_TxType) GetAuthorizationList() []*ProtoCompleteTransaction_TxType_AuthorizationType {
+	if x != nil {
+		return x.AuthorizationList
+	}
+	return nil
+}
+
 type ProtoCompleteTransaction_ReceiptType struct {
-	state             protoimpl.MessageState                          `protogen:"open.v1"`
-	GasUsed           []byte                                          `protobuf:"bytes,1,opt,name=GasUsed,proto3" json:"GasUsed,omitempty"`
-	Status            []byte                                          `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
-	Log               []*ProtoCompleteTransaction_ReceiptType_LogType `protobuf:"bytes,3,rep,name=Log,proto3" json:"Log,omitempty"`
-	L1Fee             []byte                                          `protobuf:"bytes,4,opt,name=L1Fee,proto3,oneof" json:"L1Fee,omitempty"`
-	L1FeeScalar       []byte                                          `protobuf:"bytes,5,opt,name=L1FeeScalar,proto3,oneof" json:"L1FeeScalar,omitempty"`
-	L1GasPrice        []byte                                          `protobuf:"bytes,6,opt,name=L1GasPrice,proto3,oneof" json:"L1GasPrice,omitempty"`
-	L1GasUsed         []byte                                          `protobuf:"bytes,7,opt,name=L1GasUsed,proto3,oneof" json:"L1GasUsed,omitempty"`
-	EffectiveGasPrice []byte                                          `protobuf:"bytes,8,opt,name=EffectiveGasPrice,proto3,oneof" json:"EffectiveGasPrice,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	state               protoimpl.MessageState                          `protogen:"open.v1"`
+	GasUsed             []byte                                          `protobuf:"bytes,1,opt,name=GasUsed,proto3" json:"GasUsed,omitempty"`
+	Status              []byte                                          `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	Log                 []*ProtoCompleteTransaction_ReceiptType_LogType `protobuf:"bytes,3,rep,name=Log,proto3" json:"Log,omitempty"`
+	L1Fee               []byte                                          `protobuf:"bytes,4,opt,name=L1Fee,proto3,oneof" json:"L1Fee,omitempty"`
+	L1FeeScalar         []byte                                          `protobuf:"bytes,5,opt,name=L1FeeScalar,proto3,oneof" json:"L1FeeScalar,omitempty"`
+	L1GasPrice          []byte                                          `protobuf:"bytes,6,opt,name=L1GasPrice,proto3,oneof" json:"L1GasPrice,omitempty"`
+	L1GasUsed           []byte                                          `protobuf:"bytes,7,opt,name=L1GasUsed,proto3,oneof" json:"L1GasUsed,omitempty"`
+	EffectiveGasPrice   []byte                                          `protobuf:"bytes,8,opt,name=EffectiveGasPrice,proto3,oneof" json:"EffectiveGasPrice,omitempty"`
+	L1BaseFeeScalar     []byte                                          `protobuf:"bytes,9,opt,name=L1BaseFeeScalar,proto3,oneof" json:"L1BaseFeeScalar,omitempty"`
+	L1BlobBaseFee       []byte                                          `protobuf:"bytes,10,opt,name=L1BlobBaseFee,proto3,oneof" json:"L1BlobBaseFee,omitempty"`
+	L1BlobBaseFeeScalar []byte                                          `protobuf:"bytes,11,opt,name=L1BlobBaseFeeScalar,proto3,oneof" json:"L1BlobBaseFeeScalar,omitempty"`
+	GasUsedForL1        []byte                                          `protobuf:"bytes,12,opt,name=GasUsedForL1,proto3,oneof" json:"GasUsedForL1,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *ProtoCompleteTransaction_ReceiptType) Reset() {
@@ -329,6 +341,126 @@ func (x *ProtoCompleteTransaction_ReceiptType) GetEffectiveGasPrice() []byte {
 	return nil
 }
 
+func (x *ProtoCompleteTransaction_ReceiptType) GetL1BaseFeeScalar() []byte {
+	if x != nil {
+		return x.L1BaseFeeScalar
+	}
+	return nil
+}
+
+func (x *ProtoCompleteTransaction_ReceiptType) GetL1BlobBaseFee() []byte {
+	if x != nil {
+		return x.L1BlobBaseFee
+	}
+	return nil
+}
+
+func (x *ProtoCompleteTransaction_ReceiptType) GetL1BlobBaseFeeScalar() []byte {
+	if x != nil {
+		return x.L1BlobBaseFeeScalar
+	}
+	return nil
+}
+
+func (x *ProtoCompleteTransaction_ReceiptType) GetGasUsedForL1() []byte {
+	if x != nil {
+		return x.GasUsedForL1
+	}
+	return nil
+}
+
+type ProtoCompleteTransaction_TxType_AuthorizationType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       []byte                 `protobuf:"bytes,1,opt,name=ChainId,proto3" json:"ChainId,omitempty"`
+	Address       []byte                 `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
+	Nonce         uint64                 `protobuf:"varint,3,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	YParity       uint32                 `protobuf:"varint,4,opt,name=YParity,proto3" json:"YParity,omitempty"`
+	R             []byte                 `protobuf:"bytes,5,opt,name=R,proto3" json:"R,omitempty"`
+	S             []byte                 `protobuf:"bytes,6,opt,name=S,proto3" json:"S,omitempty"`
+	Authority     []byte                 `protobuf:"bytes,7,opt,name=Authority,proto3" json:"Authority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) Reset() {
+	*x = ProtoCompleteTransaction_TxType_AuthorizationType{}
+	mi := &file_bchain_coins_eth_ethtx_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoCompleteTransaction_TxType_AuthorizationType) ProtoMessage() {}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) ProtoReflect() protoreflect.Message {
+	mi := &file_bchain_coins_eth_ethtx_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoCompleteTransaction_TxType_AuthorizationType.ProtoReflect.Descriptor instead.
+func (*ProtoCompleteTransaction_TxType_AuthorizationType) Descriptor() ([]byte, []int) {
+	return file_bchain_coins_eth_ethtx_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) GetChainId() []byte {
+	if x != nil {
+		return x.ChainId
+	}
+	return nil
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) GetYParity() uint32 {
+	if x != nil {
+		return x.YParity
+	}
+	return 0
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) GetR() []byte {
+	if x != nil {
+		return x.R
+	}
+	return nil
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+func (x *ProtoCompleteTransaction_TxType_AuthorizationType) GetAuthority() []byte {
+	if x != nil {
+		return x.Authority
+	}
+	return nil
+}
+
 type ProtoCompleteTransaction_ReceiptType_LogType struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Address       []byte                 `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
@@ -340,7 +472,7 @@ type ProtoCompleteTransaction_ReceiptType_LogType struct {
 
 func (x *ProtoCompleteTransaction_ReceiptType_LogType) Reset() {
 	*x = ProtoCompleteTransaction_ReceiptType_LogType{}
-	mi := &file_bchain_coins_eth_ethtx_proto_msgTypes[3]
+	mi := &file_bchain_coins_eth_ethtx_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -352,7 +484,7 @@ func (x *ProtoCompleteTransaction_ReceiptType_LogType) String() string {
 func (*ProtoCompleteTransaction_ReceiptType_LogType) ProtoMessage() {}
 
 func (x *ProtoCompleteTransaction_ReceiptType_LogType) ProtoReflect() protoreflect.Message {
-	mi := &file_bchain_coins_eth_ethtx_proto_msgTypes[3]
+	mi := &file_bchain_coins_eth_ethtx_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,86 +523,71 @@ func (x *ProtoCompleteTransaction_ReceiptType_LogType) GetTopics() [][]byte {
 
 var File_bchain_coins_eth_ethtx_proto protoreflect.FileDescriptor
 
-var file_bchain_coins_eth_ethtx_proto_rawDesc = string([]byte{
-	0x0a, 0x1c, 0x62, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x2f, 0x65,
-	0x74, 0x68, 0x2f, 0x65, 0x74, 0x68, 0x74, 0x78, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x97,
-	0x09, 0x0a, 0x18, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
-	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a,
-	0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x02, 0x54,
-	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x43,
-	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x54, 0x79, 0x70, 0x65, 0x52, 0x02, 0x54, 0x78, 0x12, 0x3f, 0x0a,
-	0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
-	0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x26,
-	0x0a, 0x0e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x44, 0x61, 0x74, 0x61,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x45, 0x78, 0x74,
-	0x72, 0x61, 0x44, 0x61, 0x74, 0x61, 0x1a, 0xc1, 0x03, 0x0a, 0x06, 0x54, 0x78, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x6f, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x47, 0x61, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x08, 0x47, 0x61, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73,
-	0x68, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x54,
-	0x6f, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x37, 0x0a, 0x14, 0x4d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
-	0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x48,
-	0x00, 0x52, 0x14, 0x4d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x46, 0x65,
-	0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c, 0x4d, 0x61,
-	0x78, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c,
-	0x48, 0x01, 0x52, 0x0c, 0x4d, 0x61, 0x78, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73,
-	0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x0d, 0x42, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65, 0x50, 0x65,
-	0x72, 0x47, 0x61, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x02, 0x52, 0x0d, 0x42, 0x61,
-	0x73, 0x65, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x88, 0x01, 0x01, 0x42, 0x17,
-	0x0a, 0x15, 0x5f, 0x4d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x46, 0x65,
-	0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x4d, 0x61, 0x78, 0x46,
-	0x65, 0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x42, 0x61, 0x73,
-	0x65, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x1a, 0xdb, 0x03, 0x0a, 0x0b, 0x52,
-	0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x61,
-	0x73, 0x55, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x47, 0x61, 0x73,
-	0x55, 0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3f, 0x0a, 0x03,
-	0x4c, 0x6f, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x2e, 0x4c, 0x6f, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x19, 0x0a,
-	0x05, 0x4c, 0x31, 0x46, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05,
-	0x4c, 0x31, 0x46, 0x65, 0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x4c, 0x31, 0x46, 0x65,
-	0x65, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x01, 0x52,
-	0x0b, 0x4c, 0x31, 0x46, 0x65, 0x65, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x88, 0x01, 0x01, 0x12,
-	0x23, 0x0a, 0x0a, 0x4c, 0x31, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0c, 0x48, 0x02, 0x52, 0x0a, 0x4c, 0x31, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63,
-	0x65, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09, 0x4c, 0x31, 0x47, 0x61, 0x73, 0x55, 0x73, 0x65,
-	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x03, 0x52, 0x09, 0x4c, 0x31, 0x47, 0x61, 0x73,
-	0x55, 0x73, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x11, 0x45, 0x66, 0x66, 0x65, 0x63,
-	0x74, 0x69, 0x76, 0x65, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0c, 0x48, 0x04, 0x52, 0x11, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x47,
-	0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x88, 0x01, 0x01, 0x1a, 0x4f, 0x0a, 0x07, 0x4c, 0x6f,
-	0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
-	0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x44,
-	0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x06, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x5f,
-	0x4c, 0x31, 0x46, 0x65, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x4c, 0x31, 0x46, 0x65, 0x65, 0x53,
-	0x63, 0x61, 0x6c, 0x61, 0x72, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x4c, 0x31, 0x47, 0x61, 0x73, 0x50,
-	0x72, 0x69, 0x63, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x4c, 0x31, 0x47, 0x61, 0x73, 0x55, 0x73,
-	0x65, 0x64, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65,
-	0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x42, 0x12, 0x5a, 0x10, 0x62, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x2f, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x2f, 0x65, 0x74, 0x68, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
-})
+const file_bchain_coins_eth_ethtx_proto_rawDesc = "" +
+	"\n" +
+	"\x1cbchain/coins/eth/ethtx.proto\"\xb6\r\n" +
+	"\x18ProtoCompleteTransaction\x12 \n" +
+	"\vBlockNumber\x18\x01 \x01(\rR\vBlockNumber\x12\x1c\n" +
+	"\tBlockTime\x18\x02 \x01(\x04R\tBlockTime\x120\n" +
+	"\x02Tx\x18\x03 \x01(\v2 .ProtoCompleteTransaction.TxTypeR\x02Tx\x12?\n" +
+	"\aReceipt\x18\x04 \x01(\v2%.ProtoCompleteTransaction.ReceiptTypeR\aReceipt\x12&\n" +
+	"\x0eChainExtraData\x18\x05 \x01(\fR\x0eChainExtraData\x1a\xd7\x05\n" +
+	"\x06TxType\x12\"\n" +
+	"\fAccountNonce\x18\x01 \x01(\x04R\fAccountNonce\x12\x1a\n" +
+	"\bGasPrice\x18\x02 \x01(\fR\bGasPrice\x12\x1a\n" +
+	"\bGasLimit\x18\x03 \x01(\x04R\bGasLimit\x12\x14\n" +
+	"\x05Value\x18\x04 \x01(\fR\x05Value\x12\x18\n" +
+	"\aPayload\x18\x05 \x01(\fR\aPayload\x12\x12\n" +
+	"\x04Hash\x18\x06 \x01(\fR\x04Hash\x12\x0e\n" +
+	"\x02To\x18\a \x01(\fR\x02To\x12\x12\n" +
+	"\x04From\x18\b \x01(\fR\x04From\x12*\n" +
+	"\x10TransactionIndex\x18\t \x01(\rR\x10TransactionIndex\x127\n" +
+	"\x14MaxPriorityFeePerGas\x18\n" +
+	" \x01(\fH\x00R\x14MaxPriorityFeePerGas\x88\x01\x01\x12'\n" +
+	"\fMaxFeePerGas\x18\v \x01(\fH\x01R\fMaxFeePerGas\x88\x01\x01\x12)\n" +
+	"\rBaseFeePerGas\x18\f \x01(\fH\x02R\rBaseFeePerGas\x88\x01\x01\x12`\n" +
+	"\x11AuthorizationList\x18\r \x03(\v22.ProtoCompleteTransaction.TxType.AuthorizationTypeR\x11AuthorizationList\x1a\xb1\x01\n" +
+	"\x11AuthorizationType\x12\x18\n" +
+	"\aChainId\x18\x01 \x01(\fR\aChainId\x12\x18\n" +
+	"\aAddress\x18\x02 \x01(\fR\aAddress\x12\x14\n" +
+	"\x05Nonce\x18\x03 \x01(\x04R\x05Nonce\x12\x18\n" +
+	"\aYParity\x18\x04 \x01(\rR\aYParity\x12\f\n" +
+	"\x01R\x18\x05 \x01(\fR\x01R\x12\f\n" +
+	"\x01S\x18\x06 \x01(\fR\x01S\x12\x1c\n" +
+	"\tAuthority\x18\a \x01(\fR\tAuthorityB\x17\n" +
+	"\x15_MaxPriorityFeePerGasB\x0f\n" +
+	"\r_MaxFeePerGasB\x10\n" +
+	"\x0e_BaseFeePerGas\x1a\xe4\x05\n" +
+	"\vReceiptType\x12\x18\n" +
+	"\aGasUsed\x18\x01 \x01(\fR\aGasUsed\x12\x16\n" +
+	"\x06Status\x18\x02 \x01(\fR\x06Status\x12?\n" +
+	"\x03Log\x18\x03 \x03(\v2-.ProtoCompleteTransaction.ReceiptType.LogTypeR\x03Log\x12\x19\n" +
+	"\x05L1Fee\x18\x04 \x01(\fH\x00R\x05L1Fee\x88\x01\x01\x12%\n" +
+	"\vL1FeeScalar\x18\x05 \x01(\fH\x01R\vL1FeeScalar\x88\x01\x01\x12#\n" +
+	"\n" +
+	"L1GasPrice\x18\x06 \x01(\fH\x02R\n" +
+	"L1GasPrice\x88\x01\x01\x12!\n" +
+	"\tL1GasUsed\x18\a \x01(\fH\x03R\tL1GasUsed\x88\x01\x01\x121\n" +
+	"\x11EffectiveGasPrice\x18\b \x01(\fH\x04R\x11EffectiveGasPrice\x88\x01\x01\x12-\n" +
+	"\x0fL1BaseFeeScalar\x18\t \x01(\fH\x05R\x0fL1BaseFeeScalar\x88\x01\x01\x12)\n" +
+	"\rL1BlobBaseFee\x18\n" +
+	" \x01(\fH\x06R\rL1BlobBaseFee\x88\x01\x01\x125\n" +
+	"\x13L1BlobBaseFeeScalar\x18\v \x01(\fH\aR\x13L1BlobBaseFeeScalar\x88\x01\x01\x12'\n" +
+	"\fGasUsedForL1\x18\f \x01(\fH\bR\fGasUsedForL1\x88\x01\x01\x1aO\n" +
+	"\aLogType\x12\x18\n" +
+	"\aAddress\x18\x01 \x01(\fR\aAddress\x12\x12\n" +
+	"\x04Data\x18\x02 \x01(\fR\x04Data\x12\x16\n" +
+	"\x06Topics\x18\x03 \x03(\fR\x06TopicsB\b\n" +
+	"\x06_L1FeeB\x0e\n" +
+	"\f_L1FeeScalarB\r\n" +
+	"\v_L1GasPriceB\f\n" +
+	"\n" +
+	"_L1GasUsedB\x14\n" +
+	"\x12_EffectiveGasPriceB\x12\n" +
+	"\x10_L1BaseFeeScalarB\x10\n" +
+	"\x0e_L1BlobBaseFeeB\x16\n" +
+	"\x14_L1BlobBaseFeeScalarB\x0f\n" +
+	"\r_GasUsedForL1B\x12Z\x10bchain/coins/ethb\x06proto3"
 
 var (
 	file_bchain_coins_eth_ethtx_proto_rawDescOnce sync.Once
@@ -484,22 +601,24 @@ func file_bchain_coins_eth_ethtx_proto_rawDescGZIP() []byte {
 	return file_bchain_coins_eth_ethtx_proto_rawDescData
 }
 
-var file_bchain_coins_eth_ethtx_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_bchain_coins_eth_ethtx_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_bchain_coins_eth_ethtx_proto_goTypes = []any{
-	(*ProtoCompleteTransaction)(nil),                     // 0: ProtoCompleteTransaction
-	(*ProtoCompleteTransaction_TxType)(nil),              // 1: ProtoCompleteTransaction.TxType
-	(*ProtoCompleteTransaction_ReceiptType)(nil),         // 2: ProtoCompleteTransaction.ReceiptType
-	(*ProtoCompleteTransaction_ReceiptType_LogType)(nil), // 3: ProtoCompleteTransaction.ReceiptType.LogType
+	(*ProtoCompleteTransaction)(nil),                          // 0: ProtoCompleteTransaction
+	(*ProtoCompleteTransaction_TxType)(nil),                   // 1: ProtoCompleteTransaction.TxType
+	(*ProtoCompleteTransaction_ReceiptType)(nil),              // 2: ProtoCompleteTransaction.ReceiptType
+	(*ProtoCompleteTransaction_TxType_AuthorizationType)(nil), // 3: ProtoCompleteTransaction.TxType.AuthorizationType
+	(*ProtoCompleteTransaction_ReceiptType_LogType)(nil),      // 4: ProtoCompleteTransaction.ReceiptType.LogType
 }
 var file_bchain_coins_eth_ethtx_proto_depIdxs = []int32{
 	1, // 0: ProtoCompleteTransaction.Tx:type_name -> ProtoCompleteTransaction.TxType
 	2, // 1: ProtoCompleteTransaction.Receipt:type_name -> ProtoCompleteTransaction.ReceiptType
-	3, // 2: ProtoCompleteTransaction.ReceiptType.Log:type_name -> ProtoCompleteTransaction.ReceiptType.LogType
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 2: ProtoCompleteTransaction.TxType.AuthorizationList:type_name -> ProtoCompleteTransaction.TxType.AuthorizationType
+	4, // 3: ProtoCompleteTransaction.ReceiptType.Log:type_name -> ProtoCompleteTransaction.ReceiptType.LogType
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_bchain_coins_eth_ethtx_proto_init() }
@@ -515,7 +634,7 @@ func file_bchain_coins_eth_ethtx_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bchain_coins_eth_ethtx_proto_rawDesc), len(file_bchain_coins_eth_ethtx_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,165 @@
+package eth
+
+import (
+	"context"
+	stdErrors "errors"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// simulateTxParams are the transaction fields passed from the request to the simulation
+var simulateTxParams = []string{"from", "to", "value", "data", "gas", "gasPrice", "maxFeePerGas", "maxPriorityFeePerGas"}
+
+// simulateCallArgs validates the simulation params and converts them to eth_call arguments
+func simulateCallArgs(params map[string]interface{}) (map[string]interface{}, error) {
+	args := make(map[string]interface{})
+	for _, p := range simulateTxParams {
+		s, ok := GetStringFromMap(p, params)
+		if !ok || s == "" {
+			continue
+		}
+		switch p {
+		case "from", "to":
+			if !has0xPrefix(s) || len(s) != 42 {
+				return nil, errors.Errorf("Invalid address in parameter %s", p)
+			}
+		case "data":
+			if _, err := hexutil.Decode(s); err != nil {
+				return nil, errors.Errorf("Invalid hex in parameter %s", p)
+			}
+		default:
+			if _, err := hexutil.DecodeBig(s); err != nil {
+				return nil, errors.Errorf("Invalid hex number in parameter %s", p)
+			}
+		}
+		args[p] = s
+	}
+	if _, ok := args["from"]; !ok {
+		return nil, errors.New("Missing parameter from")
+	}
+	return args, nil
+}
+
+// simulationError combines the execution error with the revert reason from the output
+func simulationError(err string, output string) string {
+	if reason := ParseErrorFromOutput(output); reason != "" {
+		return err + ": " + reason
+	}
+	return err
+}
+
+// collectTraceLogs returns the logs of the call and its subcalls in the order of the call tree
+func collectTraceLogs(call *rpcCallTrace, logs []*bchain.RpcLog) []*bchain.RpcLog {
+	logs = append(logs, call.Logs...)
+	for i := range call.Calls {
+		logs = collectTraceLogs(&call.Calls[i], logs)
+	}
+	return logs
+}
+
+// EthereumTypeSimulateTx simulates the transaction given by params (from, to, value, data, gas and optionally
+// stateOverrides) against the latest or pending block without broadcasting it.
+// It uses debug_traceCall with the callTracer, which provides the logs and internal transfers.
+// If the backend does not support tracing, it falls back to eth_call and eth_estimateGas,
+// which provide only the status, the revert reason and the estimated gas.
+func (b *EthereumRPC) EthereumTypeSimulateTx(params map[string]interface{}, block string) (*bchain.EthereumSimulatedTx, error) {
+	args, err := simulateCallArgs(params)
+	if err != nil {
+		return nil, err
+	}
+	if block == "" {
+		block = "latest"
+	} else if block != "latest" && block != "pending" {
+		return nil, errors.Errorf("Invalid block %s, expecting latest or pending", block)
+	}
+	stateOverrides, _ := params["stateOverrides"].(map[string]interface{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.Timeout)
+	defer cancel()
+	traceConfig := map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	}
+	if b.ChainConfig.TraceTimeout != "" {
+		traceConfig["timeout"] = b.ChainConfig.TraceTimeout
+	}
+	if stateOverrides != nil {
+		traceConfig["stateOverrides"] = stateOverrides
+	}
+	var trace rpcCallTrace
+	err = b.RPC.CallContext(ctx, &trace, "debug_traceCall", args, block, traceConfig)
+	if err != nil {
+		glog.V(1).Info("debug_traceCall error ", err, ", falling back to eth_call")
+		return b.simulateTxByCall(ctx, args, block, stateOverrides)
+	}
+	r := &bchain.EthereumSimulatedTx{
+		Traced: true,
+		Status: bchain.TxStatusOK,
+		From:   trace.From,
+		To:     trace.To,
+	}
+	if value, err := hexutil.DecodeBig(trace.Value); err == nil {
+		r.Value = *value
+	}
+	if trace.GasUsed != "" {
+		if r.GasUsed, err = hexutil.DecodeUint64(trace.GasUsed); err != nil {
+			return nil, errors.Annotatef(err, "debug_traceCall gasUsed %s", trace.GasUsed)
+		}
+	}
+	if trace.Error != "" {
+		r.Status = bchain.TxStatusFailure
+		r.Error = simulationError(trace.Error, trace.Output)
+		return r, nil
+	}
+	if trace.Type == "CREATE" || trace.Type == "CREATE2" {
+		r.CreatedContract = trace.To
+	}
+	var d bchain.EthereumInternalData
+	for i := range trace.Calls {
+		b.processCallTrace(&trace.Calls[i], &d, nil, 0)
+	}
+	r.InternalTransfers = d.Transfers
+	r.TokenTransfers = contractGetTransfersFromLog(collectTraceLogs(&trace, nil), "simulated")
+	return r, nil
+}
+
+// simulateTxByCall simulates the transaction using eth_call and eth_estimateGas
+func (b *EthereumRPC) simulateTxByCall(ctx context.Context, args map[string]interface{}, block string, stateOverrides map[string]interface{}) (*bchain.EthereumSimulatedTx, error) {
+	callArgs := []interface{}{args, block}
+	if stateOverrides != nil {
+		callArgs = append(callArgs, stateOverrides)
+	}
+	r := &bchain.EthereumSimulatedTx{Status: bchain.TxStatusOK}
+	r.From, _ = args["from"].(string)
+	r.To, _ = args["to"].(string)
+	if s, ok := args["value"].(string); ok {
+		if value, err := hexutil.DecodeBig(s); err == nil {
+			r.Value = *value
+		}
+	}
+	var output string
+	err := b.RPC.CallContext(ctx, &output, "eth_call", callArgs...)
+	if err != nil {
+		// a reverted call returns the revert data in the error
+		var de rpc.DataError
+		if !stdErrors.As(err, &de) {
+			return nil, err
+		}
+		r.Status = bchain.TxStatusFailure
+		data, _ := de.ErrorData().(string)
+		r.Error = simulationError(err.Error(), data)
+		return r, nil
+	}
+	var gas string
+	if err := b.RPC.CallContext(ctx, &gas, "eth_estimateGas", callArgs...); err != nil {
+		return nil, err
+	}
+	if r.GasUsed, err = hexutil.DecodeUint64(gas); err != nil {
+		return nil, errors.Annotatef(err, "eth_estimateGas %s", gas)
+	}
+	return r, nil
+}
@@ -0,0 +1,208 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+const notEnoughBalanceOutput = "0x08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000126e6f7420656e6f7567682062616c616e63650000000000000000000000000000"
+
+type mockDataError struct {
+	data string
+}
+
+func (e *mockDataError) Error() string          { return "execution reverted" }
+func (e *mockDataError) ErrorData() interface{} { return e.data }
+
+// mockSimulateRPC returns the configured JSON response or error for each method
+type mockSimulateRPC struct {
+	responses map[string]string
+	errors    map[string]error
+	calls     map[string][]interface{}
+}
+
+func (m *mockSimulateRPC) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (bchain.EVMClientSubscription, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSimulateRPC) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if m.calls == nil {
+		m.calls = make(map[string][]interface{})
+	}
+	m.calls[method] = append([]interface{}{}, args...)
+	if err, ok := m.errors[method]; ok {
+		return err
+	}
+	r, ok := m.responses[method]
+	if !ok {
+		return errors.New("unexpected method " + method)
+	}
+	return json.Unmarshal([]byte(r), result)
+}
+
+func (m *mockSimulateRPC) Close() {}
+
+func newSimulateTestRPC(m *mockSimulateRPC) *EthereumRPC {
+	return &EthereumRPC{
+		RPC:         m,
+		Timeout:     time.Second,
+		ChainConfig: &Configuration{TraceTimeout: "5s"},
+	}
+}
+
+func TestEthereumTypeSimulateTx_Trace(t *testing.T) {
+	m := &mockSimulateRPC{
+		responses: map[string]string{
+			"debug_traceCall": `{
+				"type": "CALL",
+				"from": "0x1111111111111111111111111111111111111111",
+				"to": "0x2222222222222222222222222222222222222222",
+				"value": "0x0",
+				"gasUsed": "0xc350",
+				"calls": [{
+					"type": "CALL",
+					"from": "0x2222222222222222222222222222222222222222",
+					"to": "0x3333333333333333333333333333333333333333",
+					"value": "0x64"
+				}],
+				"logs": [{
+					"address": "0x2222222222222222222222222222222222222222",
+					"topics": [
+						"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+						"0x0000000000000000000000001111111111111111111111111111111111111111",
+						"0x0000000000000000000000003333333333333333333333333333333333333333"
+					],
+					"data": "0x00000000000000000000000000000000000000000000000000000000000003e8"
+				}]
+			}`,
+		},
+	}
+	b := newSimulateTestRPC(m)
+	r, err := b.EthereumTypeSimulateTx(map[string]interface{}{
+		"from":           "0x1111111111111111111111111111111111111111",
+		"to":             "0x2222222222222222222222222222222222222222",
+		"data":           "0xa9059cbb",
+		"stateOverrides": map[string]interface{}{"0x1111111111111111111111111111111111111111": map[string]interface{}{"balance": "0x1"}},
+	}, "pending")
+	if err != nil {
+		t.Fatalf("EthereumTypeSimulateTx() error = %v", err)
+	}
+	args := m.calls["debug_traceCall"]
+	if len(args) != 3 || args[1] != "pending" {
+		t.Fatalf("debug_traceCall args = %+v", args)
+	}
+	traceConfig := args[2].(map[string]interface{})
+	if traceConfig["tracer"] != "callTracer" || traceConfig["timeout"] != "5s" || traceConfig["stateOverrides"] == nil {
+		t.Fatalf("trace config = %+v", traceConfig)
+	}
+	if !r.Traced || r.Status != bchain.TxStatusOK || r.GasUsed != 50000 {
+		t.Fatalf("result = %+v", r)
+	}
+	if len(r.InternalTransfers) != 1 || r.InternalTransfers[0].Value.Cmp(big.NewInt(100)) != 0 ||
+		r.InternalTransfers[0].To != "0x3333333333333333333333333333333333333333" {
+		t.Fatalf("InternalTransfers = %+v", r.InternalTransfers)
+	}
+	if len(r.TokenTransfers) != 1 || r.TokenTransfers[0].Standard != bchain.FungibleToken ||
+		r.TokenTransfers[0].Value.Cmp(big.NewInt(1000)) != 0 ||
+		r.TokenTransfers[0].From != "0x1111111111111111111111111111111111111111" {
+		t.Fatalf("TokenTransfers = %+v", r.TokenTransfers)
+	}
+}
+
+func TestEthereumTypeSimulateTx_TraceRevert(t *testing.T) {
+	m := &mockSimulateRPC{
+		responses: map[string]string{
+			"debug_traceCall": `{
+				"type": "CALL",
+				"from": "0x1111111111111111111111111111111111111111",
+				"to": "0x2222222222222222222222222222222222222222",
+				"value": "0x0",
+				"gasUsed": "0x5208",
+				"error": "execution reverted",
+				"output": "` + notEnoughBalanceOutput + `"
+			}`,
+		},
+	}
+	r, err := newSimulateTestRPC(m).EthereumTypeSimulateTx(map[string]interface{}{
+		"from": "0x1111111111111111111111111111111111111111",
+		"to":   "0x2222222222222222222222222222222222222222",
+	}, "")
+	if err != nil {
+		t.Fatalf("EthereumTypeSimulateTx() error = %v", err)
+	}
+	if m.calls["debug_traceCall"][1] != "latest" {
+		t.Fatalf("block = %v, want latest", m.calls["debug_traceCall"][1])
+	}
+	if r.Status != bchain.TxStatusFailure || r.Error != "execution reverted: not enough balance" || r.GasUsed != 21000 {
+		t.Fatalf("result = %+v", r)
+	}
+}
+
+func TestEthereumTypeSimulateTx_CallFallback(t *testing.T) {
+	params := map[string]interface{}{
+		"from":  "0x1111111111111111111111111111111111111111",
+		"to":    "0x2222222222222222222222222222222222222222",
+		"value": "0x10",
+	}
+	m := &mockSimulateRPC{
+		responses: map[string]string{
+			"eth_call":        `"0x"`,
+			"eth_estimateGas": `"0x5208"`,
+		},
+		errors: map[string]error{
+			"debug_traceCall": errors.New("the method debug_traceCall does not exist"),
+		},
+	}
+	r, err := newSimulateTestRPC(m).EthereumTypeSimulateTx(params, "latest")
+	if err != nil {
+		t.Fatalf("EthereumTypeSimulateTx() error = %v", err)
+	}
+	if r.Traced || r.Status != bchain.TxStatusOK || r.GasUsed != 21000 || r.Value.Cmp(big.NewInt(16)) != 0 {
+		t.Fatalf("result = %+v", r)
+	}
+
+	m.errors["eth_call"] = &mockDataError{data: notEnoughBalanceOutput}
+	r, err = newSimulateTestRPC(m).EthereumTypeSimulateTx(params, "latest")
+	if err != nil {
+		t.Fatalf("EthereumTypeSimulateTx() error = %v", err)
+	}
+	if r.Status != bchain.TxStatusFailure || r.Error != "execution reverted: not enough balance" {
+		t.Fatalf("result = %+v", r)
+	}
+
+	m.errors["eth_call"] = errors.New("connection refused")
+	if _, err = newSimulateTestRPC(m).EthereumTypeSimulateTx(params, "latest"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestEthereumTypeSimulateTx_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		block  string
+	}{
+		{name: "missing from", params: map[string]interface{}{"to": "0x2222222222222222222222222222222222222222"}},
+		{name: "invalid to", params: map[string]interface{}{"from": "0x1111111111111111111111111111111111111111", "to": "0x22"}},
+		{name: "invalid data", params: map[string]interface{}{"from": "0x1111111111111111111111111111111111111111", "data": "xyz"}},
+		{name: "invalid value", params: map[string]interface{}{"from": "0x1111111111111111111111111111111111111111", "value": "100"}},
+		{name: "invalid block", params: map[string]interface{}{"from": "0x1111111111111111111111111111111111111111"}, block: "0x10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockSimulateRPC{}
+			if _, err := newSimulateTestRPC(m).EthereumTypeSimulateTx(tt.params, tt.block); err == nil {
+				t.Fatal("expected error")
+			}
+			if len(m.calls) != 0 {
+				t.Fatalf("unexpected rpc calls %+v", m.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+// ERC-4337 EntryPoint contracts deployed at the canonical addresses, lowercase
+var erc4337EntryPoints = map[string]struct{}{
+	"0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789": {}, // v0.6
+	"0x0000000071727de22e5e9d8baf0edac6f37da032": {}, // v0.7
+	"0x4337084d9e255ff0702461cf8895ce9e3b5ff108": {}, // v0.8
+}
+
+// UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster, uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)
+const userOperationEventSignature = "0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f"
+
+const handleOpsV06MethodSignature = "0x1fad948c" // handleOps((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[],address)
+const handleOpsV07MethodSignature = "0x765e827f" // handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[],address)
+
+// IsERC4337EntryPoint returns true if the address is one of the canonical ERC-4337 EntryPoint contracts
+func IsERC4337EntryPoint(address string) bool {
+	_, ok := erc4337EntryPoints[strings.ToLower(address)]
+	return ok
+}
+
+// abiWord returns the 32 byte word at offset in data as a big.Int
+func abiWord(data []byte, offset uint64) (*big.Int, bool) {
+	if offset > uint64(len(data)) || uint64(len(data))-offset < evmWordBytes {
+		return nil, false
+	}
+	return new(big.Int).SetBytes(data[offset : offset+evmWordBytes]), true
+}
+
+// abiOffset returns the word at offset in data as an offset (or length) which fits into data
+func abiOffset(data []byte, offset uint64) (uint64, bool) {
+	w, ok := abiWord(data, offset)
+	if !ok || !w.IsUint64() || w.Uint64() > uint64(len(data)) {
+		return 0, false
+	}
+	return w.Uint64(), true
+}
+
+type handleOpsOperation struct {
+	sender   string
+	nonce    big.Int
+	callData string
+}
+
+// decodeHandleOps decodes the sender, nonce and callData of the operations from the handleOps call data,
+// the first four members of the UserOperation (v0.6) and PackedUserOperation (v0.7+) tuples are the same
+func decodeHandleOps(input string) []handleOpsOperation {
+	input = strings.ToLower(input)
+	if !strings.HasPrefix(input, handleOpsV06MethodSignature) && !strings.HasPrefix(input, handleOpsV07MethodSignature) {
+		return nil
+	}
+	data, err := hex.DecodeString(input[len(handleOpsV06MethodSignature):])
+	if err != nil {
+		return nil
+	}
+	opsOffset, ok := abiOffset(data, 0)
+	if !ok {
+		return nil
+	}
+	count, ok := abiOffset(data, opsOffset)
+	if !ok {
+		return nil
+	}
+	// each operation needs at least its offset word
+	if count > uint64(len(data))/evmWordBytes {
+		return nil
+	}
+	elements := opsOffset + evmWordBytes
+	ops := make([]handleOpsOperation, 0, count)
+	for i := uint64(0); i < count; i++ {
+		o, ok := abiOffset(data, elements+i*evmWordBytes)
+		if !ok {
+			return nil
+		}
+		tuple := elements + o
+		sender, ok := abiWord(data, tuple)
+		if !ok {
+			return nil
+		}
+		nonce, ok := abiWord(data, tuple+evmWordBytes)
+		if !ok {
+			return nil
+		}
+		o, ok = abiOffset(data, tuple+3*evmWordBytes)
+		if !ok {
+			return nil
+		}
+		callData := tuple + o
+		l, ok := abiOffset(data, callData)
+		if !ok || uint64(len(data))-callData-evmWordBytes < l {
+			return nil
+		}
+		ops = append(ops, handleOpsOperation{
+			sender:   EIP55Address(sender.FillBytes(make([]byte, EthereumTypeAddressDescriptorLen))),
+			nonce:    *nonce,
+			callData: "0x" + hex.EncodeToString(data[callData+evmWordBytes:callData+evmWordBytes+l]),
+		})
+	}
+	return ops
+}
+
+// processUserOperationEvent decodes the UserOperationEvent log of an EntryPoint contract
+func processUserOperationEvent(l *bchain.RpcLog) (*bchain.EthereumUserOperation, bool) {
+	if len(l.Topics) != 4 || l.Topics[0] != userOperationEventSignature || !IsERC4337EntryPoint(l.Address) {
+		return nil, false
+	}
+	data := l.Data
+	if has0xPrefix(data) {
+		data = data[2:]
+	}
+	b, err := hex.DecodeString(data)
+	if err != nil || len(b) != 4*evmWordBytes {
+		return nil, false
+	}
+	sender, err := addressFromPaddedHex(l.Topics[2])
+	if err != nil {
+		return nil, false
+	}
+	paymaster, err := addressFromPaddedHex(l.Topics[3])
+	if err != nil {
+		return nil, false
+	}
+	op := bchain.EthereumUserOperation{
+		UserOpHash: l.Topics[1],
+		EntryPoint: EIP55AddressFromAddress(l.Address),
+		Sender:     sender,
+		Paymaster:  paymaster,
+		Success:    b[2*evmWordBytes-1] != 0,
+	}
+	op.Nonce.SetBytes(b[:evmWordBytes])
+	op.ActualGasCost.SetBytes(b[2*evmWordBytes : 3*evmWordBytes])
+	op.ActualGasUsed.SetBytes(b[3*evmWordBytes:])
+	return &op, true
+}
+
+// GetUserOperationsFromLogs returns the ERC-4337 UserOperations executed by the canonical EntryPoint contracts
+// in the order of their UserOperationEvent logs. The callData of the operations is taken from the handleOps
+// call data in input, if the transaction calls the EntryPoint directly.
+func GetUserOperationsFromLogs(logs []*bchain.RpcLog, input string) []bchain.EthereumUserOperation {
+	var r []bchain.EthereumUserOperation
+	for _, l := range logs {
+		if op, ok := processUserOperationEvent(l); ok {
+			r = append(r, *op)
+		}
+	}
+	if len(r) > 0 {
+		if ops := decodeHandleOps(input); len(ops) > 0 {
+			for i := range r {
+				for j := range ops {
+					if ops[j].sender == r[i].Sender && ops[j].nonce.Cmp(&r[i].Nonce) == 0 {
+						r[i].CallData = ops[j].callData
+						break
+					}
+				}
+			}
+		}
+	}
+	return r
+}
+
+// EthereumTypeGetUserOperationsFromTx returns ERC-4337 UserOperations executed in the transaction
+func (p *EthereumParser) EthereumTypeGetUserOperationsFromTx(tx *bchain.Tx) ([]bchain.EthereumUserOperation, error) {
+	csd, ok := tx.CoinSpecificData.(bchain.EthereumSpecificData)
+	if !ok || csd.Receipt == nil {
+		return nil, nil
+	}
+	var input string
+	if csd.Tx != nil {
+		input = csd.Tx.Payload
+	}
+	return GetUserOperationsFromLogs(csd.Receipt.Logs, input), nil
+}
@@ -0,0 +1,119 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func abiTestWord(v uint64) string {
+	return fmt.Sprintf("%064x", v)
+}
+
+// handleOpsTestInput encodes handleOps call data with one operation, only the members decoded by decodeHandleOps are filled
+func handleOpsTestInput(signature, sender string, nonce uint64, callData string) string {
+	callData = strings.TrimPrefix(callData, "0x")
+	var b strings.Builder
+	b.WriteString(signature)
+	b.WriteString(abiTestWord(0x40))                                                       // offset of ops
+	b.WriteString("000000000000000000000000" + "9999999999999999999999999999999999999999") // beneficiary
+	b.WriteString(abiTestWord(1))                                                          // number of ops
+	b.WriteString(abiTestWord(0x20))                                                       // offset of the op
+	b.WriteString("000000000000000000000000" + strings.TrimPrefix(strings.ToLower(sender), "0x"))
+	b.WriteString(abiTestWord(nonce))
+	b.WriteString(abiTestWord(0x80)) // initCode offset
+	b.WriteString(abiTestWord(0xa0)) // callData offset
+	b.WriteString(abiTestWord(0))    // initCode length
+	b.WriteString(abiTestWord(uint64(len(callData) / 2)))
+	b.WriteString(callData)
+	if pad := len(callData) % 64; pad != 0 {
+		b.WriteString(strings.Repeat("0", 64-pad))
+	}
+	return b.String()
+}
+
+func TestGetUserOperationsFromLogs(t *testing.T) {
+	const sender = "0xa1B2c3d4E5f60718293a4B5C6d7E8F9012345678"
+	const paymaster = "0x00000000000000000000000000000000000000AA"
+	logs := []*bchain.RpcLog{
+		{
+			// token transfer, ignored
+			Address: "0x2222222222222222222222222222222222222222",
+			Topics: []string{
+				tokenTransferEventSignature,
+				"0x000000000000000000000000a1b2c3d4e5f60718293a4b5c6d7e8f9012345678",
+				"0x0000000000000000000000003333333333333333333333333333333333333333",
+			},
+			Data: "0x00000000000000000000000000000000000000000000000000000000000003e8",
+		},
+		{
+			Address: "0x0000000071727De22E5E9d8BAf0edAc6f37da032",
+			Topics: []string{
+				userOperationEventSignature,
+				"0x1111111111111111111111111111111111111111111111111111111111111111",
+				"0x000000000000000000000000a1b2c3d4e5f60718293a4b5c6d7e8f9012345678",
+				"0x00000000000000000000000000000000000000000000000000000000000000aa",
+			},
+			Data: "0x" + abiTestWord(5) + abiTestWord(1) + abiTestWord(123456) + abiTestWord(7890),
+		},
+		{
+			// UserOperationEvent emitted by a contract which is not a canonical EntryPoint, ignored
+			Address: "0x4444444444444444444444444444444444444444",
+			Topics: []string{
+				userOperationEventSignature,
+				"0x1111111111111111111111111111111111111111111111111111111111111111",
+				"0x000000000000000000000000a1b2c3d4e5f60718293a4b5c6d7e8f9012345678",
+				"0x0000000000000000000000000000000000000000000000000000000000000000",
+			},
+			Data: "0x" + abiTestWord(5) + abiTestWord(1) + abiTestWord(123456) + abiTestWord(7890),
+		},
+	}
+	input := handleOpsTestInput(handleOpsV07MethodSignature, sender, 5, "0xb61d27f6aabbcc")
+	got := GetUserOperationsFromLogs(logs, input)
+	if len(got) != 1 {
+		t.Fatalf("GetUserOperationsFromLogs() returned %d operations, want 1", len(got))
+	}
+	op := got[0]
+	if op.UserOpHash != "0x1111111111111111111111111111111111111111111111111111111111111111" ||
+		op.EntryPoint != "0x0000000071727De22E5E9d8BAf0edAc6f37da032" ||
+		op.Sender != sender || op.Paymaster != paymaster || !op.Success ||
+		op.Nonce.Cmp(big.NewInt(5)) != 0 || op.ActualGasCost.Cmp(big.NewInt(123456)) != 0 || op.ActualGasUsed.Cmp(big.NewInt(7890)) != 0 {
+		t.Errorf("GetUserOperationsFromLogs() = %+v", op)
+	}
+	if op.CallData != "0xb61d27f6aabbcc" {
+		t.Errorf("CallData = %q, want %q", op.CallData, "0xb61d27f6aabbcc")
+	}
+
+	// different nonce in handleOps, callData is not matched
+	got = GetUserOperationsFromLogs(logs, handleOpsTestInput(handleOpsV06MethodSignature, sender, 6, "0xb61d27f6"))
+	if len(got) != 1 || got[0].CallData != "" {
+		t.Errorf("GetUserOperationsFromLogs() = %+v, want operation without callData", got)
+	}
+}
+
+func Test_decodeHandleOps(t *testing.T) {
+	const sender = "0xa1B2c3d4E5f60718293a4B5C6d7E8F9012345678"
+	input := handleOpsTestInput(handleOpsV06MethodSignature, sender, 1, "0x")
+	ops := decodeHandleOps(input)
+	if len(ops) != 1 || ops[0].sender != sender || ops[0].nonce.Cmp(big.NewInt(1)) != 0 || ops[0].callData != "0x" {
+		t.Errorf("decodeHandleOps() = %+v", ops)
+	}
+	for _, tt := range []struct {
+		name  string
+		input string
+	}{
+		{name: "other method", input: "0xa9059cbb" + input[10:]},
+		{name: "truncated", input: input[:len(input)-64]},
+		{name: "invalid hex", input: input[:len(input)-1] + "x"},
+		{name: "huge count", input: input[:10+128] + strings.Repeat("f", 64) + input[10+192:]},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if ops := decodeHandleOps(tt.input); ops != nil {
+				t.Errorf("decodeHandleOps() = %+v, want nil", ops)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// the backends report the amount of withdrawals in Gwei
+var weiInGwei = big.NewInt(1e9)
+
+// ethWithdrawalsToWithdrawals converts the EIP-4895 withdrawals of a block body, the amounts are converted to Wei
+func ethWithdrawalsToWithdrawals(rws []bchain.RpcWithdrawal) ([]bchain.EthereumWithdrawal, error) {
+	if len(rws) == 0 {
+		return nil, nil
+	}
+	r := make([]bchain.EthereumWithdrawal, len(rws))
+	for i := range rws {
+		rw := &rws[i]
+		w := &r[i]
+		var err error
+		if w.Index, err = hexutil.DecodeUint64(rw.Index); err != nil {
+			return nil, errors.Annotatef(err, "Withdrawal Index %v", rw.Index)
+		}
+		if w.ValidatorIndex, err = hexutil.DecodeUint64(rw.ValidatorIndex); err != nil {
+			return nil, errors.Annotatef(err, "Withdrawal ValidatorIndex %v", rw.ValidatorIndex)
+		}
+		address, err := hexDecode(rw.Address)
+		if err != nil || len(address) != EthereumTypeAddressDescriptorLen {
+			return nil, errors.Errorf("Invalid withdrawal address %v", rw.Address)
+		}
+		w.Address = EIP55Address(address)
+		amount, err := hexutil.DecodeBig(rw.Amount)
+		if err != nil {
+			return nil, errors.Annotatef(err, "Withdrawal Amount %v", rw.Amount)
+		}
+		w.Amount.Mul(amount, weiInGwei)
+	}
+	return r, nil
+}
@@ -0,0 +1,48 @@
+package eth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func TestEthWithdrawalsToWithdrawals(t *testing.T) {
+	// block body with two withdrawals to the same address
+	var body rpcBlockTransactions
+	if err := json.Unmarshal([]byte(`{"transactions":[],"withdrawals":[
+		{"index":"0x0","validatorIndex":"0x3b8d1","address":"0x8306300ffd616049fee7e8d0c12e4e2bf8f3f1e8","amount":"0xe1b9d9"},
+		{"index":"0x1","validatorIndex":"0x3b8d2","address":"0x8306300ffd616049fee7e8d0c12e4e2bf8f3f1e8","amount":"0xe1b2c5"}]}`), &body); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ethWithdrawalsToWithdrawals(body.Withdrawals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("ethWithdrawalsToWithdrawals() returned %d withdrawals, want 2", len(got))
+	}
+	if got[0].Index != 0 || got[0].ValidatorIndex != 243921 || got[0].Address != "0x8306300ffd616049FEe7e8D0c12e4e2bf8F3F1e8" {
+		t.Errorf("ethWithdrawalsToWithdrawals()[0] = %+v", got[0])
+	}
+	// the amount is converted from Gwei to Wei
+	if got[0].Amount.String() != "14793177000000000" {
+		t.Errorf("ethWithdrawalsToWithdrawals()[0].Amount = %v, want 14793177000000000", got[0].Amount.String())
+	}
+	if got[1].Index != 1 || got[1].Amount.String() != "14791365000000000" {
+		t.Errorf("ethWithdrawalsToWithdrawals()[1] = %+v", got[1])
+	}
+
+	if got, err := ethWithdrawalsToWithdrawals(nil); got != nil || err != nil {
+		t.Errorf("ethWithdrawalsToWithdrawals(nil) = %v, %v, want nil, nil", got, err)
+	}
+	for _, rw := range []bchain.RpcWithdrawal{
+		{Index: "0x0", ValidatorIndex: "0x1", Address: "0x1234", Amount: "0x1"},
+		{Index: "x", ValidatorIndex: "0x1", Address: "0x8306300ffd616049fee7e8d0c12e4e2bf8f3f1e8", Amount: "0x1"},
+		{Index: "0x0", ValidatorIndex: "0x1", Address: "0x8306300ffd616049fee7e8d0c12e4e2bf8f3f1e8", Amount: ""},
+	} {
+		if _, err := ethWithdrawalsToWithdrawals([]bchain.RpcWithdrawal{rw}); err == nil {
+			t.Errorf("ethWithdrawalsToWithdrawals(%+v), expected error", rw)
+		}
+	}
+}
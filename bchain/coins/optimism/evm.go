@@ -31,7 +31,7 @@ func (c *OptimismRPCClient) CallContext(ctx context.Context, result interface{},
 	// special case to handle empty gas price for a valid rpc transaction
 	// (https://goerli-optimism.etherscan.io/tx/0x9b62094073147508471e3371920b68070979beea32100acdc49c721350b69cb9)
 	if r, ok := result.(*bchain.RpcTransaction); ok {
-		if *r != (bchain.RpcTransaction{}) && r.GasPrice == "" {
+		if r.Hash != "" && r.GasPrice == "" {
 			r.GasPrice = "0x0"
 		}
 	}
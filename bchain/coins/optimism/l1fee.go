@@ -0,0 +1,107 @@
+package optimism
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/eth"
+)
+
+// GasPriceOracleAddress is the OP stack predeploy computing the L1 data fee of transactions
+const GasPriceOracleAddress = "0x420000000000000000000000000000000000000F"
+
+const getL1FeeSignature = "0x49948e0e" // getL1Fee(bytes)
+
+// placeholders of the unsigned transaction fields which are not known at the time of the estimation,
+// they are sized so that the L1 data fee is not underestimated for real world values
+const (
+	l1FeePlaceholderNonce = 1 << 24
+	l1FeePlaceholderGas   = 1 << 24
+)
+
+var l1FeePlaceholderFeeCap = big.NewInt(1e12)
+
+var bytesArguments abi.Arguments
+
+func init() {
+	t, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	bytesArguments = abi.Arguments{{Type: t}}
+}
+
+// unsignedTxForL1Fee serializes the unsigned EIP-1559 transaction described by the params,
+// the GasPriceOracle accounts for the signature itself
+func unsignedTxForL1Fee(chainID *big.Int, params map[string]interface{}) ([]byte, error) {
+	tx := &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     l1FeePlaceholderNonce,
+		GasTipCap: l1FeePlaceholderFeeCap,
+		GasFeeCap: l1FeePlaceholderFeeCap,
+		Gas:       l1FeePlaceholderGas,
+	}
+	if s, ok := eth.GetStringFromMap("to", params); ok && len(s) > 0 {
+		if !ethcommon.IsHexAddress(s) {
+			return nil, errors.Errorf("Invalid address %v", s)
+		}
+		to := ethcommon.HexToAddress(s)
+		tx.To = &to
+	}
+	if s, ok := eth.GetStringFromMap("data", params); ok && len(s) > 0 {
+		tx.Data = ethcommon.FromHex(s)
+	}
+	if s, ok := eth.GetStringFromMap("value", params); ok && len(s) > 0 {
+		v, err := hexutil.DecodeBig(s)
+		if err != nil {
+			return nil, errors.Annotatef(err, "Value %v", s)
+		}
+		tx.Value = v
+	}
+	return types.NewTx(tx).MarshalBinary()
+}
+
+// getL1FeeCallData returns the GasPriceOracle getL1Fee call data for the transaction described by the params
+func getL1FeeCallData(chainID *big.Int, params map[string]interface{}) (string, error) {
+	unsignedTx, err := unsignedTxForL1Fee(chainID, params)
+	if err != nil {
+		return "", err
+	}
+	packed, err := bytesArguments.Pack(unsignedTx)
+	if err != nil {
+		return "", err
+	}
+	return getL1FeeSignature + hexutil.Encode(packed)[2:], nil
+}
+
+// parseL1Fee parses the uint256 result of the getL1Fee call
+func parseL1Fee(result string) (*big.Int, error) {
+	b, err := hexutil.Decode(result)
+	if err != nil || len(b) != 32 {
+		return nil, errors.Errorf("Invalid getL1Fee result %v", result)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// EstimateL1Fee estimates the L1 data fee of the transaction described by the params using the GasPriceOracle,
+// it is shared by all OP stack networks
+func EstimateL1Fee(b *eth.EthereumRPC, params map[string]interface{}) (*bchain.EthereumL1FeeEstimate, error) {
+	data, err := getL1FeeCallData(big.NewInt(int64(b.MainNetChainID)), params)
+	if err != nil {
+		return nil, err
+	}
+	result, err := b.EthereumTypeRpcCall(data, GasPriceOracleAddress, "")
+	if err != nil {
+		return nil, err
+	}
+	fee, err := parseL1Fee(result)
+	if err != nil {
+		return nil, err
+	}
+	return &bchain.EthereumL1FeeEstimate{L1Fee: fee}, nil
+}
@@ -0,0 +1,80 @@
+package optimism
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestGetL1FeeCallData(t *testing.T) {
+	params := map[string]interface{}{
+		"to":    "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f",
+		"data":  "0xa9059cbb000000000000000000000000",
+		"value": "0x2386f26fc10000",
+	}
+	data, err := getL1FeeCallData(big.NewInt(10), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(data, getL1FeeSignature) {
+		t.Fatalf("getL1FeeCallData() = %v, expected prefix %v", data, getL1FeeSignature)
+	}
+	unpacked, err := bytesArguments.Unpack(hexutil.MustDecode("0x" + data[len(getL1FeeSignature):]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tx types.Transaction
+	if err = tx.UnmarshalBinary(unpacked[0].([]byte)); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type() != types.DynamicFeeTxType || tx.ChainId().Int64() != 10 {
+		t.Errorf("unsigned tx type %d chainId %v, want %d 10", tx.Type(), tx.ChainId(), types.DynamicFeeTxType)
+	}
+	if tx.To() == nil || tx.To().Hex() != params["to"] {
+		t.Errorf("unsigned tx to = %v, want %v", tx.To(), params["to"])
+	}
+	if got := hexutil.Encode(tx.Data()); got != params["data"] {
+		t.Errorf("unsigned tx data = %v, want %v", got, params["data"])
+	}
+	if got := hexutil.EncodeBig(tx.Value()); got != params["value"] {
+		t.Errorf("unsigned tx value = %v, want %v", got, params["value"])
+	}
+
+	// contract creation has no recipient
+	data, err = getL1FeeCallData(big.NewInt(10), map[string]interface{}{"data": "0x6080604052"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpacked, err = bytesArguments.Unpack(hexutil.MustDecode("0x" + data[len(getL1FeeSignature):]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tx.UnmarshalBinary(unpacked[0].([]byte)); err != nil {
+		t.Fatal(err)
+	}
+	if tx.To() != nil {
+		t.Errorf("contract creation unsigned tx to = %v, want nil", tx.To())
+	}
+
+	if _, err = getL1FeeCallData(big.NewInt(10), map[string]interface{}{"to": "0x1234"}); err == nil {
+		t.Error("getL1FeeCallData() with invalid address, expected error")
+	}
+}
+
+func TestParseL1Fee(t *testing.T) {
+	got, err := parseL1Fee("0x00000000000000000000000000000000000000000000000000000002a9f3c1b0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "11441258928" {
+		t.Errorf("parseL1Fee() = %v, want 11441258928", got)
+	}
+	for _, r := range []string{"0x", "0x2a9f3c1b0", "not hex"} {
+		if _, err := parseL1Fee(r); err == nil {
+			t.Errorf("parseL1Fee(%q), expected error", r)
+		}
+	}
+}
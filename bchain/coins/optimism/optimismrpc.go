@@ -79,3 +79,8 @@ func (b *OptimismRPC) Initialize() error {
 func (b *OptimismRPC) ResolveENS(name string) (*bchain.ENSResolution, error) {
 	return b.EthereumRPC.ResolveENS(name)
 }
+
+// EthereumTypeEstimateL1Fee returns estimation of the L1 data fee charged on top of the L2 execution fee
+func (b *OptimismRPC) EthereumTypeEstimateL1Fee(params map[string]interface{}) (*bchain.EthereumL1FeeEstimate, error) {
+	return EstimateL1Fee(b.EthereumRPC, params)
+}
@@ -1176,6 +1176,11 @@ func (b *TronRPC) EthereumTypeGetNonces(addrDesc bchain.AddressDescriptor, withC
 	return n, n, withConfirmed, nil
 }
 
+// EthereumTypeGetDelegation returns empty delegation, Tron does not support EIP-7702
+func (b *TronRPC) EthereumTypeGetDelegation(addrDesc bchain.AddressDescriptor) (string, error) {
+	return "", nil
+}
+
 // GetContractInfo returns information about a contract
 func (b *TronRPC) GetContractInfo(contractDesc bchain.AddressDescriptor) (*bchain.ContractInfo, error) {
 	contract, err := b.EthereumRPC.GetContractInfo(contractDesc)
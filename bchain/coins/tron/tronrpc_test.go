@@ -79,6 +79,36 @@ func (m *tronTestMempool) GetTransactionTime(txid string) uint32 {
 	return m.txTimes[txid]
 }
 
+func (m *tronTestMempool) GetFeeEntries() []bchain.MempoolFeeEntry {
+	return nil
+}
+
+func (m *tronTestMempool) SetReplacementTracking(retention time.Duration, onTxReplaced bchain.OnTxReplacedFunc) {
+}
+
+func (m *tronTestMempool) GetTxReplacement(txid string) *bchain.MempoolTxReplacement {
+	return nil
+}
+
+func (m *tronTestMempool) SetDroppedTracking(retention time.Duration, check bchain.TxDroppedCheckFunc, onTxDropped bchain.OnTxDroppedFunc) {
+}
+
+func (m *tronTestMempool) GetTxDropped(txid string) *bchain.MempoolTxDropped {
+	return nil
+}
+
+func (m *tronTestMempool) GetAccountTxs(addrDesc bchain.AddressDescriptor) []bchain.MempoolAccountTx {
+	return nil
+}
+
+func (m *tronTestMempool) GetSnapshot() *bchain.MempoolSnapshot {
+	return nil
+}
+
+func (m *tronTestMempool) LoadSnapshot(snapshot *bchain.MempoolSnapshot) int {
+	return 0
+}
+
 func (m *tronTestMempool) GetTxidFilterEntries(filterScripts string, fromTimestamp uint32) (bchain.MempoolTxidFilterEntries, error) {
 	return bchain.MempoolTxidFilterEntries{}, nil
 }
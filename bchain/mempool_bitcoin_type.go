@@ -129,11 +129,11 @@ func NewMempoolBitcoinType(chain BlockChain, workers int, subworkers int, golomb
 				}(j)
 			}
 			for payload := range m.chanTx {
-				io, golombFilter, ok := m.getTxAddrs(payload.txid, payload.tx, chanInput, chanResult)
+				io, golombFilter, fees, spends, ok := m.getTxAddrs(payload.txid, payload.tx, chanInput, chanResult)
 				if !ok {
 					io = []addrIndex{}
 				}
-				m.chanAddrIndex <- txidio{payload.txid, io, golombFilter}
+				m.chanAddrIndex <- txidio{payload.txid, io, golombFilter, fees, spends}
 			}
 		}(i)
 	}
@@ -242,13 +242,13 @@ func (m *MempoolBitcoinType) computeGolombFilter(mtx *MempoolTx, tx *Tx) string
 	return hex.EncodeToString(fb)
 }
 
-func (m *MempoolBitcoinType) getTxAddrs(txid string, tx *Tx, chanInput chan chanInputPayload, chanResult chan *addrIndex) ([]addrIndex, string, bool) {
+func (m *MempoolBitcoinType) getTxAddrs(txid string, tx *Tx, chanInput chan chanInputPayload, chanResult chan *addrIndex) ([]addrIndex, string, *txFeeData, []Outpoint, bool) {
 	if tx == nil {
 		var err error
 		tx, err = m.chain.GetTransactionForMempool(txid)
 		if err != nil {
 			glog.Error("cannot get transaction ", txid, ": ", err)
-			return nil, "", false
+			return nil, "", nil, nil, false
 		}
 	}
 	glog.V(2).Info("mempool: gettxaddrs ", txid, ", ", len(tx.Vin), " inputs")
@@ -269,11 +269,13 @@ func (m *MempoolBitcoinType) getTxAddrs(txid string, tx *Tx, chanInput chan chan
 		}
 	}
 	dispatched := 0
+	inputs, resolved := 0, 0
 	for i := range tx.Vin {
 		input := &tx.Vin[i]
 		if input.Coinbase != "" {
 			continue
 		}
+		inputs++
 		payload := chanInputPayload{mtx, i}
 	loop:
 		for {
@@ -282,6 +284,7 @@ func (m *MempoolBitcoinType) getTxAddrs(txid string, tx *Tx, chanInput chan chan
 			case ai := <-chanResult:
 				if ai != nil {
 					io = append(io, *ai)
+					resolved++
 				}
 				dispatched--
 			// send input to be processed
@@ -295,16 +298,32 @@ func (m *MempoolBitcoinType) getTxAddrs(txid string, tx *Tx, chanInput chan chan
 		ai := <-chanResult
 		if ai != nil {
 			io = append(io, *ai)
+			resolved++
 		}
 	}
 	var golombFilter string
 	if m.golombFilterP > 0 {
 		golombFilter = m.computeGolombFilter(mtx, tx)
 	}
+	// the fee is known only if the values of all inputs were resolved
+	var fees *txFeeData
+	if inputs > 0 && resolved == inputs {
+		fees = mempoolTxFeeData(mtx)
+	}
+	// the spent outpoints are needed to detect the replacements and the conflicts of the dropped transactions
+	var spends []Outpoint
+	if m.spendsTracked() {
+		spends = make([]Outpoint, 0, len(tx.Vin))
+		for i := range tx.Vin {
+			if tx.Vin[i].Coinbase == "" && tx.Vin[i].Txid != "" {
+				spends = append(spends, Outpoint{tx.Vin[i].Txid, int32(tx.Vin[i].Vout)})
+			}
+		}
+	}
 	if m.OnNewTx != nil {
 		m.OnNewTx(mtx)
 	}
-	return io, golombFilter, true
+	return io, golombFilter, fees, spends, true
 }
 
 func (m *MempoolBitcoinType) dispatchResyncPayloads(txids []string, cache map[string]*Tx, txTime uint32, onNewEntry func(txid string, entry txEntry)) {
@@ -319,7 +338,7 @@ func (m *MempoolBitcoinType) dispatchResyncPayloads(txids []string, cache map[st
 			select {
 			// store as many processed transactions as possible
 			case tio := <-m.chanAddrIndex:
-				onNewEntry(tio.txid, txEntry{tio.io, txTime, tio.filter})
+				onNewEntry(tio.txid, txEntry{tio.io, txTime, tio.filter, tio.fees, tio.spends})
 				dispatched--
 			// send transaction to be processed
 			case m.chanTx <- txPayload{txid: txid, tx: tx}:
@@ -330,7 +349,7 @@ func (m *MempoolBitcoinType) dispatchResyncPayloads(txids []string, cache map[st
 	}
 	for i := 0; i < dispatched; i++ {
 		tio := <-m.chanAddrIndex
-		onNewEntry(tio.txid, txEntry{tio.io, txTime, tio.filter})
+		onNewEntry(tio.txid, txEntry{tio.io, txTime, tio.filter, tio.fees, tio.spends})
 	}
 }
 
@@ -494,12 +513,15 @@ func (m *MempoolBitcoinType) Resync() (count int, err error) {
 	glog.V(2).Info("mempool: resync ", len(txs), " txs")
 	onNewEntry := func(txid string, entry txEntry) {
 		if len(entry.addrIndexes) > 0 {
+			var replaced []*TxReplacement
 			m.mux.Lock()
 			m.txEntries[txid] = entry
 			for _, si := range entry.addrIndexes {
 				m.addrDescToTx[si.addrDesc] = append(m.addrDescToTx[si.addrDesc], Outpoint{txid, si.n})
 			}
+			replaced = m.updateSpentOutpoints(txid, entry.spends, replaced)
 			m.mux.Unlock()
+			m.notifyTxReplaced(replaced)
 		}
 	}
 	txsMap := make(map[string]struct{}, len(txs))
@@ -540,18 +562,45 @@ func (m *MempoolBitcoinType) Resync() (count int, err error) {
 		}
 	}
 
+	now := time.Now()
 	for txid, entry := range m.txEntries {
 		if _, exists := txsMap[txid]; !exists {
 			m.mux.Lock()
 			m.removeEntryFromMempool(txid, entry)
+			m.addDroppedCandidate(txid, entry, now)
 			m.mux.Unlock()
 		}
 	}
+	if m.replacements.enabled() {
+		m.mux.Lock()
+		m.replacements.prune(now)
+		m.mux.Unlock()
+	}
+	m.processDroppedCandidates(now)
 	processDuration = time.Since(processStart)
 	count = len(m.txEntries)
 	return count, nil
 }
 
+// updateSpentOutpoints registers the outpoints spent by the transaction, a different mempool transaction
+// spending the same outpoint is replaced by the transaction. The caller is responsible for locking!
+func (m *MempoolBitcoinType) updateSpentOutpoints(txid string, spends []Outpoint, replaced []*TxReplacement) []*TxReplacement {
+	if m.spentOutpoints == nil {
+		return replaced
+	}
+	now := time.Now()
+	for _, o := range spends {
+		if spender, found := m.spentOutpoints[o]; found && spender != txid {
+			// the transaction can spend several outpoints of the replaced one, notify only once
+			if e, done := m.replacements.replacedBy[spender]; !done || e.txid != txid {
+				replaced = append(replaced, m.replaceTx(spender, txid, now))
+			}
+		}
+		m.spentOutpoints[o] = txid
+	}
+	return replaced
+}
+
 // GetTxidFilterEntries returns all mempool entries with golomb filter from
 func (m *MempoolBitcoinType) GetTxidFilterEntries(filterScripts string, fromTimestamp uint32) (MempoolTxidFilterEntries, error) {
 	if m.filterScripts != filterScripts {
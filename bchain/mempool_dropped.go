@@ -0,0 +1,150 @@
+package bchain
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// droppedGracePeriod is the time after which a transaction which left the mempool is checked,
+// it gives the index time to process the block which confirmed it
+var droppedGracePeriod = time.Minute
+
+// droppedCandidate is a transaction which left the mempool and waits for the check
+type droppedCandidate struct {
+	removed   time.Time
+	spends    []Outpoint
+	addrDescs []AddressDescriptor
+	outputs   []AddressDescriptor
+}
+
+// mempoolDropped keeps the transactions which left the mempool without being confirmed for the retention period.
+// The caller is responsible for locking!
+type mempoolDropped struct {
+	retention   time.Duration
+	candidates  map[string]droppedCandidate
+	history     map[string]MempoolTxDropped
+	check       TxDroppedCheckFunc
+	onTxDropped OnTxDroppedFunc
+}
+
+func (d *mempoolDropped) enabled() bool {
+	return d.retention > 0 && d.check != nil
+}
+
+// prune removes the dropped transactions older than the retention period
+func (d *mempoolDropped) prune(now time.Time) {
+	threshold := now.Add(-d.retention).Unix()
+	for txid, h := range d.history {
+		if h.Time < threshold {
+			delete(d.history, txid)
+		}
+	}
+}
+
+// SetDroppedTracking enables tracking of the transactions which left the mempool without being confirmed,
+// the check decides if a transaction was dropped, the dropped transactions are kept for the retention period,
+// zero retention disables the tracking
+func (m *BaseMempool) SetDroppedTracking(retention time.Duration, check TxDroppedCheckFunc, onTxDropped OnTxDroppedFunc) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.dropped = mempoolDropped{
+		retention:   retention,
+		candidates:  make(map[string]droppedCandidate),
+		history:     make(map[string]MempoolTxDropped),
+		check:       check,
+		onTxDropped: onTxDropped,
+	}
+}
+
+// GetTxDropped returns the information about a dropped transaction or nil if the transaction was not dropped
+func (m *BaseMempool) GetTxDropped(txid string) *MempoolTxDropped {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if !m.dropped.enabled() {
+		return nil
+	}
+	if h, found := m.dropped.history[txid]; found {
+		return &h
+	}
+	return nil
+}
+
+// addDroppedCandidate registers a transaction removed from the mempool for the check,
+// the replaced transactions are skipped, they were already notified. The caller is responsible for locking!
+func (m *BaseMempool) addDroppedCandidate(txid string, entry txEntry, now time.Time) {
+	if !m.dropped.enabled() {
+		return
+	}
+	if _, replaced := m.replacements.replacedBy[txid]; replaced {
+		return
+	}
+	m.dropped.candidates[txid] = droppedCandidate{
+		removed:   now,
+		spends:    entry.spends,
+		addrDescs: entryAddrDescs(entry, false),
+		outputs:   entryAddrDescs(entry, true),
+	}
+}
+
+// processDroppedCandidates checks the transactions which left the mempool before the grace period,
+// records the dropped ones and sends the notifications about them. It must be called without the lock.
+func (m *BaseMempool) processDroppedCandidates(now time.Time) {
+	m.mux.Lock()
+	if !m.dropped.enabled() {
+		m.mux.Unlock()
+		return
+	}
+	check, onTxDropped := m.dropped.check, m.dropped.onTxDropped
+	threshold := now.Add(-droppedGracePeriod)
+	due := make(map[string]droppedCandidate)
+	for txid, c := range m.dropped.candidates {
+		if _, back := m.txEntries[txid]; back {
+			delete(m.dropped.candidates, txid)
+		} else if !c.removed.After(threshold) {
+			due[txid] = c
+			delete(m.dropped.candidates, txid)
+		}
+	}
+	m.dropped.prune(now)
+	m.mux.Unlock()
+	if len(due) == 0 {
+		return
+	}
+	var dropped []*TxDropped
+	postponed := make(map[string]droppedCandidate)
+	for txid, c := range due {
+		r := check(txid, c.spends)
+		if r.Postpone {
+			postponed[txid] = c
+			continue
+		}
+		if !r.Dropped {
+			continue
+		}
+		d := TxDropped{Txid: txid, ConflictingTxid: r.ConflictingTxid, AddrDescs: c.addrDescs}
+		if r.ConflictingTxid != "" {
+			d.DoubleSpentAddrDescs = doubleSpentAddrDescs(c.outputs, r.ConflictingAddrDescs)
+		}
+		dropped = append(dropped, &d)
+	}
+	if len(dropped) == 0 && len(postponed) == 0 {
+		return
+	}
+	m.mux.Lock()
+	for txid, c := range postponed {
+		if _, found := m.dropped.candidates[txid]; !found {
+			m.dropped.candidates[txid] = c
+		}
+	}
+	for _, d := range dropped {
+		m.dropped.history[d.Txid] = MempoolTxDropped{Time: now.Unix(), ConflictingTxid: d.ConflictingTxid}
+	}
+	m.mux.Unlock()
+	for _, d := range dropped {
+		glog.V(1).Info("mempool: tx ", d.Txid, " dropped, conflicting tx ", d.ConflictingTxid)
+		if onTxDropped != nil {
+			onTxDropped(d)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package bchain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBaseMempool_processDroppedCandidates(t *testing.T) {
+	m := &BaseMempool{
+		txEntries:    make(map[string]txEntry),
+		addrDescToTx: make(map[string][]Outpoint),
+	}
+	m.SetReplacementTracking(time.Hour, nil)
+	checks := map[string]TxDroppedCheck{
+		"confirmed":  {},
+		"evicted":    {Dropped: true},
+		"conflicted": {Dropped: true, ConflictingTxid: "conflicting", ConflictingAddrDescs: []AddressDescriptor{AddressDescriptor("change")}},
+		"postponed":  {Postpone: true},
+	}
+	var checked []string
+	var notified []*TxDropped
+	m.SetDroppedTracking(time.Hour, func(txid string, spends []Outpoint) TxDroppedCheck {
+		checked = append(checked, txid)
+		return checks[txid]
+	}, func(d *TxDropped) { notified = append(notified, d) })
+	now := time.Now()
+	removed := now.Add(-2 * droppedGracePeriod)
+	for txid := range checks {
+		m.addDroppedCandidate(txid, txEntry{addrIndexes: []addrIndex{{addrDesc: "payee", n: 0}, {addrDesc: "change", n: 1}, {addrDesc: "payer", n: ^0}}}, removed)
+	}
+	// the transaction which returned to mempool, the replaced one and the recent one are not checked
+	m.addDroppedCandidate("returned", txEntry{}, removed)
+	m.txEntries["returned"] = txEntry{}
+	m.replacements.add("replaced", "replacement", removed)
+	m.addDroppedCandidate("replaced", txEntry{}, removed)
+	m.addDroppedCandidate("recent", txEntry{}, now)
+
+	m.processDroppedCandidates(now)
+	if len(checked) != 4 {
+		t.Errorf("checked = %v, want 4 transactions", checked)
+	}
+	if len(notified) != 2 {
+		t.Fatalf("notified = %+v, want 2 transactions", notified)
+	}
+	addrDescs := []AddressDescriptor{AddressDescriptor("payee"), AddressDescriptor("change"), AddressDescriptor("payer")}
+	for _, d := range notified {
+		var want TxDropped
+		switch d.Txid {
+		case "evicted":
+			want = TxDropped{Txid: "evicted", AddrDescs: addrDescs}
+		case "conflicted":
+			want = TxDropped{Txid: "conflicted", ConflictingTxid: "conflicting", AddrDescs: addrDescs, DoubleSpentAddrDescs: []AddressDescriptor{AddressDescriptor("payee")}}
+		}
+		if !reflect.DeepEqual(*d, want) {
+			t.Errorf("notified %+v, want %+v", *d, want)
+		}
+	}
+	if got := m.GetTxDropped("conflicted"); got == nil || got.ConflictingTxid != "conflicting" || got.Time != now.Unix() {
+		t.Errorf("GetTxDropped(conflicted) = %+v", got)
+	}
+	if got := m.GetTxDropped("confirmed"); got != nil {
+		t.Errorf("GetTxDropped(confirmed) = %+v, want nil", got)
+	}
+	wantCandidates := []string{"postponed", "recent"}
+	for _, txid := range wantCandidates {
+		if _, found := m.dropped.candidates[txid]; !found {
+			t.Errorf("candidate %v not kept", txid)
+		}
+	}
+	if len(m.dropped.candidates) != len(wantCandidates) {
+		t.Errorf("candidates = %+v, want %v", m.dropped.candidates, wantCandidates)
+	}
+	// the history is pruned after the retention period
+	m.processDroppedCandidates(now.Add(2 * time.Hour))
+	if got := m.GetTxDropped("evicted"); got != nil {
+		t.Errorf("GetTxDropped(evicted) = %+v, want nil after the retention period", got)
+	}
+}
@@ -2,6 +2,7 @@ package bchain
 
 import (
 	"errors"
+	"math/big"
 	"time"
 
 	"github.com/golang/glog"
@@ -84,10 +85,11 @@ func (m *MempoolEthereumType) createTxEntry(txid string, txTime uint32) (txEntry
 			addrIndexes, _ = appendAddress(addrIndexes, int32(i+1), t[i].To, parser)
 		}
 	}
+	fees := mempoolEthereumTxFeeData(mtx, parser.GetEthereumTxData(tx))
 	if m.OnNewTx != nil {
 		m.OnNewTx(mtx)
 	}
-	return txEntry{addrIndexes: addrIndexes, time: txTime}, true
+	return txEntry{addrIndexes: addrIndexes, time: txTime, fees: fees}, true
 }
 
 // Resync ethereum type removes timed out transactions and returns number of transactions in mempool.
@@ -118,6 +120,7 @@ func (m *MempoolEthereumType) Resync() (int, error) {
 		for txid, entry := range m.txEntries {
 			if time.Unix(int64(entry.time), 0).Before(threshold) {
 				m.removeEntryFromMempool(txid, entry)
+				m.addDroppedCandidate(txid, entry, now)
 			}
 		}
 		removed := entries - len(m.txEntries)
@@ -125,7 +128,11 @@ func (m *MempoolEthereumType) Resync() (int, error) {
 		glog.Info("Mempool: cleanup, removed ", removed, " transactions from mempool")
 		m.nextTimeoutRun = now.Add(mempoolTimeoutRunPeriod)
 	}
+	if m.replacements.enabled() {
+		m.replacements.prune(now)
+	}
 	m.mux.Unlock()
+	m.processDroppedCandidates(now)
 	duration := time.Since(start)
 	durationRounded := duration.Round(time.Millisecond)
 	if durationRounded == 0 {
@@ -145,6 +152,7 @@ func (m *MempoolEthereumType) Resync() (int, error) {
 
 func (m *MempoolEthereumType) removeTransactionsMissingFromBackend(backendTxs map[string]struct{}, backendSnapshotTime uint32) int {
 	removed := 0
+	now := time.Now()
 	m.mux.Lock()
 	defer m.mux.Unlock()
 	for txid, entry := range m.txEntries {
@@ -152,6 +160,7 @@ func (m *MempoolEthereumType) removeTransactionsMissingFromBackend(backendTxs ma
 			continue
 		}
 		m.removeEntryFromMempool(txid, entry)
+		m.addDroppedCandidate(txid, entry, now)
 		removed++
 	}
 	return removed
@@ -170,16 +179,39 @@ func (m *MempoolEthereumType) AddTransactionToMempool(txid string) bool {
 		if !ok {
 			return false
 		}
+		var replaced *TxReplacement
 		m.mux.Lock()
 		m.txEntries[txid] = entry
 		for _, si := range entry.addrIndexes {
 			m.addrDescToTx[si.addrDesc] = append(m.addrDescToTx[si.addrDesc], Outpoint{txid, si.n})
 		}
+		replaced = m.replaceSameNonceTx(txid, entry)
 		m.mux.Unlock()
+		if replaced != nil {
+			m.notifyTxReplaced([]*TxReplacement{replaced})
+		}
 	}
 	return !exists
 }
 
+// replaceSameNonceTx removes the mempool transaction of the same sender with the same nonce,
+// which is replaced by the new transaction. The caller is responsible for locking!
+func (m *MempoolEthereumType) replaceSameNonceTx(txid string, entry txEntry) *TxReplacement {
+	if m.nonceTxs == nil || entry.fees == nil || entry.fees.sender == "" {
+		return nil
+	}
+	sn := senderNonce{entry.fees.sender, entry.fees.nonce}
+	var r *TxReplacement
+	if old, found := m.nonceTxs[sn]; found && old != txid {
+		r = m.replaceTx(old, txid, time.Now())
+		if oldEntry, found := m.txEntries[old]; found {
+			m.removeEntryFromMempool(old, oldEntry)
+		}
+	}
+	m.nonceTxs[sn] = txid
+	return r
+}
+
 // RemoveTransactionFromMempool removes transaction from mempool
 func (m *MempoolEthereumType) RemoveTransactionFromMempool(txid string) {
 	m.mux.Lock()
@@ -193,6 +225,31 @@ func (m *MempoolEthereumType) RemoveTransactionFromMempool(txid string) {
 	m.mux.Unlock()
 }
 
+// MempoolPriorityFee is the priority fee per gas a mempool transaction pays and its gas limit
+type MempoolPriorityFee struct {
+	PriorityFee *big.Int
+	Gas         int64
+}
+
+// GetPriorityFees returns the effective priority fees the mempool transactions would pay in a block with the base fee,
+// the transactions with the fee cap below the base fee are skipped
+func (m *MempoolEthereumType) GetPriorityFees(baseFee *big.Int) []MempoolPriorityFee {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	r := make([]MempoolPriorityFee, 0, len(m.txEntries))
+	for _, e := range m.txEntries {
+		if e.fees == nil || e.fees.maxFeePerGas == nil || e.fees.maxFeePerGas.Cmp(baseFee) < 0 {
+			continue
+		}
+		tip := new(big.Int).Sub(e.fees.maxFeePerGas, baseFee)
+		if tip.Cmp(e.fees.maxPriorityFeePerGas) > 0 {
+			tip.Set(e.fees.maxPriorityFeePerGas)
+		}
+		r = append(r, MempoolPriorityFee{PriorityFee: tip, Gas: e.fees.vsize})
+	}
+	return r
+}
+
 // GetTxidFilterEntries returns all mempool entries with golomb filter from
 func (m *MempoolEthereumType) GetTxidFilterEntries(filterScripts string, fromTimestamp uint32) (MempoolTxidFilterEntries, error) {
 	return MempoolTxidFilterEntries{}, errors.New("Not supported")
@@ -0,0 +1,412 @@
+package bchain
+
+import (
+	"container/heap"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// MaxBitcoinBlockVSize is the maximum virtual size of a Bitcoin block
+const MaxBitcoinBlockVSize = 1000000
+
+// DefaultEthereumBlockGasLimit is used for the Ethereum type chains until the gas limit of a block is known
+const DefaultEthereumBlockGasLimit = 30000000
+
+// maxProjectionAncestors limits the in-mempool ancestors of a transaction scored by the projection, the default limit
+// of Bitcoin Core. A transaction with more ancestors (a long Ethereum nonce chain) is scored once enough of them are
+// included, which keeps the recomputation of the packages linear in the length of the chain.
+const maxProjectionAncestors = 25
+
+// EthereumMempoolFeeUnit is the unit of the fees of the Ethereum type mempool fee entries in wei (Mwei),
+// the fees in wei of the large transactions would not fit into int64
+const EthereumMempoolFeeUnit = 1000000
+
+// MempoolFeeEntry is a mempool transaction with the data needed for the fee estimation
+type MempoolFeeEntry struct {
+	Txid string
+	// Fee is in satoshi for Bitcoin type chains and in EthereumMempoolFeeUnit for Ethereum type chains
+	Fee int64
+	// VSize is the virtual size for Bitcoin type chains and the gas limit for Ethereum type chains
+	VSize int64
+	// Parents are the txids of the transactions spent by the inputs (they can be confirmed)
+	// or the transaction of the sender with the previous nonce
+	Parents []string
+	// MaxFeePerGas and MaxPriorityFeePerGas are set only for Ethereum type chains, for the legacy transactions both are the gas price
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// SetEthereumEffectiveFees sets the fees of the Ethereum type entries to the fees they would pay in a block with the base fee,
+// which ranks them by the effective priority fee min(maxPriorityFeePerGas, maxFeePerGas-baseFee). The transactions with
+// the fee cap below the base fee pay the fee cap and are ranked after all includable transactions. Nil base fee keeps the fees.
+func SetEthereumEffectiveFees(entries []MempoolFeeEntry, baseFee *big.Int) {
+	if baseFee == nil {
+		return
+	}
+	unit := big.NewInt(EthereumMempoolFeeUnit)
+	for i := range entries {
+		e := &entries[i]
+		if e.MaxFeePerGas == nil || e.MaxPriorityFeePerGas == nil {
+			continue
+		}
+		price := new(big.Int).Add(baseFee, e.MaxPriorityFeePerGas)
+		if price.Cmp(e.MaxFeePerGas) > 0 {
+			price.Set(e.MaxFeePerGas)
+		}
+		fee := price.Mul(price, big.NewInt(e.VSize))
+		fee.Div(fee, unit)
+		if fee.IsInt64() {
+			e.Fee = fee.Int64()
+		}
+	}
+}
+
+// ProjectedBlock is a block which would be mined from the current mempool by a miner maximizing the fees
+type ProjectedBlock struct {
+	VSize    int64
+	TxCount  int
+	TotalFee int64
+	// FeeRange is the minimum, the 10th, 25th, 50th, 75th and 90th percentile and the maximum
+	// of the effective (ancestor package) fee rates of the transactions in sat/vB, weighted by their vsize
+	FeeRange [7]float64
+}
+
+// MedianFeeRate returns the median effective fee rate of the block in sat/vB
+func (b *ProjectedBlock) MedianFeeRate() float64 {
+	return b.FeeRange[3]
+}
+
+// FeeHistogramBucket holds the mempool transactions with the effective fee rate in [FeeRate, FeeRate of the next bucket)
+type FeeHistogramBucket struct {
+	FeeRate float64
+	VSize   int64
+	TxCount int
+}
+
+// MempoolProjection is the result of the projection of the mempool into the blocks
+type MempoolProjection struct {
+	Blocks []ProjectedBlock
+	// Histogram contains the non empty buckets ordered from the highest fee rate
+	Histogram []FeeHistogramBucket
+}
+
+// mempoolTxFeeData computes the fee of the mempool transaction, the values of all inputs must be resolved
+func mempoolTxFeeData(mtx *MempoolTx) *txFeeData {
+	var fee int64
+	parents := make([]string, 0, len(mtx.Vin))
+	for i := range mtx.Vin {
+		vin := &mtx.Vin[i]
+		fee += vin.ValueSat.Int64()
+		if len(parents) == 0 || parents[len(parents)-1] != vin.Txid {
+			parents = append(parents, vin.Txid)
+		}
+	}
+	for i := range mtx.Vout {
+		fee -= mtx.Vout[i].ValueSat.Int64()
+	}
+	vsize := mtx.VSize
+	if vsize <= 0 {
+		vsize = int64(len(mtx.Hex) / 2)
+	}
+	if fee < 0 || vsize <= 0 {
+		return nil
+	}
+	return &txFeeData{fee: fee, vsize: vsize, parents: parents}
+}
+
+// mempoolEthereumTxFeeData computes the maximum fee of the mempool transaction in EthereumMempoolFeeUnit,
+// the fee the transaction pays in a block is set by SetEthereumEffectiveFees from the fee caps once the base fee is known
+func mempoolEthereumTxFeeData(mtx *MempoolTx, etd *EthereumTxData) *txFeeData {
+	price := etd.GasPrice
+	if price == nil {
+		price = etd.MaxFeePerGas
+	}
+	if price == nil || etd.GasLimit == nil || etd.GasLimit.Sign() <= 0 || !etd.GasLimit.IsInt64() ||
+		len(mtx.Vin) == 0 || len(mtx.Vin[0].Addresses) == 0 {
+		return nil
+	}
+	fee := new(big.Int).Mul(price, etd.GasLimit)
+	fee.Div(fee, big.NewInt(EthereumMempoolFeeUnit))
+	if !fee.IsInt64() {
+		return nil
+	}
+	feeCap, tipCap := etd.MaxFeePerGas, etd.MaxPriorityFeePerGas
+	if feeCap == nil || tipCap == nil {
+		feeCap, tipCap = price, price
+	}
+	return &txFeeData{
+		fee:                  fee.Int64(),
+		vsize:                etd.GasLimit.Int64(),
+		sender:               mtx.Vin[0].Addresses[0],
+		nonce:                etd.Nonce,
+		maxFeePerGas:         feeCap,
+		maxPriorityFeePerGas: tipCap,
+	}
+}
+
+// GetFeeEntries returns the mempool transactions with known fee
+func (m *BaseMempool) GetFeeEntries() []MempoolFeeEntry {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	entries := make([]MempoolFeeEntry, 0, len(m.txEntries))
+	for txid, e := range m.txEntries {
+		if e.fees != nil {
+			entries = append(entries, MempoolFeeEntry{
+				Txid:    txid,
+				Fee:     e.fees.fee,
+				VSize:   e.fees.vsize,
+				Parents: e.fees.parents,
+			})
+		}
+	}
+	return entries
+}
+
+// GetFeeEntries returns the mempool transactions with known fee,
+// the parent of a transaction is the transaction of the same sender with the previous nonce
+func (m *MempoolEthereumType) GetFeeEntries() []MempoolFeeEntry {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	nonces := make(map[string]string, len(m.txEntries))
+	nonceKey := func(sender string, nonce uint64) string {
+		return sender + ":" + strconv.FormatUint(nonce, 10)
+	}
+	for txid, e := range m.txEntries {
+		if e.fees != nil {
+			nonces[nonceKey(e.fees.sender, e.fees.nonce)] = txid
+		}
+	}
+	entries := make([]MempoolFeeEntry, 0, len(nonces))
+	for txid, e := range m.txEntries {
+		if e.fees != nil {
+			var parents []string
+			if e.fees.nonce > 0 {
+				if parent, ok := nonces[nonceKey(e.fees.sender, e.fees.nonce-1)]; ok {
+					parents = []string{parent}
+				}
+			}
+			entries = append(entries, MempoolFeeEntry{
+				Txid:                 txid,
+				Fee:                  e.fees.fee,
+				VSize:                e.fees.vsize,
+				Parents:              parents,
+				MaxFeePerGas:         e.fees.maxFeePerGas,
+				MaxPriorityFeePerGas: e.fees.maxPriorityFeePerGas,
+			})
+		}
+	}
+	return entries
+}
+
+type feeRateVSize struct {
+	rate  float64
+	vsize int64
+}
+
+type packageScore struct {
+	index   int
+	fee     int64
+	vsize   int64
+	version int
+}
+
+type packageScoreHeap []packageScore
+
+func (h packageScoreHeap) Len() int { return len(h) }
+func (h packageScoreHeap) Less(i, j int) bool {
+	// higher fee rate first, compared without the division in float64 as the products can overflow int64
+	return float64(h[i].fee)*float64(h[j].vsize) > float64(h[j].fee)*float64(h[i].vsize)
+}
+func (h packageScoreHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *packageScoreHeap) Push(x interface{}) { *h = append(*h, x.(packageScore)) }
+func (h *packageScoreHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+type blockProjection struct {
+	entries  []MempoolFeeEntry
+	parents  [][]int
+	children [][]int
+	included []bool
+	version  []int
+	scores   packageScoreHeap
+}
+
+// ancestors returns the not yet included in-mempool ancestors of the transaction including itself,
+// ordered so that each transaction follows its ancestors, or false if there are more than maxProjectionAncestors of them
+func (p *blockProjection) ancestors(i int) ([]int, bool) {
+	var r []int
+	visited := map[int]struct{}{}
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		visited[i] = struct{}{}
+		if len(visited) > maxProjectionAncestors+1 {
+			return false
+		}
+		for _, a := range p.parents[i] {
+			if _, ok := visited[a]; !ok && !p.included[a] {
+				if !visit(a) {
+					return false
+				}
+			}
+		}
+		r = append(r, i)
+		return true
+	}
+	if !visit(i) {
+		return nil, false
+	}
+	return r, true
+}
+
+// pushScore scores the package of the transaction with its ancestors, the transaction with too many ancestors
+// is not scored and its previous score is invalidated
+func (p *blockProjection) pushScore(i int) {
+	p.version[i]++
+	pkg, ok := p.ancestors(i)
+	if !ok {
+		return
+	}
+	var fee, vsize int64
+	for _, a := range pkg {
+		fee += p.entries[a].Fee
+		vsize += p.entries[a].VSize
+	}
+	heap.Push(&p.scores, packageScore{index: i, fee: fee, vsize: vsize, version: p.version[i]})
+}
+
+// ProjectBlocks builds up to maxBlocks blocks of maxBlockVSize from the mempool entries, selecting the transactions
+// by the fee rate of their ancestor packages the way the miners do. The last block contains all remaining transactions.
+func ProjectBlocks(entries []MempoolFeeEntry, maxBlockVSize int64, maxBlocks int) []ProjectedBlock {
+	return ProjectMempool(entries, maxBlockVSize, maxBlocks, nil).Blocks
+}
+
+// ProjectMempool projects the mempool entries into blocks the same way as ProjectBlocks and if histogramBounds
+// (ascending lower bounds of the buckets) are specified, computes the histogram of the effective fee rates,
+// the fee rates lower than the first bound are counted in the first bucket
+func ProjectMempool(entries []MempoolFeeEntry, maxBlockVSize int64, maxBlocks int, histogramBounds []float64) *MempoolProjection {
+	if len(entries) == 0 || maxBlocks <= 0 {
+		return &MempoolProjection{}
+	}
+	p := blockProjection{
+		entries:  entries,
+		parents:  make([][]int, len(entries)),
+		children: make([][]int, len(entries)),
+		included: make([]bool, len(entries)),
+		version:  make([]int, len(entries)),
+		scores:   make(packageScoreHeap, 0, len(entries)),
+	}
+	indexes := make(map[string]int, len(entries))
+	for i := range entries {
+		indexes[entries[i].Txid] = i
+	}
+	for i := range entries {
+		for _, parent := range entries[i].Parents {
+			if j, ok := indexes[parent]; ok && j != i {
+				p.parents[i] = append(p.parents[i], j)
+				p.children[j] = append(p.children[j], i)
+			}
+		}
+	}
+	for i := range entries {
+		p.pushScore(i)
+	}
+	blocks := make([]ProjectedBlock, 0, maxBlocks)
+	var block ProjectedBlock
+	var rates []feeRateVSize
+	histogram := make([]FeeHistogramBucket, len(histogramBounds))
+	for i := range histogramBounds {
+		histogram[i].FeeRate = histogramBounds[i]
+	}
+	closeBlock := func() {
+		block.FeeRange = feeRange(rates, block.VSize)
+		blocks = append(blocks, block)
+		block = ProjectedBlock{}
+		rates = nil
+	}
+	for p.scores.Len() > 0 {
+		s := heap.Pop(&p.scores).(packageScore)
+		if p.included[s.index] || s.version != p.version[s.index] {
+			continue
+		}
+		if block.TxCount > 0 && block.VSize+s.vsize > maxBlockVSize && len(blocks) < maxBlocks-1 {
+			closeBlock()
+		}
+		rate := float64(s.fee) / float64(s.vsize)
+		// the package of a valid score only got smaller since it was scored
+		pkg, _ := p.ancestors(s.index)
+		for _, i := range pkg {
+			p.included[i] = true
+			block.TxCount++
+			block.VSize += entries[i].VSize
+			block.TotalFee += entries[i].Fee
+			rates = append(rates, feeRateVSize{rate, entries[i].VSize})
+			if len(histogram) > 0 {
+				// SearchFloat64s returns the index of the first bound >= rate, the bucket is the last bound <= rate
+				b := sort.SearchFloat64s(histogramBounds, rate)
+				if b == len(histogramBounds) || histogramBounds[b] > rate {
+					b--
+				}
+				if b < 0 {
+					b = 0
+				}
+				histogram[b].VSize += entries[i].VSize
+				histogram[b].TxCount++
+			}
+		}
+		// the packages of the descendants of the included transactions got smaller, the descendants
+		// further than maxProjectionAncestors still have too many ancestors and are not rescored
+		updated := map[int]struct{}{}
+		frontier := pkg
+		for depth := 1; depth <= maxProjectionAncestors && len(frontier) > 0; depth++ {
+			var next []int
+			for _, i := range frontier {
+				for _, c := range p.children[i] {
+					if _, ok := updated[c]; !ok && !p.included[c] {
+						updated[c] = struct{}{}
+						p.pushScore(c)
+						next = append(next, c)
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+	if block.TxCount > 0 {
+		closeBlock()
+	}
+	r := &MempoolProjection{Blocks: blocks}
+	for i := len(histogram) - 1; i >= 0; i-- {
+		if histogram[i].TxCount > 0 {
+			r.Histogram = append(r.Histogram, histogram[i])
+		}
+	}
+	return r
+}
+
+// feeRange returns the minimum, percentiles and the maximum of the fee rates weighted by vsize
+func feeRange(rates []feeRateVSize, vsize int64) [7]float64 {
+	var r [7]float64
+	if len(rates) == 0 || vsize <= 0 {
+		return r
+	}
+	sort.SliceStable(rates, func(i, j int) bool { return rates[i].rate < rates[j].rate })
+	percentiles := [5]int64{10, 25, 50, 75, 90}
+	r[0] = rates[0].rate
+	r[6] = rates[len(rates)-1].rate
+	var cumulative int64
+	j := 0
+	for _, fr := range rates {
+		cumulative += fr.vsize
+		for j < len(percentiles) && cumulative*100 >= percentiles[j]*vsize {
+			r[j+1] = fr.rate
+			j++
+		}
+	}
+	return r
+}
@@ -0,0 +1,218 @@
+//go:build unittest
+
+package bchain
+
+import (
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestProjectBlocks(t *testing.T) {
+	tests := []struct {
+		name          string
+		entries       []MempoolFeeEntry
+		maxBlockVSize int64
+		maxBlocks     int
+		want          []ProjectedBlock
+	}{
+		{
+			name:          "empty",
+			maxBlockVSize: 1000,
+			maxBlocks:     2,
+		},
+		{
+			name: "child pays for parent",
+			entries: []MempoolFeeEntry{
+				{Txid: "parent", Fee: 100, VSize: 100, Parents: []string{"confirmed"}},
+				{Txid: "child", Fee: 1900, VSize: 100, Parents: []string{"parent"}},
+				{Txid: "other", Fee: 500, VSize: 100},
+			},
+			maxBlockVSize: 200,
+			maxBlocks:     3,
+			want: []ProjectedBlock{
+				{VSize: 200, TxCount: 2, TotalFee: 2000, FeeRange: [7]float64{10, 10, 10, 10, 10, 10, 10}},
+				{VSize: 100, TxCount: 1, TotalFee: 500, FeeRange: [7]float64{5, 5, 5, 5, 5, 5, 5}},
+			},
+		},
+		{
+			name: "descendant rescored after parent inclusion",
+			entries: []MempoolFeeEntry{
+				{Txid: "parent", Fee: 500, VSize: 100},
+				{Txid: "child", Fee: 100, VSize: 100, Parents: []string{"parent"}},
+				{Txid: "other", Fee: 250, VSize: 100},
+			},
+			maxBlockVSize: 200,
+			maxBlocks:     3,
+			want: []ProjectedBlock{
+				{VSize: 200, TxCount: 2, TotalFee: 750, FeeRange: [7]float64{2.5, 2.5, 2.5, 2.5, 5, 5, 5}},
+				{VSize: 100, TxCount: 1, TotalFee: 100, FeeRange: [7]float64{1, 1, 1, 1, 1, 1, 1}},
+			},
+		},
+		{
+			name: "last block contains the rest",
+			entries: []MempoolFeeEntry{
+				{Txid: "a", Fee: 300, VSize: 100},
+				{Txid: "b", Fee: 200, VSize: 100},
+				{Txid: "c", Fee: 100, VSize: 100},
+			},
+			maxBlockVSize: 100,
+			maxBlocks:     2,
+			want: []ProjectedBlock{
+				{VSize: 100, TxCount: 1, TotalFee: 300, FeeRange: [7]float64{3, 3, 3, 3, 3, 3, 3}},
+				{VSize: 200, TxCount: 2, TotalFee: 300, FeeRange: [7]float64{1, 1, 1, 1, 2, 2, 2}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProjectBlocks(tt.entries, tt.maxBlockVSize, tt.maxBlocks)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProjectBlocks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectBlocksLongChain(t *testing.T) {
+	// a nonce chain longer than maxProjectionAncestors is projected in parts, all its transactions are included
+	const n = 20 * maxProjectionAncestors
+	entries := make([]MempoolFeeEntry, n)
+	for i := range entries {
+		entries[i] = MempoolFeeEntry{Txid: strconv.Itoa(i), Fee: 100, VSize: 10}
+		if i > 0 {
+			entries[i].Parents = []string{strconv.Itoa(i - 1)}
+		}
+	}
+	got := ProjectBlocks(entries, 10*n, 2)
+	if len(got) != 1 || got[0].TxCount != n || got[0].TotalFee != 100*n {
+		t.Errorf("ProjectBlocks() = %+v, want one block with %d transactions", got, n)
+	}
+}
+
+func Test_feeRange(t *testing.T) {
+	rates := []feeRateVSize{{10, 600}, {1, 100}, {2, 300}}
+	want := [7]float64{1, 1, 2, 10, 10, 10, 10}
+	if got := feeRange(rates, 1000); got != want {
+		t.Errorf("feeRange() = %v, want %v", got, want)
+	}
+}
+
+func Test_mempoolTxFeeData(t *testing.T) {
+	mtx := MempoolTx{
+		VSize: 150,
+		Vin: []MempoolVin{
+			{Vin: Vin{Txid: "a"}, ValueSat: *big.NewInt(10000)},
+			{Vin: Vin{Txid: "a"}, ValueSat: *big.NewInt(5000)},
+			{Vin: Vin{Txid: "b"}, ValueSat: *big.NewInt(1000)},
+		},
+		Vout: []Vout{{ValueSat: *big.NewInt(14000)}, {ValueSat: *big.NewInt(1500)}},
+	}
+	want := &txFeeData{fee: 500, vsize: 150, parents: []string{"a", "b"}}
+	if got := mempoolTxFeeData(&mtx); !reflect.DeepEqual(got, want) {
+		t.Errorf("mempoolTxFeeData() = %+v, want %+v", got, want)
+	}
+	mtx.Vout[0].ValueSat = *big.NewInt(20000)
+	if got := mempoolTxFeeData(&mtx); got != nil {
+		t.Errorf("mempoolTxFeeData() = %+v, want nil for negative fee", got)
+	}
+}
+
+func TestProjectMempoolHistogram(t *testing.T) {
+	entries := []MempoolFeeEntry{
+		{Txid: "parent", Fee: 100, VSize: 100},
+		{Txid: "child", Fee: 1900, VSize: 100, Parents: []string{"parent"}},
+		{Txid: "other", Fee: 500, VSize: 100},
+		{Txid: "cheap", Fee: 50, VSize: 100},
+	}
+	got := ProjectMempool(entries, 1000, 2, []float64{1, 2, 5, 10, 20})
+	want := []FeeHistogramBucket{
+		{FeeRate: 10, VSize: 200, TxCount: 2},
+		{FeeRate: 5, VSize: 100, TxCount: 1},
+		{FeeRate: 1, VSize: 100, TxCount: 1},
+	}
+	if !reflect.DeepEqual(got.Histogram, want) {
+		t.Errorf("ProjectMempool().Histogram = %+v, want %+v", got.Histogram, want)
+	}
+	if len(got.Blocks) != 1 || got.Blocks[0].TxCount != 4 {
+		t.Errorf("ProjectMempool().Blocks = %+v, want one block with 4 txs", got.Blocks)
+	}
+}
+
+func TestSetEthereumEffectiveFees(t *testing.T) {
+	gwei := func(v int64) *big.Int { return big.NewInt(v * 1000000000) }
+	entries := []MempoolFeeEntry{
+		// legacy transaction pays the gas price
+		{Txid: "legacy", Fee: 1, VSize: 21000, MaxFeePerGas: gwei(12), MaxPriorityFeePerGas: gwei(12)},
+		// high fee cap but low tip pays only the base fee and the tip
+		{Txid: "lowtip", Fee: 1, VSize: 21000, MaxFeePerGas: gwei(100), MaxPriorityFeePerGas: gwei(1)},
+		// the tip is limited by the fee cap
+		{Txid: "capped", Fee: 1, VSize: 21000, MaxFeePerGas: gwei(13), MaxPriorityFeePerGas: gwei(5)},
+		// the fee cap below the base fee
+		{Txid: "below", Fee: 1, VSize: 21000, MaxFeePerGas: gwei(8), MaxPriorityFeePerGas: gwei(2)},
+		{Txid: "unknown", Fee: 7, VSize: 21000},
+	}
+	SetEthereumEffectiveFees(entries, gwei(10))
+	// fees in Mwei for 21000 gas
+	want := map[string]int64{"legacy": 252000000, "lowtip": 231000000, "capped": 273000000, "below": 168000000, "unknown": 7}
+	for _, e := range entries {
+		if e.Fee != want[e.Txid] {
+			t.Errorf("%s: fee %d, want %d", e.Txid, e.Fee, want[e.Txid])
+		}
+	}
+	SetEthereumEffectiveFees(entries[:1], nil)
+	if entries[0].Fee != 252000000 {
+		t.Errorf("fee changed without the base fee: %d", entries[0].Fee)
+	}
+}
+
+func Test_mempoolEthereumTxFeeData(t *testing.T) {
+	mtx := MempoolTx{Vin: []MempoolVin{{Vin: Vin{Addresses: []string{"0xsender"}}}}}
+	etd := EthereumTxData{Nonce: 7, GasLimit: big.NewInt(21000), GasPrice: big.NewInt(2000000000)}
+	want := &txFeeData{fee: 42000000, vsize: 21000, sender: "0xsender", nonce: 7, maxFeePerGas: etd.GasPrice, maxPriorityFeePerGas: etd.GasPrice}
+	if got := mempoolEthereumTxFeeData(&mtx, &etd); !reflect.DeepEqual(got, want) {
+		t.Errorf("mempoolEthereumTxFeeData() = %+v, want %+v", got, want)
+	}
+	etd.GasPrice = nil
+	if got := mempoolEthereumTxFeeData(&mtx, &etd); got != nil {
+		t.Errorf("mempoolEthereumTxFeeData() = %+v, want nil without gas price", got)
+	}
+}
+
+func TestMempoolEthereumTypeGetPriorityFees(t *testing.T) {
+	m := NewMempoolEthereumType(nil, 0, false)
+	m.txEntries["legacy"] = txEntry{fees: &txFeeData{vsize: 21000, maxFeePerGas: big.NewInt(30), maxPriorityFeePerGas: big.NewInt(30)}}
+	m.txEntries["capped"] = txEntry{fees: &txFeeData{vsize: 50000, maxFeePerGas: big.NewInt(12), maxPriorityFeePerGas: big.NewInt(5)}}
+	m.txEntries["tip"] = txEntry{fees: &txFeeData{vsize: 60000, maxFeePerGas: big.NewInt(100), maxPriorityFeePerGas: big.NewInt(3)}}
+	m.txEntries["underpriced"] = txEntry{fees: &txFeeData{vsize: 21000, maxFeePerGas: big.NewInt(9), maxPriorityFeePerGas: big.NewInt(2)}}
+	got := m.GetPriorityFees(big.NewInt(10))
+	sort.Slice(got, func(i, j int) bool { return got[i].Gas < got[j].Gas })
+	want := []MempoolPriorityFee{
+		{PriorityFee: big.NewInt(20), Gas: 21000},
+		{PriorityFee: big.NewInt(2), Gas: 50000},
+		{PriorityFee: big.NewInt(3), Gas: 60000},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPriorityFees() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMempoolEthereumTypeGetFeeEntries(t *testing.T) {
+	m := NewMempoolEthereumType(nil, 0, false)
+	m.txEntries["tx1"] = txEntry{fees: &txFeeData{fee: 10, vsize: 21000, sender: "a", nonce: 1}}
+	m.txEntries["tx2"] = txEntry{fees: &txFeeData{fee: 20, vsize: 21000, sender: "a", nonce: 2}}
+	m.txEntries["tx3"] = txEntry{fees: &txFeeData{fee: 30, vsize: 21000, sender: "b", nonce: 2}}
+	m.txEntries["tx4"] = txEntry{}
+	got := m.GetFeeEntries()
+	sort.Slice(got, func(i, j int) bool { return got[i].Txid < got[j].Txid })
+	want := []MempoolFeeEntry{
+		{Txid: "tx1", Fee: 10, VSize: 21000},
+		{Txid: "tx2", Fee: 20, VSize: 21000, Parents: []string{"tx1"}},
+		{Txid: "tx3", Fee: 30, VSize: 21000},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFeeEntries() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,171 @@
+package bchain
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// replacementEdge links a replaced mempool transaction to the transaction which replaced it
+type replacementEdge struct {
+	txid string
+	time time.Time
+}
+
+// senderNonce identifies the Ethereum type transactions which replace each other
+type senderNonce struct {
+	sender string
+	nonce  uint64
+}
+
+// mempoolReplacements keeps the replacements of the mempool transactions for the retention period.
+// The caller is responsible for locking!
+type mempoolReplacements struct {
+	retention    time.Duration
+	replacedBy   map[string]replacementEdge
+	replaces     map[string][]string
+	onTxReplaced OnTxReplacedFunc
+}
+
+func (r *mempoolReplacements) enabled() bool {
+	return r.retention > 0
+}
+
+func (r *mempoolReplacements) add(replaced, replacedBy string, t time.Time) {
+	if e, found := r.replacedBy[replaced]; found {
+		if e.txid == replacedBy {
+			return
+		}
+		r.removeReplaces(e.txid, replaced)
+	}
+	r.replacedBy[replaced] = replacementEdge{txid: replacedBy, time: t}
+	r.replaces[replacedBy] = append(r.replaces[replacedBy], replaced)
+}
+
+func (r *mempoolReplacements) removeReplaces(replacedBy, replaced string) {
+	replaces := r.replaces[replacedBy]
+	j := 0
+	for i := range replaces {
+		if replaces[i] != replaced {
+			replaces[j] = replaces[i]
+			j++
+		}
+	}
+	if j > 0 {
+		r.replaces[replacedBy] = replaces[:j]
+	} else {
+		delete(r.replaces, replacedBy)
+	}
+}
+
+// prune removes the replacements older than the retention period
+func (r *mempoolReplacements) prune(now time.Time) {
+	threshold := now.Add(-r.retention)
+	for txid, e := range r.replacedBy {
+		if e.time.Before(threshold) {
+			delete(r.replacedBy, txid)
+			r.removeReplaces(e.txid, txid)
+		}
+	}
+}
+
+// SetReplacementTracking enables tracking of the replaced mempool transactions, the replacements
+// are kept for the retention period, zero retention disables the tracking
+func (m *BaseMempool) SetReplacementTracking(retention time.Duration, onTxReplaced OnTxReplacedFunc) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.replacements = mempoolReplacements{
+		retention:    retention,
+		replacedBy:   make(map[string]replacementEdge),
+		replaces:     make(map[string][]string),
+		onTxReplaced: onTxReplaced,
+	}
+	if retention > 0 {
+		m.spentOutpoints = make(map[Outpoint]string)
+		m.nonceTxs = make(map[senderNonce]string)
+	} else {
+		m.spentOutpoints = nil
+		m.nonceTxs = nil
+	}
+}
+
+// GetTxReplacement returns the replacements of the transaction or nil if there are none
+func (m *BaseMempool) GetTxReplacement(txid string) *MempoolTxReplacement {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if !m.replacements.enabled() {
+		return nil
+	}
+	e, replaced := m.replacements.replacedBy[txid]
+	replaces := m.replacements.replaces[txid]
+	if !replaced && len(replaces) == 0 {
+		return nil
+	}
+	r := MempoolTxReplacement{ReplacedBy: e.txid}
+	if len(replaces) > 0 {
+		r.Replaces = append([]string(nil), replaces...)
+	}
+	return &r
+}
+
+// replaceTx records the replacement of a mempool transaction and returns the notification about it.
+// The caller is responsible for locking!
+func (m *BaseMempool) replaceTx(replaced, replacedBy string, now time.Time) *TxReplacement {
+	m.replacements.add(replaced, replacedBy, now)
+	glog.V(1).Info("mempool: tx ", replaced, " replaced by ", replacedBy)
+	r := TxReplacement{Txid: replaced, ReplacedBy: replacedBy}
+	if entry, found := m.txEntries[replaced]; found {
+		r.AddrDescs = entryAddrDescs(entry, false)
+		var paid []AddressDescriptor
+		if e, found := m.txEntries[replacedBy]; found {
+			paid = entryAddrDescs(e, true)
+		}
+		r.DoubleSpentAddrDescs = doubleSpentAddrDescs(entryAddrDescs(entry, true), paid)
+	}
+	return &r
+}
+
+// entryAddrDescs returns the distinct addresses of the mempool transaction, optionally only of its outputs
+func entryAddrDescs(entry txEntry, outputsOnly bool) []AddressDescriptor {
+	var addrDescs []AddressDescriptor
+	processed := make(map[string]struct{}, len(entry.addrIndexes))
+	for _, ai := range entry.addrIndexes {
+		if outputsOnly && ai.n < 0 {
+			continue
+		}
+		if _, p := processed[ai.addrDesc]; !p {
+			processed[ai.addrDesc] = struct{}{}
+			addrDescs = append(addrDescs, AddressDescriptor(ai.addrDesc))
+		}
+	}
+	return addrDescs
+}
+
+// doubleSpentAddrDescs returns the output addresses of a conflicted transaction which are not paid by the conflicting one
+func doubleSpentAddrDescs(outputs, conflictingOutputs []AddressDescriptor) []AddressDescriptor {
+	var addrDescs []AddressDescriptor
+	for _, a := range outputs {
+		paid := false
+		for _, c := range conflictingOutputs {
+			if bytes.Equal(a, c) {
+				paid = true
+				break
+			}
+		}
+		if !paid {
+			addrDescs = append(addrDescs, a)
+		}
+	}
+	return addrDescs
+}
+
+// notifyTxReplaced sends the notifications about the replaced transactions, it must be called without the lock
+func (m *BaseMempool) notifyTxReplaced(replacements []*TxReplacement) {
+	if m.replacements.onTxReplaced == nil {
+		return
+	}
+	for _, r := range replacements {
+		m.replacements.onTxReplaced(r)
+	}
+}
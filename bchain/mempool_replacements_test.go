@@ -0,0 +1,108 @@
+package bchain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMempoolBitcoinType_updateSpentOutpoints(t *testing.T) {
+	m := &MempoolBitcoinType{
+		BaseMempool: BaseMempool{
+			txEntries:    make(map[string]txEntry),
+			addrDescToTx: make(map[string][]Outpoint),
+		},
+	}
+	var notified []*TxReplacement
+	m.SetReplacementTracking(time.Hour, func(r *TxReplacement) { notified = append(notified, r) })
+	spendsOld := []Outpoint{{Txid: "in", Vout: 0}, {Txid: "in", Vout: 1}}
+	m.txEntries["old"] = txEntry{addrIndexes: []addrIndex{{addrDesc: "a1", n: 0}, {addrDesc: "a2", n: ^0}, {addrDesc: "a1", n: 1}}, spends: spendsOld}
+	if r := m.updateSpentOutpoints("old", spendsOld, nil); len(r) != 0 {
+		t.Fatalf("unexpected replacements %+v", r)
+	}
+	// the new transaction spends both outpoints of the old one, it must be reported only once
+	spendsNew := []Outpoint{{Txid: "in", Vout: 1}, {Txid: "in", Vout: 0}}
+	m.txEntries["new"] = txEntry{addrIndexes: []addrIndex{{addrDesc: "a3", n: 0}}, spends: spendsNew}
+	replaced := m.updateSpentOutpoints("new", spendsNew, nil)
+	m.notifyTxReplaced(replaced)
+	want := []*TxReplacement{{
+		Txid:                 "old",
+		ReplacedBy:           "new",
+		AddrDescs:            []AddressDescriptor{AddressDescriptor("a1"), AddressDescriptor("a2")},
+		DoubleSpentAddrDescs: []AddressDescriptor{AddressDescriptor("a1")},
+	}}
+	if !reflect.DeepEqual(notified, want) {
+		t.Errorf("notified = %+v, want %+v", notified, want)
+	}
+	if got := m.GetTxReplacement("old"); !reflect.DeepEqual(got, &MempoolTxReplacement{ReplacedBy: "new"}) {
+		t.Errorf("GetTxReplacement(old) = %+v", got)
+	}
+	if got := m.GetTxReplacement("new"); !reflect.DeepEqual(got, &MempoolTxReplacement{Replaces: []string{"old"}}) {
+		t.Errorf("GetTxReplacement(new) = %+v", got)
+	}
+	// removal of the replaced transaction keeps the outpoints of the new one
+	m.removeEntryFromMempool("old", m.txEntries["old"])
+	if len(m.spentOutpoints) != 2 || m.spentOutpoints[Outpoint{Txid: "in", Vout: 0}] != "new" {
+		t.Errorf("spentOutpoints = %+v", m.spentOutpoints)
+	}
+	m.removeEntryFromMempool("new", m.txEntries["new"])
+	if len(m.spentOutpoints) != 0 {
+		t.Errorf("spentOutpoints = %+v, want empty", m.spentOutpoints)
+	}
+	if got := m.GetTxReplacement("old"); got == nil {
+		t.Error("GetTxReplacement(old) = nil, the replacement must be kept after the removal from mempool")
+	}
+}
+
+func TestMempoolEthereumType_replaceSameNonceTx(t *testing.T) {
+	m := NewMempoolEthereumType(nil, time.Hour, false)
+	var notified []*TxReplacement
+	m.SetReplacementTracking(time.Hour, func(r *TxReplacement) { notified = append(notified, r) })
+	add := func(txid string, nonce uint64) *TxReplacement {
+		entry := txEntry{addrIndexes: []addrIndex{{addrDesc: "sender", n: ^0}}, fees: &txFeeData{sender: "0xsender", nonce: nonce}}
+		m.txEntries[txid] = entry
+		m.addrDescToTx["sender"] = append(m.addrDescToTx["sender"], Outpoint{txid, ^0})
+		return m.replaceSameNonceTx(txid, entry)
+	}
+	if r := add("tx1", 1); r != nil {
+		t.Fatalf("unexpected replacement %+v", r)
+	}
+	if r := add("tx2", 2); r != nil {
+		t.Fatalf("unexpected replacement %+v", r)
+	}
+	r := add("tx1b", 1)
+	want := &TxReplacement{Txid: "tx1", ReplacedBy: "tx1b", AddrDescs: []AddressDescriptor{AddressDescriptor("sender")}}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("replaceSameNonceTx() = %+v, want %+v", r, want)
+	}
+	if _, found := m.txEntries["tx1"]; found {
+		t.Error("replaced tx1 still in mempool")
+	}
+	if got := m.addrDescToTx["sender"]; !reflect.DeepEqual(got, []Outpoint{{"tx2", ^0}, {"tx1b", ^0}}) {
+		t.Errorf("addrDescToTx = %+v", got)
+	}
+	if got := m.GetTxReplacement("tx1b"); !reflect.DeepEqual(got, &MempoolTxReplacement{Replaces: []string{"tx1"}}) {
+		t.Errorf("GetTxReplacement(tx1b) = %+v", got)
+	}
+}
+
+func Test_mempoolReplacements_prune(t *testing.T) {
+	now := time.Now()
+	r := mempoolReplacements{
+		retention:  time.Hour,
+		replacedBy: make(map[string]replacementEdge),
+		replaces:   make(map[string][]string),
+	}
+	r.add("old1", "new", now.Add(-2*time.Hour))
+	r.add("old2", "new", now.Add(-time.Minute))
+	r.add("old3", "other", now.Add(-3*time.Hour))
+	r.prune(now)
+	wantReplacedBy := map[string]replacementEdge{"old2": {txid: "new", time: now.Add(-time.Minute)}}
+	if !reflect.DeepEqual(r.replacedBy, wantReplacedBy) {
+		t.Errorf("replacedBy = %+v, want %+v", r.replacedBy, wantReplacedBy)
+	}
+	wantReplaces := map[string][]string{"new": {"old2"}}
+	if !reflect.DeepEqual(r.replaces, wantReplaces) {
+		t.Errorf("replaces = %+v, want %+v", r.replaces, wantReplaces)
+	}
+}
@@ -0,0 +1,166 @@
+package bchain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// GetSnapshot returns the mempool transactions and the replacements in the form which can be persisted and loaded after a restart
+func (m *BaseMempool) GetSnapshot() *MempoolSnapshot {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	entries := make([]MempoolSnapshotEntry, 0, len(m.txEntries))
+	for txid, entry := range m.txEntries {
+		e := MempoolSnapshotEntry{
+			Txid:        txid,
+			Time:        entry.time,
+			Filter:      entry.filter,
+			AddrIndexes: make([]MempoolSnapshotAddrIndex, len(entry.addrIndexes)),
+			Spends:      entry.spends,
+		}
+		for i, ai := range entry.addrIndexes {
+			e.AddrIndexes[i] = MempoolSnapshotAddrIndex{AddrDesc: AddressDescriptor(ai.addrDesc), N: ai.n}
+		}
+		if f := entry.fees; f != nil {
+			e.Fees = &MempoolSnapshotFees{
+				Fee:                  f.fee,
+				VSize:                f.vsize,
+				Parents:              f.parents,
+				Sender:               f.sender,
+				Nonce:                f.nonce,
+				MaxFeePerGas:         f.maxFeePerGas,
+				MaxPriorityFeePerGas: f.maxPriorityFeePerGas,
+			}
+		}
+		entries = append(entries, e)
+	}
+	return &MempoolSnapshot{Entries: entries, Replacements: m.replacementsSnapshot()}
+}
+
+// replacementsSnapshot returns the tracked replacements in the order they were found.
+// The caller is responsible for locking!
+func (m *BaseMempool) replacementsSnapshot() []MempoolSnapshotReplacement {
+	if !m.replacements.enabled() || len(m.replacements.replacedBy) == 0 {
+		return nil
+	}
+	r := make([]MempoolSnapshotReplacement, 0, len(m.replacements.replacedBy))
+	for txid, e := range m.replacements.replacedBy {
+		r = append(r, MempoolSnapshotReplacement{Txid: txid, ReplacedBy: e.txid, Time: e.time.Unix()})
+	}
+	sort.Slice(r, func(i, j int) bool { return r[i].Time < r[j].Time })
+	return r
+}
+
+// loadReplacements adds the replacements from the snapshot which are within the retention period,
+// the replacements already tracked are kept
+func (m *BaseMempool) loadReplacements(replacements []MempoolSnapshotReplacement) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if !m.replacements.enabled() {
+		return
+	}
+	threshold := time.Now().Add(-m.replacements.retention)
+	for i := range replacements {
+		r := &replacements[i]
+		t := time.Unix(r.Time, 0)
+		if _, found := m.replacements.replacedBy[r.Txid]; found || t.Before(threshold) {
+			continue
+		}
+		m.replacements.add(r.Txid, r.ReplacedBy, t)
+	}
+}
+
+// loadSnapshot adds the transactions from the snapshot to the mempool, the transactions already
+// in the mempool are skipped. The OnNewTx callback is not called for the loaded transactions.
+// The golomb filters of the transactions are dropped unless keepFilters is set.
+func (m *BaseMempool) loadSnapshot(entries []MempoolSnapshotEntry, keepFilters bool) int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	loaded := 0
+	for i := range entries {
+		e := &entries[i]
+		if _, exists := m.txEntries[e.Txid]; exists || len(e.AddrIndexes) == 0 {
+			continue
+		}
+		entry := txEntry{
+			addrIndexes: make([]addrIndex, len(e.AddrIndexes)),
+			time:        e.Time,
+		}
+		if keepFilters {
+			entry.filter = e.Filter
+		}
+		for j, ai := range e.AddrIndexes {
+			entry.addrIndexes[j] = addrIndex{addrDesc: string(ai.AddrDesc), n: ai.N}
+		}
+		if m.spendsTracked() {
+			entry.spends = e.Spends
+		}
+		if m.spentOutpoints != nil {
+			for _, o := range e.Spends {
+				m.spentOutpoints[o] = e.Txid
+			}
+		}
+		if f := e.Fees; f != nil {
+			entry.fees = &txFeeData{
+				fee:                  f.Fee,
+				vsize:                f.VSize,
+				parents:              f.Parents,
+				sender:               f.Sender,
+				nonce:                f.Nonce,
+				maxFeePerGas:         f.MaxFeePerGas,
+				maxPriorityFeePerGas: f.MaxPriorityFeePerGas,
+			}
+			if m.nonceTxs != nil && f.Sender != "" {
+				m.nonceTxs[senderNonce{f.Sender, f.Nonce}] = e.Txid
+			}
+		}
+		m.txEntries[e.Txid] = entry
+		for _, ai := range entry.addrIndexes {
+			m.addrDescToTx[ai.addrDesc] = append(m.addrDescToTx[ai.addrDesc], Outpoint{e.Txid, ai.n})
+		}
+		loaded++
+	}
+	return loaded
+}
+
+// filterConfig identifies the parameters of the golomb filters computed by the mempool, empty if the filters are disabled
+func (m *MempoolBitcoinType) filterConfig() string {
+	if m.golombFilterP == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%s:%t", m.golombFilterP, m.filterScripts, m.useZeroedKey)
+}
+
+// GetSnapshot returns the mempool transactions and the replacements in the form which can be persisted and loaded after a restart
+func (m *MempoolBitcoinType) GetSnapshot() *MempoolSnapshot {
+	snapshot := m.BaseMempool.GetSnapshot()
+	snapshot.FilterConfig = m.filterConfig()
+	return snapshot
+}
+
+// LoadSnapshot adds the transactions and the replacements from the mempool snapshot to the mempool. It must be called
+// before the first Resync, which removes the transactions no longer in the mempool of the backend and keeps the first seen time of the others.
+// The golomb filters of the snapshot are loaded only if they were computed with the current filter parameters.
+func (m *MempoolBitcoinType) LoadSnapshot(snapshot *MempoolSnapshot) int {
+	keepFilters := snapshot.FilterConfig == m.filterConfig()
+	if !keepFilters {
+		glog.Infof("mempool: snapshot golomb filters dropped, computed with the parameters %q instead of %q", snapshot.FilterConfig, m.filterConfig())
+	}
+	m.loadReplacements(snapshot.Replacements)
+	return m.loadSnapshot(snapshot.Entries, keepFilters)
+}
+
+// LoadSnapshot adds the transactions and the replacements from the mempool snapshot to the mempool. The transactions
+// are loaded only if the mempool is reconciled with the backend on resync, otherwise the transactions confirmed
+// while Blockbook was not running would stay in the mempool until they time out.
+func (m *MempoolEthereumType) LoadSnapshot(snapshot *MempoolSnapshot) int {
+	m.loadReplacements(snapshot.Replacements)
+	if !m.queryBackendOnResync {
+		glog.Info("mempool: snapshot transactions not loaded, the mempool is not reconciled with the backend on resync")
+		return 0
+	}
+	return m.loadSnapshot(snapshot.Entries, false)
+}
@@ -0,0 +1,135 @@
+package bchain
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMempoolBitcoinType_LoadSnapshot(t *testing.T) {
+	newMempool := func() *MempoolBitcoinType {
+		m := &MempoolBitcoinType{
+			BaseMempool: BaseMempool{
+				txEntries:    make(map[string]txEntry),
+				addrDescToTx: make(map[string][]Outpoint),
+			},
+		}
+		m.SetReplacementTracking(time.Hour, nil)
+		return m
+	}
+	m := newMempool()
+	tx1 := txEntry{
+		addrIndexes: []addrIndex{{addrDesc: "a1", n: 0}, {addrDesc: "a2", n: ^0}},
+		time:        1700000000,
+		filter:      "0102",
+		fees:        &txFeeData{fee: 282, vsize: 141},
+		spends:      []Outpoint{{Txid: "in", Vout: 1}},
+	}
+	m.txEntries["tx1"] = tx1
+	m.addrDescToTx["a1"] = []Outpoint{{"tx1", 0}}
+	m.addrDescToTx["a2"] = []Outpoint{{"tx1", ^0}}
+	now := time.Now().Truncate(time.Second)
+	m.replacements.add("old", "tx1", now.Add(-time.Minute))
+	m.replacements.add("expired", "tx1", now.Add(-2*time.Hour))
+	snapshot := m.GetSnapshot()
+	wantReplacements := []MempoolSnapshotReplacement{
+		{Txid: "expired", ReplacedBy: "tx1", Time: now.Add(-2 * time.Hour).Unix()},
+		{Txid: "old", ReplacedBy: "tx1", Time: now.Add(-time.Minute).Unix()},
+	}
+	if !reflect.DeepEqual(snapshot.Replacements, wantReplacements) {
+		t.Errorf("GetSnapshot().Replacements = %+v, want %+v", snapshot.Replacements, wantReplacements)
+	}
+
+	l := newMempool()
+	// the transaction already in the mempool is not overwritten by the snapshot
+	current := txEntry{addrIndexes: []addrIndex{{addrDesc: "a3", n: 0}}, time: 1700000500}
+	l.txEntries["tx2"] = current
+	l.addrDescToTx["a3"] = []Outpoint{{"tx2", 0}}
+	snapshot.Entries = append(snapshot.Entries, MempoolSnapshotEntry{Txid: "tx2", Time: 1, AddrIndexes: []MempoolSnapshotAddrIndex{{AddrDesc: AddressDescriptor("a1"), N: 1}}})
+	if n := l.LoadSnapshot(snapshot); n != 1 {
+		t.Fatalf("LoadSnapshot() = %d, want 1", n)
+	}
+	if !reflect.DeepEqual(l.txEntries["tx1"], tx1) {
+		t.Errorf("txEntries[tx1] = %+v, want %+v", l.txEntries["tx1"], tx1)
+	}
+	if !reflect.DeepEqual(l.txEntries["tx2"], current) {
+		t.Errorf("txEntries[tx2] = %+v, want %+v", l.txEntries["tx2"], current)
+	}
+	wantAddrDescToTx := map[string][]Outpoint{"a1": {{"tx1", 0}}, "a2": {{"tx1", ^0}}, "a3": {{"tx2", 0}}}
+	if !reflect.DeepEqual(l.addrDescToTx, wantAddrDescToTx) {
+		t.Errorf("addrDescToTx = %+v, want %+v", l.addrDescToTx, wantAddrDescToTx)
+	}
+	if got := l.spentOutpoints[Outpoint{Txid: "in", Vout: 1}]; got != "tx1" {
+		t.Errorf("spentOutpoints = %+v", l.spentOutpoints)
+	}
+	if got := l.GetTransactionTime("tx1"); got != 1700000000 {
+		t.Errorf("GetTransactionTime(tx1) = %d, want the first seen time from the snapshot", got)
+	}
+	// the replacements older than the retention period are not loaded
+	if r := l.GetTxReplacement("old"); r == nil || r.ReplacedBy != "tx1" {
+		t.Errorf("GetTxReplacement(old) = %+v, want replaced by tx1", r)
+	}
+	if r := l.GetTxReplacement("expired"); r != nil {
+		t.Errorf("GetTxReplacement(expired) = %+v, want nil", r)
+	}
+	if r := l.GetTxReplacement("tx1"); r == nil || !reflect.DeepEqual(r.Replaces, []string{"old"}) {
+		t.Errorf("GetTxReplacement(tx1) = %+v, want replaces old", r)
+	}
+
+	// the golomb filters computed with other parameters are dropped
+	f := newMempool()
+	f.golombFilterP = 20
+	f.filterScripts = "taproot"
+	if n := f.LoadSnapshot(snapshot); n != 2 {
+		t.Fatalf("LoadSnapshot() = %d, want 2", n)
+	}
+	if got := f.txEntries["tx1"].filter; got != "" {
+		t.Errorf("txEntries[tx1].filter = %q, want dropped", got)
+	}
+	if got := f.GetSnapshot().FilterConfig; got != "20:taproot:false" {
+		t.Errorf("GetSnapshot().FilterConfig = %q", got)
+	}
+
+	// the spends are kept for the dropped transaction checks also without the replacement tracking
+	d := &MempoolBitcoinType{
+		BaseMempool: BaseMempool{
+			txEntries:    make(map[string]txEntry),
+			addrDescToTx: make(map[string][]Outpoint),
+		},
+	}
+	d.SetDroppedTracking(time.Hour, func(txid string, spends []Outpoint) TxDroppedCheck { return TxDroppedCheck{} }, nil)
+	if n := d.LoadSnapshot(snapshot); n != 2 {
+		t.Fatalf("LoadSnapshot() = %d, want 2", n)
+	}
+	if got := d.txEntries["tx1"].spends; !reflect.DeepEqual(got, tx1.spends) {
+		t.Errorf("txEntries[tx1].spends = %+v, want %+v", got, tx1.spends)
+	}
+	if d.spentOutpoints != nil {
+		t.Errorf("spentOutpoints = %+v, want nil", d.spentOutpoints)
+	}
+}
+
+func TestMempoolEthereumType_LoadSnapshot(t *testing.T) {
+	snapshot := &MempoolSnapshot{Entries: []MempoolSnapshotEntry{{
+		Txid:        "0x01",
+		Time:        1700000000,
+		AddrIndexes: []MempoolSnapshotAddrIndex{{AddrDesc: AddressDescriptor("sender"), N: ^0}},
+		Fees:        &MempoolSnapshotFees{Sender: "0xsender", Nonce: 3, MaxFeePerGas: big.NewInt(2), MaxPriorityFeePerGas: big.NewInt(1)},
+	}}}
+	m := NewMempoolEthereumType(nil, time.Hour, false)
+	if n := m.LoadSnapshot(snapshot); n != 0 {
+		t.Errorf("LoadSnapshot() = %d, want 0 without the reconciliation with the backend", n)
+	}
+	m = NewMempoolEthereumType(nil, time.Hour, true)
+	m.SetReplacementTracking(time.Hour, nil)
+	if n := m.LoadSnapshot(snapshot); n != 1 {
+		t.Fatalf("LoadSnapshot() = %d, want 1", n)
+	}
+	if got := m.nonceTxs[senderNonce{"0xsender", 3}]; got != "0x01" {
+		t.Errorf("nonceTxs = %+v", m.nonceTxs)
+	}
+	if got := m.GetSnapshot(); !reflect.DeepEqual(got, snapshot) {
+		t.Errorf("GetSnapshot() = %+v, want %+v", got, snapshot)
+	}
+}
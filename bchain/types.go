@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/trezor/blockbook/common"
 )
@@ -192,6 +193,23 @@ type MempoolEntry struct {
 	AncestorSize    uint32            `json:"ancestorsize" ts_doc:"Total size of all ancestor transactions in bytes."`
 	AncestorFees    uint32            `json:"ancestorfees" ts_doc:"Combined fees of all ancestor transactions."`
 	Depends         []string          `json:"depends" ts_doc:"List of txids this transaction depends on."`
+	// fields returned by the newer backends, the fees of the packages are in Fees instead of the deprecated fields
+	VSize             uint32            `json:"vsize"`
+	Weight            uint32            `json:"weight"`
+	Fees              *MempoolEntryFees `json:"fees,omitempty"`
+	SpentBy           []string          `json:"spentby"`
+	Bip125Replaceable bool              `json:"bip125-replaceable"`
+	// AncestorFeesSat and DescendantFeesSat are the fees of the packages including the transaction in satoshi/base units
+	AncestorFeesSat   big.Int
+	DescendantFeesSat big.Int
+}
+
+// MempoolEntryFees holds the fees of a mempool entry in coins, as returned by the newer backends
+type MempoolEntryFees struct {
+	Base       common.JSONNumber `json:"base"`
+	Modified   common.JSONNumber `json:"modified"`
+	Ancestor   common.JSONNumber `json:"ancestor"`
+	Descendant common.JSONNumber `json:"descendant"`
 }
 
 // ChainInfo is used to get information about blockchain
@@ -217,6 +235,19 @@ type LongTermFeeRate struct {
 	Blocks     uint64  `json:"blocks" ts_doc:"Amount of blocks used for the long term fee rate estimation."`
 }
 
+// MempoolFeeEstimate is a fee rate estimate for a confirmation target computed from the projected blocks of the mempool
+// and the fee rates of the recently confirmed blocks, the fee rates are in sat/kByte
+type MempoolFeeEstimate struct {
+	Blocks int
+	// Low is the fee rate of the cheapest transactions fitting into the projected blocks
+	Low big.Int
+	// Medium is the fee rate of the 25th percentile of the target projected block
+	Medium big.Int
+	// High is the median fee rate of the target projected block, at least the fee rate
+	// which recently got the cheapest transactions into the confirmed blocks
+	High big.Int
+}
+
 // RPCError defines rpc error returned by backend
 type RPCError struct {
 	Code    int    `json:"code" ts_doc:"Error code returned by the backend RPC."`
@@ -291,6 +322,121 @@ type MempoolTxidFilterEntries struct {
 	UsedZeroedKey bool              `json:"usedZeroedKey,omitempty" ts_doc:"Indicates if a zeroed key was used in filter calculation."`
 }
 
+// MempoolTxReplacement describes the replacements of a mempool transaction
+type MempoolTxReplacement struct {
+	// ReplacedBy is the transaction which replaced the transaction
+	ReplacedBy string
+	// Replaces are the transactions replaced by the transaction
+	Replaces []string
+}
+
+// TxReplacement is the notification about a mempool transaction replaced by another one
+type TxReplacement struct {
+	Txid       string
+	ReplacedBy string
+	// AddrDescs are the addresses of the replaced transaction
+	AddrDescs []AddressDescriptor
+	// DoubleSpentAddrDescs are the output addresses of the replaced transaction not paid by the replacing one
+	DoubleSpentAddrDescs []AddressDescriptor
+}
+
+// MempoolTxDropped describes a transaction which left the mempool without being confirmed
+type MempoolTxDropped struct {
+	// Time is the unix time when the transaction was found dropped
+	Time int64
+	// ConflictingTxid is the confirmed transaction spending the same inputs, if known
+	ConflictingTxid string
+}
+
+// TxDropped is the notification about a transaction which left the mempool without being confirmed
+type TxDropped struct {
+	Txid            string
+	ConflictingTxid string
+	// AddrDescs are the addresses of the dropped transaction
+	AddrDescs []AddressDescriptor
+	// DoubleSpentAddrDescs are the output addresses of the dropped transaction not paid by the conflicting one
+	DoubleSpentAddrDescs []AddressDescriptor
+}
+
+// DisconnectedTx is a transaction of a block disconnected from the index by a reorg
+type DisconnectedTx struct {
+	Txid string
+	// AddrDescs are the addresses affected by the transaction
+	AddrDescs []AddressDescriptor
+}
+
+// DisconnectedBlock is a block disconnected from the index by a reorg
+type DisconnectedBlock struct {
+	Height uint32
+	Hash   string
+	Txs    []DisconnectedTx
+}
+
+// MempoolAccountTx is a mempool transaction sent by an Ethereum type account
+type MempoolAccountTx struct {
+	Txid string
+	// Time is the unix time when the transaction was first seen in the mempool
+	Time                 uint32
+	Nonce                uint64
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// MempoolSnapshotAddrIndex is an address of a mempool transaction in the mempool snapshot,
+// N is the output index, the input index is stored as its bitwise complement
+type MempoolSnapshotAddrIndex struct {
+	AddrDesc AddressDescriptor
+	N        int32
+}
+
+// MempoolSnapshotFees are the fee data of a mempool transaction in the mempool snapshot
+type MempoolSnapshotFees struct {
+	Fee                  int64
+	VSize                int64
+	Parents              []string
+	Sender               string
+	Nonce                uint64
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// MempoolSnapshotEntry is a mempool transaction in the mempool snapshot persisted across restarts
+type MempoolSnapshotEntry struct {
+	Txid        string
+	Time        uint32
+	Filter      string
+	AddrIndexes []MempoolSnapshotAddrIndex
+	Spends      []Outpoint
+	Fees        *MempoolSnapshotFees
+}
+
+// MempoolSnapshotReplacement is a replacement of a mempool transaction in the mempool snapshot
+type MempoolSnapshotReplacement struct {
+	Txid       string
+	ReplacedBy string
+	// Time is the unix time when the replacement was found
+	Time int64
+}
+
+// MempoolSnapshot is the state of the mempool persisted across restarts
+type MempoolSnapshot struct {
+	// FilterConfig identifies the parameters the golomb filters of the entries were computed with
+	FilterConfig string
+	Entries      []MempoolSnapshotEntry
+	Replacements []MempoolSnapshotReplacement
+}
+
+// TxDroppedCheck is the result of the check of a transaction which left the mempool
+type TxDroppedCheck struct {
+	// Dropped is false if the transaction was confirmed or is still known to the backend
+	Dropped bool
+	// Postpone requests the repeated check, for example if the index is not synchronized
+	Postpone        bool
+	ConflictingTxid string
+	// ConflictingAddrDescs are the output addresses of the conflicting transaction
+	ConflictingAddrDescs []AddressDescriptor
+}
+
 // ENSResolution represents the result of resolving an ENS name to an Ethereum address.
 type ENSResolution struct {
 	Name    string `json:"name"`
@@ -301,9 +447,21 @@ type ENSResolution struct {
 // OnNewBlockFunc is used to send notification about a new block
 type OnNewBlockFunc func(block *Block)
 
+// OnDisconnectBlocksFunc is used to send notification about the blocks disconnected by a reorg, ordered from the highest
+type OnDisconnectBlocksFunc func(blocks []DisconnectedBlock)
+
 // OnNewTxFunc is used to send notification about a new transaction/address
 type OnNewTxFunc func(tx *MempoolTx)
 
+// OnTxReplacedFunc is used to send notification about a mempool transaction replaced by another one
+type OnTxReplacedFunc func(r *TxReplacement)
+
+// OnTxDroppedFunc is used to send notification about a transaction which left the mempool without being confirmed
+type OnTxDroppedFunc func(d *TxDropped)
+
+// TxDroppedCheckFunc checks if a transaction which left the mempool was dropped, spends are its inputs (Bitcoin type)
+type TxDroppedCheckFunc func(txid string, spends []Outpoint) TxDroppedCheck
+
 // AddrDescForOutpointFunc returns address descriptor and value for given outpoint or nil if outpoint not found
 type AddrDescForOutpointFunc func(outpoint Outpoint) (AddressDescriptor, *big.Int)
 
@@ -345,6 +503,7 @@ type BlockChain interface {
 	EstimateSmartFee(blocks int, conservative bool) (big.Int, error)
 	EstimateFee(blocks int) (big.Int, error)
 	LongTermFeeRate() (*LongTermFeeRate, error)
+	EstimateMempoolFee(blocks int) (*MempoolFeeEstimate, error)
 	SendRawTransaction(tx string, disableAlternativeRPC bool) (string, error)
 	GetMempoolEntry(txid string) (*MempoolEntry, error)
 	GetContractInfo(contractDesc AddressDescriptor) (*ContractInfo, error)
@@ -353,7 +512,9 @@ type BlockChain interface {
 	// EthereumType specific
 	EthereumTypeGetBalance(addrDesc AddressDescriptor) (*big.Int, error)
 	EthereumTypeGetNonces(addrDesc AddressDescriptor, withConfirmed bool) (pending uint64, confirmed uint64, confirmedOK bool, err error)
+	EthereumTypeGetDelegation(addrDesc AddressDescriptor) (string, error)
 	EthereumTypeEstimateGas(params map[string]interface{}) (uint64, error)
+	EthereumTypeEstimateL1Fee(params map[string]interface{}) (*EthereumL1FeeEstimate, error)
 	EthereumTypeGetEip1559Fees() (*Eip1559Fees, error)
 	EthereumTypeGetErc20ContractBalance(addrDesc, contractDesc AddressDescriptor) (*big.Int, error)
 	EthereumTypeGetErc20ContractBalances(addrDesc AddressDescriptor, contractDescs []AddressDescriptor) ([]*big.Int, error)
@@ -362,6 +523,7 @@ type BlockChain interface {
 	EthereumTypeRpcCall(data, to, from string) (string, error)
 	EthereumTypeGetRawTransaction(txid string) (string, error)
 	EthereumTypeGetTransactionReceipt(txid string) (*RpcReceipt, error)
+	EthereumTypeSimulateTx(params map[string]interface{}, block string) (*EthereumSimulatedTx, error)
 	GetTokenURI(contractDesc AddressDescriptor, tokenID *big.Int) (string, error)
 }
 
@@ -411,6 +573,7 @@ type BlockChainParser interface {
 	DeriveAddressDescriptorsFromTo(descriptor *XpubDescriptor, change uint32, fromIndex uint32, toIndex uint32) ([]AddressDescriptor, error)
 	// EthereumType specific
 	EthereumTypeGetTokenTransfersFromTx(tx *Tx) (TokenTransfers, error)
+	EthereumTypeGetUserOperationsFromTx(tx *Tx) ([]EthereumUserOperation, error)
 	GetEthereumTxData(tx *Tx) *EthereumTxData
 	GetChainExtraPayloadType() ChainExtraPayloadType
 	GetChainExtraData(tx *Tx) (json.RawMessage, error)
@@ -427,6 +590,14 @@ type Mempool interface {
 	GetAllEntries() MempoolTxidEntries
 	GetTransactionTime(txid string) uint32
 	GetTxidFilterEntries(filterScripts string, fromTimestamp uint32) (MempoolTxidFilterEntries, error)
+	GetFeeEntries() []MempoolFeeEntry
+	SetReplacementTracking(retention time.Duration, onTxReplaced OnTxReplacedFunc)
+	GetTxReplacement(txid string) *MempoolTxReplacement
+	SetDroppedTracking(retention time.Duration, check TxDroppedCheckFunc, onTxDropped OnTxDroppedFunc)
+	GetTxDropped(txid string) *MempoolTxDropped
+	GetSnapshot() *MempoolSnapshot
+	GetAccountTxs(addrDesc AddressDescriptor) []MempoolAccountTx
+	LoadSnapshot(snapshot *MempoolSnapshot) int
 }
 
 // MissingBlockRetry is the JSON wire shape for per-chain overrides of the
@@ -3,6 +3,7 @@ package bchain
 import (
 	"encoding/json"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 )
@@ -37,6 +38,30 @@ type FourByteSignature struct {
 	ParsedParameters []abi.Type `ts_doc:"ABI-parsed parameter types (cached for efficiency)."`
 }
 
+// FourByteSignaturesState contains the persisted progress of the 4byte signatures import
+type FourByteSignaturesState struct {
+	Count            int64     `json:"count" ts_doc:"Number of signatures stored in the database."`
+	LastId           uint32    `json:"lastId" ts_doc:"Highest signature id up to which the remote source is fully downloaded."`
+	ResumeURL        string    `json:"resumeUrl,omitempty" ts_doc:"Next page of an interrupted download, the next refresh continues from it."`
+	ResumeTopId      uint32    `json:"resumeTopId,omitempty" ts_doc:"Highest signature id seen by the interrupted download."`
+	LastUpdate       time.Time `json:"lastUpdate" ts_doc:"Time of the last change of the stored signatures."`
+	LastBundleImport time.Time `json:"lastBundleImport" ts_doc:"Time of the last import of a signatures bundle from disk."`
+}
+
+// EthereumSimulatedTx contains the result of a transaction simulated against a block without broadcasting it
+type EthereumSimulatedTx struct {
+	Traced            bool                       `ts_doc:"True if the simulation was traced, false if only eth_call was available (no transfers)."`
+	Status            TxStatus                   `ts_doc:"Execution status of the simulated transaction (1: success, 0: fail)."`
+	Error             string                     `ts_doc:"Revert reason or execution error of a failed simulation."`
+	GasUsed           uint64                     `ts_doc:"Gas used by the simulated execution (an eth_estimateGas estimate if not traced)."`
+	From              string                     `ts_doc:"Sender of the simulated transaction."`
+	To                string                     `ts_doc:"Recipient of the simulated transaction."`
+	Value             big.Int                    `ts_doc:"Value sent by the simulated transaction."`
+	CreatedContract   string                     `ts_doc:"Address of the contract the simulated transaction would create."`
+	InternalTransfers []EthereumInternalTransfer `ts_doc:"Internal transfers of the simulated execution."`
+	TokenTransfers    TokenTransfers             `ts_doc:"Token transfers decoded from the logs of the simulated execution."`
+}
+
 // EthereumParsedInputParam contains data about a contract function parameter
 type EthereumParsedInputParam struct {
 	Type   string   `json:"type" ts_doc:"Parameter type (e.g. 'uint256')."`
@@ -142,28 +167,72 @@ type TokenTransfer struct {
 	MultiTokenValues []MultiTokenValue `ts_doc:"List of ID-value pairs for multi-token transfers (e.g., ERC1155)."`
 }
 
+// EthereumUserOperation is an ERC-4337 UserOperation executed by an EntryPoint contract
+type EthereumUserOperation struct {
+	UserOpHash    string  `ts_doc:"Hash of the UserOperation."`
+	EntryPoint    string  `ts_doc:"EntryPoint contract which executed the UserOperation."`
+	Sender        string  `ts_doc:"Smart account which sent the UserOperation."`
+	Paymaster     string  `ts_doc:"Paymaster which paid for the UserOperation, zero address if none."`
+	Nonce         big.Int `ts_doc:"Nonce of the UserOperation."`
+	Success       bool    `ts_doc:"Whether the execution of the UserOperation succeeded."`
+	ActualGasCost big.Int `ts_doc:"Actual gas cost paid for the UserOperation (in Wei)."`
+	ActualGasUsed big.Int `ts_doc:"Actual gas used by the UserOperation."`
+	CallData      string  `ts_doc:"Call data executed by the smart account, decoded from the handleOps call."`
+}
+
 // RpcTransaction is returned by eth_getTransactionByHash
 type RpcTransaction struct {
-	AccountNonce         string `json:"nonce" ts_doc:"Transaction nonce from the sender's account."`
-	GasPrice             string `json:"gasPrice" ts_doc:"Gas price bid by the sender in Wei."`
-	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
-	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
-	BaseFeePerGas        string `json:"baseFeePerGas,omitempty"`
-	GasLimit             string `json:"gas" ts_doc:"Maximum gas allowed for this transaction."`
-	To                   string `json:"to" ts_doc:"Recipient address if not a contract creation. Empty if it's contract creation."`
-	Value                string `json:"value" ts_doc:"Amount of Ether (in Wei) sent in this transaction."`
-	Payload              string `json:"input" ts_doc:"Hex-encoded input data for contract calls."`
-	Hash                 string `json:"hash" ts_doc:"Transaction hash."`
-	BlockNumber          string `json:"blockNumber" ts_doc:"Block number where this transaction was included, if mined."`
-	BlockHash            string `json:"blockHash,omitempty" ts_doc:"Hash of the block in which this transaction was included, if mined."`
-	From                 string `json:"from" ts_doc:"Sender's address derived by the backend."`
-	TransactionIndex     string `json:"transactionIndex" ts_doc:"Index of the transaction within the block, if mined."`
+	AccountNonce         string             `json:"nonce" ts_doc:"Transaction nonce from the sender's account."`
+	GasPrice             string             `json:"gasPrice" ts_doc:"Gas price bid by the sender in Wei."`
+	MaxPriorityFeePerGas string             `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         string             `json:"maxFeePerGas,omitempty"`
+	BaseFeePerGas        string             `json:"baseFeePerGas,omitempty"`
+	GasLimit             string             `json:"gas" ts_doc:"Maximum gas allowed for this transaction."`
+	To                   string             `json:"to" ts_doc:"Recipient address if not a contract creation. Empty if it's contract creation."`
+	Value                string             `json:"value" ts_doc:"Amount of Ether (in Wei) sent in this transaction."`
+	Payload              string             `json:"input" ts_doc:"Hex-encoded input data for contract calls."`
+	Hash                 string             `json:"hash" ts_doc:"Transaction hash."`
+	BlockNumber          string             `json:"blockNumber" ts_doc:"Block number where this transaction was included, if mined."`
+	BlockHash            string             `json:"blockHash,omitempty" ts_doc:"Hash of the block in which this transaction was included, if mined."`
+	From                 string             `json:"from" ts_doc:"Sender's address derived by the backend."`
+	TransactionIndex     string             `json:"transactionIndex" ts_doc:"Index of the transaction within the block, if mined."`
+	AuthorizationList    []RpcAuthorization `json:"authorizationList,omitempty" ts_doc:"EIP-7702 authorizations of a set-code (type 4) transaction."`
 	// Signature values - ignored
 	// V string `json:"v"`
 	// R string `json:"r"`
 	// S string `json:"s"`
 }
 
+// RpcAuthorization is an EIP-7702 authorization tuple of a set-code transaction
+type RpcAuthorization struct {
+	ChainID string `json:"chainId" ts_doc:"Chain ID the authorization is valid for, 0 for any chain."`
+	Address string `json:"address" ts_doc:"Address of the code the authority delegates to."`
+	Nonce   string `json:"nonce" ts_doc:"Nonce of the authority account."`
+	YParity string `json:"yParity" ts_doc:"Signature y parity."`
+	R       string `json:"r" ts_doc:"Signature r value."`
+	S       string `json:"s" ts_doc:"Signature s value."`
+	// Authority is not returned by the backends, it is recovered from the signature by the parser
+	Authority string `json:"authority,omitempty" ts_doc:"Account which signed the authorization, empty if the signature is invalid."`
+}
+
+// RpcWithdrawal is an EIP-4895 beacon chain withdrawal returned in the block body
+type RpcWithdrawal struct {
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validatorIndex"`
+	Address        string `json:"address"`
+	// Amount is in Gwei
+	Amount string `json:"amount"`
+}
+
+// EthereumWithdrawal is an EIP-4895 beacon chain withdrawal, it credits the amount to the address without a transaction
+type EthereumWithdrawal struct {
+	Index          uint64
+	ValidatorIndex uint64
+	Address        string
+	// Amount is in Wei
+	Amount big.Int
+}
+
 // RpcLog is returned by eth_getLogs
 type RpcLog struct {
 	Address string   `json:"address" ts_doc:"Contract or address from which this log originated."`
@@ -173,15 +242,19 @@ type RpcLog struct {
 
 // RpcReceipt is returned by eth_getTransactionReceipt
 type RpcReceipt struct {
-	GasUsed           string    `json:"gasUsed" ts_doc:"Amount of gas actually used by the transaction."`
-	EffectiveGasPrice string    `json:"effectiveGasPrice,omitempty" ts_doc:"Actual gas price paid per gas unit; on L2 networks this differs from the transaction gasPrice bid."`
-	Status            string    `json:"status" ts_doc:"Transaction execution status (0x0 = fail, 0x1 = success)."`
-	Logs              []*RpcLog `json:"logs" ts_doc:"Array of log entries generated by this transaction."`
-	L1Fee             string    `json:"l1Fee,omitempty" ts_doc:"Additional Layer 1 fee, if on a rollup network."`
-	L1FeeScalar       string    `json:"l1FeeScalar,omitempty" ts_doc:"Fee scaling factor for L1 fees on some L2s."`
-	L1GasPrice        string    `json:"l1GasPrice,omitempty" ts_doc:"Gas price used on L1 for the rollup network."`
-	L1GasUsed         string    `json:"l1GasUsed,omitempty" ts_doc:"Amount of L1 gas used by the transaction, if any."`
-	ContractAddress   string    `json:"contractAddress,omitempty"`
+	GasUsed             string    `json:"gasUsed" ts_doc:"Amount of gas actually used by the transaction."`
+	EffectiveGasPrice   string    `json:"effectiveGasPrice,omitempty" ts_doc:"Actual gas price paid per gas unit; on L2 networks this differs from the transaction gasPrice bid."`
+	Status              string    `json:"status" ts_doc:"Transaction execution status (0x0 = fail, 0x1 = success)."`
+	Logs                []*RpcLog `json:"logs" ts_doc:"Array of log entries generated by this transaction."`
+	L1Fee               string    `json:"l1Fee,omitempty" ts_doc:"Additional Layer 1 fee, if on a rollup network."`
+	L1FeeScalar         string    `json:"l1FeeScalar,omitempty" ts_doc:"Fee scaling factor for L1 fees on some L2s."`
+	L1GasPrice          string    `json:"l1GasPrice,omitempty" ts_doc:"Gas price used on L1 for the rollup network."`
+	L1GasUsed           string    `json:"l1GasUsed,omitempty" ts_doc:"Amount of L1 gas used by the transaction, if any."`
+	L1BaseFeeScalar     string    `json:"l1BaseFeeScalar,omitempty" ts_doc:"Scalar applied to the L1 base fee on OP stack networks."`
+	L1BlobBaseFee       string    `json:"l1BlobBaseFee,omitempty" ts_doc:"L1 blob base fee used for the L1 data fee on OP stack networks."`
+	L1BlobBaseFeeScalar string    `json:"l1BlobBaseFeeScalar,omitempty" ts_doc:"Scalar applied to the L1 blob base fee on OP stack networks."`
+	GasUsedForL1        string    `json:"gasUsedForL1,omitempty" ts_doc:"Part of gasUsed paying for the L1 data posting on Arbitrum, already included in gasUsed."`
+	ContractAddress     string    `json:"contractAddress,omitempty"`
 }
 
 // TxStatus is status of transaction.
@@ -210,6 +283,10 @@ type EthereumTxData struct {
 	L1FeeScalar          string   `json:"l1FeeScalar,omitempty"`
 	L1GasPrice           *big.Int `json:"l1GasPrice,omitempty"`
 	L1GasUsed            *big.Int `json:"L1GasUsed,omitempty"`
+	L1BaseFeeScalar      *big.Int `json:"l1BaseFeeScalar,omitempty"`
+	L1BlobBaseFee        *big.Int `json:"l1BlobBaseFee,omitempty"`
+	L1BlobBaseFeeScalar  *big.Int `json:"l1BlobBaseFeeScalar,omitempty"`
+	GasUsedForL1         *big.Int `json:"gasUsedForL1,omitempty"`
 	Data                 string   `json:"data"`
 }
 
@@ -233,6 +310,7 @@ type EthereumBlockSpecificData struct {
 	InternalDataError   string               `ts_doc:"Error message for processing block internal data, if any."`
 	AddressAliasRecords []AddressAliasRecord `ts_doc:"List of address-to-alias mappings discovered in this block."`
 	Contracts           []ContractInfo       `ts_doc:"List of contracts created or updated in this block."`
+	Withdrawals         []EthereumWithdrawal `ts_doc:"EIP-4895 beacon chain withdrawals of this block."`
 	// GasUsed/GasLimit/BaseFeePerGas are transient block-level EIP-1559 figures used for the new-block
 	// notification; they are not persisted (storeBlockSpecificDataEthereumType ignores them).
 	GasUsed       *big.Int `json:"-"`
@@ -259,6 +337,8 @@ type Eip1559Fee struct {
 	MaxPriorityFeePerGas *big.Int `json:"maxPriorityFeePerGas"`
 	MinWaitTimeEstimate  int      `json:"minWaitTimeEstimate,omitempty"`
 	MaxWaitTimeEstimate  int      `json:"maxWaitTimeEstimate,omitempty"`
+	// MaxFeePerBlobGas is set for the chains with blob transactions (type 3)
+	MaxFeePerBlobGas *big.Int `json:"maxFeePerBlobGas,omitempty"`
 }
 
 // Eip1559Fees
@@ -274,4 +354,13 @@ type Eip1559Fees struct {
 	HistoricalBaseFeeRange     []*big.Int  `json:"historicalBaseFeeRange,omitempty"`
 	PriorityFeeTrend           string      `json:"priorityFeeTrend,omitempty"`
 	BaseFeeTrend               string      `json:"baseFeeTrend,omitempty"`
+	BaseFeePerBlobGas          *big.Int    `json:"baseFeePerBlobGas,omitempty"`
+}
+
+// EthereumL1FeeEstimate is the estimated L1 data fee of a transaction on a rollup network
+type EthereumL1FeeEstimate struct {
+	L1Fee *big.Int `json:"l1Fee,omitempty"`
+	// GasUsedForL1 is set by rollups which charge the L1 data fee as a part of the L2 gas (Arbitrum),
+	// the L1Fee is then already included in the gas estimate and must not be added to it again
+	GasUsedForL1 *big.Int `json:"gasUsedForL1,omitempty"`
 }
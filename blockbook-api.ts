@@ -128,12 +128,56 @@ export interface EthereumSpecific {
     l1GasPrice?: string;
     /** Amount of gas used in L1 for this tx, if applicable. */
     l1GasUsed?: number;
+    /** Scalar applied to the L1 base fee on OP stack networks. */
+    l1BaseFeeScalar?: number;
+    /** L1 blob base fee used for the L1 data fee on OP stack networks. */
+    l1BlobBaseFee?: string;
+    /** Scalar applied to the L1 blob base fee on OP stack networks. */
+    l1BlobBaseFeeScalar?: number;
+    /** Part of gasUsed paying for the L1 data posting on Arbitrum, already included in the fee of gasUsed. */
+    gasUsedForL1?: number;
     /** Hex-encoded input data for the transaction. */
     data?: string;
     /** Decoded transaction data (function name, params, etc.). */
     parsedData?: EthereumParsedInputData;
     /** List of internal (sub-call) transfers. */
     internalTransfers?: EthereumInternalTransfer[];
+    /** ERC-4337 UserOperations executed by the transaction (bundled by an EntryPoint contract). */
+    userOperations?: EthereumUserOperation[];
+    /** EIP-7702 authorizations of a set-code (type 4) transaction. */
+    authorizationList?: EthereumAuthorization[];
+}
+export interface EthereumAuthorization {
+    /** Chain ID the authorization is valid for, 0 for any chain. */
+    chainId: string;
+    /** Address of the code the authority delegates to, the zero address clears the delegation. */
+    address: string;
+    /** Nonce of the authority account. */
+    nonce: number;
+    /** Account which signed the authorization, omitted if the signature is invalid. */
+    authority?: string;
+}
+export interface EthereumUserOperation {
+    /** Hash of the UserOperation. */
+    userOpHash: string;
+    /** EntryPoint contract which executed the UserOperation. */
+    entryPoint: string;
+    /** Smart account which sent the UserOperation. */
+    sender: string;
+    /** Paymaster which paid for the UserOperation, omitted if the sender paid. */
+    paymaster?: string;
+    /** Nonce of the UserOperation. */
+    nonce: string;
+    /** Whether the execution of the UserOperation succeeded. */
+    success: boolean;
+    /** Actual gas cost paid for the UserOperation (in Wei). */
+    actualGasCost: string;
+    /** Actual gas used by the UserOperation. */
+    actualGasUsed: string;
+    /** Call data executed by the smart account, if the transaction calls the EntryPoint directly. */
+    callData?: string;
+    /** Decoded call data of the UserOperation. */
+    parsedData?: EthereumParsedInputData;
 }
 export interface MultiTokenValue {
     /** Token ID (for ERC1155). */
@@ -212,6 +256,38 @@ export interface Vin {
     /** Data for coinbase inputs (when mining). */
     coinbase?: string;
 }
+export interface MempoolPackage {
+    /** Virtual size of the transaction. */
+    vsize: number;
+    /** Fee of the transaction in satoshi. */
+    fees?: string;
+    /** Fee rate of the transaction in sat/vB. */
+    feeRate: number;
+    /** Number of in-mempool ancestors including the transaction. */
+    ancestorCount: number;
+    /** Virtual size of the in-mempool ancestors including the transaction. */
+    ancestorSize: number;
+    /** Fees of the in-mempool ancestors including the transaction in satoshi. */
+    ancestorFees?: string;
+    /** Package fee rate of the transaction with its ancestors in sat/vB. */
+    ancestorFeeRate: number;
+    /** Number of in-mempool descendants including the transaction. */
+    descendantCount: number;
+    /** Virtual size of the in-mempool descendants including the transaction. */
+    descendantSize: number;
+    /** Fees of the in-mempool descendants including the transaction in satoshi. */
+    descendantFees?: string;
+    /** Fee rate of the transaction with its descendants in sat/vB. */
+    descendantFeeRate: number;
+    /** Fee rate at which the transaction is expected to be mined, taking into account the ancestors and the descendants (CPFP) in sat/vB. */
+    effectiveFeeRate: number;
+    /** Unconfirmed parent transactions. */
+    depends?: string[];
+    /** Unconfirmed child transactions. */
+    spentBy?: string[];
+    /** True if the transaction signals replaceability (BIP125). */
+    bip125Replaceable: boolean;
+}
 export interface Tx {
     /** Transaction ID (hash). */
     txid: string;
@@ -259,6 +335,12 @@ export interface Tx {
     ethereumSpecific?: EthereumSpecific;
     /** Aliases for addresses involved in this transaction. */
     addressAliases?: {[key: string]: AddressAlias};
+    /** Transaction which replaced this mempool transaction (RBF or the same nonce). If the backend no longer knows the replaced transaction, only txid and replacedBy are set. */
+    replacedBy?: string;
+    /** Mempool transactions replaced by this transaction. */
+    replaces?: string[];
+    /** Ancestor and descendant package data of an unconfirmed transaction (Bitcoin-type), returned if requested by mempoolPackage. */
+    mempoolPackage?: MempoolPackage;
 }
 export interface FeeStats {
     /** Number of transactions in the given block. */
@@ -270,6 +352,123 @@ export interface FeeStats {
     /** Fee distribution deciles (0%..100%) in satoshi or base units per kB. */
     decilesFeePerKb: number[];
 }
+export interface MempoolBlock {
+    /** Virtual size (Bitcoin-type) or the sum of gas limits (Ethereum-type) of the transactions in the block. */
+    size: number;
+    /** Number of transactions in the block. */
+    txCount: number;
+    /** Sum of the fees (maximum fees for Ethereum-type) in satoshi or base units. */
+    totalFeesSat?: string;
+    /** Median effective fee rate of the block in feeRateUnit. */
+    medianFeeRate: number;
+    /** Minimum, 10th, 25th, 50th, 75th, 90th percentile and maximum of the effective fee rates in feeRateUnit. */
+    feeRange: number[];
+}
+export interface MempoolBlocks {
+    /** Unit of the fee rates, sat/vB for Bitcoin-type or Gwei for Ethereum-type chains. */
+    feeRateUnit: string;
+    /** Unix timestamp of the projection. */
+    time: number;
+    /** Projected blocks from the next one, the last block contains all remaining transactions. */
+    blocks: MempoolBlock[];
+}
+export interface MempoolFeeHistogramBucket {
+    /** Lower bound of the effective fee rate of the bucket in feeRateUnit. */
+    feeRate: number;
+    /** Virtual size or gas limit of the transactions in the bucket. */
+    size: number;
+    /** Number of transactions in the bucket. */
+    txCount: number;
+}
+export interface MempoolFeeHistogram {
+    /** Unit of the fee rates, sat/vB for Bitcoin-type or Gwei for Ethereum-type chains. */
+    feeRateUnit: string;
+    /** Unix timestamp of the histogram. */
+    time: number;
+    /** Non-empty buckets ordered from the highest fee rate. */
+    buckets: MempoolFeeHistogramBucket[];
+}
+export interface FeeBumpCpfpOutput {
+    /** Index of the output. */
+    n: number;
+    /** Value of the output in satoshi. */
+    value?: string;
+    /** Address of the output. */
+    address?: string;
+}
+export interface FeeBumpCpfp {
+    /** Assumed virtual size of the child transaction spending one output to one output. */
+    childVSize: number;
+    /** Fee of the child transaction in satoshi needed to reach the target fee rate of the package. */
+    childFee?: string;
+    /** Outputs of the transaction whose value covers the child fee. */
+    outputs: FeeBumpCpfpOutput[];
+}
+export interface FeeBumpRbf {
+    /** Minimum fee of the replacement of the same size in satoshi. */
+    fee?: string;
+    /** Fee to add to the original fee in satoshi. */
+    additionalFee?: string;
+    /** True if the transaction signals replaceability (BIP125), otherwise the replacement relies on the full RBF policy of the nodes. */
+    signaled: boolean;
+}
+export interface FeeBump {
+    /** Stuck transaction. */
+    txid: string;
+    /** Requested fee rate in sat/vB. */
+    targetFeeRate: number;
+    /** Current effective fee rate of the transaction in sat/vB. */
+    effectiveFeeRate: number;
+    /** Child-pays-for-parent suggestion, omitted if the package already pays the target fee rate. */
+    cpfp?: FeeBumpCpfp;
+    /** Replace-by-fee suggestion, omitted if the transaction already pays the target fee rate. */
+    rbf?: FeeBumpRbf;
+}
+export interface DroppedTx {
+    /** Dropped transaction. */
+    txid: string;
+    /** Unix timestamp when the transaction was found dropped. */
+    droppedTime: number;
+    /** Confirmed transaction spending the same inputs, if known. */
+    conflictingTxid?: string;
+}
+export interface BroadcastTx {
+    txid: string;
+    /** Broadcast status of the transaction. */
+    status: 'accepted' | 'mempool' | 'confirmed' | 'dropped' | 'replaced';
+    /** Unix timestamp of the first broadcast. */
+    firstBroadcast: number;
+    /** Unix timestamp of the last broadcast or rebroadcast. */
+    lastBroadcast: number;
+    /** Number of broadcasts including the rebroadcasts. */
+    broadcasts: number;
+    /** Unix timestamp when the transaction was last seen in the mempool. */
+    lastSeenInMempool?: number;
+    /** Height of the block containing the confirmed transaction. */
+    blockHeight?: number;
+    /** Transaction which replaced or double spent the transaction. */
+    replacedBy?: string;
+    /** Error returned by the backend for the last rebroadcast. */
+    lastError?: string;
+    /** True while Blockbook rebroadcasts the transaction if it is missing from the mempool. */
+    rebroadcasting: boolean;
+}
+export interface EthereumWithdrawal {
+    /** Global index of the withdrawal. */
+    index: number;
+    /** Index of the validator the withdrawal comes from. */
+    validatorIndex: number;
+    /** Address credited by the withdrawal. */
+    address: string;
+    /** Withdrawn amount (in Wei). */
+    amount: string;
+    /** Height of the block containing the withdrawal. */
+    blockHeight: number;
+    /** Unix timestamp of the block containing the withdrawal. */
+    blockTime: number;
+    /** Number of confirmations of the withdrawal. */
+    confirmations: number;
+}
 export interface StakingPool {
     /** Staking pool contract address on-chain. */
     contract: string;
@@ -290,6 +489,54 @@ export interface StakingPool {
     /** Any balance automatically reinvested into the pool. */
     autocompoundBalance?: string;
 }
+export interface EthereumNonceGap {
+    /** First missing nonce. */
+    from: number;
+    /** Last missing nonce (inclusive). */
+    to: number;
+}
+export interface EthereumReplacementFees {
+    /** Suggested fee cap per gas (in Wei). */
+    maxFeePerGas: string;
+    /** Suggested priority fee per gas (in Wei). */
+    maxPriorityFeePerGas: string;
+}
+export interface EthereumPendingTxDiagnostics {
+    txid: string;
+    nonce: number;
+    /** Unix timestamp when the transaction was first seen in the mempool. */
+    firstSeen: number;
+    /** Fee cap per gas of the transaction (gas price of legacy transactions). */
+    maxFeePerGas?: string;
+    /** Priority fee cap per gas of the transaction (gas price of legacy transactions). */
+    maxPriorityFeePerGas?: string;
+    /** The nonce was already used by a confirmed transaction, this transaction cannot be mined. */
+    nonceUsed?: boolean;
+    /** The fee cap is below the current base fee. */
+    belowBaseFee?: boolean;
+    /** The priority fee is below the current low priority fee estimate. */
+    lowPriorityFee?: boolean;
+    /** A lower nonce is missing, the transaction waits until the gap is filled. */
+    blockedByNonceGap?: boolean;
+    /** Stuck pending transaction with a lower nonce which blocks this transaction. */
+    blockedBy?: string;
+    /** Suggested fees of a replacement with the same nonce, if the transaction is stuck because of low fees. */
+    replacement?: EthereumReplacementFees;
+}
+export interface EthereumAccountDiagnostics {
+    /** Nonce of the next transaction to be mined. */
+    confirmedNonce: number;
+    /** Next nonce reported by the backend including its pending transactions. */
+    pendingNonce: number;
+    /** Current base fee per gas (in Wei), if known. */
+    baseFeePerGas?: string;
+    /** True if any pending transaction is stuck. */
+    stuck: boolean;
+    /** Nonces missing before the pending transactions. */
+    nonceGaps?: EthereumNonceGap[];
+    /** Pending transactions of the account ordered by nonce. */
+    pendingTxs?: EthereumPendingTxDiagnostics[];
+}
 export interface Erc4626TokenMetadata {
     /** Token contract address. */
     contract: string;
@@ -386,10 +633,18 @@ export interface Address {
     transactions?: Tx[];
     /** List of transaction IDs (if detailed data is not requested). */
     txids?: string[];
+    /** EIP-4895 beacon chain withdrawals credited to the address, ordered by block height together with the transactions of the page and counted in its paging. */
+    withdrawals?: EthereumWithdrawal[];
+    /** Cursor of the page of the older confirmed transactions, omitted if there are none. */
+    nextCursor?: string;
+    /** Cursor of the page of the newer confirmed transactions, keep it to fetch the transactions confirmed later. */
+    prevCursor?: string;
     /** Current (pending) transaction nonce for Ethereum-like addresses, including mempool transactions. This is the next nonce the account will use. */
     nonce?: string;
     /** Confirmed transaction nonce for Ethereum-like addresses, reflecting only mined transactions (eth_getTransactionCount at the latest block). Equals nonce when the account has no pending transactions. */
     confirmedNonce?: string;
+    /** Address the account currently delegates its code to using EIP-7702, if any. Returned only if requested (extra backend call). */
+    delegation?: string;
     /** Number of tokens with any historical usage at this address. */
     usedTokens?: number;
     /** List of tokens associated with this address. */
@@ -412,6 +667,8 @@ export interface Address {
     addressAliases?: {[key: string]: AddressAlias};
     /** List of staking pool data if address interacts with staking. */
     stakingPools?: StakingPool[];
+    /** Diagnostics of the pending transactions for Ethereum-like addresses, if requested. */
+    diagnostics?: EthereumAccountDiagnostics;
     /** Additional normalized chain-specific account/address data. Use payloadType as discriminator for payload. */
     chainExtraData?: AccountChainExtraData;
 }
@@ -450,6 +707,18 @@ export interface ContractInfoResult {
     /** Indexed best block height used as freshness metadata for this response. */
     blockHeight: number;
 }
+export interface AccountsInfoEntry {
+    /** Address or XPUB descriptor from the request. */
+    descriptor: string;
+    /** Account data, omitted if the entry failed. */
+    account?: Address;
+    /** Error of the entry, the other entries are not affected. */
+    error?: string;
+}
+export interface AccountsInfo {
+    /** Results in the order of the request. */
+    accounts: AccountsInfoEntry[];
+}
 export interface Utxo {
     /** Transaction ID in which this UTXO was created. */
     txid: string;
@@ -475,6 +744,8 @@ export interface BalanceHistory {
     time: number;
     /** Number of transactions in this interval. */
     txs: number;
+    /** Number of beacon chain withdrawals in this interval, their amount is included in received. */
+    withdrawals?: number;
     /** Amount received in this interval (in satoshi or base units). */
     received?: string;
     /** Amount sent in this interval (in satoshi or base units). */
@@ -547,6 +818,26 @@ export interface BlockRaw {
     /** Hex-encoded block data. */
     hex: string;
 }
+export interface ChainEvent {
+    /** Kind of the change of the chain. */
+    type: 'connect' | 'disconnect';
+    /** Height of the block. */
+    height: number;
+    /** Hash of the block. */
+    hash: string;
+    /** Timestamp of the connected block. */
+    time?: number;
+    /** Transactions of the disconnected block, they lost their confirmation. */
+    txids?: string[];
+}
+export interface ChainEvents {
+    /** Height of the best indexed block. */
+    bestHeight: number;
+    /** Disconnections of the blocks of the client ordered from the highest followed by the connections of the blocks of the current chain, at most 1000 events. */
+    events: ChainEvent[];
+    /** True if the disconnection of the given block is no longer known, the client must resync its data. */
+    resync?: boolean;
+}
 export interface BackendInfo {
     /** Error message if something went wrong in the backend. */
     error?: string;
@@ -591,6 +882,20 @@ export interface InternalStateColumn {
     /** Timestamp of the last update to this column. */
     updated: string;
 }
+export interface FourByteSignaturesState {
+    /** Number of signatures stored in the database. */
+    count: number;
+    /** Highest signature id up to which the remote source is fully downloaded. */
+    lastId: number;
+    /** Next page of an interrupted download, the next refresh continues from it. */
+    resumeUrl?: string;
+    /** Highest signature id seen by the interrupted download. */
+    resumeTopId?: number;
+    /** Time of the last change of the stored signatures. */
+    lastUpdate: string;
+    /** Time of the last import of a signatures bundle from disk. */
+    lastBundleImport: string;
+}
 export interface BlockbookInfo {
     /** Coin name, e.g. 'Bitcoin'. */
     coin: string;
@@ -640,6 +945,8 @@ export interface BlockbookInfo {
     dbSizeFromColumns?: number;
     /** List of columns/tables in the DB for internal state. */
     dbColumns?: InternalStateColumn[];
+    /** State of the 4byte signatures import (internal status only). */
+    fourByteSignatures?: FourByteSignaturesState;
     /** Additional human-readable info about this blockbook instance. */
     about: string;
 }
@@ -669,11 +976,47 @@ export interface AvailableVsCurrencies {
     /** Error message, if any, when fetching the available currencies. */
     error?: string;
 }
+export interface SimulatedBalanceChange {
+    /** Address whose balance changes. */
+    address: string;
+    /** Contract of the token, empty for the native coin. */
+    contract?: string;
+    /** Token symbol. */
+    symbol?: string;
+    /** Number of decimals of the coin or token. */
+    decimals: number;
+    /** Signed change of the balance (in base units), excluding the transaction fee. */
+    delta: string;
+}
+export interface SimulatedTx {
+    /** True if the simulation was traced; if false, the backend supports only eth_call and transfers are not available. */
+    traced: boolean;
+    /** Execution status of the simulated transaction (1: success, 0: fail). */
+    status: number;
+    /** Revert reason or execution error of a failed simulation. */
+    error?: string;
+    /** Gas used by the simulated execution (an eth_estimateGas estimate if not traced). */
+    gasUsed: number;
+    /** Sender of the simulated transaction. */
+    from: string;
+    /** Recipient of the simulated transaction. */
+    to?: string;
+    /** Value (in base units) sent by the simulated transaction. */
+    value: string;
+    /** Address of the contract the simulated transaction would create. */
+    createdContract?: string;
+    /** Internal transfers of the simulated execution. */
+    internalTransfers?: EthereumInternalTransfer[];
+    /** Token transfers of the simulated execution. */
+    tokenTransfers?: TokenTransfer[];
+    /** Balance changes of the native coin and fungible tokens caused by the simulated transaction. */
+    balanceChanges?: SimulatedBalanceChange[];
+}
 export interface WsReq {
     /** Unique request identifier. */
     id: string;
     /** Requested method name. */
-    method: 'getAccountInfo' | 'getContractInfo' | 'getInfo' | 'getBlockHash'| 'getBlock' | 'getAccountUtxo' | 'getBalanceHistory' | 'getTransaction' | 'getTransactionSpecific' | 'estimateFee' | 'sendTransaction' | 'subscribeNewBlock' | 'unsubscribeNewBlock' | 'subscribeNewTransaction' | 'unsubscribeNewTransaction' | 'subscribeAddresses' | 'unsubscribeAddresses' | 'subscribeFiatRates' | 'unsubscribeFiatRates' | 'ping' | 'getCurrentFiatRates' | 'getFiatRatesForTimestamps' | 'getFiatRatesTickersList' | 'getMempoolFilters';
+    method: 'getAccountInfo' | 'getContractInfo' | 'getInfo' | 'getBlockHash'| 'getBlock' | 'getAccountUtxo' | 'getBalanceHistory' | 'getTransaction' | 'getTransactionSpecific' | 'estimateFee' | 'sendTransaction' | 'subscribeNewBlock' | 'unsubscribeNewBlock' | 'subscribeNewTransaction' | 'unsubscribeNewTransaction' | 'subscribeAddresses' | 'unsubscribeAddresses' | 'subscribeFiatRates' | 'unsubscribeFiatRates' | 'subscribeMempoolBlocks' | 'unsubscribeMempoolBlocks' | 'ping' | 'getCurrentFiatRates' | 'getFiatRatesForTimestamps' | 'getFiatRatesTickersList' | 'getMempoolFilters' | 'simulateTransaction';
     /** Parameters for the requested method in raw JSON format. */
     params: any;
 }
@@ -682,6 +1025,8 @@ export interface WsRes {
     id: string;
     /** Payload of the response, structure depends on the request. */
     data: any;
+    /** Sequence number of the subscription notification, present if the notification journal is enabled. */
+    seq?: number;
 }
 export interface WsAccountInfoReq {
     /** Address or XPUB descriptor to query. */
@@ -696,6 +1041,8 @@ export interface WsAccountInfoReq {
     pageSize?: number;
     /** Requested page index, if paging is used. */
     page?: number;
+    /** Cursor returned as nextCursor or prevCursor by the previous request, used instead of page. */
+    cursor?: string;
     /** Starting block height for transaction filtering. */
     from?: number;
     /** Ending block height for transaction filtering. */
@@ -708,6 +1055,26 @@ export interface WsAccountInfoReq {
     gap?: number;
     /** If true, additionally return the confirmed nonce for Ethereum-like addresses (extra backend call). */
     confirmedNonce?: boolean;
+    /** If true, additionally return the diagnostics of stuck pending transactions for Ethereum-like addresses (extra backend calls). */
+    diagnostics?: boolean;
+    /** If true, additionally return the EIP-7702 delegation of Ethereum-like addresses (extra backend call). */
+    delegation?: boolean;
+}
+export interface WsAccountsInfoReq {
+    /** Addresses or XPUB descriptors to query. */
+    descriptors: string[];
+    /** Level of detail to retrieve about the accounts. */
+    details?: 'basic' | 'tokens' | 'tokenBalances';
+    /** Which tokens to include in the account info. */
+    tokens?: 'derived' | 'used' | 'nonzero';
+    /** Optional protocol enrichments to include. Supported values currently include 'erc4626'. */
+    protocols?: string[];
+    /** Filter by specific contract address (for token data). */
+    contractFilter?: string;
+    /** Currency code to convert values into (e.g. 'USD'). */
+    secondaryCurrency?: string;
+    /** Gap limit for XPUB scanning, if relevant. */
+    gap?: number;
 }
 export interface WsContractInfoReq {
     /** Contract address to query. */
@@ -804,6 +1171,8 @@ export interface WsBalanceHistoryReq {
 export interface WsTransactionReq {
     /** Transaction ID to retrieve details for. */
     txid: string;
+    /** Include the ancestor and descendant package data of an unconfirmed transaction (Bitcoin-type). */
+    mempoolPackage?: boolean;
 }
 export interface WsTransactionSpecificReq {
     /** Transaction ID for the detailed blockchain-specific data. */
@@ -813,13 +1182,15 @@ export interface WsEstimateFeeReq {
     /** Block confirmations targets for which fees should be estimated. */
     blocks?: number[];
     /** Additional chain-specific parameters (e.g. for Ethereum). */
-    specific?: {conservative?: boolean; txsize?: number; from?: string; to?: string; data?: string; value?: string;};
+    specific?: {conservative?: boolean; txsize?: number; provider?: 'mempool'; from?: string; to?: string; data?: string; value?: string;};
 }
 export interface Eip1559Fee {
     maxFeePerGas?: string;
     maxPriorityFeePerGas?: string;
     minWaitTimeEstimate?: number;
     maxWaitTimeEstimate?: number;
+    /** Max fee per blob gas for blob (type 3) transactions. */
+    maxFeePerBlobGas?: string;
 }
 export interface Eip1559Fees {
     baseFeePerGas?: string;
@@ -833,6 +1204,18 @@ export interface Eip1559Fees {
     historicalBaseFeeRange?: string[];
     priorityFeeTrend?: 'up' | 'down';
     baseFeeTrend?: 'up' | 'down';
+    /** Estimated blob base fee of the next block, on chains with blob transactions. */
+    baseFeePerBlobGas?: string;
+}
+export interface WsFeeConfidence {
+    /** Confirmation target the fees were estimated for, can differ from the requested one. */
+    blocks: number;
+    /** Fee per unit which is likely to confirm if the mempool does not grow. */
+    low: string;
+    /** Fee per unit which is expected to confirm. */
+    medium: string;
+    /** Fee per unit which is expected to confirm even if the mempool grows or the recent blocks were expensive. */
+    high: string;
 }
 export interface WsEstimateFeeRes {
     /** Estimated total fee per transaction, if relevant. */
@@ -841,7 +1224,11 @@ export interface WsEstimateFeeRes {
     feePerUnit?: string;
     /** Max fee limit for blockchains like Ethereum. */
     feeLimit?: string;
+    /** Estimated L1 data fee on rollup networks, included in feePerTx. */
+    l1Fee?: string;
     eip1559?: Eip1559Fees;
+    /** Fees per unit for the confidence levels, returned by the built-in mempool fee estimator. */
+    confidence?: WsFeeConfidence;
 }
 export interface WsLongTermFeeRateRes {
     /** Long term fee rate (in sat/kByte). */
@@ -865,6 +1252,70 @@ export interface WsNewBlock {
     /** EVM gas data for the EIP-1559 base-fee projection; null on non-EVM chains. */
     evmData: EthereumGasData | null;
 }
+export interface WsDisconnectedBlock {
+    /** Height of the disconnected block. */
+    height: number;
+    /** Hash of the disconnected block. */
+    hash: string;
+    /** Transactions of the disconnected block, they lost their confirmation. */
+    txids: string[];
+}
+export interface WsBlockDisconnected {
+    /** Disconnected blocks ordered from the highest. */
+    blockDisconnected: WsDisconnectedBlock[];
+}
+export interface WsTxUnconfirmed {
+    /** Transaction of the disconnected block. */
+    txid: string;
+    /** Height of the disconnected block. */
+    height: number;
+    /** Hash of the disconnected block. */
+    hash: string;
+}
+export interface WsAddressTxUnconfirmed {
+    /** Subscribed address involved in the transaction. */
+    address: string;
+    txUnconfirmed?: WsTxUnconfirmed;
+}
+export interface WsMempoolBlocks {
+    /** Next blocks projected from the mempool. */
+    blocks?: MempoolBlocks;
+    /** Histogram of the effective fee rates of the mempool transactions. */
+    histogram?: MempoolFeeHistogram;
+}
+export interface WsTxReplaced {
+    /** Replaced mempool transaction. */
+    txid: string;
+    /** Transaction which replaced it (RBF or the same nonce). */
+    replacedBy: string;
+}
+export interface WsAddressTxReplaced {
+    /** Subscribed address involved in the replaced transaction. */
+    address: string;
+    txReplaced?: WsTxReplaced;
+}
+export interface WsDoubleSpend {
+    /** Conflicted transaction paying the address. */
+    txid: string;
+    /** Transaction spending the same inputs, in mempool or confirmed. */
+    conflictingTxid: string;
+}
+export interface WsAddressDoubleSpend {
+    /** Subscribed address paid by the conflicted transaction. */
+    address: string;
+    doubleSpend?: WsDoubleSpend;
+}
+export interface WsTxDropped {
+    /** Dropped transaction. */
+    txid: string;
+    /** Confirmed transaction spending the same inputs, if known. */
+    conflictingTxid?: string;
+}
+export interface WsAddressTxDropped {
+    /** Subscribed address involved in the dropped transaction. */
+    address: string;
+    txDropped?: WsTxDropped;
+}
 export interface WsSendTransactionReq {
     /** Hex-encoded transaction data to broadcast (string format). */
     hex?: string;
@@ -876,6 +1327,38 @@ export interface WsSubscribeAddressesReq {
     addresses: string[];
     /** If true, also publish confirmed transactions for subscribed addresses when new blocks are connected. */
     newBlockTxs?: boolean;
+    /** Sequence number of the last notification received before the reconnect, the missed notifications are replayed. */
+    sinceSequence?: number;
+    /** Height of the last block notified before the reconnect, used if sinceSequence is not set. */
+    sinceHeight?: number;
+}
+export interface WsSubscribeNewBlockReq {
+    /** Sequence number of the last notification received before the reconnect, the missed notifications are replayed. */
+    sinceSequence?: number;
+    /** Height of the last block notified before the reconnect, used if sinceSequence is not set. */
+    sinceHeight?: number;
+}
+export interface WsSubscriptionRes {
+    /** True if the subscription is active. */
+    subscribed: boolean;
+    /** Sequence number of the last notification at the time of the subscription. */
+    sequence: number;
+    /** Number of the missed notifications which follow this response. */
+    replayed?: number;
+    /** True if the missed notifications are no longer available, the client must reload the state of the subscribed addresses or blocks. */
+    resync?: boolean;
+}
+export interface SseSubscriptionRes {
+    /** Subscription the response belongs to, also the name of the events carrying its notifications. */
+    subscription: 'newBlock' | 'newTransaction' | 'addresses' | 'fiatRates';
+    /** True if the subscription is active. */
+    subscribed: boolean;
+    /** Sequence number of the last notification at the time of the subscription. */
+    sequence: number;
+    /** Number of the missed notifications which follow this response. */
+    replayed?: number;
+    /** True if the missed notifications are no longer available, the client must reload the state of the subscribed addresses or blocks. */
+    resync?: boolean;
 }
 export interface WsSubscribeFiatRatesReq {
     /** Fiat currency code (e.g. 'USD'). */
@@ -923,6 +1406,12 @@ export interface WsRpcCallRes {
     /** Hex-encoded return data from the call. */
     data: string;
 }
+export interface WsSimulateTransactionReq {
+    /** Transaction to simulate, the numeric fields are hex encoded. */
+    tx: { from: string; to?: string; value?: string; data?: string; gas?: string; gasPrice?: string; maxFeePerGas?: string; maxPriorityFeePerGas?: string; stateOverrides?: Record<string, unknown> };
+    /** Block to simulate against: latest (default) or pending. */
+    block?: string;
+}
 export interface MempoolTxidFilterEntries {
     /** Map of txid to filter data (hex-encoded). */
     entries?: {[key: string]: string};
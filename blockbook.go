@@ -68,6 +68,8 @@ var (
 
 	publicBinding = flag.String("public", "", "public http server binding [address]:port[/path] (default no public server)")
 
+	grpcBinding = flag.String("grpc", "", "gRPC server binding [address]:port, requires the public server (default no gRPC server)")
+
 	certFiles = flag.String("certfile", "", "to enable SSL specify path to certificate files without extension, expecting <certfile>.crt and <certfile>.key (default no SSL)")
 
 	explorerURL = flag.String("explorer", "", "address of blockchain explorer")
@@ -89,7 +91,26 @@ var (
 	// resync mempool at least each resyncMempoolPeriodMs (could be more often if invoked by message from ZeroMQ)
 	resyncMempoolPeriodMs = flag.Int("resyncmempoolperiod", 60017, "resync mempool period in milliseconds")
 
+	// keep the replacements of the mempool transactions (RBF, the same nonce) for mempoolReplacementRetention minutes
+	mempoolReplacementRetention = flag.Int("mempoolreplacementretention", 1440, "period in minutes for which the replacements of mempool transactions are kept, 0 disables the tracking")
+
+	// keep the transactions which left the mempool without being confirmed for mempoolDroppedRetention minutes
+	mempoolDroppedRetention = flag.Int("mempooldroppedretention", 60, "period in minutes for which the transactions dropped from mempool are kept, 0 disables the tracking")
+
+	// track the transactions sent through the public interfaces for broadcastTrackingRetention minutes, rebroadcast the missing ones
+	broadcastTrackingRetention = flag.Int("broadcasttrackingretention", 1440, "period in minutes for which the sent transactions are tracked, 0 disables the tracking")
+	rebroadcastPeriod          = flag.Int("rebroadcastperiod", 10, "period in minutes of rebroadcasting the sent transactions missing from mempool, 0 disables the rebroadcasting")
+
+	// store the mempool to the db each mempoolSnapshotPeriod minutes and on shutdown, load it at startup
+	mempoolSnapshotPeriod = flag.Int("mempoolsnapshotperiod", 15, "period in minutes of storing the mempool snapshot loaded at startup, 0 stores it only on shutdown, negative value disables the mempool persistence")
+
+	// number the websocket notifications and keep the last wsJournalSize of them for the replay to the reconnected clients
+	wsJournalSize      = flag.Int("wsjournalsize", 5000, "number of the last websocket notifications kept for the replay to the reconnected clients, 0 disables the notification journal")
+	wsJournalRetention = flag.Int("wsjournalretention", 10, "period in minutes for which the notifications of an address are journaled after its last subscriber disconnected")
+
 	extendedIndex = flag.Bool("extendedindex", false, "if true, create index of input txids and spending transactions")
+
+	fourByteBundle = flag.String("fourbytebundle", "", "path to a 4byte signatures bundle (JSON lines or 4byte API dump) imported at startup, for deployments without access to the 4byte API")
 )
 
 var (
@@ -112,7 +133,11 @@ var (
 	internalState                 *common.InternalState
 	fiatRates                     *fiat.FiatRates
 	callbacksOnNewBlock           []bchain.OnNewBlockFunc
+	callbacksOnDisconnectBlocks   []bchain.OnDisconnectBlocksFunc
 	callbacksOnNewTx              []bchain.OnNewTxFunc
+	callbacksOnTxReplaced         []bchain.OnTxReplacedFunc
+	callbacksOnTxDropped          []bchain.OnTxDroppedFunc
+	callbacksOnMempoolResync      []func()
 	callbacksOnNewFiatRatesTicker []fiat.OnNewFiatRatesTicker
 	chanOsSignal                  chan os.Signal
 )
@@ -174,6 +199,11 @@ func mainWithExitCode() int {
 		return exitCodeFatal
 	}
 
+	if *grpcBinding != "" && *publicBinding == "" {
+		glog.Error("-grpc requires the public server; specify -public as well")
+		return exitCodeFatal
+	}
+
 	if *repair {
 		if err := db.RepairRocksDB(*dbPath, *forceRepair); err != nil {
 			glog.Errorf("RepairRocksDB %s: %v", *dbPath, err)
@@ -310,6 +340,7 @@ func mainWithExitCode() int {
 		glog.Errorf("NewSyncWorker %v", err)
 		return exitCodeFatal
 	}
+	syncWorker.SetOnDisconnectBlocks(onDisconnectBlocks)
 
 	// set the DbState to open at this moment, after all important workers are initialized
 	internalState.DbState = common.DbStateOpen
@@ -375,11 +406,14 @@ func mainWithExitCode() int {
 		if chain.GetChainParser().GetChainType() == bchain.ChainBitcoinType {
 			addrDescForOutpoint = index.AddrDescForOutpoint
 		}
+		mempool.SetReplacementTracking(time.Duration(*mempoolReplacementRetention)*time.Minute, onTxReplaced)
+		mempool.SetDroppedTracking(time.Duration(*mempoolDroppedRetention)*time.Minute, checkTxDropped, onTxDropped)
 		err = chain.InitializeMempool(addrDescForOutpoint, onNewTx)
 		if err != nil {
 			glog.Error("initializeMempool ", err)
 			return exitCodeFatal
 		}
+		loadMempoolSnapshot()
 		var mempoolCount int
 		if mempoolCount, err = mempool.Resync(); err != nil {
 			glog.Error("resyncMempool ", err)
@@ -394,10 +428,31 @@ func mainWithExitCode() int {
 
 	if publicServer != nil {
 		// start full public interface
+		if *wsJournalSize > 0 {
+			publicServer.EnableNotificationJournal(*wsJournalSize, time.Duration(*wsJournalRetention)*time.Minute)
+		} else if err := index.DeleteWsJournal(); err != nil {
+			glog.Error("DeleteWsJournal ", err)
+		}
 		callbacksOnNewBlock = append(callbacksOnNewBlock, publicServer.OnNewBlock)
+		callbacksOnDisconnectBlocks = append(callbacksOnDisconnectBlocks, publicServer.OnDisconnectBlocks)
 		callbacksOnNewTx = append(callbacksOnNewTx, publicServer.OnNewTx)
+		callbacksOnTxReplaced = append(callbacksOnTxReplaced, publicServer.OnTxReplaced)
+		callbacksOnTxDropped = append(callbacksOnTxDropped, publicServer.OnTxDropped)
+		callbacksOnMempoolResync = append(callbacksOnMempoolResync, publicServer.OnMempoolResync)
 		callbacksOnNewFiatRatesTicker = append(callbacksOnNewFiatRatesTicker, publicServer.OnNewFiatRatesTicker)
+		if *broadcastTrackingRetention > 0 && mempool != nil {
+			broadcastTracker := api.NewBroadcastTracker(index, chain, mempool, internalState,
+				time.Duration(*broadcastTrackingRetention)*time.Minute, time.Duration(*rebroadcastPeriod)*time.Minute)
+			publicServer.SetBroadcastTracker(broadcastTracker)
+			callbacksOnMempoolResync = append(callbacksOnMempoolResync, broadcastTracker.OnMempoolResync)
+		}
 		publicServer.ConnectFullPublicInterface()
+		if *grpcBinding != "" {
+			if err = publicServer.ConnectGrpcInterface(*grpcBinding); err != nil {
+				glog.Error("gRPC server: ", err)
+				return exitCodeFatal
+			}
+		}
 	}
 
 	if *blockFrom >= 0 {
@@ -431,6 +486,14 @@ func mainWithExitCode() int {
 		close(chanSyncMempool)
 		<-chanSyncIndexDone
 		<-chanSyncMempoolDone
+		if *mempoolSnapshotPeriod >= 0 {
+			storeMempoolSnapshot()
+		}
+	}
+	if publicServer != nil && *wsJournalSize > 0 {
+		if err := publicServer.StoreNotificationJournal(); err != nil {
+			glog.Error("StoreNotificationJournal ", err)
+		}
 	}
 	<-chanStoreInternalStateDone
 	return exitCodeOK
@@ -677,6 +740,7 @@ func onNewFiatRatesTicker(ticker *common.CurrencyRatesTicker) {
 func syncMempoolLoop() {
 	defer close(chanSyncMempoolDone)
 	glog.Info("syncMempoolLoop starting")
+	lastSnapshot := time.Now()
 	// resync mempool about every minute if there are no chanSyncMempool requests, with debounce 1 second
 	common.TickAndDebounce(time.Duration(*resyncMempoolPeriodMs)*time.Millisecond, debounceResyncMempoolMs*time.Millisecond, chanSyncMempool, func() {
 		internalState.StartedMempoolSync()
@@ -684,7 +748,11 @@ func syncMempoolLoop() {
 			glog.Error("syncMempoolLoop ", errors.ErrorStack(err))
 		} else {
 			internalState.FinishedMempoolSync(count)
-
+			onMempoolResync()
+			if *mempoolSnapshotPeriod > 0 && time.Since(lastSnapshot) >= time.Duration(*mempoolSnapshotPeriod)*time.Minute {
+				storeMempoolSnapshot()
+				lastSnapshot = time.Now()
+			}
 		}
 	})
 	glog.Info("syncMempoolLoop stopped")
@@ -735,6 +803,17 @@ func storeInternalStateLoop() {
 	glog.Info("storeInternalStateLoop stopped")
 }
 
+func onMempoolResync() {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("onMempoolResync recovered from panic: ", r)
+		}
+	}()
+	for _, c := range callbacksOnMempoolResync {
+		c()
+	}
+}
+
 func onNewTx(tx *bchain.MempoolTx) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -746,6 +825,115 @@ func onNewTx(tx *bchain.MempoolTx) {
 	}
 }
 
+func onDisconnectBlocks(blocks []bchain.DisconnectedBlock) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("onDisconnectBlocks recovered from panic: ", r)
+		}
+	}()
+	for _, c := range callbacksOnDisconnectBlocks {
+		c(blocks)
+	}
+}
+
+func onTxReplaced(r *bchain.TxReplacement) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("onTxReplaced recovered from panic: ", r)
+		}
+	}()
+	for _, c := range callbacksOnTxReplaced {
+		c(r)
+	}
+}
+
+// loadMempoolSnapshot loads the mempool stored before the restart, the following resync reconciles it with the backend
+func loadMempoolSnapshot() {
+	if *mempoolSnapshotPeriod < 0 {
+		if err := index.DeleteMempoolSnapshot(); err != nil {
+			glog.Error("DeleteMempoolSnapshot ", err)
+		}
+		return
+	}
+	start := time.Now()
+	snapshot, t, err := index.LoadMempoolSnapshot()
+	if err != nil {
+		glog.Error("LoadMempoolSnapshot ", err)
+		return
+	}
+	if snapshot == nil {
+		return
+	}
+	loaded := mempool.LoadSnapshot(snapshot)
+	glog.Info("mempool: loaded ", loaded, " of ", len(snapshot.Entries), " transactions and ", len(snapshot.Replacements), " replacements from the snapshot taken at ", t.UTC().Format(time.RFC3339), ", duration ", time.Since(start))
+}
+
+// storeMempoolSnapshot stores the mempool to the db so that it is available after the restart
+func storeMempoolSnapshot() {
+	start := time.Now()
+	snapshot := mempool.GetSnapshot()
+	if err := index.StoreMempoolSnapshot(snapshot); err != nil {
+		glog.Error("StoreMempoolSnapshot ", err)
+		return
+	}
+	glog.Info("mempool: stored snapshot of ", len(snapshot.Entries), " transactions and ", len(snapshot.Replacements), " replacements, duration ", time.Since(start))
+}
+
+func onTxDropped(d *bchain.TxDropped) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("onTxDropped recovered from panic: ", r)
+		}
+	}()
+	for _, c := range callbacksOnTxDropped {
+		c(d)
+	}
+}
+
+// checkTxDropped checks if a transaction which left the mempool was confirmed. Bitcoin type transactions are
+// looked up in the index, the spending transactions of their inputs are the conflicting ones (requires -extendedindex),
+// other transactions are looked up in the backend.
+func checkTxDropped(txid string, spends []bchain.Outpoint) bchain.TxDroppedCheck {
+	if synchronized, _, _, _ := internalState.GetSyncState(); !synchronized {
+		return bchain.TxDroppedCheck{Postpone: true}
+	}
+	if chain.GetChainParser().GetChainType() != bchain.ChainBitcoinType {
+		tx, err := chain.GetTransaction(txid)
+		if err == bchain.ErrTxNotFound {
+			return bchain.TxDroppedCheck{Dropped: true}
+		}
+		if err != nil {
+			glog.Warning("checkTxDropped ", txid, ": ", err)
+		} else if tx.Confirmations == 0 {
+			glog.V(1).Info("checkTxDropped ", txid, " still known to the backend")
+		}
+		return bchain.TxDroppedCheck{}
+	}
+	ta, err := index.GetTxAddresses(txid)
+	if err != nil {
+		glog.Warning("checkTxDropped ", txid, ": ", err)
+		return bchain.TxDroppedCheck{}
+	}
+	if ta != nil {
+		return bchain.TxDroppedCheck{}
+	}
+	r := bchain.TxDroppedCheck{Dropped: true}
+	for _, o := range spends {
+		out, err := index.GetTxAddressesOutput(o.Txid, uint32(o.Vout))
+		if err != nil || out == nil || !out.Spent || out.SpentTxid == "" {
+			continue
+		}
+		r.ConflictingTxid = out.SpentTxid
+		if cta, err := index.GetTxAddresses(out.SpentTxid); err == nil && cta != nil {
+			for i := range cta.Outputs {
+				r.ConflictingAddrDescs = append(r.ConflictingAddrDescs, cta.Outputs[i].AddrDesc)
+			}
+		}
+		break
+	}
+	return r
+}
+
 func pushSynchronizationHandler(nt bchain.NotificationType) {
 	glog.V(1).Info("MQ: notification ", nt)
 	if common.IsInShutdown() {
@@ -832,15 +1020,27 @@ func initDownloaders(db *db.RocksDB, chain bchain.BlockChain, config *common.Con
 		go fiatRates.RunDownloader()
 	}
 
-	if config.FourByteSignatures != "" && chain.GetChainParser().GetChainType() == bchain.ChainEthereumType {
-		fbsd, err := fourbyte.NewFourByteSignaturesDownloader(db, config.FourByteSignatures)
-		if err != nil {
-			glog.Errorf("NewFourByteSignaturesDownloader Init error: %v", err)
-		} else {
-			glog.Infof("Starting FourByteSignatures downloader...")
-			go fbsd.Run()
+	if chain.GetChainParser().GetChainType() == bchain.ChainEthereumType && (config.FourByteSignatures != "" || *fourByteBundle != "") {
+		var fbsd *fourbyte.FourByteSignaturesDownloader
+		if config.FourByteSignatures != "" {
+			var err error
+			fbsd, err = fourbyte.NewFourByteSignaturesDownloader(db, config.FourByteSignatures)
+			if err != nil {
+				glog.Errorf("NewFourByteSignaturesDownloader Init error: %v", err)
+			}
 		}
-
+		go func() {
+			// import the bundle first, the downloader then fetches only the newer signatures
+			if *fourByteBundle != "" {
+				if _, err := fourbyte.ImportBundleFile(db, *fourByteBundle); err != nil {
+					glog.Errorf("FourByteSignatures bundle %s import error: %v", *fourByteBundle, err)
+				}
+			}
+			if fbsd != nil {
+				glog.Infof("Starting FourByteSignatures downloader...")
+				fbsd.Run()
+			}
+		}()
 	}
 
 }
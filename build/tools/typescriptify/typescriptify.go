@@ -29,22 +29,31 @@ func main() {
 	t.Add(bchain.TronAccountExtraData{})
 	t.Add(api.Tx{})
 	t.Add(api.FeeStats{})
+	t.Add(api.MempoolBlocks{})
+	t.Add(api.MempoolFeeHistogram{})
+	t.Add(api.FeeBump{})
+	t.Add(api.DroppedTx{})
+	t.Add(api.BroadcastTx{})
 	t.Add(api.Address{})
+	t.Add(api.AccountsInfo{})
 	t.Add(api.ContractInfoResult{})
 	t.Add(api.Utxo{})
 	t.Add(api.BalanceHistory{})
 	t.Add(api.Blocks{})
 	t.Add(api.Block{})
 	t.Add(api.BlockRaw{})
+	t.Add(api.ChainEvents{})
 	t.Add(api.SystemInfo{})
 	t.Add(api.FiatTicker{})
 	t.Add(api.FiatTickers{})
 	t.Add(api.AvailableVsCurrencies{})
+	t.Add(api.SimulatedTx{})
 
 	// Websocket specific
 	t.Add(server.WsReq{})
 	t.Add(server.WsRes{})
 	t.Add(server.WsAccountInfoReq{})
+	t.Add(server.WsAccountsInfoReq{})
 	t.Add(server.WsContractInfoReq{})
 	t.Add(server.WsInfoRes{})
 	t.Add(server.WsBlockHashReq{})
@@ -60,8 +69,17 @@ func main() {
 	t.Add(server.WsEstimateFeeRes{})
 	t.Add(server.WsLongTermFeeRateRes{})
 	t.Add(server.WsNewBlock{})
+	t.Add(server.WsBlockDisconnected{})
+	t.Add(server.WsAddressTxUnconfirmed{})
+	t.Add(server.WsMempoolBlocks{})
+	t.Add(server.WsAddressTxReplaced{})
+	t.Add(server.WsAddressDoubleSpend{})
+	t.Add(server.WsAddressTxDropped{})
 	t.Add(server.WsSendTransactionReq{})
 	t.Add(server.WsSubscribeAddressesReq{})
+	t.Add(server.WsSubscribeNewBlockReq{})
+	t.Add(server.WsSubscriptionRes{})
+	t.Add(server.SseSubscriptionRes{})
 	t.Add(server.WsSubscribeFiatRatesReq{})
 	t.Add(server.WsCurrentFiatRatesReq{})
 	t.Add(server.WsFiatRatesForTimestampsReq{})
@@ -69,6 +87,7 @@ func main() {
 	t.Add(server.WsMempoolFiltersReq{})
 	t.Add(server.WsRpcCallReq{})
 	t.Add(server.WsRpcCallRes{})
+	t.Add(server.WsSimulateTransactionReq{})
 	t.Add(bchain.MempoolTxidFilterEntries{})
 
 	err := t.ConvertToFile("blockbook-api.ts")
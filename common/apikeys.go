@@ -0,0 +1,133 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ApiKey is an API key of a tenant of the public interfaces together with its limits. The limits of a key replace
+// the per-IP limits for the clients presenting the key, a zero limit means unlimited. Only the SHA-256 hash of
+// the secret is kept, the secret itself is returned once when the key is created.
+type ApiKey struct {
+	// ID identifies the key in the admin interface and labels its metrics
+	ID         string        `json:"id"`
+	SecretHash string        `json:"secretHash"`
+	RateLimit  int           `json:"rateLimit"`
+	RateWindow time.Duration `json:"rateWindow"`
+	Burst      int           `json:"burst"`
+	// MaxConcurrent is the limit of concurrently processed REST requests
+	MaxConcurrent           int `json:"maxConcurrent"`
+	MaxWebsocketConnections int `json:"maxWebsocketConnections"`
+	// MaxSubscriptions is the limit of addresses subscribed by all connections of the key together
+	MaxSubscriptions int `json:"maxSubscriptions"`
+	// AllowedMethods are the websocket methods the key may call, all methods if empty;
+	// DeniedMethods are refused even if allowed
+	AllowedMethods []string  `json:"allowedMethods,omitempty"`
+	DeniedMethods  []string  `json:"deniedMethods,omitempty"`
+	Created        time.Time `json:"created"`
+	Updated        time.Time `json:"updated"`
+}
+
+// MethodAllowed returns true if the key may call the websocket method
+func (k *ApiKey) MethodAllowed(method string) bool {
+	if slices.Contains(k.DeniedMethods, method) {
+		return false
+	}
+	return len(k.AllowedMethods) == 0 || slices.Contains(k.AllowedMethods, method)
+}
+
+// ApiKeySecretHash returns the hash under which the secret of an API key is stored
+func ApiKeySecretHash(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// ApiKeys is an immutable snapshot of the API keys. Readers must not mutate the keys; writers build a new snapshot
+// and replace it via SetApiKeys.
+type ApiKeys struct {
+	byID   map[string]*ApiKey
+	byHash map[string]*ApiKey
+}
+
+// NewApiKeys creates the snapshot of the given keys
+func NewApiKeys(keys []*ApiKey) *ApiKeys {
+	a := &ApiKeys{
+		byID:   make(map[string]*ApiKey, len(keys)),
+		byHash: make(map[string]*ApiKey, len(keys)),
+	}
+	for _, k := range keys {
+		a.byID[k.ID] = k
+		a.byHash[k.SecretHash] = k
+	}
+	return a
+}
+
+// ByID returns the key with the given ID, nil if there is none
+func (a *ApiKeys) ByID(id string) *ApiKey {
+	if a == nil {
+		return nil
+	}
+	return a.byID[id]
+}
+
+// BySecret returns the key with the given secret, nil if there is none
+func (a *ApiKeys) BySecret(secret string) *ApiKey {
+	if a == nil {
+		return nil
+	}
+	return a.byHash[ApiKeySecretHash(secret)]
+}
+
+// List returns the keys sorted by ID
+func (a *ApiKeys) List() []*ApiKey {
+	if a == nil {
+		return nil
+	}
+	keys := make([]*ApiKey, 0, len(a.byID))
+	for _, k := range a.byID {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
+
+// With returns a new snapshot with the key added or replaced
+func (a *ApiKeys) With(k *ApiKey) *ApiKeys {
+	keys := make([]*ApiKey, 0, len(a.List())+1)
+	for _, o := range a.List() {
+		if o.ID != k.ID {
+			keys = append(keys, o)
+		}
+	}
+	return NewApiKeys(append(keys, k))
+}
+
+// Without returns a new snapshot without the key with the given ID
+func (a *ApiKeys) Without(id string) *ApiKeys {
+	keys := make([]*ApiKey, 0, len(a.List()))
+	for _, o := range a.List() {
+		if o.ID != id {
+			keys = append(keys, o)
+		}
+	}
+	return NewApiKeys(keys)
+}
+
+// GetApiKeys returns the current API keys snapshot, nil when not yet initialized
+func (is *InternalState) GetApiKeys() *ApiKeys {
+	return is.apiKeys.Load()
+}
+
+// SetApiKeys atomically replaces the API keys snapshot
+func (is *InternalState) SetApiKeys(a *ApiKeys) {
+	is.apiKeys.Store(a)
+}
+
+// InitApiKeys publishes the initial snapshot only when none exists yet and reports whether it did,
+// see InitRpcCallAllowlists
+func (is *InternalState) InitApiKeys(a *ApiKeys) bool {
+	return is.apiKeys.CompareAndSwap(nil, a)
+}
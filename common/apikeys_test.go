@@ -0,0 +1,57 @@
+//go:build unittest
+
+package common
+
+import (
+	"testing"
+)
+
+func TestApiKeyMethodAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    ApiKey
+		method string
+		want   bool
+	}{
+		{name: "no restriction", key: ApiKey{}, method: "rpcCall", want: true},
+		{name: "denied", key: ApiKey{DeniedMethods: []string{"rpcCall"}}, method: "rpcCall", want: false},
+		{name: "not denied", key: ApiKey{DeniedMethods: []string{"rpcCall"}}, method: "getInfo", want: true},
+		{name: "allowed", key: ApiKey{AllowedMethods: []string{"getInfo"}}, method: "getInfo", want: true},
+		{name: "not allowed", key: ApiKey{AllowedMethods: []string{"getInfo"}}, method: "getBlock", want: false},
+		{name: "denial wins", key: ApiKey{AllowedMethods: []string{"rpcCall"}, DeniedMethods: []string{"rpcCall"}}, method: "rpcCall", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.MethodAllowed(tt.method); got != tt.want {
+				t.Errorf("MethodAllowed(%q) = %v, want %v", tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApiKeysSnapshot(t *testing.T) {
+	a := NewApiKeys([]*ApiKey{{ID: "b", SecretHash: ApiKeySecretHash("secret-b")}})
+	b := a.With(&ApiKey{ID: "a", SecretHash: ApiKeySecretHash("secret-a")})
+	if len(a.List()) != 1 {
+		t.Fatal("With modified the original snapshot")
+	}
+	if l := b.List(); len(l) != 2 || l[0].ID != "a" || l[1].ID != "b" {
+		t.Fatalf("List() = %v, want keys a and b", l)
+	}
+	if k := b.BySecret("secret-a"); k == nil || k.ID != "a" {
+		t.Fatalf("BySecret() = %v, want key a", k)
+	}
+	// a replaced key is not found by its previous secret
+	c := b.With(&ApiKey{ID: "a", SecretHash: ApiKeySecretHash("secret-a2")})
+	if c.BySecret("secret-a") != nil || c.BySecret("secret-a2") == nil {
+		t.Fatal("replaced key found by the previous secret")
+	}
+	d := c.Without("a")
+	if d.ByID("a") != nil || d.ByID("b") == nil || c.ByID("a") == nil {
+		t.Fatal("Without did not remove only the key from the new snapshot")
+	}
+	var none *ApiKeys
+	if none.BySecret("secret-b") != nil || none.ByID("b") != nil || none.List() != nil {
+		t.Fatal("nil snapshot returned a key")
+	}
+}
@@ -126,6 +126,10 @@ type InternalState struct {
 	// serialized by Pack; replaced wholesale via the accessors below, giving
 	// the rpcCall hot path a lock-free, consistent view.
 	rpcCallAllowlists atomic.Pointer[RpcCallAllowlists]
+
+	// apiKeys is the snapshot of the API keys of the public interfaces, loaded
+	// from the DB and managed by the /admin interface (see server initApiKeys)
+	apiKeys atomic.Pointer[ApiKeys]
 }
 
 // Sources of a runtime setting value, reported by the /admin runtime-settings
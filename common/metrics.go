@@ -45,6 +45,13 @@ type Metrics struct {
 	RestUIActiveIPs                   prometheus.Gauge         `metric:"rest_ui_active_ips"`
 	RestUIMaxActiveRequestsPerIP      prometheus.Gauge         `metric:"rest_ui_max_active_requests_per_ip"`
 	RestUIBlockedIPs                  prometheus.Gauge         `metric:"rest_ui_blocked_ips"`
+	ApiKeyRequests                    *prometheus.CounterVec   `metric:"api_key_requests"`
+	ApiKeyRejections                  *prometheus.CounterVec   `metric:"api_key_rejections"`
+	ApiKeyWebsocketConnections        *prometheus.GaugeVec     `metric:"api_key_websocket_connections"`
+	ApiKeySubscriptions               *prometheus.GaugeVec     `metric:"api_key_subscriptions"`
+	RequestCost                       *prometheus.HistogramVec `metric:"request_cost"`
+	WebsocketCostLimitRejections      *prometheus.CounterVec   `metric:"websocket_cost_limit_rejections"`
+	ApiResultCache                    *prometheus.CounterVec   `metric:"api_result_cache"`
 	IndexResyncDuration               prometheus.Histogram     `metric:"index_resync_duration"`
 	MempoolResyncDuration             prometheus.Histogram     `metric:"mempool_resync_duration"`
 	MempoolResyncThroughput           *prometheus.HistogramVec `metric:"mempool_resync_throughput_txs_per_second"`
@@ -0,0 +1,141 @@
+package db
+
+import (
+	"math/big"
+
+	vlq "github.com/bsm/go-vlq"
+)
+
+// The helpers below pack and unpack the binary blobs stored under a single key (the mempool snapshot,
+// the websocket journal) as a sequence of varints, length prefixed byte slices and optional big ints.
+
+func appendBlobVaruint(buf []byte, i uint64) []byte {
+	var varBuf [vlq.MaxLen64]byte
+	l := vlq.PutUint(varBuf[:], i)
+	return append(buf, varBuf[:l]...)
+}
+
+func appendBlobVarint(buf []byte, i int64) []byte {
+	var varBuf [vlq.MaxLen64]byte
+	l := vlq.PutInt(varBuf[:], i)
+	return append(buf, varBuf[:l]...)
+}
+
+func appendBlobBytes(buf []byte, b []byte) []byte {
+	buf = appendBlobVaruint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// appendBlobBigint packs the optional big int as a presence flag followed by the packed value
+func appendBlobBigint(buf []byte, bi *big.Int) []byte {
+	if bi == nil {
+		return append(buf, 0)
+	}
+	var varBuf [maxPackedBigintBytes]byte
+	l := packBigint(bi, varBuf[:])
+	buf = append(buf, 1)
+	return append(buf, varBuf[:l]...)
+}
+
+// blobReader unpacks a blob, the first error stops the reading
+type blobReader struct {
+	buf []byte
+	err error
+	// inconsistent is the error returned if the blob is truncated or malformed
+	inconsistent error
+}
+
+func newBlobReader(buf []byte, inconsistent error) blobReader {
+	return blobReader{buf: buf, inconsistent: inconsistent}
+}
+
+func (r *blobReader) varuint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.buf) == 0 {
+		r.err = r.inconsistent
+		return 0
+	}
+	i, l := vlq.Uint(r.buf)
+	if l <= 0 || l > len(r.buf) {
+		r.err = r.inconsistent
+		return 0
+	}
+	r.buf = r.buf[l:]
+	return i
+}
+
+func (r *blobReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.buf) == 0 {
+		r.err = r.inconsistent
+		return 0
+	}
+	i, l := vlq.Int(r.buf)
+	if l <= 0 || l > len(r.buf) {
+		r.err = r.inconsistent
+		return 0
+	}
+	r.buf = r.buf[l:]
+	return i
+}
+
+func (r *blobReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.buf) == 0 {
+		r.err = r.inconsistent
+		return 0
+	}
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *blobReader) bytes() []byte {
+	l := r.varuint()
+	if r.err != nil {
+		return nil
+	}
+	if l > uint64(len(r.buf)) {
+		r.err = r.inconsistent
+		return nil
+	}
+	b := r.buf[:l:l]
+	r.buf = r.buf[l:]
+	return b
+}
+
+// count reads the number of the following items, each of them takes at least minItemLen bytes
+func (r *blobReader) count(minItemLen int) int {
+	c := r.varuint()
+	if r.err == nil && c > uint64(len(r.buf)/minItemLen) {
+		r.err = r.inconsistent
+		return 0
+	}
+	return int(c)
+}
+
+func (r *blobReader) bigint() *big.Int {
+	if r.byte() == 0 || r.err != nil {
+		return nil
+	}
+	if len(r.buf) == 0 || packedBigintLen(r.buf) > len(r.buf) {
+		r.err = r.inconsistent
+		return nil
+	}
+	bi, l := unpackBigint(r.buf)
+	r.buf = r.buf[l:]
+	return &bi
+}
+
+// end checks that the whole blob was read
+func (r *blobReader) end() {
+	if r.err == nil && len(r.buf) != 0 {
+		r.err = r.inconsistent
+	}
+}
@@ -346,6 +346,14 @@ const (
 	// cfErcProtocols stores per-protocol detection records keyed by contract;
 	// decoupled from cfContracts so API writes never collide with sync.
 	cfErcProtocols
+	// cfUserOperations stores ERC-4337 UserOperations executed in a transaction
+	cfUserOperations
+	// cfAuthorizations stores EIP-7702 authorizations of a set-code transaction
+	cfAuthorizations
+	// cfWithdrawals stores EIP-4895 beacon chain withdrawals of a block
+	cfWithdrawals
+	// cfAddressWithdrawals indexes EIP-4895 beacon chain withdrawals under their recipient
+	cfAddressWithdrawals
 )
 
 // common columns
@@ -354,7 +362,7 @@ var cfBaseNames = []string{"default", "height", "addresses", "blockTxs", "transa
 
 // type specific columns
 var cfNamesBitcoinType = []string{"addressBalance", "txAddresses", "blockFilter"}
-var cfNamesEthereumType = []string{"addressContracts", "internalData", "contracts", "functionSignatures", "blockInternalDataErrors", "addressAliases", "ercProtocols"}
+var cfNamesEthereumType = []string{"addressContracts", "internalData", "contracts", "functionSignatures", "blockInternalDataErrors", "addressAliases", "ercProtocols", "userOperations", "authorizations", "withdrawals", "addressWithdrawals"}
 
 func openDB(path string, c *grocksdb.Cache, openFiles int) (*grocksdb.DB, []*grocksdb.ColumnFamilyHandle, error) {
 	// opts with bloom filter
@@ -1315,6 +1323,31 @@ func (d *RocksDB) GetAddrDescBalance(addrDesc bchain.AddressDescriptor, detail A
 	return unpackAddrBalance(buf, d.chainParser.PackedTxidLen(), detail)
 }
 
+// MultiGetAddrDescBalance returns the balances of the addresses read by one MultiGet, the balance is nil for the addresses not found
+func (d *RocksDB) MultiGetAddrDescBalance(addrDescs []bchain.AddressDescriptor, detail AddressBalanceDetail) ([]*AddrBalance, error) {
+	keys := make([][]byte, len(addrDescs))
+	for i := range addrDescs {
+		keys[i] = addrDescs[i]
+	}
+	vals, err := d.db.MultiGetCF(d.ro, d.cfh[cfAddressBalance], keys...)
+	if err != nil {
+		return nil, err
+	}
+	defer vals.Destroy()
+	balances := make([]*AddrBalance, len(addrDescs))
+	for i, val := range vals {
+		buf := val.Data()
+		// 3 is minimum length of addrBalance - 1 byte txs, 1 byte sent, 1 byte balance
+		if len(buf) < 3 {
+			continue
+		}
+		if balances[i], err = unpackAddrBalance(buf, d.chainParser.PackedTxidLen(), detail); err != nil {
+			return nil, err
+		}
+	}
+	return balances, nil
+}
+
 // GetAddressBalance returns address balance for an address or nil if address not found
 func (d *RocksDB) GetAddressBalance(address string, detail AddressBalanceDetail) (*AddrBalance, error) {
 	addrDesc, err := d.chainParser.GetAddrDescFromAddress(address)
@@ -2116,6 +2149,78 @@ func (d *RocksDB) disconnectBlock(height uint32, blockTxs []blockTxs) error {
 	return d.WriteBatch(wb)
 }
 
+// GetBlocksToDisconnect returns the blocks in range lower-higher with their transactions and the addresses affected by them,
+// ordered from the highest block. It describes the blocks to the subscribers before the blocks are disconnected.
+func (d *RocksDB) GetBlocksToDisconnect(lower uint32, higher uint32) ([]bchain.DisconnectedBlock, error) {
+	blocks := make([]bchain.DisconnectedBlock, 0, higher-lower+1)
+	for height := higher; height >= lower; height-- {
+		hash, err := d.GetBlockHash(height)
+		if err != nil {
+			return nil, err
+		}
+		var txs []bchain.DisconnectedTx
+		if d.chainParser.GetChainType() == bchain.ChainEthereumType {
+			txs, err = d.getDisconnectedTxsEthereumType(height)
+		} else {
+			txs, err = d.getDisconnectedTxsBitcoinType(height)
+		}
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, bchain.DisconnectedBlock{Height: height, Hash: hash, Txs: txs})
+		if height == 0 {
+			break
+		}
+	}
+	return blocks, nil
+}
+
+// disconnectedTxAddrDescs collects the unique addresses of a disconnected transaction
+type disconnectedTxAddrDescs struct {
+	addrDescs []bchain.AddressDescriptor
+	seen      map[string]struct{}
+}
+
+func (a *disconnectedTxAddrDescs) add(addrDesc bchain.AddressDescriptor) {
+	if len(addrDesc) == 0 {
+		return
+	}
+	if _, found := a.seen[string(addrDesc)]; found {
+		return
+	}
+	a.seen[string(addrDesc)] = struct{}{}
+	a.addrDescs = append(a.addrDescs, addrDesc)
+}
+
+func (d *RocksDB) getDisconnectedTxsBitcoinType(height uint32) ([]bchain.DisconnectedTx, error) {
+	blockTxs, err := d.getBlockTxs(height)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]bchain.DisconnectedTx, 0, len(blockTxs))
+	for i := range blockTxs {
+		txid, err := d.chainParser.UnpackTxid(blockTxs[i].btxID)
+		if err != nil {
+			return nil, err
+		}
+		txa, err := d.getTxAddresses(blockTxs[i].btxID)
+		if err != nil {
+			return nil, err
+		}
+		a := disconnectedTxAddrDescs{seen: make(map[string]struct{})}
+		if txa != nil {
+			for j := range txa.Inputs {
+				a.add(txa.Inputs[j].AddrDesc)
+			}
+			for j := range txa.Outputs {
+				a.add(txa.Outputs[j].AddrDesc)
+			}
+		}
+		txs = append(txs, bchain.DisconnectedTx{Txid: txid, AddrDescs: a.addrDescs})
+	}
+	return txs, nil
+}
+
 // DisconnectBlockRangeBitcoinType removes all data belonging to blocks in range lower-higher
 // it is able to disconnect only blocks for which there are data in the blockTxs column
 func (d *RocksDB) DisconnectBlockRangeBitcoinType(lower uint32, higher uint32) error {
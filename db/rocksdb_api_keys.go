@@ -0,0 +1,47 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/common"
+)
+
+// apiKeyKeyPrefix prefixes cfDefault keys holding the API keys written through
+// the internal /admin interface, one row per key stored under its ID.
+const apiKeyKeyPrefix = "apiKey:"
+
+// GetApiKeys returns all stored API keys
+func (d *RocksDB) GetApiKeys() ([]*common.ApiKey, error) {
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfDefault])
+	defer it.Close()
+	prefix := []byte(apiKeyKeyPrefix)
+	var keys []*common.ApiKey
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		key := it.Key().Data()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		var k common.ApiKey
+		if err := json.Unmarshal(it.Value().Data(), &k); err != nil {
+			return nil, errors.Annotatef(err, "cannot unpack API key %s", key[len(prefix):])
+		}
+		keys = append(keys, &k)
+	}
+	return keys, nil
+}
+
+// StoreApiKey persists the API key, replacing a stored key with the same ID
+func (d *RocksDB) StoreApiKey(k *common.ApiKey) error {
+	buf, err := json.Marshal(k)
+	if err != nil {
+		return err
+	}
+	return d.db.PutCF(d.wo, d.cfh[cfDefault], []byte(apiKeyKeyPrefix+k.ID), buf)
+}
+
+// DeleteApiKey removes the API key with the given ID
+func (d *RocksDB) DeleteApiKey(id string) error {
+	return d.db.DeleteCF(d.wo, d.cfh[cfDefault], []byte(apiKeyKeyPrefix+id))
+}
@@ -0,0 +1,77 @@
+//go:build unittest
+
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/common"
+)
+
+func TestRocksDB_ApiKeys(t *testing.T) {
+	d := setupRocksDB(t, &testBitcoinParser{
+		BitcoinParser: bitcoinTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	keys, err := d.GetApiKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 0 {
+		t.Fatalf("GetApiKeys() = %v, want no keys", keys)
+	}
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	partner := &common.ApiKey{
+		ID:                      "partner",
+		SecretHash:              common.ApiKeySecretHash("secret1"),
+		RateLimit:               600,
+		RateWindow:              time.Minute,
+		Burst:                   100,
+		MaxWebsocketConnections: 1000,
+		MaxSubscriptions:        5000,
+		DeniedMethods:           []string{"rpcCall"},
+		Created:                 created,
+		Updated:                 created,
+	}
+	wallet := &common.ApiKey{ID: "wallet", SecretHash: common.ApiKeySecretHash("secret2"), AllowedMethods: []string{"getInfo"}, Created: created, Updated: created}
+	for _, k := range []*common.ApiKey{wallet, partner} {
+		if err := d.StoreApiKey(k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// other rows of the default column must not be listed as keys
+	if err := d.StoreRuntimeSetting("ALLOWED_RPC_CALL_TO", "0x1234"); err != nil {
+		t.Fatal(err)
+	}
+	keys, err = d.GetApiKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*common.ApiKey{partner, wallet}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("GetApiKeys() = %+v, want %+v", keys, want)
+	}
+
+	// overwrite and delete
+	partner.RateLimit = 60
+	if err := d.StoreApiKey(partner); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.DeleteApiKey("wallet"); err != nil {
+		t.Fatal(err)
+	}
+	keys, err = d.GetApiKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*common.ApiKey{partner}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("GetApiKeys() after update = %+v, want %+v", keys, want)
+	}
+	// deleting a missing key is not an error
+	if err := d.DeleteApiKey("wallet"); err != nil {
+		t.Fatal(err)
+	}
+}
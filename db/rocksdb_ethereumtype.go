@@ -3,6 +3,7 @@ package db
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"math/big"
 	"os"
 	"sort"
@@ -351,6 +352,30 @@ func (d *RocksDB) GetAddrDescContracts(addrDesc bchain.AddressDescriptor) (*Addr
 	return unpackAddrContracts(buf, addrDesc)
 }
 
+// MultiGetAddrDescContracts returns the contracts of the addresses read by one MultiGet, the contracts are nil for the addresses not found
+func (d *RocksDB) MultiGetAddrDescContracts(addrDescs []bchain.AddressDescriptor) ([]*AddrContracts, error) {
+	keys := make([][]byte, len(addrDescs))
+	for i := range addrDescs {
+		keys[i] = addrDescs[i]
+	}
+	vals, err := d.db.MultiGetCF(d.ro, d.cfh[cfAddressContracts], keys...)
+	if err != nil {
+		return nil, err
+	}
+	defer vals.Destroy()
+	contracts := make([]*AddrContracts, len(addrDescs))
+	for i, val := range vals {
+		buf := val.Data()
+		if len(buf) == 0 {
+			continue
+		}
+		if contracts[i], err = unpackAddrContracts(buf, addrDescs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return contracts, nil
+}
+
 func findContractInAddressContracts(contract bchain.AddressDescriptor, contracts []unpackedAddrContract) (int, bool) {
 	for i := range contracts {
 		if bytes.Equal(contract, contracts[i].Contract) {
@@ -541,11 +566,17 @@ type ethInternalData struct {
 	errorMsg     string
 }
 
+type ethAuthorization struct {
+	authority, delegate bchain.AddressDescriptor
+}
+
 type ethBlockTx struct {
-	btxID        []byte
-	from, to     bchain.AddressDescriptor
-	contracts    []ethBlockTxContract
-	internalData *ethInternalData
+	btxID          []byte
+	from, to       bchain.AddressDescriptor
+	contracts      []ethBlockTxContract
+	internalData   *ethInternalData
+	userOpSenders  []bchain.AddressDescriptor
+	authorizations []ethAuthorization
 }
 
 func (d *RocksDB) processBaseTxData(blockTx *ethBlockTx, tx *bchain.Tx, addresses addressesMap, addressContracts map[string]*unpackedAddrContracts) error {
@@ -709,6 +740,94 @@ func (d *RocksDB) processContractTransfers(blockTx *ethBlockTx, tx *bchain.Tx, a
 	return nil
 }
 
+// processUserOperations indexes the transaction under the senders of the ERC-4337 UserOperations it executes,
+// the sender is indexed as the originator of an internal transfer, the EntryPoint calls the smart account.
+// Only the senders are stored, they are needed to remove the index on disconnect, the API decodes
+// the UserOperations from the receipt logs of the transaction.
+func (d *RocksDB) processUserOperations(blockTx *ethBlockTx, tx *bchain.Tx, addresses addressesMap, addressContracts map[string]*unpackedAddrContracts) error {
+	userOps, err := d.chainParser.EthereumTypeGetUserOperationsFromTx(tx)
+	if err != nil {
+		glog.Warningf("rocksdb: processUserOperations %v, tx %v", err, tx.Txid)
+		return nil
+	}
+	if len(userOps) == 0 {
+		return nil
+	}
+	blockTx.userOpSenders = make([]bchain.AddressDescriptor, 0, len(userOps))
+	for i := range userOps {
+		op := &userOps[i]
+		sender, err := d.chainParser.GetAddrDescFromAddress(op.Sender)
+		if err != nil {
+			glog.Warningf("rocksdb: processUserOperations %v, tx %v, user operation %v", err, tx.Txid, op.UserOpHash)
+			continue
+		}
+		// a sender with multiple operations in the transaction is indexed only once
+		indexed := false
+		for _, s := range blockTx.userOpSenders {
+			if bytes.Equal(s, sender) {
+				indexed = true
+				break
+			}
+		}
+		if indexed {
+			continue
+		}
+		if err = d.addToAddressesAndContractsEthereumType(sender, blockTx.btxID, internalTransferFrom, nil, nil, true, true, addresses, addressContracts); err != nil {
+			return err
+		}
+		blockTx.userOpSenders = append(blockTx.userOpSenders, sender)
+	}
+	return nil
+}
+
+// processAuthorizations indexes the transaction under the authorities of its EIP-7702 authorizations,
+// the authority signs the authorization as the sender signs the transaction and is indexed the same way.
+// Authorities equal to the sender or the recipient of the transaction are already indexed.
+func (d *RocksDB) processAuthorizations(blockTx *ethBlockTx, tx *bchain.Tx, addresses addressesMap, addressContracts map[string]*unpackedAddrContracts) error {
+	csd, ok := tx.CoinSpecificData.(bchain.EthereumSpecificData)
+	if !ok || csd.Tx == nil || len(csd.Tx.AuthorizationList) == 0 {
+		return nil
+	}
+	for i := range csd.Tx.AuthorizationList {
+		a := &csd.Tx.AuthorizationList[i]
+		// authorizations with invalid signature do not have authority, they are skipped by the EVM
+		if a.Authority == "" {
+			continue
+		}
+		authority, err := d.chainParser.GetAddrDescFromAddress(a.Authority)
+		if err != nil {
+			glog.Warningf("rocksdb: processAuthorizations %v, tx %v, authority %v", err, tx.Txid, a.Authority)
+			continue
+		}
+		delegate, err := d.chainParser.GetAddrDescFromAddress(a.Address)
+		if err != nil {
+			glog.Warningf("rocksdb: processAuthorizations %v, tx %v, address %v", err, tx.Txid, a.Address)
+			continue
+		}
+		if isAuthorityIndexed(authority, blockTx.from, blockTx.to, blockTx.authorizations) {
+			if err = d.addToAddressesAndContractsEthereumType(authority, blockTx.btxID, transferFrom, nil, nil, true, true, addresses, addressContracts); err != nil {
+				return err
+			}
+		}
+		blockTx.authorizations = append(blockTx.authorizations, ethAuthorization{authority: authority, delegate: delegate})
+	}
+	return nil
+}
+
+// isAuthorityIndexed returns true if the authority is indexed by processAuthorizations,
+// i.e. it is not the sender or the recipient of the transaction or an authority of a preceding authorization
+func isAuthorityIndexed(authority, from, to bchain.AddressDescriptor, preceding []ethAuthorization) bool {
+	if bytes.Equal(authority, from) || bytes.Equal(authority, to) {
+		return false
+	}
+	for i := range preceding {
+		if bytes.Equal(authority, preceding[i].authority) {
+			return false
+		}
+	}
+	return true
+}
+
 func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses addressesMap, addressContracts map[string]*unpackedAddrContracts) ([]ethBlockTx, error) {
 	if d.hotAddrTracker != nil {
 		d.hotAddrTracker.BeginBlock()
@@ -736,6 +855,12 @@ func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses ad
 		if err = d.processContractTransfers(blockTx, tx, addresses, addressContracts); err != nil {
 			return nil, err
 		}
+		if err = d.processUserOperations(blockTx, tx, addresses, addressContracts); err != nil {
+			return nil, err
+		}
+		if err = d.processAuthorizations(blockTx, tx, addresses, addressContracts); err != nil {
+			return nil, err
+		}
 	}
 	return blockTxs, nil
 }
@@ -863,6 +988,262 @@ func (d *RocksDB) unpackEthInternalData(buf []byte) (*bchain.EthereumInternalDat
 	return &id, nil
 }
 
+func packEthUserOpSenders(senders []bchain.AddressDescriptor) []byte {
+	buf := make([]byte, 0, 1+len(senders)*eth.EthereumTypeAddressDescriptorLen)
+	varBuf := make([]byte, maxPackedBigintBytes)
+	l := packVaruint(uint(len(senders)), varBuf)
+	buf = append(buf, varBuf[:l]...)
+	for _, sender := range senders {
+		buf = appendAddress(buf, sender)
+	}
+	return buf
+}
+
+func unpackEthUserOpSenders(buf []byte) ([]bchain.AddressDescriptor, error) {
+	c, l := unpackVaruint(buf)
+	if c > uint(len(buf)) || len(buf)-l != int(c)*eth.EthereumTypeAddressDescriptorLen {
+		return nil, errors.New("Inconsistent data in userOperations")
+	}
+	senders := make([]bchain.AddressDescriptor, c)
+	for i := range senders {
+		senders[i] = append(bchain.AddressDescriptor(nil), buf[l:l+eth.EthereumTypeAddressDescriptorLen]...)
+		l += eth.EthereumTypeAddressDescriptorLen
+	}
+	return senders, nil
+}
+
+func (d *RocksDB) getEthereumUserOpSenders(btxID []byte) ([]bchain.AddressDescriptor, error) {
+	val, err := d.db.GetCF(d.ro, d.cfh[cfUserOperations], btxID)
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	buf := val.Data()
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	return unpackEthUserOpSenders(buf)
+}
+
+func packEthAuthorizations(authorizations []ethAuthorization) []byte {
+	buf := make([]byte, 0, 1+len(authorizations)*2*eth.EthereumTypeAddressDescriptorLen)
+	varBuf := make([]byte, maxPackedBigintBytes)
+	l := packVaruint(uint(len(authorizations)), varBuf)
+	buf = append(buf, varBuf[:l]...)
+	for i := range authorizations {
+		buf = appendAddress(buf, authorizations[i].authority)
+		buf = appendAddress(buf, authorizations[i].delegate)
+	}
+	return buf
+}
+
+func unpackEthAuthorizations(buf []byte) ([]ethAuthorization, error) {
+	c, l := unpackVaruint(buf)
+	if c > uint(len(buf)) || len(buf)-l != int(c)*2*eth.EthereumTypeAddressDescriptorLen {
+		return nil, errors.New("Inconsistent data in authorizations")
+	}
+	authorizations := make([]ethAuthorization, c)
+	for i := range authorizations {
+		authorizations[i].authority = append(bchain.AddressDescriptor(nil), buf[l:l+eth.EthereumTypeAddressDescriptorLen]...)
+		l += eth.EthereumTypeAddressDescriptorLen
+		authorizations[i].delegate = append(bchain.AddressDescriptor(nil), buf[l:l+eth.EthereumTypeAddressDescriptorLen]...)
+		l += eth.EthereumTypeAddressDescriptorLen
+	}
+	return authorizations, nil
+}
+
+func (d *RocksDB) getEthereumAuthorizations(btxID []byte) ([]ethAuthorization, error) {
+	val, err := d.db.GetCF(d.ro, d.cfh[cfAuthorizations], btxID)
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	buf := val.Data()
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	return unpackEthAuthorizations(buf)
+}
+
+// Withdrawal is an EIP-4895 beacon chain withdrawal crediting the amount to the address outside of any transaction
+type Withdrawal struct {
+	Height         uint32
+	Index          uint64
+	ValidatorIndex uint64
+	AddrDesc       bchain.AddressDescriptor
+	Amount         big.Int
+}
+
+// GetWithdrawalsCallback is called by GetAddrDescWithdrawals for each found withdrawal
+type GetWithdrawalsCallback func(w *Withdrawal) error
+
+// packWithdrawals packs the withdrawals, the address is stored only in the record of the block (withAddress)
+func packWithdrawals(withdrawals []Withdrawal, withAddress bool) []byte {
+	buf := make([]byte, 0, 1+len(withdrawals)*(eth.EthereumTypeAddressDescriptorLen+24))
+	varBuf := make([]byte, maxPackedBigintBytes)
+	l := packVaruint(uint(len(withdrawals)), varBuf)
+	buf = append(buf, varBuf[:l]...)
+	for i := range withdrawals {
+		w := &withdrawals[i]
+		l = packVaruint(uint(w.Index), varBuf)
+		buf = append(buf, varBuf[:l]...)
+		l = packVaruint(uint(w.ValidatorIndex), varBuf)
+		buf = append(buf, varBuf[:l]...)
+		if withAddress {
+			buf = appendAddress(buf, w.AddrDesc)
+		}
+		l = packBigint(&w.Amount, varBuf)
+		buf = append(buf, varBuf[:l]...)
+	}
+	return buf
+}
+
+func unpackWithdrawals(buf []byte, withAddress bool) ([]Withdrawal, error) {
+	c, l := unpackVaruint(buf)
+	if c > uint(len(buf)) {
+		return nil, errors.New("Inconsistent data in withdrawals")
+	}
+	withdrawals := make([]Withdrawal, c)
+	for i := range withdrawals {
+		w := &withdrawals[i]
+		if l >= len(buf) {
+			return nil, errors.New("Inconsistent data in withdrawals")
+		}
+		v, ll := unpackVaruint(buf[l:])
+		w.Index = uint64(v)
+		l += ll
+		if l >= len(buf) {
+			return nil, errors.New("Inconsistent data in withdrawals")
+		}
+		v, ll = unpackVaruint(buf[l:])
+		w.ValidatorIndex = uint64(v)
+		l += ll
+		if withAddress {
+			if l+eth.EthereumTypeAddressDescriptorLen > len(buf) {
+				return nil, errors.New("Inconsistent data in withdrawals")
+			}
+			w.AddrDesc = append(bchain.AddressDescriptor(nil), buf[l:l+eth.EthereumTypeAddressDescriptorLen]...)
+			l += eth.EthereumTypeAddressDescriptorLen
+		}
+		if l >= len(buf) {
+			return nil, errors.New("Inconsistent data in withdrawals")
+		}
+		w.Amount, ll = unpackBigint(buf[l:])
+		l += ll
+	}
+	if l != len(buf) {
+		return nil, errors.New("Inconsistent data in withdrawals")
+	}
+	return withdrawals, nil
+}
+
+// storeWithdrawalsEthereumType stores the withdrawals of the block and indexes them under their recipients
+func (d *RocksDB) storeWithdrawalsEthereumType(wb *grocksdb.WriteBatch, height uint32, blockWithdrawals []bchain.EthereumWithdrawal) error {
+	if len(blockWithdrawals) == 0 {
+		return nil
+	}
+	withdrawals := make([]Withdrawal, len(blockWithdrawals))
+	addresses := make([]string, 0, len(blockWithdrawals))
+	byAddress := make(map[string][]Withdrawal)
+	for i := range blockWithdrawals {
+		bw := &blockWithdrawals[i]
+		addrDesc, err := d.chainParser.GetAddrDescFromAddress(bw.Address)
+		if err != nil {
+			return errors.Annotatef(err, "withdrawal %v address %v", bw.Index, bw.Address)
+		}
+		w := Withdrawal{
+			Height:         height,
+			Index:          bw.Index,
+			ValidatorIndex: bw.ValidatorIndex,
+			AddrDesc:       addrDesc,
+			Amount:         bw.Amount,
+		}
+		withdrawals[i] = w
+		s := string(addrDesc)
+		if _, found := byAddress[s]; !found {
+			addresses = append(addresses, s)
+		}
+		byAddress[s] = append(byAddress[s], w)
+	}
+	wb.PutCF(d.cfh[cfWithdrawals], packUint(height), packWithdrawals(withdrawals, true))
+	for _, s := range addresses {
+		wb.PutCF(d.cfh[cfAddressWithdrawals], packAddressKey(bchain.AddressDescriptor(s), height), packWithdrawals(byAddress[s], false))
+	}
+	return nil
+}
+
+// GetBlockWithdrawals returns the EIP-4895 withdrawals of the block at the given height
+func (d *RocksDB) GetBlockWithdrawals(height uint32) ([]Withdrawal, error) {
+	val, err := d.db.GetCF(d.ro, d.cfh[cfWithdrawals], packUint(height))
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	buf := val.Data()
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	withdrawals, err := unpackWithdrawals(buf, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range withdrawals {
+		withdrawals[i].Height = height
+	}
+	return withdrawals, nil
+}
+
+// GetAddrDescWithdrawals calls fn for the withdrawals credited to the address in the blocks with height in the range [lower, higher],
+// from the newest to the oldest
+func (d *RocksDB) GetAddrDescWithdrawals(addrDesc bchain.AddressDescriptor, lower uint32, higher uint32, fn GetWithdrawalsCallback) error {
+	startKey := packAddressKey(addrDesc, higher)
+	stopKey := packAddressKey(addrDesc, lower)
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfAddressWithdrawals])
+	defer it.Close()
+	for it.Seek(startKey); it.Valid(); it.Next() {
+		key := it.Key().Data()
+		if bytes.Compare(key, stopKey) > 0 {
+			break
+		}
+		if len(key) != len(addrDesc)+packedHeightBytes {
+			continue
+		}
+		_, height, err := unpackAddressKey(key)
+		if err != nil {
+			return err
+		}
+		withdrawals, err := unpackWithdrawals(it.Value().Data(), false)
+		if err != nil {
+			return err
+		}
+		for i := len(withdrawals) - 1; i >= 0; i-- {
+			w := &withdrawals[i]
+			w.Height = height
+			w.AddrDesc = addrDesc
+			if err := fn(w); err != nil {
+				if _, ok := err.(*StopIteration); ok {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// disconnectWithdrawalsEthereumType removes the withdrawals of the block and their index
+func (d *RocksDB) disconnectWithdrawalsEthereumType(wb *grocksdb.WriteBatch, height uint32) error {
+	withdrawals, err := d.GetBlockWithdrawals(height)
+	if err != nil {
+		return err
+	}
+	for i := range withdrawals {
+		wb.DeleteCF(d.cfh[cfAddressWithdrawals], packAddressKey(withdrawals[i].AddrDesc, height))
+	}
+	wb.DeleteCF(d.cfh[cfWithdrawals], packUint(height))
+	return nil
+}
+
 // FourByteSignature contains 4byte signature of transaction value with parameters
 // and parsed parameters (that are not stored in DB)
 func packFourByteKey(fourBytes uint32, id uint32) []byte {
@@ -951,6 +1332,50 @@ func (d *RocksDB) StoreFourByteSignature(wb *grocksdb.WriteBatch, fourBytes uint
 	return nil
 }
 
+const fourByteSignaturesStateKey = "FourByteSignaturesState"
+
+// GetFourByteSignaturesState gets the persisted progress of the 4byte signatures import,
+// nil if no state was stored yet
+func (d *RocksDB) GetFourByteSignaturesState() (*bchain.FourByteSignaturesState, error) {
+	val, err := d.db.GetCF(d.ro, d.cfh[cfDefault], []byte(fourByteSignaturesStateKey))
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	data := val.Data()
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var state bchain.FourByteSignaturesState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.Annotatef(err, "cannot unpack 4byte signatures state")
+	}
+	return &state, nil
+}
+
+// StoreFourByteSignaturesState stores the progress of the 4byte signatures import
+func (d *RocksDB) StoreFourByteSignaturesState(state *bchain.FourByteSignaturesState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return d.db.PutCF(d.wo, d.cfh[cfDefault], []byte(fourByteSignaturesStateKey), data)
+}
+
+// CountFourByteSignatures counts the 4byte signatures stored in DB
+func (d *RocksDB) CountFourByteSignatures() (int64, error) {
+	ro := grocksdb.NewDefaultReadOptions()
+	defer ro.Destroy()
+	ro.SetFillCache(false)
+	it := d.db.NewIteratorCF(ro, d.cfh[cfFunctionSignatures])
+	defer it.Close()
+	var count int64
+	for it.SeekToFirst(); it.Valid(); it.Next() {
+		count++
+	}
+	return count, it.Err()
+}
+
 // GetEthereumInternalData gets transaction internal data from DB
 func (d *RocksDB) GetEthereumInternalData(txid string) (*bchain.EthereumInternalData, error) {
 	btxID, err := d.chainParser.PackTxid(txid)
@@ -973,12 +1398,19 @@ func (d *RocksDB) getEthereumInternalData(btxID []byte) (*bchain.EthereumInterna
 	return d.unpackEthInternalData(buf)
 }
 
+// storeInternalDataEthereumType stores the internal data, the ERC-4337 UserOperations and the EIP-7702 authorizations of the transactions
 func (d *RocksDB) storeInternalDataEthereumType(wb *grocksdb.WriteBatch, blockTxs []ethBlockTx) error {
 	for i := range blockTxs {
 		blockTx := &blockTxs[i]
 		if blockTx.internalData != nil {
 			wb.PutCF(d.cfh[cfInternalData], blockTx.btxID, packEthInternalData(blockTx.internalData))
 		}
+		if len(blockTx.userOpSenders) > 0 {
+			wb.PutCF(d.cfh[cfUserOperations], blockTx.btxID, packEthUserOpSenders(blockTx.userOpSenders))
+		}
+		if len(blockTx.authorizations) > 0 {
+			wb.PutCF(d.cfh[cfAuthorizations], blockTx.btxID, packEthAuthorizations(blockTx.authorizations))
+		}
 	}
 	return nil
 }
@@ -1111,6 +1543,9 @@ func (d *RocksDB) storeBlockSpecificDataEthereumType(wb *grocksdb.WriteBatch, bl
 				return err
 			}
 		}
+		if err := d.storeWithdrawalsEthereumType(wb, block.Height, blockSpecificData.Withdrawals); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1186,6 +1621,37 @@ func unpackBlockTx(buf []byte, pos int) (*ethBlockTx, int, error) {
 	}, pos, nil
 }
 
+func (d *RocksDB) getDisconnectedTxsEthereumType(height uint32) ([]bchain.DisconnectedTx, error) {
+	blockTxs, err := d.getBlockTxsEthereumType(height)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]bchain.DisconnectedTx, 0, len(blockTxs))
+	for i := range blockTxs {
+		btx := &blockTxs[i]
+		txid, err := d.chainParser.UnpackTxid(btx.btxID)
+		if err != nil {
+			return nil, err
+		}
+		a := disconnectedTxAddrDescs{seen: make(map[string]struct{})}
+		a.add(btx.from)
+		a.add(btx.to)
+		for j := range btx.contracts {
+			a.add(btx.contracts[j].from)
+			a.add(btx.contracts[j].to)
+		}
+		if btx.internalData != nil {
+			a.add(btx.internalData.contract)
+			for j := range btx.internalData.transfers {
+				a.add(btx.internalData.transfers[j].from)
+				a.add(btx.internalData.transfers[j].to)
+			}
+		}
+		txs = append(txs, bchain.DisconnectedTx{Txid: txid, AddrDescs: a.addrDescs})
+	}
+	return txs, nil
+}
+
 func (d *RocksDB) getBlockTxsEthereumType(height uint32) ([]ethBlockTx, error) {
 	val, err := d.db.GetCF(d.ro, d.cfh[cfBlockTxs], packUint(height))
 	if err != nil {
@@ -1347,6 +1813,34 @@ func (d *RocksDB) disconnectInternalData(btxID []byte, addresses map[string]map[
 	return nil
 }
 
+func (d *RocksDB) disconnectUserOperations(btxID []byte, addresses map[string]map[string]struct{}, contracts map[string]*unpackedAddrContracts) error {
+	senders, err := d.getEthereumUserOpSenders(btxID)
+	if err != nil {
+		return err
+	}
+	for _, sender := range senders {
+		if err := d.disconnectAddress(btxID, true, sender, nil, addresses, contracts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *RocksDB) disconnectAuthorizations(blockTx *ethBlockTx, addresses map[string]map[string]struct{}, contracts map[string]*unpackedAddrContracts) error {
+	authorizations, err := d.getEthereumAuthorizations(blockTx.btxID)
+	if err != nil {
+		return err
+	}
+	for i := range authorizations {
+		if isAuthorityIndexed(authorizations[i].authority, blockTx.from, blockTx.to, authorizations[:i]) {
+			if err := d.disconnectAddress(blockTx.btxID, false, authorizations[i].authority, nil, addresses, contracts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (d *RocksDB) disconnectBlockTxsEthereumType(wb *grocksdb.WriteBatch, height uint32, blockTxs []ethBlockTx, contracts map[string]*unpackedAddrContracts) error {
 	glog.Info("Disconnecting block ", height, " containing ", len(blockTxs), " transactions")
 	addresses := make(map[string]map[string]struct{})
@@ -1367,6 +1861,12 @@ func (d *RocksDB) disconnectBlockTxsEthereumType(wb *grocksdb.WriteBatch, height
 			return err
 
 		}
+		if err := d.disconnectUserOperations(blockTx.btxID, addresses, contracts); err != nil {
+			return err
+		}
+		if err := d.disconnectAuthorizations(blockTx, addresses, contracts); err != nil {
+			return err
+		}
 		// contracts
 		for j := range blockTx.contracts {
 			c := &blockTx.contracts[j]
@@ -1381,6 +1881,8 @@ func (d *RocksDB) disconnectBlockTxsEthereumType(wb *grocksdb.WriteBatch, height
 		}
 		wb.DeleteCF(d.cfh[cfTransactions], blockTx.btxID)
 		wb.DeleteCF(d.cfh[cfInternalData], blockTx.btxID)
+		wb.DeleteCF(d.cfh[cfUserOperations], blockTx.btxID)
+		wb.DeleteCF(d.cfh[cfAuthorizations], blockTx.btxID)
 	}
 	for a := range addresses {
 		key := packAddressKey([]byte(a), height)
@@ -1416,6 +1918,9 @@ func (d *RocksDB) DisconnectBlockRangeEthereumType(lower uint32, higher uint32)
 		if err := d.disconnectBlockTxsEthereumType(wb, height, blocks[height-lower], contracts); err != nil {
 			return err
 		}
+		if err := d.disconnectWithdrawalsEthereumType(wb, height); err != nil {
+			return err
+		}
 		key := packUint(height)
 		wb.DeleteCF(d.cfh[cfBlockTxs], key)
 		wb.DeleteCF(d.cfh[cfHeight], key)
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/linxGnu/grocksdb"
@@ -40,6 +41,15 @@ func (p *txTokenTransferTestParser) EthereumTypeGetTokenTransfersFromTx(tx *bcha
 	return p.transfers[tx.Txid], nil
 }
 
+type userOperationsTestParser struct {
+	*eth.EthereumParser
+	userOperations map[string][]bchain.EthereumUserOperation
+}
+
+func (p *userOperationsTestParser) EthereumTypeGetUserOperationsFromTx(tx *bchain.Tx) ([]bchain.EthereumUserOperation, error) {
+	return p.userOperations[tx.Txid], nil
+}
+
 func ethereumTestnetParser() *eth.EthereumParser {
 	return eth.NewEthereumParser(1, true)
 }
@@ -255,6 +265,266 @@ func TestERC721SelfTransferConnectDisconnectRoundTrip(t *testing.T) {
 	}
 }
 
+func TestUserOperationsConnectDisconnectRoundTrip(t *testing.T) {
+	parser := ethereumTestnetParser()
+	bundler := dbtestdata.EthAddr3e
+	entryPoint := "0x0000000071727De22E5E9d8BAf0edAc6f37da032"
+	sender := eth.EIP55AddressFromAddress(dbtestdata.EthAddr20)
+	txid := "80a0533b0f66e9d29aa4dbbdc8c4b90326b073e0d6b864e02c9598032ed05301"
+	userOps := []bchain.EthereumUserOperation{
+		{
+			UserOpHash:    "0x1111111111111111111111111111111111111111111111111111111111111111",
+			EntryPoint:    entryPoint,
+			Sender:        sender,
+			Paymaster:     eth.EthereumZeroAddress,
+			Nonce:         *big.NewInt(1),
+			Success:       true,
+			ActualGasCost: *big.NewInt(123456),
+			ActualGasUsed: *big.NewInt(7890),
+		},
+		{
+			UserOpHash:    "0x2222222222222222222222222222222222222222222222222222222222222222",
+			EntryPoint:    entryPoint,
+			Sender:        sender,
+			Paymaster:     eth.EIP55AddressFromAddress(dbtestdata.EthAddr9f),
+			Nonce:         *big.NewInt(2),
+			ActualGasCost: *big.NewInt(654321),
+			ActualGasUsed: *big.NewInt(987),
+		},
+	}
+	d := setupRocksDB(t, &userOperationsTestParser{
+		EthereumParser: parser,
+		userOperations: map[string][]bchain.EthereumUserOperation{txid: userOps},
+	})
+	defer closeAndDestroyRocksDB(t, d)
+	senderDesc := addressToAddrDesc(dbtestdata.EthAddr20, parser)
+
+	block := &bchain.Block{
+		BlockHeader: bchain.BlockHeader{
+			Height: 4321000,
+			Hash:   "0xc7b98df95acfd11c51ba25611a39e004fe56c8fdfc1582af99354fcd09c17b11",
+			Time:   1534858022,
+		},
+		Txs: []bchain.Tx{{
+			Txid: txid,
+			Vin:  []bchain.Vin{{Addresses: []string{bundler}}},
+			Vout: []bchain.Vout{{ScriptPubKey: bchain.ScriptPubKey{Addresses: []string{entryPoint}}}},
+		}},
+	}
+	if err := d.ConnectBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	acs, err := d.getUnpackedAddrDescContracts(senderDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the sender with two operations is indexed once
+	if acs == nil || acs.TotalTxs != 1 || acs.InternalTxs != 1 || acs.NonContractTxs != 0 {
+		t.Fatalf("sender address contracts = %+v, want TotalTxs 1, InternalTxs 1", acs)
+	}
+	var txids []string
+	var txIndexes []int32
+	if err = d.GetAddrDescTransactions(senderDesc, 0, ^uint32(0), func(txid string, height uint32, indexes []int32) error {
+		txids = append(txids, txid)
+		txIndexes = append(txIndexes, indexes...)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(txids) != 1 || txids[0] != txid || !reflect.DeepEqual(txIndexes, []int32{internalTransferFrom}) {
+		t.Fatalf("sender txids = %v, indexes %v, want [%s], [%d]", txids, txIndexes, txid, internalTransferFrom)
+	}
+	btxID, err := parser.PackTxid(txid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only the sender of the two operations is stored
+	got, err := d.getEthereumUserOpSenders(btxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []bchain.AddressDescriptor{senderDesc}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("getEthereumUserOpSenders() = %v, want %v", got, want)
+	}
+
+	if err := d.DisconnectBlockRangeEthereumType(block.Height, block.Height); err != nil {
+		t.Fatal(err)
+	}
+	if acs, err = d.getUnpackedAddrDescContracts(senderDesc); err != nil {
+		t.Fatal(err)
+	}
+	if acs != nil {
+		t.Fatalf("sender address contracts after disconnect = %+v, want nil", acs)
+	}
+	if got, err = d.getEthereumUserOpSenders(btxID); err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Fatalf("getEthereumUserOpSenders() after disconnect = %v, want nil", got)
+	}
+}
+
+func TestAuthorizationsConnectDisconnectRoundTrip(t *testing.T) {
+	parser := ethereumTestnetParser()
+	d := setupRocksDB(t, parser)
+	defer closeAndDestroyRocksDB(t, d)
+	sender := eth.EIP55AddressFromAddress(dbtestdata.EthAddr3e)
+	authority := eth.EIP55AddressFromAddress(dbtestdata.EthAddr9f)
+	delegate := eth.EIP55AddressFromAddress(dbtestdata.EthAddr20)
+	txid := "80a0533b0f66e9d29aa4dbbdc8c4b90326b073e0d6b864e02c9598032ed05301"
+	block := &bchain.Block{
+		BlockHeader: bchain.BlockHeader{
+			Height: 4321000,
+			Hash:   "0xc7b98df95acfd11c51ba25611a39e004fe56c8fdfc1582af99354fcd09c17b11",
+			Time:   1534858022,
+		},
+		Txs: []bchain.Tx{{
+			Txid: txid,
+			Vin:  []bchain.Vin{{Addresses: []string{sender}}},
+			Vout: []bchain.Vout{{ScriptPubKey: bchain.ScriptPubKey{Addresses: []string{authority}}}},
+			CoinSpecificData: bchain.EthereumSpecificData{
+				Tx: &bchain.RpcTransaction{
+					Hash: "0x" + txid,
+					From: sender,
+					To:   authority,
+					AuthorizationList: []bchain.RpcAuthorization{
+						// the authority is the recipient, already indexed
+						{Address: delegate, Authority: authority},
+						// the sender delegates its own account
+						{Address: delegate, Authority: sender},
+						// invalid signature
+						{Address: delegate},
+						{Address: delegate, Authority: eth.EIP55AddressFromAddress(dbtestdata.EthAddr4b)},
+						{Address: delegate, Authority: eth.EIP55AddressFromAddress(dbtestdata.EthAddr4b)},
+					},
+				},
+			},
+		}},
+	}
+	if err := d.ConnectBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		addr                     string
+		totalTxs, nonContractTxs uint
+		indexes                  []int32
+	}{
+		{addr: dbtestdata.EthAddr3e, totalTxs: 1, nonContractTxs: 1, indexes: []int32{transferFrom}},
+		{addr: dbtestdata.EthAddr9f, totalTxs: 1, nonContractTxs: 1, indexes: []int32{transferTo}},
+		{addr: dbtestdata.EthAddr4b, totalTxs: 1, nonContractTxs: 1, indexes: []int32{transferFrom}},
+	} {
+		addrDesc := addressToAddrDesc(tt.addr, parser)
+		acs, err := d.getUnpackedAddrDescContracts(addrDesc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acs == nil || acs.TotalTxs != tt.totalTxs || acs.NonContractTxs != tt.nonContractTxs {
+			t.Fatalf("%s address contracts = %+v, want TotalTxs %d, NonContractTxs %d", tt.addr, acs, tt.totalTxs, tt.nonContractTxs)
+		}
+		var indexes []int32
+		if err = d.GetAddrDescTransactions(addrDesc, 0, ^uint32(0), func(txid string, height uint32, txIndexes []int32) error {
+			indexes = append(indexes, txIndexes...)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(indexes, tt.indexes) {
+			t.Fatalf("%s indexes = %v, want %v", tt.addr, indexes, tt.indexes)
+		}
+	}
+
+	if err := d.DisconnectBlockRangeEthereumType(block.Height, block.Height); err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range []string{dbtestdata.EthAddr3e, dbtestdata.EthAddr9f, dbtestdata.EthAddr4b} {
+		acs, err := d.getUnpackedAddrDescContracts(addressToAddrDesc(addr, parser))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acs != nil {
+			t.Fatalf("%s address contracts after disconnect = %+v, want nil", addr, acs)
+		}
+	}
+	btxID, _ := parser.PackTxid(txid)
+	if authorizations, err := d.getEthereumAuthorizations(btxID); err != nil || authorizations != nil {
+		t.Fatalf("getEthereumAuthorizations() after disconnect = %+v, %v, want nil", authorizations, err)
+	}
+}
+
+func TestWithdrawalsConnectDisconnectRoundTrip(t *testing.T) {
+	parser := ethereumTestnetParser()
+	d := setupRocksDB(t, parser)
+	defer closeAndDestroyRocksDB(t, d)
+	staker := eth.EIP55AddressFromAddress(dbtestdata.EthAddr3e)
+	other := eth.EIP55AddressFromAddress(dbtestdata.EthAddr9f)
+	blocks := []*bchain.Block{
+		{
+			BlockHeader: bchain.BlockHeader{Height: 4321000, Hash: "0xc7b98df95acfd11c51ba25611a39e004fe56c8fdfc1582af99354fcd09c17b11", Time: 1534858022},
+			CoinSpecificData: &bchain.EthereumBlockSpecificData{
+				Withdrawals: []bchain.EthereumWithdrawal{
+					{Index: 10, ValidatorIndex: 100, Address: staker, Amount: *big.NewInt(1000)},
+					{Index: 11, ValidatorIndex: 101, Address: other, Amount: *big.NewInt(2000)},
+					{Index: 12, ValidatorIndex: 102, Address: staker, Amount: *big.NewInt(3000)},
+				},
+			},
+		},
+		{
+			BlockHeader: bchain.BlockHeader{Height: 4321001, Hash: "0x2b57e15e93a0ed197417a34c2498b7187df79099572c04a6b6e6ff418f74e6ee", Time: 1534859988},
+			CoinSpecificData: &bchain.EthereumBlockSpecificData{
+				Withdrawals: []bchain.EthereumWithdrawal{
+					{Index: 13, ValidatorIndex: 100, Address: staker, Amount: *big.NewInt(4000)},
+				},
+			},
+		},
+	}
+	for _, block := range blocks {
+		if err := d.ConnectBlock(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stakerDesc := addressToAddrDesc(dbtestdata.EthAddr3e, parser)
+	getIndexes := func(addrDesc bchain.AddressDescriptor, lower, higher uint32) []uint64 {
+		var indexes []uint64
+		if err := d.GetAddrDescWithdrawals(addrDesc, lower, higher, func(w *Withdrawal) error {
+			if !bytes.Equal(w.AddrDesc, addrDesc) {
+				t.Fatalf("withdrawal %d address %v, want %v", w.Index, w.AddrDesc, addrDesc)
+			}
+			indexes = append(indexes, w.Index)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return indexes
+	}
+	if got := getIndexes(stakerDesc, 0, ^uint32(0)); !reflect.DeepEqual(got, []uint64{13, 12, 10}) {
+		t.Fatalf("staker withdrawals = %v, want [13 12 10]", got)
+	}
+	if got := getIndexes(stakerDesc, 0, 4321000); !reflect.DeepEqual(got, []uint64{12, 10}) {
+		t.Fatalf("staker withdrawals up to 4321000 = %v, want [12 10]", got)
+	}
+	if got := getIndexes(addressToAddrDesc(dbtestdata.EthAddr9f, parser), 0, ^uint32(0)); !reflect.DeepEqual(got, []uint64{11}) {
+		t.Fatalf("other withdrawals = %v, want [11]", got)
+	}
+	// withdrawals do not create transaction history
+	if acs, err := d.getUnpackedAddrDescContracts(stakerDesc); err != nil || acs != nil {
+		t.Fatalf("staker address contracts = %+v, %v, want nil", acs, err)
+	}
+
+	if err := d.DisconnectBlockRangeEthereumType(4321001, 4321001); err != nil {
+		t.Fatal(err)
+	}
+	if got := getIndexes(stakerDesc, 0, ^uint32(0)); !reflect.DeepEqual(got, []uint64{12, 10}) {
+		t.Fatalf("staker withdrawals after disconnect = %v, want [12 10]", got)
+	}
+	if withdrawals, err := d.GetBlockWithdrawals(4321001); err != nil || withdrawals != nil {
+		t.Fatalf("GetBlockWithdrawals() after disconnect = %+v, %v, want nil", withdrawals, err)
+	}
+	withdrawals, err := d.GetBlockWithdrawals(4321000)
+	if err != nil || len(withdrawals) != 3 || withdrawals[1].Index != 11 || withdrawals[1].Amount.Int64() != 2000 {
+		t.Fatalf("GetBlockWithdrawals(4321000) = %+v, %v", withdrawals, err)
+	}
+}
+
 func Test_unpackedAddrContracts_findContractIndex_LazyMap(t *testing.T) {
 	acs := &unpackedAddrContracts{}
 	minContracts := 192
@@ -810,6 +1080,37 @@ func testFourByteSignature(t *testing.T, d *RocksDB) {
 	if !reflect.DeepEqual(*gotSlice, []bchain.FourByteSignature{signature}) {
 		t.Errorf("testFourByteSignature: got %+v, want %+v", *gotSlice, []bchain.FourByteSignature{signature})
 	}
+	count, err := d.CountFourByteSignatures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("testFourByteSignature: CountFourByteSignatures got %d, want 1", count)
+	}
+	state, err := d.GetFourByteSignaturesState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != nil {
+		t.Errorf("testFourByteSignature: GetFourByteSignaturesState got %+v, want nil", state)
+	}
+	wantState := bchain.FourByteSignaturesState{
+		Count:       1,
+		LastId:      id,
+		ResumeURL:   "https://www.4byte.directory/api/v1/signatures/?page=2",
+		ResumeTopId: id + 10,
+		LastUpdate:  time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+	}
+	if err := d.StoreFourByteSignaturesState(&wantState); err != nil {
+		t.Fatal(err)
+	}
+	state, err = d.GetFourByteSignaturesState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state == nil || !reflect.DeepEqual(*state, wantState) {
+		t.Errorf("testFourByteSignature: GetFourByteSignaturesState got %+v, want %+v", state, wantState)
+	}
 }
 
 // TestRocksDB_Index_EthereumType is an integration test probing the whole indexing functionality for EthereumType chains
@@ -1995,6 +2296,72 @@ func Test_packUnpackBlockTx(t *testing.T) {
 	}
 }
 
+func Test_packUnpackEthUserOpSenders(t *testing.T) {
+	parser := ethereumTestnetParser()
+	senders := []bchain.AddressDescriptor{
+		addressToAddrDesc(dbtestdata.EthAddr20, parser),
+		addressToAddrDesc(dbtestdata.EthAddr3e, parser),
+	}
+	packed := packEthUserOpSenders(senders)
+	got, err := unpackEthUserOpSenders(packed)
+	if err != nil {
+		t.Fatalf("unpackEthUserOpSenders() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, senders) {
+		t.Errorf("unpackEthUserOpSenders() = %+v, want %+v", got, senders)
+	}
+	if _, err = unpackEthUserOpSenders(packed[:len(packed)-1]); err == nil {
+		t.Error("unpackEthUserOpSenders() of truncated data, expected error")
+	}
+}
+
+func Test_packUnpackEthAuthorizations(t *testing.T) {
+	parser := ethereumTestnetParser()
+	authorizations := []ethAuthorization{
+		{authority: addressToAddrDesc(dbtestdata.EthAddr3e, parser), delegate: addressToAddrDesc(dbtestdata.EthAddr20, parser)},
+		{authority: addressToAddrDesc(dbtestdata.EthAddr9f, parser), delegate: ethZeroAddress},
+	}
+	packed := packEthAuthorizations(authorizations)
+	got, err := unpackEthAuthorizations(packed)
+	if err != nil {
+		t.Fatalf("unpackEthAuthorizations() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, authorizations) {
+		t.Errorf("unpackEthAuthorizations() = %+v, want %+v", got, authorizations)
+	}
+	if _, err = unpackEthAuthorizations(packed[:len(packed)-1]); err == nil {
+		t.Error("unpackEthAuthorizations() of truncated data, expected error")
+	}
+}
+
+func Test_packUnpackWithdrawals(t *testing.T) {
+	parser := ethereumTestnetParser()
+	withdrawals := []Withdrawal{
+		{Index: 0, ValidatorIndex: 243921, AddrDesc: addressToAddrDesc(dbtestdata.EthAddr3e, parser), Amount: *big.NewInt(14793177000000000)},
+		{Index: 1 << 40, ValidatorIndex: 1, AddrDesc: addressToAddrDesc(dbtestdata.EthAddr9f, parser), Amount: *big.NewInt(0)},
+	}
+	for _, withAddress := range []bool{true, false} {
+		want := make([]Withdrawal, len(withdrawals))
+		copy(want, withdrawals)
+		if !withAddress {
+			for i := range want {
+				want[i].AddrDesc = nil
+			}
+		}
+		packed := packWithdrawals(withdrawals, withAddress)
+		got, err := unpackWithdrawals(packed, withAddress)
+		if err != nil {
+			t.Fatalf("unpackWithdrawals(%v) error = %v", withAddress, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unpackWithdrawals(%v) = %+v, want %+v", withAddress, got, want)
+		}
+		if _, err = unpackWithdrawals(packed[:len(packed)-1], withAddress); err == nil {
+			t.Errorf("unpackWithdrawals(%v) of truncated data, expected error", withAddress)
+		}
+	}
+}
+
 func Test_packUnpackFourByteSignature(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// mempoolSnapshotKey is the cfDefault key of the mempool snapshot persisted across restarts
+const mempoolSnapshotKey = "mempoolSnapshot"
+
+const mempoolSnapshotVersion = 3
+
+var errInconsistentMempoolSnapshot = errors.New("Inconsistent data in mempool snapshot")
+
+// packMempoolSnapshot packs the mempool snapshot taken at the time t, the txids are packed by the parser
+func packMempoolSnapshot(parser bchain.BlockChainParser, snapshot *bchain.MempoolSnapshot, t time.Time) ([]byte, error) {
+	entries := snapshot.Entries
+	buf := make([]byte, 0, 1+len(snapshot.FilterConfig)+len(entries)*192+len(snapshot.Replacements)*72)
+	var err error
+	appendTxid := func(txid string) {
+		if err != nil {
+			return
+		}
+		var btxid []byte
+		if btxid, err = parser.PackTxid(txid); err != nil {
+			err = errors.Annotatef(err, "txid %v", txid)
+			return
+		}
+		buf = appendBlobBytes(buf, btxid)
+	}
+	buf = append(buf, mempoolSnapshotVersion)
+	buf = appendBlobVarint(buf, t.Unix())
+	buf = appendBlobBytes(buf, []byte(snapshot.FilterConfig))
+	buf = appendBlobVaruint(buf, uint64(len(entries)))
+	for i := range entries {
+		e := &entries[i]
+		appendTxid(e.Txid)
+		buf = appendBlobVaruint(buf, uint64(e.Time))
+		buf = appendBlobBytes(buf, []byte(e.Filter))
+		buf = appendBlobVaruint(buf, uint64(len(e.AddrIndexes)))
+		for _, ai := range e.AddrIndexes {
+			buf = appendBlobBytes(buf, ai.AddrDesc)
+			buf = appendBlobVarint(buf, int64(ai.N))
+		}
+		buf = appendBlobVaruint(buf, uint64(len(e.Spends)))
+		for _, o := range e.Spends {
+			appendTxid(o.Txid)
+			buf = appendBlobVarint(buf, int64(o.Vout))
+		}
+		if e.Fees == nil {
+			buf = append(buf, 0)
+			continue
+		}
+		f := e.Fees
+		buf = append(buf, 1)
+		buf = appendBlobVarint(buf, f.Fee)
+		buf = appendBlobVarint(buf, f.VSize)
+		buf = appendBlobVaruint(buf, uint64(len(f.Parents)))
+		for _, p := range f.Parents {
+			appendTxid(p)
+		}
+		buf = appendBlobBytes(buf, []byte(f.Sender))
+		buf = appendBlobVaruint(buf, f.Nonce)
+		buf = appendBlobBigint(buf, f.MaxFeePerGas)
+		buf = appendBlobBigint(buf, f.MaxPriorityFeePerGas)
+	}
+	buf = appendBlobVaruint(buf, uint64(len(snapshot.Replacements)))
+	for i := range snapshot.Replacements {
+		r := &snapshot.Replacements[i]
+		appendTxid(r.Txid)
+		appendTxid(r.ReplacedBy)
+		buf = appendBlobVarint(buf, r.Time)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// mempoolSnapshotReader unpacks the mempool snapshot, the txids are unpacked by the parser
+type mempoolSnapshotReader struct {
+	blobReader
+	parser bchain.BlockChainParser
+}
+
+func (r *mempoolSnapshotReader) txid() string {
+	b := r.bytes()
+	if r.err != nil {
+		return ""
+	}
+	txid, err := r.parser.UnpackTxid(b)
+	if err != nil {
+		r.err = err
+	}
+	return txid
+}
+
+// unpackMempoolSnapshot unpacks the mempool snapshot and returns it with the time it was taken
+func unpackMempoolSnapshot(parser bchain.BlockChainParser, buf []byte) (*bchain.MempoolSnapshot, time.Time, error) {
+	r := mempoolSnapshotReader{blobReader: newBlobReader(buf, errInconsistentMempoolSnapshot), parser: parser}
+	if v := r.byte(); r.err == nil && v != mempoolSnapshotVersion {
+		return nil, time.Time{}, errors.Errorf("Unsupported mempool snapshot version %d", v)
+	}
+	t := time.Unix(r.varint(), 0)
+	filterConfig := string(r.bytes())
+	entries := make([]bchain.MempoolSnapshotEntry, r.count(4))
+	for i := range entries {
+		e := &entries[i]
+		e.Txid = r.txid()
+		e.Time = uint32(r.varuint())
+		e.Filter = string(r.bytes())
+		e.AddrIndexes = make([]bchain.MempoolSnapshotAddrIndex, r.count(2))
+		for j := range e.AddrIndexes {
+			// copy the address descriptor, the buffer is freed after the unpacking
+			e.AddrIndexes[j].AddrDesc = append(bchain.AddressDescriptor(nil), r.bytes()...)
+			e.AddrIndexes[j].N = int32(r.varint())
+		}
+		if c := r.count(2); c > 0 {
+			e.Spends = make([]bchain.Outpoint, c)
+			for j := range e.Spends {
+				e.Spends[j].Txid = r.txid()
+				e.Spends[j].Vout = int32(r.varint())
+			}
+		}
+		if r.byte() == 1 {
+			f := bchain.MempoolSnapshotFees{
+				Fee:   r.varint(),
+				VSize: r.varint(),
+			}
+			if c := r.count(1); c > 0 {
+				f.Parents = make([]string, c)
+				for j := range f.Parents {
+					f.Parents[j] = r.txid()
+				}
+			}
+			f.Sender = string(r.bytes())
+			f.Nonce = r.varuint()
+			f.MaxFeePerGas = r.bigint()
+			f.MaxPriorityFeePerGas = r.bigint()
+			e.Fees = &f
+		}
+		if r.err != nil {
+			return nil, time.Time{}, r.err
+		}
+	}
+	snapshot := bchain.MempoolSnapshot{FilterConfig: filterConfig, Entries: entries}
+	if c := r.count(3); c > 0 {
+		snapshot.Replacements = make([]bchain.MempoolSnapshotReplacement, c)
+		for i := range snapshot.Replacements {
+			rp := &snapshot.Replacements[i]
+			rp.Txid = r.txid()
+			rp.ReplacedBy = r.txid()
+			rp.Time = r.varint()
+		}
+	}
+	r.end()
+	if r.err != nil {
+		return nil, time.Time{}, r.err
+	}
+	return &snapshot, t, nil
+}
+
+// StoreMempoolSnapshot stores the snapshot of the mempool, replacing the previously stored one
+func (d *RocksDB) StoreMempoolSnapshot(snapshot *bchain.MempoolSnapshot) error {
+	buf, err := packMempoolSnapshot(d.chainParser, snapshot, time.Now())
+	if err != nil {
+		return err
+	}
+	return d.db.PutCF(d.wo, d.cfh[cfDefault], []byte(mempoolSnapshotKey), buf)
+}
+
+// LoadMempoolSnapshot returns the stored snapshot of the mempool and the time it was taken, nil if there is none
+func (d *RocksDB) LoadMempoolSnapshot() (*bchain.MempoolSnapshot, time.Time, error) {
+	val, err := d.db.GetCF(d.ro, d.cfh[cfDefault], []byte(mempoolSnapshotKey))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer val.Free()
+	data := val.Data()
+	if len(data) == 0 {
+		return nil, time.Time{}, nil
+	}
+	return unpackMempoolSnapshot(d.chainParser, data)
+}
+
+// DeleteMempoolSnapshot removes the stored snapshot of the mempool
+func (d *RocksDB) DeleteMempoolSnapshot() error {
+	return d.db.DeleteCF(d.wo, d.cfh[cfDefault], []byte(mempoolSnapshotKey))
+}
@@ -0,0 +1,112 @@
+//go:build unittest
+
+package db
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+var testMempoolSnapshot = &bchain.MempoolSnapshot{
+	FilterConfig: "19:taproot-noordinals:false",
+	Entries: []bchain.MempoolSnapshotEntry{
+		{
+			Txid:   "7c3be24063f268aaa1ed81b64776798f56088757641a34fb156c4f51ed2e9d25",
+			Time:   1700000000,
+			Filter: "0123456789abcdef",
+			AddrIndexes: []bchain.MempoolSnapshotAddrIndex{
+				{AddrDesc: bchain.AddressDescriptor{0x00, 0x14, 0x01, 0x02}, N: 0},
+				{AddrDesc: bchain.AddressDescriptor{0x76, 0xa9, 0x14}, N: ^1},
+			},
+			Spends: []bchain.Outpoint{{Txid: "effd9ef509383d536b1c8af5bf434c8efbf521a4f2befd4022bbd68694b4ac75", Vout: 3}},
+			Fees:   &bchain.MempoolSnapshotFees{Fee: 2820, VSize: 141, Parents: []string{"effd9ef509383d536b1c8af5bf434c8efbf521a4f2befd4022bbd68694b4ac75"}},
+		},
+		{
+			Txid:        "1f2ee9a7c1e8ad3d1e4b0d0c4ab1b9a47a8fbd2b0c1c2b3e4d5f6a7b8c9d0e1f",
+			Time:        1700000100,
+			AddrIndexes: []bchain.MempoolSnapshotAddrIndex{{AddrDesc: bchain.AddressDescriptor{0x55, 0x66}, N: ^0}},
+			Fees: &bchain.MempoolSnapshotFees{
+				Fee:                  21000000000000,
+				VSize:                21000,
+				Sender:               "0x5566",
+				Nonce:                7,
+				MaxFeePerGas:         big.NewInt(30000000000),
+				MaxPriorityFeePerGas: big.NewInt(1500000000),
+			},
+		},
+		{
+			Txid:        "a6f3dcd3e0ad0b4e6f6fad0bd1e0bb7dd8b1a3c3c2f5e3e8e8c6e2d5b6c1a3f2",
+			Time:        1700000200,
+			AddrIndexes: []bchain.MempoolSnapshotAddrIndex{{AddrDesc: bchain.AddressDescriptor{0xa9, 0x14}, N: 1}},
+		},
+	},
+	Replacements: []bchain.MempoolSnapshotReplacement{
+		{
+			Txid:       "b5f3dcd3e0ad0b4e6f6fad0bd1e0bb7dd8b1a3c3c2f5e3e8e8c6e2d5b6c1a3f2",
+			ReplacedBy: "a6f3dcd3e0ad0b4e6f6fad0bd1e0bb7dd8b1a3c3c2f5e3e8e8c6e2d5b6c1a3f2",
+			Time:       1700000250,
+		},
+	},
+}
+
+func Test_packMempoolSnapshot(t *testing.T) {
+	parser := bitcoinTestnetParser()
+	now := time.Unix(1700000300, 0)
+	buf, err := packMempoolSnapshot(parser, testMempoolSnapshot, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, gotTime, err := unpackMempoolSnapshot(parser, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotTime.Equal(now) {
+		t.Errorf("time = %v, want %v", gotTime, now)
+	}
+	if !reflect.DeepEqual(got, testMempoolSnapshot) {
+		t.Errorf("unpackMempoolSnapshot() = %+v, want %+v", got, testMempoolSnapshot)
+	}
+	for _, l := range []int{0, 1, len(buf) / 2, len(buf) - 1} {
+		if _, _, err := unpackMempoolSnapshot(parser, buf[:l]); err == nil {
+			t.Errorf("unpackMempoolSnapshot() of truncated data (%d bytes) did not fail", l)
+		}
+	}
+	if _, _, err := unpackMempoolSnapshot(parser, append(buf, 0)); err == nil {
+		t.Error("unpackMempoolSnapshot() of data with trailing bytes did not fail")
+	}
+	invalid := &bchain.MempoolSnapshot{Entries: []bchain.MempoolSnapshotEntry{{Txid: "not a txid"}}}
+	if _, err := packMempoolSnapshot(parser, invalid, now); err == nil {
+		t.Error("packMempoolSnapshot() of an invalid txid did not fail")
+	}
+}
+
+func TestRocksDB_MempoolSnapshot(t *testing.T) {
+	d := setupRocksDB(t, &testBitcoinParser{
+		BitcoinParser: bitcoinTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+	snapshot, _, err := d.LoadMempoolSnapshot()
+	if err != nil || snapshot != nil {
+		t.Fatalf("LoadMempoolSnapshot() = %+v, %v, want no snapshot", snapshot, err)
+	}
+	if err := d.StoreMempoolSnapshot(testMempoolSnapshot); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, _, err = d.LoadMempoolSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(snapshot, testMempoolSnapshot) {
+		t.Errorf("LoadMempoolSnapshot() = %+v, want %+v", snapshot, testMempoolSnapshot)
+	}
+	if err := d.DeleteMempoolSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot, _, err = d.LoadMempoolSnapshot(); err != nil || snapshot != nil {
+		t.Errorf("LoadMempoolSnapshot() after delete = %+v, %v, want no snapshot", snapshot, err)
+	}
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// wsJournalKey is the cfDefault key of the journal of the websocket notifications persisted across restarts
+const wsJournalKey = "wsJournal"
+
+const wsJournalVersion = 1
+
+var errInconsistentWsJournal = errors.New("Inconsistent data in websocket journal")
+
+// WsJournalEvent is a websocket notification kept in the journal for the replay to the reconnected clients
+type WsJournalEvent struct {
+	Seq      uint64
+	Height   uint32
+	Kind     byte
+	AddrDesc bchain.AddressDescriptor
+	Data     []byte
+}
+
+// WsJournal is the persisted state of the journal of the websocket notifications
+type WsJournal struct {
+	// LastSeq is the sequence number of the last notification, the numbering continues from it after the restart
+	LastSeq uint64
+	// BestHash is the hash of the best block at the time the journal was stored
+	BestHash string
+	Events   []WsJournalEvent
+}
+
+func packWsJournal(j *WsJournal) []byte {
+	buf := make([]byte, 0, 64+len(j.Events)*256)
+	buf = append(buf, wsJournalVersion)
+	buf = appendBlobVaruint(buf, j.LastSeq)
+	buf = appendBlobBytes(buf, []byte(j.BestHash))
+	buf = appendBlobVaruint(buf, uint64(len(j.Events)))
+	for i := range j.Events {
+		e := &j.Events[i]
+		buf = appendBlobVaruint(buf, e.Seq)
+		buf = appendBlobVaruint(buf, uint64(e.Height))
+		buf = append(buf, e.Kind)
+		buf = appendBlobBytes(buf, e.AddrDesc)
+		buf = appendBlobBytes(buf, e.Data)
+	}
+	return buf
+}
+
+func unpackWsJournal(buf []byte) (*WsJournal, error) {
+	r := newBlobReader(buf, errInconsistentWsJournal)
+	if v := r.byte(); r.err == nil && v != wsJournalVersion {
+		return nil, errors.Errorf("Unsupported websocket journal version %d", v)
+	}
+	j := &WsJournal{
+		LastSeq:  r.varuint(),
+		BestHash: string(r.bytes()),
+	}
+	j.Events = make([]WsJournalEvent, r.count(5))
+	for i := range j.Events {
+		e := &j.Events[i]
+		e.Seq = r.varuint()
+		e.Height = uint32(r.varuint())
+		e.Kind = r.byte()
+		// copy the data, the buffer is freed after the unpacking
+		if ad := r.bytes(); len(ad) > 0 {
+			e.AddrDesc = append(bchain.AddressDescriptor(nil), ad...)
+		}
+		e.Data = append([]byte(nil), r.bytes()...)
+	}
+	r.end()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return j, nil
+}
+
+// StoreWsJournal stores the journal of the websocket notifications, replacing the previously stored one
+func (d *RocksDB) StoreWsJournal(j *WsJournal) error {
+	return d.db.PutCF(d.wo, d.cfh[cfDefault], []byte(wsJournalKey), packWsJournal(j))
+}
+
+// LoadWsJournal returns the stored journal of the websocket notifications, nil if there is none
+func (d *RocksDB) LoadWsJournal() (*WsJournal, error) {
+	val, err := d.db.GetCF(d.ro, d.cfh[cfDefault], []byte(wsJournalKey))
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	data := val.Data()
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return unpackWsJournal(data)
+}
+
+// DeleteWsJournal removes the stored journal of the websocket notifications
+func (d *RocksDB) DeleteWsJournal() error {
+	return d.db.DeleteCF(d.wo, d.cfh[cfDefault], []byte(wsJournalKey))
+}
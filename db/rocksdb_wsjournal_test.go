@@ -0,0 +1,39 @@
+//go:build unittest
+
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+var testWsJournal = &WsJournal{
+	LastSeq:  1700000000000123,
+	BestHash: "00000000eb0443fd7dc4a1ed5c686a8e995057805f9a161d9a5a77a95e72b7b6",
+	Events: []WsJournalEvent{
+		{Seq: 1700000000000100, Height: 225493, Kind: 0, Data: []byte(`{"height":225493}`)},
+		{Seq: 1700000000000101, Kind: 1, AddrDesc: bchain.AddressDescriptor{0x00, 0x14, 0x01, 0x02}, Data: []byte(`{"address":"a"}`)},
+		{Seq: 1700000000000123, Height: 225494, Kind: 0, Data: []byte(`{"height":225494}`)},
+	},
+}
+
+func Test_packWsJournal(t *testing.T) {
+	buf := packWsJournal(testWsJournal)
+	got, err := unpackWsJournal(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, testWsJournal) {
+		t.Errorf("unpackWsJournal() = %+v, want %+v", got, testWsJournal)
+	}
+	for _, l := range []int{0, 1, len(buf) / 2, len(buf) - 1} {
+		if _, err := unpackWsJournal(buf[:l]); err == nil {
+			t.Errorf("unpackWsJournal() of truncated data (%d bytes) did not fail", l)
+		}
+	}
+	if _, err := unpackWsJournal(append(buf, 0)); err == nil {
+		t.Error("unpackWsJournal() of data with trailing bytes did not fail")
+	}
+}
@@ -31,6 +31,8 @@ type SyncWorker struct {
 	missingBlockRetry      MissingBlockRetryConfig
 	metrics                *common.Metrics
 	is                     *common.InternalState
+	// onDisconnectBlocks is notified about the blocks disconnected by a reorg, nil if nobody is interested
+	onDisconnectBlocks bchain.OnDisconnectBlocksFunc
 }
 
 // MissingBlockRetryConfig controls how long we retry a missing block before re-checking chain state.
@@ -141,6 +143,11 @@ func NewSyncWorkerWithConfig(db *RocksDB, chain bchain.BlockChain, syncWorkers,
 	}, nil
 }
 
+// SetOnDisconnectBlocks sets the callback notified about the blocks disconnected by a reorg
+func (w *SyncWorker) SetOnDisconnectBlocks(f bchain.OnDisconnectBlocksFunc) {
+	w.onDisconnectBlocks = f
+}
+
 // syncNotNeeded is returned by resyncIndex when the local tip already matches
 // the backend tip. ResyncIndex treats it as a successful no-op.
 var syncNotNeeded = errors.New("sync not needed")
@@ -349,9 +356,20 @@ func (w *SyncWorker) handleFork(localBestHeight uint32, localBestHash string, on
 		hashes = append(hashes, local)
 	}
 	w.metrics.IndexReorgEvents.With(common.Labels{"type": "disconnect"}).Inc()
+	// the disconnected blocks are described before their data are removed from the index
+	var disconnected []bchain.DisconnectedBlock
+	if w.onDisconnectBlocks != nil && !initialSync {
+		var err error
+		if disconnected, err = w.db.GetBlocksToDisconnect(height+1, localBestHeight); err != nil {
+			glog.Error("sync: GetBlocksToDisconnect ", err)
+		}
+	}
 	if err := w.DisconnectBlocks(height+1, localBestHeight, hashes); err != nil {
 		return err
 	}
+	if disconnected != nil {
+		w.onDisconnectBlocks(disconnected)
+	}
 	return w.resyncIndex(onNewBlock, initialSync)
 }
 
@@ -0,0 +1,130 @@
+package fourbyte
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/db"
+)
+
+// bundleBatchSize is the number of signatures stored in one write batch during a bundle import
+const bundleBatchSize = 10000
+
+// bundleEntry is a single JSON value of a bundle, either one signature
+// or a page of signatures as returned by the 4byte API
+type bundleEntry struct {
+	signatureData
+	Results []signatureData `json:"results"`
+}
+
+// readBundle parses a signatures bundle and passes the signatures to onBatch in batches.
+// The bundle is a stream of JSON values, each value being a signature object
+// ({"id":1,"text_signature":"transfer(address,uint256)","hex_signature":"0xa9059cbb"}),
+// an array of signature objects or a saved page of the 4byte API ({"results":[...]}).
+// This covers both JSON lines files and dumps of the 4byte API.
+func readBundle(r io.Reader, batchSize int, onBatch func([]signatureData) error) error {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	batch := make([]signatureData, 0, batchSize)
+	add := func(s *signatureData) error {
+		if s.Id <= 0 || s.HexSignature == "" || s.TextSignature == "" {
+			return fmt.Errorf("invalid signature entry %+v", *s)
+		}
+		batch = append(batch, *s)
+		if len(batch) >= batchSize {
+			if err := onBatch(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		return nil
+	}
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if len(raw) > 0 && raw[0] == '[' {
+			var signatures []signatureData
+			if err := json.Unmarshal(raw, &signatures); err != nil {
+				return err
+			}
+			for i := range signatures {
+				if err := add(&signatures[i]); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		var e bundleEntry
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return err
+		}
+		if e.Results != nil {
+			for i := range e.Results {
+				if err := add(&e.Results[i]); err != nil {
+					return err
+				}
+			}
+		} else if err := add(&e.signatureData); err != nil {
+			return err
+		}
+	}
+	if len(batch) > 0 {
+		return onBatch(batch)
+	}
+	return nil
+}
+
+// ImportBundle imports the signatures from a bundle (see readBundle for the format) and returns
+// the number of newly stored signatures. The bundle is expected to be a complete dump
+// up to its highest id, the periodic download then continues only with newer signatures.
+func ImportBundle(d *db.RocksDB, r io.Reader) (int, error) {
+	var stored int
+	var maxId uint32
+	err := readBundle(r, bundleBatchSize, func(signatures []signatureData) error {
+		for i := range signatures {
+			if uint32(signatures[i].Id) > maxId {
+				maxId = uint32(signatures[i].Id)
+			}
+		}
+		s, err := storeSignatures(d, signatures)
+		stored += s
+		return err
+	})
+	// record the stored signatures even if the import failed in the middle
+	if serr := updateState(d, stored, func(state *bchain.FourByteSignaturesState) {
+		if err == nil {
+			state.LastBundleImport = time.Now().UTC()
+			if maxId > state.LastId {
+				state.LastId = maxId
+			}
+		}
+	}); serr != nil && err == nil {
+		err = serr
+	}
+	return stored, err
+}
+
+// ImportBundleFile imports the signatures from a bundle file
+func ImportBundleFile(d *db.RocksDB, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	glog.Infof("FourByteSignatures importing bundle %s", path)
+	stored, err := ImportBundle(d, f)
+	if err != nil {
+		return stored, err
+	}
+	glog.Infof("FourByteSignatures imported %d new signatures from bundle %s", stored, path)
+	return stored, nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/glog"
@@ -128,73 +129,179 @@ func parseSignatureFromText(t string) *bchain.FourByteSignature {
 	return &signature
 }
 
+// loadState returns the persisted import state, for databases without stored state
+// (created before the state was persisted) the count is computed from the stored signatures
+// and counted is true
+func loadState(d *db.RocksDB) (state *bchain.FourByteSignaturesState, counted bool, err error) {
+	state, err = d.GetFourByteSignaturesState()
+	if err != nil || state != nil {
+		return state, false, err
+	}
+	count, err := d.CountFourByteSignatures()
+	if err != nil {
+		return nil, false, err
+	}
+	return &bchain.FourByteSignaturesState{Count: count}, true, nil
+}
+
+// stateMux serializes the updates of the persisted import state,
+// the downloader and the bundle import (startup flag or admin upload) may run concurrently
+var stateMux sync.Mutex
+
+// updateState adds the number of the newly stored signatures to the persisted import state,
+// applies the update function and stores the state. A count computed by loadState already
+// includes the stored signatures, they are not added again.
+func updateState(d *db.RocksDB, stored int, update func(state *bchain.FourByteSignaturesState)) error {
+	stateMux.Lock()
+	defer stateMux.Unlock()
+	state, counted, err := loadState(d)
+	if err != nil {
+		return err
+	}
+	if !counted {
+		state.Count += int64(stored)
+	}
+	if stored > 0 {
+		state.LastUpdate = time.Now().UTC()
+	}
+	update(state)
+	return d.StoreFourByteSignaturesState(state)
+}
+
+// storeSignatures stores the signatures not yet present in the db and returns their number
+func storeSignatures(d *db.RocksDB, signatures []signatureData) (int, error) {
+	wb := grocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	stored := 0
+	for i := range signatures {
+		r := &signatures[i]
+		fourBytes, err := strconv.ParseUint(r.HexSignature, 0, 32)
+		if err != nil {
+			glog.Errorf("Invalid 4byte signature %+v: %v", r, err)
+			continue
+		}
+		sig, err := d.GetFourByteSignature(uint32(fourBytes), uint32(r.Id))
+		if err != nil {
+			return 0, err
+		}
+		if sig != nil {
+			continue
+		}
+		fbs := parseSignatureFromText(r.TextSignature)
+		if fbs == nil {
+			glog.Errorf("FourByteSignaturesDownloader invalid signature %s", r.TextSignature)
+			continue
+		}
+		if err := d.StoreFourByteSignature(wb, uint32(fourBytes), uint32(r.Id), fbs); err != nil {
+			return 0, err
+		}
+		stored++
+	}
+	if stored > 0 {
+		if err := d.WriteBatch(wb); err != nil {
+			return 0, err
+		}
+	}
+	return stored, nil
+}
+
 func (fd *FourByteSignaturesDownloader) downloadSignatures() {
+	glog.Info("FourByteSignaturesDownloader starting download")
+	state, _, err := loadState(fd.db)
+	if err != nil {
+		glog.Errorf("FourByteSignaturesDownloader failed to load state, %v", err)
+		return
+	}
+	// first finish the download interrupted in the previous run
+	if state.ResumeURL != "" {
+		glog.Infof("FourByteSignaturesDownloader resuming interrupted download from %s", state.ResumeURL)
+		if !fd.downloadPages(state.ResumeURL, state.LastId, state.ResumeTopId) {
+			return
+		}
+		if state, _, err = loadState(fd.db); err != nil {
+			glog.Errorf("FourByteSignaturesDownloader failed to load state, %v", err)
+			return
+		}
+	}
+	fd.downloadPages(fd.url, state.LastId, 0)
+	glog.Infof("FourByteSignaturesDownloader finished")
+}
+
+// downloadPages walks the pages from url until it reaches signatures with id lower or equal
+// to stopId (the API returns the newest signatures first). Each page is stored immediately
+// and the next page is persisted, so that an interrupted download resumes from it.
+// topId is the highest id of the walk, it becomes the new LastId when the walk completes.
+func (fd *FourByteSignaturesDownloader) downloadPages(url string, stopId uint32, topId uint32) bool {
 	period := time.Millisecond * 100
 	timer := time.NewTimer(period)
-	url := fd.url
-	results := make([]signatureData, 0)
-	glog.Info("FourByteSignaturesDownloader starting download")
+	defer timer.Stop()
 	for {
 		page, err := fd.getPageWithRetry(url)
 		if err != nil {
 			glog.Errorf("Error getting 4byte signatures from %s: %v", url, err)
-			return
-		}
-		if page == nil {
-			glog.Errorf("Empty page from 4byte signatures from %s: %v", url, err)
-			return
+			return false
 		}
 		glog.Infof("FourByteSignaturesDownloader downloaded %s with %d results", url, len(page.Results))
+		done := page.Next == ""
+		results := make([]signatureData, 0, len(page.Results))
+		for i := range page.Results {
+			if uint32(page.Results[i].Id) <= stopId {
+				done = true
+				continue
+			}
+			results = append(results, page.Results[i])
+		}
 		if len(page.Results) > 0 {
-			fourBytes, err := strconv.ParseUint(page.Results[0].HexSignature, 0, 0)
-			if err != nil {
-				glog.Errorf("Invalid 4byte signature %+v on page %s: %v", page.Results[0], url, err)
-				return
+			if topId == 0 {
+				topId = uint32(page.Results[0].Id)
 			}
-			sig, err := fd.db.GetFourByteSignature(uint32(fourBytes), uint32(page.Results[0].Id))
-			if err != nil {
-				glog.Errorf("db.GetFourByteSignature error %+v on page %s: %v", page.Results[0], url, err)
-				return
+			// databases without persisted state, the signature is already stored in db
+			if stopId == 0 {
+				fourBytes, err := strconv.ParseUint(page.Results[0].HexSignature, 0, 32)
+				if err != nil {
+					glog.Errorf("Invalid 4byte signature %+v on page %s: %v", page.Results[0], url, err)
+					return false
+				}
+				sig, err := fd.db.GetFourByteSignature(uint32(fourBytes), uint32(page.Results[0].Id))
+				if err != nil {
+					glog.Errorf("db.GetFourByteSignature error %+v on page %s: %v", page.Results[0], url, err)
+					return false
+				}
+				if sig != nil {
+					done = true
+				}
 			}
-			// signature is already stored in db, break
-			if sig != nil {
-				break
+		}
+		stored, err := storeSignatures(fd.db, results)
+		if err != nil {
+			glog.Errorf("FourByteSignaturesDownloader failed to store signatures, %v", err)
+			return false
+		}
+		if stored > 0 {
+			glog.Infof("FourByteSignaturesDownloader stored %d new signatures", stored)
+		}
+		err = updateState(fd.db, stored, func(state *bchain.FourByteSignaturesState) {
+			if done {
+				if topId > state.LastId {
+					state.LastId = topId
+				}
+				state.ResumeURL = ""
+				state.ResumeTopId = 0
+			} else {
+				state.ResumeURL = page.Next
+				state.ResumeTopId = topId
 			}
-			results = append(results, page.Results...)
+		})
+		if err != nil {
+			glog.Errorf("FourByteSignaturesDownloader failed to store state, %v", err)
+			return false
 		}
-		if page.Next == "" {
-			// at the end
-			break
+		if done {
+			return true
 		}
 		url = page.Next
 		// wait a bit to not to flood the server
 		<-timer.C
 		timer.Reset(period)
 	}
-	if len(results) > 0 {
-		glog.Infof("FourByteSignaturesDownloader storing %d new signatures", len(results))
-		wb := grocksdb.NewWriteBatch()
-		defer wb.Destroy()
-
-		for i := range results {
-			r := &results[i]
-			fourBytes, err := strconv.ParseUint(r.HexSignature, 0, 0)
-			if err != nil {
-				glog.Errorf("Invalid 4byte signature %+v: %v", r, err)
-				return
-			}
-			fbs := parseSignatureFromText(r.TextSignature)
-			if fbs != nil {
-				fd.db.StoreFourByteSignature(wb, uint32(fourBytes), uint32(r.Id), fbs)
-			} else {
-				glog.Errorf("FourByteSignaturesDownloader invalid signature %s", r.TextSignature)
-			}
-		}
-
-		if err := fd.db.WriteBatch(wb); err != nil {
-			glog.Errorf("FourByteSignaturesDownloader failed to store signatures, %v", err)
-		}
-
-	}
-	glog.Infof("FourByteSignaturesDownloader finished")
 }
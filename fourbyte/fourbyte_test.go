@@ -2,6 +2,7 @@ package fourbyte
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/trezor/blockbook/bchain"
@@ -53,3 +54,66 @@ func Test_parseSignatureFromText(t *testing.T) {
 		})
 	}
 }
+
+func Test_readBundle(t *testing.T) {
+	tests := []struct {
+		name    string
+		bundle  string
+		want    []signatureData
+		wantErr bool
+	}{
+		{
+			name: "JSON lines",
+			bundle: `{"id":2,"text_signature":"transfer(address,uint256)","hex_signature":"0xa9059cbb"}
+{"id":1,"text_signature":"vestingDeposits(address)","hex_signature":"0x3a0e4e3b"}
+`,
+			want: []signatureData{
+				{Id: 2, TextSignature: "transfer(address,uint256)", HexSignature: "0xa9059cbb"},
+				{Id: 1, TextSignature: "vestingDeposits(address)", HexSignature: "0x3a0e4e3b"},
+			},
+		},
+		{
+			name: "API dump pages and array",
+			bundle: `{"count":3,"next":"https://www.4byte.directory/api/v1/signatures/?page=2","results":[{"id":3,"text_signature":"a()","hex_signature":"0x0dbe671f"},{"id":2,"text_signature":"b()","hex_signature":"0x4df7e3d0"}]}
+[{"id":1,"text_signature":"c()","hex_signature":"0xc3da42b8"}]`,
+			want: []signatureData{
+				{Id: 3, TextSignature: "a()", HexSignature: "0x0dbe671f"},
+				{Id: 2, TextSignature: "b()", HexSignature: "0x4df7e3d0"},
+				{Id: 1, TextSignature: "c()", HexSignature: "0xc3da42b8"},
+			},
+		},
+		{
+			name:    "missing id",
+			bundle:  `{"text_signature":"a()","hex_signature":"0x0dbe671f"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			bundle:  `{"id":1,"text_signature":"a()"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []signatureData
+			batches := 0
+			err := readBundle(strings.NewReader(tt.bundle), 2, func(s []signatureData) error {
+				batches++
+				got = append(got, s...)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readBundle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readBundle() = %+v, want %+v", got, tt.want)
+			}
+			if wantBatches := (len(tt.want) + 1) / 2; batches != wantBatches {
+				t.Errorf("readBundle() batches = %d, want %d", batches, wantBatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,4554 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: grpcapi/blockbook.proto
+
+// The gRPC interface of Blockbook mirrors the methods of the websocket API, the messages mirror the types of the api
+// package with the same field names in snake case. The amounts are decimal strings in the base units of the coin,
+// the same as in the JSON API, since they do not fit into 64-bit integers on Ethereum-type chains.
+//
+// bchain.ProtoTransaction (bchain/tx.proto) is the storage format of the Bitcoin-type transactions in the index,
+// it does not carry the normalized data of the API (values of the inputs, fees, confirmations, token transfers),
+// therefore the transactions are defined here again.
+
+package grpcapi
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInfoRequest) Reset() {
+	*x = GetInfoRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoRequest) ProtoMessage() {}
+
+func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{0}
+}
+
+type BackendInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Version          string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Subversion       string                 `protobuf:"bytes,2,opt,name=subversion,proto3" json:"subversion,omitempty"`
+	ConsensusVersion string                 `protobuf:"bytes,3,opt,name=consensus_version,json=consensusVersion,proto3" json:"consensus_version,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BackendInfo) Reset() {
+	*x = BackendInfo{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackendInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackendInfo) ProtoMessage() {}
+
+func (x *BackendInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackendInfo.ProtoReflect.Descriptor instead.
+func (*BackendInfo) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BackendInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *BackendInfo) GetSubversion() string {
+	if x != nil {
+		return x.Subversion
+	}
+	return ""
+}
+
+func (x *BackendInfo) GetConsensusVersion() string {
+	if x != nil {
+		return x.ConsensusVersion
+	}
+	return ""
+}
+
+type Info struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Shortcut      string                 `protobuf:"bytes,2,opt,name=shortcut,proto3" json:"shortcut,omitempty"`
+	Network       string                 `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	Decimals      int32                  `protobuf:"varint,4,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Version       string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	BestHeight    int64                  `protobuf:"varint,6,opt,name=best_height,json=bestHeight,proto3" json:"best_height,omitempty"`
+	BestHash      string                 `protobuf:"bytes,7,opt,name=best_hash,json=bestHash,proto3" json:"best_hash,omitempty"`
+	Block0Hash    string                 `protobuf:"bytes,8,opt,name=block0_hash,json=block0Hash,proto3" json:"block0_hash,omitempty"`
+	Testnet       bool                   `protobuf:"varint,9,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Backend       *BackendInfo           `protobuf:"bytes,10,opt,name=backend,proto3" json:"backend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Info) Reset() {
+	*x = Info{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Info) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Info) ProtoMessage() {}
+
+func (x *Info) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Info.ProtoReflect.Descriptor instead.
+func (*Info) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Info) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Info) GetShortcut() string {
+	if x != nil {
+		return x.Shortcut
+	}
+	return ""
+}
+
+func (x *Info) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Info) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Info) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Info) GetBestHeight() int64 {
+	if x != nil {
+		return x.BestHeight
+	}
+	return 0
+}
+
+func (x *Info) GetBestHash() string {
+	if x != nil {
+		return x.BestHash
+	}
+	return ""
+}
+
+func (x *Info) GetBlock0Hash() string {
+	if x != nil {
+		return x.Block0Hash
+	}
+	return ""
+}
+
+func (x *Info) GetTestnet() bool {
+	if x != nil {
+		return x.Testnet
+	}
+	return false
+}
+
+func (x *Info) GetBackend() *BackendInfo {
+	if x != nil {
+		return x.Backend
+	}
+	return nil
+}
+
+type GetAccountInfoRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Descriptor_ string                 `protobuf:"bytes,1,opt,name=descriptor,proto3" json:"descriptor,omitempty"`
+	// details is one of basic, tokens, tokenBalances, txids, txslight, txs
+	Details string `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
+	// tokens is one of derived, used, nonzero
+	Tokens            string   `protobuf:"bytes,3,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	PageSize          int32    `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Page              int32    `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	Cursor            string   `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	FromHeight        int32    `protobuf:"varint,7,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	ToHeight          int32    `protobuf:"varint,8,opt,name=to_height,json=toHeight,proto3" json:"to_height,omitempty"`
+	ContractFilter    string   `protobuf:"bytes,9,opt,name=contract_filter,json=contractFilter,proto3" json:"contract_filter,omitempty"`
+	SecondaryCurrency string   `protobuf:"bytes,10,opt,name=secondary_currency,json=secondaryCurrency,proto3" json:"secondary_currency,omitempty"`
+	Gap               int32    `protobuf:"varint,11,opt,name=gap,proto3" json:"gap,omitempty"`
+	Protocols         []string `protobuf:"bytes,12,rep,name=protocols,proto3" json:"protocols,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetAccountInfoRequest) Reset() {
+	*x = GetAccountInfoRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountInfoRequest) ProtoMessage() {}
+
+func (x *GetAccountInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountInfoRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAccountInfoRequest) GetDescriptor_() string {
+	if x != nil {
+		return x.Descriptor_
+	}
+	return ""
+}
+
+func (x *GetAccountInfoRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *GetAccountInfoRequest) GetTokens() string {
+	if x != nil {
+		return x.Tokens
+	}
+	return ""
+}
+
+func (x *GetAccountInfoRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAccountInfoRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetAccountInfoRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetAccountInfoRequest) GetFromHeight() int32 {
+	if x != nil {
+		return x.FromHeight
+	}
+	return 0
+}
+
+func (x *GetAccountInfoRequest) GetToHeight() int32 {
+	if x != nil {
+		return x.ToHeight
+	}
+	return 0
+}
+
+func (x *GetAccountInfoRequest) GetContractFilter() string {
+	if x != nil {
+		return x.ContractFilter
+	}
+	return ""
+}
+
+func (x *GetAccountInfoRequest) GetSecondaryCurrency() string {
+	if x != nil {
+		return x.SecondaryCurrency
+	}
+	return ""
+}
+
+func (x *GetAccountInfoRequest) GetGap() int32 {
+	if x != nil {
+		return x.Gap
+	}
+	return 0
+}
+
+func (x *GetAccountInfoRequest) GetProtocols() []string {
+	if x != nil {
+		return x.Protocols
+	}
+	return nil
+}
+
+type Token struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Standard         string                 `protobuf:"bytes,1,opt,name=standard,proto3" json:"standard,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Path             string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Contract         string                 `protobuf:"bytes,4,opt,name=contract,proto3" json:"contract,omitempty"`
+	Transfers        int32                  `protobuf:"varint,5,opt,name=transfers,proto3" json:"transfers,omitempty"`
+	Symbol           string                 `protobuf:"bytes,6,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals         int32                  `protobuf:"varint,7,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Balance          string                 `protobuf:"bytes,8,opt,name=balance,proto3" json:"balance,omitempty"`
+	BaseValue        float64                `protobuf:"fixed64,9,opt,name=base_value,json=baseValue,proto3" json:"base_value,omitempty"`
+	SecondaryValue   float64                `protobuf:"fixed64,10,opt,name=secondary_value,json=secondaryValue,proto3" json:"secondary_value,omitempty"`
+	Ids              []string               `protobuf:"bytes,11,rep,name=ids,proto3" json:"ids,omitempty"`
+	MultiTokenValues []*MultiTokenValue     `protobuf:"bytes,12,rep,name=multi_token_values,json=multiTokenValues,proto3" json:"multi_token_values,omitempty"`
+	TotalReceived    string                 `protobuf:"bytes,13,opt,name=total_received,json=totalReceived,proto3" json:"total_received,omitempty"`
+	TotalSent        string                 `protobuf:"bytes,14,opt,name=total_sent,json=totalSent,proto3" json:"total_sent,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Token) Reset() {
+	*x = Token{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Token) ProtoMessage() {}
+
+func (x *Token) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Token.ProtoReflect.Descriptor instead.
+func (*Token) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Token) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *Token) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Token) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Token) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *Token) GetTransfers() int32 {
+	if x != nil {
+		return x.Transfers
+	}
+	return 0
+}
+
+func (x *Token) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Token) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Token) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *Token) GetBaseValue() float64 {
+	if x != nil {
+		return x.BaseValue
+	}
+	return 0
+}
+
+func (x *Token) GetSecondaryValue() float64 {
+	if x != nil {
+		return x.SecondaryValue
+	}
+	return 0
+}
+
+func (x *Token) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *Token) GetMultiTokenValues() []*MultiTokenValue {
+	if x != nil {
+		return x.MultiTokenValues
+	}
+	return nil
+}
+
+func (x *Token) GetTotalReceived() string {
+	if x != nil {
+		return x.TotalReceived
+	}
+	return ""
+}
+
+func (x *Token) GetTotalSent() string {
+	if x != nil {
+		return x.TotalSent
+	}
+	return ""
+}
+
+type Address struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Page                 int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	TotalPages           int32                  `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	ItemsOnPage          int32                  `protobuf:"varint,3,opt,name=items_on_page,json=itemsOnPage,proto3" json:"items_on_page,omitempty"`
+	Address              string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Balance              string                 `protobuf:"bytes,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	TotalReceived        string                 `protobuf:"bytes,6,opt,name=total_received,json=totalReceived,proto3" json:"total_received,omitempty"`
+	TotalSent            string                 `protobuf:"bytes,7,opt,name=total_sent,json=totalSent,proto3" json:"total_sent,omitempty"`
+	UnconfirmedBalance   string                 `protobuf:"bytes,8,opt,name=unconfirmed_balance,json=unconfirmedBalance,proto3" json:"unconfirmed_balance,omitempty"`
+	UnconfirmedTxs       int32                  `protobuf:"varint,9,opt,name=unconfirmed_txs,json=unconfirmedTxs,proto3" json:"unconfirmed_txs,omitempty"`
+	Txs                  int32                  `protobuf:"varint,10,opt,name=txs,proto3" json:"txs,omitempty"`
+	NonTokenTxs          int32                  `protobuf:"varint,11,opt,name=non_token_txs,json=nonTokenTxs,proto3" json:"non_token_txs,omitempty"`
+	InternalTxs          int32                  `protobuf:"varint,12,opt,name=internal_txs,json=internalTxs,proto3" json:"internal_txs,omitempty"`
+	Transactions         []*Tx                  `protobuf:"bytes,13,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Txids                []string               `protobuf:"bytes,14,rep,name=txids,proto3" json:"txids,omitempty"`
+	NextCursor           string                 `protobuf:"bytes,15,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor           string                 `protobuf:"bytes,16,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	Nonce                string                 `protobuf:"bytes,17,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	UsedTokens           int32                  `protobuf:"varint,18,opt,name=used_tokens,json=usedTokens,proto3" json:"used_tokens,omitempty"`
+	Tokens               []*Token               `protobuf:"bytes,19,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	SecondaryValue       float64                `protobuf:"fixed64,20,opt,name=secondary_value,json=secondaryValue,proto3" json:"secondary_value,omitempty"`
+	TokensBaseValue      float64                `protobuf:"fixed64,21,opt,name=tokens_base_value,json=tokensBaseValue,proto3" json:"tokens_base_value,omitempty"`
+	TokensSecondaryValue float64                `protobuf:"fixed64,22,opt,name=tokens_secondary_value,json=tokensSecondaryValue,proto3" json:"tokens_secondary_value,omitempty"`
+	TotalBaseValue       float64                `protobuf:"fixed64,23,opt,name=total_base_value,json=totalBaseValue,proto3" json:"total_base_value,omitempty"`
+	TotalSecondaryValue  float64                `protobuf:"fixed64,24,opt,name=total_secondary_value,json=totalSecondaryValue,proto3" json:"total_secondary_value,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Address) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Address) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *Address) GetItemsOnPage() int32 {
+	if x != nil {
+		return x.ItemsOnPage
+	}
+	return 0
+}
+
+func (x *Address) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Address) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *Address) GetTotalReceived() string {
+	if x != nil {
+		return x.TotalReceived
+	}
+	return ""
+}
+
+func (x *Address) GetTotalSent() string {
+	if x != nil {
+		return x.TotalSent
+	}
+	return ""
+}
+
+func (x *Address) GetUnconfirmedBalance() string {
+	if x != nil {
+		return x.UnconfirmedBalance
+	}
+	return ""
+}
+
+func (x *Address) GetUnconfirmedTxs() int32 {
+	if x != nil {
+		return x.UnconfirmedTxs
+	}
+	return 0
+}
+
+func (x *Address) GetTxs() int32 {
+	if x != nil {
+		return x.Txs
+	}
+	return 0
+}
+
+func (x *Address) GetNonTokenTxs() int32 {
+	if x != nil {
+		return x.NonTokenTxs
+	}
+	return 0
+}
+
+func (x *Address) GetInternalTxs() int32 {
+	if x != nil {
+		return x.InternalTxs
+	}
+	return 0
+}
+
+func (x *Address) GetTransactions() []*Tx {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *Address) GetTxids() []string {
+	if x != nil {
+		return x.Txids
+	}
+	return nil
+}
+
+func (x *Address) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *Address) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *Address) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *Address) GetUsedTokens() int32 {
+	if x != nil {
+		return x.UsedTokens
+	}
+	return 0
+}
+
+func (x *Address) GetTokens() []*Token {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *Address) GetSecondaryValue() float64 {
+	if x != nil {
+		return x.SecondaryValue
+	}
+	return 0
+}
+
+func (x *Address) GetTokensBaseValue() float64 {
+	if x != nil {
+		return x.TokensBaseValue
+	}
+	return 0
+}
+
+func (x *Address) GetTokensSecondaryValue() float64 {
+	if x != nil {
+		return x.TokensSecondaryValue
+	}
+	return 0
+}
+
+func (x *Address) GetTotalBaseValue() float64 {
+	if x != nil {
+		return x.TotalBaseValue
+	}
+	return 0
+}
+
+func (x *Address) GetTotalSecondaryValue() float64 {
+	if x != nil {
+		return x.TotalSecondaryValue
+	}
+	return 0
+}
+
+type Vin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Vout          uint32                 `protobuf:"varint,2,opt,name=vout,proto3" json:"vout,omitempty"`
+	Sequence      int64                  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	N             int32                  `protobuf:"varint,4,opt,name=n,proto3" json:"n,omitempty"`
+	Addresses     []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	IsAddress     bool                   `protobuf:"varint,6,opt,name=is_address,json=isAddress,proto3" json:"is_address,omitempty"`
+	IsOwn         bool                   `protobuf:"varint,7,opt,name=is_own,json=isOwn,proto3" json:"is_own,omitempty"`
+	Value         string                 `protobuf:"bytes,8,opt,name=value,proto3" json:"value,omitempty"`
+	Hex           string                 `protobuf:"bytes,9,opt,name=hex,proto3" json:"hex,omitempty"`
+	Asm           string                 `protobuf:"bytes,10,opt,name=asm,proto3" json:"asm,omitempty"`
+	Coinbase      string                 `protobuf:"bytes,11,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vin) Reset() {
+	*x = Vin{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vin) ProtoMessage() {}
+
+func (x *Vin) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vin.ProtoReflect.Descriptor instead.
+func (*Vin) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Vin) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *Vin) GetVout() uint32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *Vin) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Vin) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *Vin) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *Vin) GetIsAddress() bool {
+	if x != nil {
+		return x.IsAddress
+	}
+	return false
+}
+
+func (x *Vin) GetIsOwn() bool {
+	if x != nil {
+		return x.IsOwn
+	}
+	return false
+}
+
+func (x *Vin) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Vin) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *Vin) GetAsm() string {
+	if x != nil {
+		return x.Asm
+	}
+	return ""
+}
+
+func (x *Vin) GetCoinbase() string {
+	if x != nil {
+		return x.Coinbase
+	}
+	return ""
+}
+
+type Vout struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	N             int32                  `protobuf:"varint,2,opt,name=n,proto3" json:"n,omitempty"`
+	Spent         bool                   `protobuf:"varint,3,opt,name=spent,proto3" json:"spent,omitempty"`
+	SpentTxId     string                 `protobuf:"bytes,4,opt,name=spent_tx_id,json=spentTxId,proto3" json:"spent_tx_id,omitempty"`
+	SpentIndex    int32                  `protobuf:"varint,5,opt,name=spent_index,json=spentIndex,proto3" json:"spent_index,omitempty"`
+	SpentHeight   int32                  `protobuf:"varint,6,opt,name=spent_height,json=spentHeight,proto3" json:"spent_height,omitempty"`
+	Hex           string                 `protobuf:"bytes,7,opt,name=hex,proto3" json:"hex,omitempty"`
+	Asm           string                 `protobuf:"bytes,8,opt,name=asm,proto3" json:"asm,omitempty"`
+	Addresses     []string               `protobuf:"bytes,9,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	IsAddress     bool                   `protobuf:"varint,10,opt,name=is_address,json=isAddress,proto3" json:"is_address,omitempty"`
+	IsOwn         bool                   `protobuf:"varint,11,opt,name=is_own,json=isOwn,proto3" json:"is_own,omitempty"`
+	Type          string                 `protobuf:"bytes,12,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vout) Reset() {
+	*x = Vout{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vout) ProtoMessage() {}
+
+func (x *Vout) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vout.ProtoReflect.Descriptor instead.
+func (*Vout) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Vout) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Vout) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *Vout) GetSpent() bool {
+	if x != nil {
+		return x.Spent
+	}
+	return false
+}
+
+func (x *Vout) GetSpentTxId() string {
+	if x != nil {
+		return x.SpentTxId
+	}
+	return ""
+}
+
+func (x *Vout) GetSpentIndex() int32 {
+	if x != nil {
+		return x.SpentIndex
+	}
+	return 0
+}
+
+func (x *Vout) GetSpentHeight() int32 {
+	if x != nil {
+		return x.SpentHeight
+	}
+	return 0
+}
+
+func (x *Vout) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *Vout) GetAsm() string {
+	if x != nil {
+		return x.Asm
+	}
+	return ""
+}
+
+func (x *Vout) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *Vout) GetIsAddress() bool {
+	if x != nil {
+		return x.IsAddress
+	}
+	return false
+}
+
+func (x *Vout) GetIsOwn() bool {
+	if x != nil {
+		return x.IsOwn
+	}
+	return false
+}
+
+func (x *Vout) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type MultiTokenValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiTokenValue) Reset() {
+	*x = MultiTokenValue{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiTokenValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiTokenValue) ProtoMessage() {}
+
+func (x *MultiTokenValue) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiTokenValue.ProtoReflect.Descriptor instead.
+func (*MultiTokenValue) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MultiTokenValue) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MultiTokenValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type TokenTransfer struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Standard         string                 `protobuf:"bytes,1,opt,name=standard,proto3" json:"standard,omitempty"`
+	From             string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To               string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Contract         string                 `protobuf:"bytes,4,opt,name=contract,proto3" json:"contract,omitempty"`
+	Name             string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol           string                 `protobuf:"bytes,6,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals         int32                  `protobuf:"varint,7,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Value            string                 `protobuf:"bytes,8,opt,name=value,proto3" json:"value,omitempty"`
+	MultiTokenValues []*MultiTokenValue     `protobuf:"bytes,9,rep,name=multi_token_values,json=multiTokenValues,proto3" json:"multi_token_values,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TokenTransfer) Reset() {
+	*x = TokenTransfer{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenTransfer) ProtoMessage() {}
+
+func (x *TokenTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenTransfer.ProtoReflect.Descriptor instead.
+func (*TokenTransfer) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TokenTransfer) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *TokenTransfer) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetMultiTokenValues() []*MultiTokenValue {
+	if x != nil {
+		return x.MultiTokenValues
+	}
+	return nil
+}
+
+type EthereumSpecific struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Status               int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error                string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Nonce                uint64                 `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	GasLimit             string                 `protobuf:"bytes,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	GasUsed              string                 `protobuf:"bytes,5,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	GasPrice             string                 `protobuf:"bytes,6,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	EffectiveGasPrice    string                 `protobuf:"bytes,7,opt,name=effective_gas_price,json=effectiveGasPrice,proto3" json:"effective_gas_price,omitempty"`
+	MaxPriorityFeePerGas string                 `protobuf:"bytes,8,opt,name=max_priority_fee_per_gas,json=maxPriorityFeePerGas,proto3" json:"max_priority_fee_per_gas,omitempty"`
+	MaxFeePerGas         string                 `protobuf:"bytes,9,opt,name=max_fee_per_gas,json=maxFeePerGas,proto3" json:"max_fee_per_gas,omitempty"`
+	BaseFeePerGas        string                 `protobuf:"bytes,10,opt,name=base_fee_per_gas,json=baseFeePerGas,proto3" json:"base_fee_per_gas,omitempty"`
+	CreatedContract      string                 `protobuf:"bytes,11,opt,name=created_contract,json=createdContract,proto3" json:"created_contract,omitempty"`
+	Data                 string                 `protobuf:"bytes,12,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *EthereumSpecific) Reset() {
+	*x = EthereumSpecific{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EthereumSpecific) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EthereumSpecific) ProtoMessage() {}
+
+func (x *EthereumSpecific) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EthereumSpecific.ProtoReflect.Descriptor instead.
+func (*EthereumSpecific) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EthereumSpecific) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *EthereumSpecific) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *EthereumSpecific) GetGasLimit() string {
+	if x != nil {
+		return x.GasLimit
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetGasUsed() string {
+	if x != nil {
+		return x.GasUsed
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetGasPrice() string {
+	if x != nil {
+		return x.GasPrice
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetEffectiveGasPrice() string {
+	if x != nil {
+		return x.EffectiveGasPrice
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetMaxPriorityFeePerGas() string {
+	if x != nil {
+		return x.MaxPriorityFeePerGas
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetMaxFeePerGas() string {
+	if x != nil {
+		return x.MaxFeePerGas
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetBaseFeePerGas() string {
+	if x != nil {
+		return x.BaseFeePerGas
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetCreatedContract() string {
+	if x != nil {
+		return x.CreatedContract
+	}
+	return ""
+}
+
+func (x *EthereumSpecific) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type Tx struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Txid                   string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Version                int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	LockTime               uint32                 `protobuf:"varint,3,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	Vin                    []*Vin                 `protobuf:"bytes,4,rep,name=vin,proto3" json:"vin,omitempty"`
+	Vout                   []*Vout                `protobuf:"bytes,5,rep,name=vout,proto3" json:"vout,omitempty"`
+	BlockHash              string                 `protobuf:"bytes,6,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockHeight            int32                  `protobuf:"varint,7,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Confirmations          uint32                 `protobuf:"varint,8,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	ConfirmationEtaBlocks  uint32                 `protobuf:"varint,9,opt,name=confirmation_eta_blocks,json=confirmationEtaBlocks,proto3" json:"confirmation_eta_blocks,omitempty"`
+	ConfirmationEtaSeconds int64                  `protobuf:"varint,10,opt,name=confirmation_eta_seconds,json=confirmationEtaSeconds,proto3" json:"confirmation_eta_seconds,omitempty"`
+	BlockTime              int64                  `protobuf:"varint,11,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	Size                   int32                  `protobuf:"varint,12,opt,name=size,proto3" json:"size,omitempty"`
+	Vsize                  int32                  `protobuf:"varint,13,opt,name=vsize,proto3" json:"vsize,omitempty"`
+	Value                  string                 `protobuf:"bytes,14,opt,name=value,proto3" json:"value,omitempty"`
+	ValueIn                string                 `protobuf:"bytes,15,opt,name=value_in,json=valueIn,proto3" json:"value_in,omitempty"`
+	Fees                   string                 `protobuf:"bytes,16,opt,name=fees,proto3" json:"fees,omitempty"`
+	Hex                    string                 `protobuf:"bytes,17,opt,name=hex,proto3" json:"hex,omitempty"`
+	Rbf                    bool                   `protobuf:"varint,18,opt,name=rbf,proto3" json:"rbf,omitempty"`
+	TokenTransfers         []*TokenTransfer       `protobuf:"bytes,19,rep,name=token_transfers,json=tokenTransfers,proto3" json:"token_transfers,omitempty"`
+	EthereumSpecific       *EthereumSpecific      `protobuf:"bytes,20,opt,name=ethereum_specific,json=ethereumSpecific,proto3" json:"ethereum_specific,omitempty"`
+	ReplacedBy             string                 `protobuf:"bytes,21,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	Replaces               []string               `protobuf:"bytes,22,rep,name=replaces,proto3" json:"replaces,omitempty"`
+	// coin_specific_data is the JSON of the chain-specific data of the transaction, if the chain provides it
+	CoinSpecificData []byte `protobuf:"bytes,23,opt,name=coin_specific_data,json=coinSpecificData,proto3" json:"coin_specific_data,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Tx) Reset() {
+	*x = Tx{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tx) ProtoMessage() {}
+
+func (x *Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tx.ProtoReflect.Descriptor instead.
+func (*Tx) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Tx) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *Tx) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Tx) GetLockTime() uint32 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+func (x *Tx) GetVin() []*Vin {
+	if x != nil {
+		return x.Vin
+	}
+	return nil
+}
+
+func (x *Tx) GetVout() []*Vout {
+	if x != nil {
+		return x.Vout
+	}
+	return nil
+}
+
+func (x *Tx) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *Tx) GetBlockHeight() int32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *Tx) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Tx) GetConfirmationEtaBlocks() uint32 {
+	if x != nil {
+		return x.ConfirmationEtaBlocks
+	}
+	return 0
+}
+
+func (x *Tx) GetConfirmationEtaSeconds() int64 {
+	if x != nil {
+		return x.ConfirmationEtaSeconds
+	}
+	return 0
+}
+
+func (x *Tx) GetBlockTime() int64 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+func (x *Tx) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Tx) GetVsize() int32 {
+	if x != nil {
+		return x.Vsize
+	}
+	return 0
+}
+
+func (x *Tx) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Tx) GetValueIn() string {
+	if x != nil {
+		return x.ValueIn
+	}
+	return ""
+}
+
+func (x *Tx) GetFees() string {
+	if x != nil {
+		return x.Fees
+	}
+	return ""
+}
+
+func (x *Tx) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *Tx) GetRbf() bool {
+	if x != nil {
+		return x.Rbf
+	}
+	return false
+}
+
+func (x *Tx) GetTokenTransfers() []*TokenTransfer {
+	if x != nil {
+		return x.TokenTransfers
+	}
+	return nil
+}
+
+func (x *Tx) GetEthereumSpecific() *EthereumSpecific {
+	if x != nil {
+		return x.EthereumSpecific
+	}
+	return nil
+}
+
+func (x *Tx) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+func (x *Tx) GetReplaces() []string {
+	if x != nil {
+		return x.Replaces
+	}
+	return nil
+}
+
+func (x *Tx) GetCoinSpecificData() []byte {
+	if x != nil {
+		return x.CoinSpecificData
+	}
+	return nil
+}
+
+type GetAccountUtxoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Descriptor_   string                 `protobuf:"bytes,1,opt,name=descriptor,proto3" json:"descriptor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountUtxoRequest) Reset() {
+	*x = GetAccountUtxoRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountUtxoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountUtxoRequest) ProtoMessage() {}
+
+func (x *GetAccountUtxoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountUtxoRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountUtxoRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetAccountUtxoRequest) GetDescriptor_() string {
+	if x != nil {
+		return x.Descriptor_
+	}
+	return ""
+}
+
+type Utxo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Vout          int32                  `protobuf:"varint,2,opt,name=vout,proto3" json:"vout,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Confirmations int32                  `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Address       string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	Path          string                 `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	LockTime      uint32                 `protobuf:"varint,8,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	Coinbase      bool                   `protobuf:"varint,9,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Utxo) Reset() {
+	*x = Utxo{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Utxo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Utxo) ProtoMessage() {}
+
+func (x *Utxo) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Utxo.ProtoReflect.Descriptor instead.
+func (*Utxo) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Utxo) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *Utxo) GetVout() int32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *Utxo) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Utxo) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Utxo) GetConfirmations() int32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Utxo) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Utxo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Utxo) GetLockTime() uint32 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+func (x *Utxo) GetCoinbase() bool {
+	if x != nil {
+		return x.Coinbase
+	}
+	return false
+}
+
+type Utxos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Utxos         []*Utxo                `protobuf:"bytes,1,rep,name=utxos,proto3" json:"utxos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Utxos) Reset() {
+	*x = Utxos{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Utxos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Utxos) ProtoMessage() {}
+
+func (x *Utxos) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Utxos.ProtoReflect.Descriptor instead.
+func (*Utxos) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Utxos) GetUtxos() []*Utxo {
+	if x != nil {
+		return x.Utxos
+	}
+	return nil
+}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTransactionRequest) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type GetBlockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the hash or the height of the block
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Page          int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetBlockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetBlockRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetBlockRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type Block struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Page              int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	TotalPages        int32                  `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	ItemsOnPage       int32                  `protobuf:"varint,3,opt,name=items_on_page,json=itemsOnPage,proto3" json:"items_on_page,omitempty"`
+	Hash              string                 `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	PreviousBlockHash string                 `protobuf:"bytes,5,opt,name=previous_block_hash,json=previousBlockHash,proto3" json:"previous_block_hash,omitempty"`
+	NextBlockHash     string                 `protobuf:"bytes,6,opt,name=next_block_hash,json=nextBlockHash,proto3" json:"next_block_hash,omitempty"`
+	Height            uint32                 `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	Confirmations     int32                  `protobuf:"varint,8,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Size              int32                  `protobuf:"varint,9,opt,name=size,proto3" json:"size,omitempty"`
+	Time              int64                  `protobuf:"varint,10,opt,name=time,proto3" json:"time,omitempty"`
+	Version           string                 `protobuf:"bytes,11,opt,name=version,proto3" json:"version,omitempty"`
+	MerkleRoot        string                 `protobuf:"bytes,12,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	Nonce             string                 `protobuf:"bytes,13,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Bits              string                 `protobuf:"bytes,14,opt,name=bits,proto3" json:"bits,omitempty"`
+	Difficulty        string                 `protobuf:"bytes,15,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Txids             []string               `protobuf:"bytes,16,rep,name=txids,proto3" json:"txids,omitempty"`
+	TxCount           int32                  `protobuf:"varint,17,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	Txs               []*Tx                  `protobuf:"bytes,18,rep,name=txs,proto3" json:"txs,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Block) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Block) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *Block) GetItemsOnPage() int32 {
+	if x != nil {
+		return x.ItemsOnPage
+	}
+	return 0
+}
+
+func (x *Block) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Block) GetPreviousBlockHash() string {
+	if x != nil {
+		return x.PreviousBlockHash
+	}
+	return ""
+}
+
+func (x *Block) GetNextBlockHash() string {
+	if x != nil {
+		return x.NextBlockHash
+	}
+	return ""
+}
+
+func (x *Block) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Block) GetConfirmations() int32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Block) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Block) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Block) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Block) GetMerkleRoot() string {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return ""
+}
+
+func (x *Block) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *Block) GetBits() string {
+	if x != nil {
+		return x.Bits
+	}
+	return ""
+}
+
+func (x *Block) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *Block) GetTxids() []string {
+	if x != nil {
+		return x.Txids
+	}
+	return nil
+}
+
+func (x *Block) GetTxCount() int32 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+func (x *Block) GetTxs() []*Tx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+type GetBalanceHistoryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Descriptor_ string                 `protobuf:"bytes,1,opt,name=descriptor,proto3" json:"descriptor,omitempty"`
+	From        int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To          int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Currencies  []string               `protobuf:"bytes,4,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	Gap         int32                  `protobuf:"varint,5,opt,name=gap,proto3" json:"gap,omitempty"`
+	// group_by is the size of the aggregated intervals in seconds, 3600 if not set
+	GroupBy       uint32 `protobuf:"varint,6,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceHistoryRequest) Reset() {
+	*x = GetBalanceHistoryRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceHistoryRequest) ProtoMessage() {}
+
+func (x *GetBalanceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetBalanceHistoryRequest) GetDescriptor_() string {
+	if x != nil {
+		return x.Descriptor_
+	}
+	return ""
+}
+
+func (x *GetBalanceHistoryRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetBalanceHistoryRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetBalanceHistoryRequest) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *GetBalanceHistoryRequest) GetGap() int32 {
+	if x != nil {
+		return x.Gap
+	}
+	return 0
+}
+
+func (x *GetBalanceHistoryRequest) GetGroupBy() uint32 {
+	if x != nil {
+		return x.GroupBy
+	}
+	return 0
+}
+
+type BalanceHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          uint32                 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Txs           uint32                 `protobuf:"varint,2,opt,name=txs,proto3" json:"txs,omitempty"`
+	Received      string                 `protobuf:"bytes,3,opt,name=received,proto3" json:"received,omitempty"`
+	Sent          string                 `protobuf:"bytes,4,opt,name=sent,proto3" json:"sent,omitempty"`
+	SentToSelf    string                 `protobuf:"bytes,5,opt,name=sent_to_self,json=sentToSelf,proto3" json:"sent_to_self,omitempty"`
+	Rates         map[string]float32     `protobuf:"bytes,6,rep,name=rates,proto3" json:"rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
+	Txid          string                 `protobuf:"bytes,7,opt,name=txid,proto3" json:"txid,omitempty"`
+	Withdrawals   uint32                 `protobuf:"varint,8,opt,name=withdrawals,proto3" json:"withdrawals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceHistory) Reset() {
+	*x = BalanceHistory{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceHistory) ProtoMessage() {}
+
+func (x *BalanceHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceHistory.ProtoReflect.Descriptor instead.
+func (*BalanceHistory) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BalanceHistory) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *BalanceHistory) GetTxs() uint32 {
+	if x != nil {
+		return x.Txs
+	}
+	return 0
+}
+
+func (x *BalanceHistory) GetReceived() string {
+	if x != nil {
+		return x.Received
+	}
+	return ""
+}
+
+func (x *BalanceHistory) GetSent() string {
+	if x != nil {
+		return x.Sent
+	}
+	return ""
+}
+
+func (x *BalanceHistory) GetSentToSelf() string {
+	if x != nil {
+		return x.SentToSelf
+	}
+	return ""
+}
+
+func (x *BalanceHistory) GetRates() map[string]float32 {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *BalanceHistory) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *BalanceHistory) GetWithdrawals() uint32 {
+	if x != nil {
+		return x.Withdrawals
+	}
+	return 0
+}
+
+type BalanceHistories struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Histories     []*BalanceHistory      `protobuf:"bytes,1,rep,name=histories,proto3" json:"histories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceHistories) Reset() {
+	*x = BalanceHistories{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceHistories) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceHistories) ProtoMessage() {}
+
+func (x *BalanceHistories) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceHistories.ProtoReflect.Descriptor instead.
+func (*BalanceHistories) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BalanceHistories) GetHistories() []*BalanceHistory {
+	if x != nil {
+		return x.Histories
+	}
+	return nil
+}
+
+type EstimateFeeRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Blocks []int32                `protobuf:"varint,1,rep,packed,name=blocks,proto3" json:"blocks,omitempty"`
+	// specific holds the chain-specific parameters of the estimate, the same as in the websocket method estimateFee
+	Specific      *structpb.Struct `protobuf:"bytes,2,opt,name=specific,proto3" json:"specific,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateFeeRequest) Reset() {
+	*x = EstimateFeeRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateFeeRequest) ProtoMessage() {}
+
+func (x *EstimateFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateFeeRequest.ProtoReflect.Descriptor instead.
+func (*EstimateFeeRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EstimateFeeRequest) GetBlocks() []int32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *EstimateFeeRequest) GetSpecific() *structpb.Struct {
+	if x != nil {
+		return x.Specific
+	}
+	return nil
+}
+
+type Eip1559Fee struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	MaxFeePerGas         string                 `protobuf:"bytes,1,opt,name=max_fee_per_gas,json=maxFeePerGas,proto3" json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas string                 `protobuf:"bytes,2,opt,name=max_priority_fee_per_gas,json=maxPriorityFeePerGas,proto3" json:"max_priority_fee_per_gas,omitempty"`
+	MinWaitTimeEstimate  int32                  `protobuf:"varint,3,opt,name=min_wait_time_estimate,json=minWaitTimeEstimate,proto3" json:"min_wait_time_estimate,omitempty"`
+	MaxWaitTimeEstimate  int32                  `protobuf:"varint,4,opt,name=max_wait_time_estimate,json=maxWaitTimeEstimate,proto3" json:"max_wait_time_estimate,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Eip1559Fee) Reset() {
+	*x = Eip1559Fee{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Eip1559Fee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Eip1559Fee) ProtoMessage() {}
+
+func (x *Eip1559Fee) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Eip1559Fee.ProtoReflect.Descriptor instead.
+func (*Eip1559Fee) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Eip1559Fee) GetMaxFeePerGas() string {
+	if x != nil {
+		return x.MaxFeePerGas
+	}
+	return ""
+}
+
+func (x *Eip1559Fee) GetMaxPriorityFeePerGas() string {
+	if x != nil {
+		return x.MaxPriorityFeePerGas
+	}
+	return ""
+}
+
+func (x *Eip1559Fee) GetMinWaitTimeEstimate() int32 {
+	if x != nil {
+		return x.MinWaitTimeEstimate
+	}
+	return 0
+}
+
+func (x *Eip1559Fee) GetMaxWaitTimeEstimate() int32 {
+	if x != nil {
+		return x.MaxWaitTimeEstimate
+	}
+	return 0
+}
+
+type Eip1559Fees struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BaseFeePerGas     string                 `protobuf:"bytes,1,opt,name=base_fee_per_gas,json=baseFeePerGas,proto3" json:"base_fee_per_gas,omitempty"`
+	Low               *Eip1559Fee            `protobuf:"bytes,2,opt,name=low,proto3" json:"low,omitempty"`
+	Medium            *Eip1559Fee            `protobuf:"bytes,3,opt,name=medium,proto3" json:"medium,omitempty"`
+	High              *Eip1559Fee            `protobuf:"bytes,4,opt,name=high,proto3" json:"high,omitempty"`
+	Instant           *Eip1559Fee            `protobuf:"bytes,5,opt,name=instant,proto3" json:"instant,omitempty"`
+	NetworkCongestion float64                `protobuf:"fixed64,6,opt,name=network_congestion,json=networkCongestion,proto3" json:"network_congestion,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Eip1559Fees) Reset() {
+	*x = Eip1559Fees{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Eip1559Fees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Eip1559Fees) ProtoMessage() {}
+
+func (x *Eip1559Fees) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Eip1559Fees.ProtoReflect.Descriptor instead.
+func (*Eip1559Fees) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Eip1559Fees) GetBaseFeePerGas() string {
+	if x != nil {
+		return x.BaseFeePerGas
+	}
+	return ""
+}
+
+func (x *Eip1559Fees) GetLow() *Eip1559Fee {
+	if x != nil {
+		return x.Low
+	}
+	return nil
+}
+
+func (x *Eip1559Fees) GetMedium() *Eip1559Fee {
+	if x != nil {
+		return x.Medium
+	}
+	return nil
+}
+
+func (x *Eip1559Fees) GetHigh() *Eip1559Fee {
+	if x != nil {
+		return x.High
+	}
+	return nil
+}
+
+func (x *Eip1559Fees) GetInstant() *Eip1559Fee {
+	if x != nil {
+		return x.Instant
+	}
+	return nil
+}
+
+func (x *Eip1559Fees) GetNetworkCongestion() float64 {
+	if x != nil {
+		return x.NetworkCongestion
+	}
+	return 0
+}
+
+type FeeConfidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        int32                  `protobuf:"varint,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	Low           string                 `protobuf:"bytes,2,opt,name=low,proto3" json:"low,omitempty"`
+	Medium        string                 `protobuf:"bytes,3,opt,name=medium,proto3" json:"medium,omitempty"`
+	High          string                 `protobuf:"bytes,4,opt,name=high,proto3" json:"high,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeConfidence) Reset() {
+	*x = FeeConfidence{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeConfidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeConfidence) ProtoMessage() {}
+
+func (x *FeeConfidence) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeConfidence.ProtoReflect.Descriptor instead.
+func (*FeeConfidence) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FeeConfidence) GetBlocks() int32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *FeeConfidence) GetLow() string {
+	if x != nil {
+		return x.Low
+	}
+	return ""
+}
+
+func (x *FeeConfidence) GetMedium() string {
+	if x != nil {
+		return x.Medium
+	}
+	return ""
+}
+
+func (x *FeeConfidence) GetHigh() string {
+	if x != nil {
+		return x.High
+	}
+	return ""
+}
+
+type FeeEstimate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FeePerTx      string                 `protobuf:"bytes,1,opt,name=fee_per_tx,json=feePerTx,proto3" json:"fee_per_tx,omitempty"`
+	FeePerUnit    string                 `protobuf:"bytes,2,opt,name=fee_per_unit,json=feePerUnit,proto3" json:"fee_per_unit,omitempty"`
+	FeeLimit      string                 `protobuf:"bytes,3,opt,name=fee_limit,json=feeLimit,proto3" json:"fee_limit,omitempty"`
+	L1Fee         string                 `protobuf:"bytes,4,opt,name=l1_fee,json=l1Fee,proto3" json:"l1_fee,omitempty"`
+	Eip1559       *Eip1559Fees           `protobuf:"bytes,5,opt,name=eip1559,proto3" json:"eip1559,omitempty"`
+	Confidence    *FeeConfidence         `protobuf:"bytes,6,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeEstimate) Reset() {
+	*x = FeeEstimate{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeEstimate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeEstimate) ProtoMessage() {}
+
+func (x *FeeEstimate) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeEstimate.ProtoReflect.Descriptor instead.
+func (*FeeEstimate) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FeeEstimate) GetFeePerTx() string {
+	if x != nil {
+		return x.FeePerTx
+	}
+	return ""
+}
+
+func (x *FeeEstimate) GetFeePerUnit() string {
+	if x != nil {
+		return x.FeePerUnit
+	}
+	return ""
+}
+
+func (x *FeeEstimate) GetFeeLimit() string {
+	if x != nil {
+		return x.FeeLimit
+	}
+	return ""
+}
+
+func (x *FeeEstimate) GetL1Fee() string {
+	if x != nil {
+		return x.L1Fee
+	}
+	return ""
+}
+
+func (x *FeeEstimate) GetEip1559() *Eip1559Fees {
+	if x != nil {
+		return x.Eip1559
+	}
+	return nil
+}
+
+func (x *FeeEstimate) GetConfidence() *FeeConfidence {
+	if x != nil {
+		return x.Confidence
+	}
+	return nil
+}
+
+type EstimateFeeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// estimates are in the order of the requested blocks
+	Estimates     []*FeeEstimate `protobuf:"bytes,1,rep,name=estimates,proto3" json:"estimates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateFeeResponse) Reset() {
+	*x = EstimateFeeResponse{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateFeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateFeeResponse) ProtoMessage() {}
+
+func (x *EstimateFeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateFeeResponse.ProtoReflect.Descriptor instead.
+func (*EstimateFeeResponse) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EstimateFeeResponse) GetEstimates() []*FeeEstimate {
+	if x != nil {
+		return x.Estimates
+	}
+	return nil
+}
+
+type SendTransactionRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Hex                   string                 `protobuf:"bytes,1,opt,name=hex,proto3" json:"hex,omitempty"`
+	DisableAlternativeRpc bool                   `protobuf:"varint,2,opt,name=disable_alternative_rpc,json=disableAlternativeRpc,proto3" json:"disable_alternative_rpc,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SendTransactionRequest) Reset() {
+	*x = SendTransactionRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTransactionRequest) ProtoMessage() {}
+
+func (x *SendTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTransactionRequest.ProtoReflect.Descriptor instead.
+func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SendTransactionRequest) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *SendTransactionRequest) GetDisableAlternativeRpc() bool {
+	if x != nil {
+		return x.DisableAlternativeRpc
+	}
+	return false
+}
+
+type SendTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTransactionResponse) Reset() {
+	*x = SendTransactionResponse{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTransactionResponse) ProtoMessage() {}
+
+func (x *SendTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTransactionResponse.ProtoReflect.Descriptor instead.
+func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SendTransactionResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+// Subscribed is the first message of each stream. If the notification journal is enabled, sequence is the position
+// of the stream in the journal and the notifications missed since the requested position follow it.
+type Subscribed struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sequence uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Replayed int32                  `protobuf:"varint,2,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	// resync is set if the missed notifications are no longer available, the client must reload its state
+	Resync        bool `protobuf:"varint,3,opt,name=resync,proto3" json:"resync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subscribed) Reset() {
+	*x = Subscribed{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscribed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscribed) ProtoMessage() {}
+
+func (x *Subscribed) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscribed.ProtoReflect.Descriptor instead.
+func (*Subscribed) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Subscribed) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Subscribed) GetReplayed() int32 {
+	if x != nil {
+		return x.Replayed
+	}
+	return 0
+}
+
+func (x *Subscribed) GetResync() bool {
+	if x != nil {
+		return x.Resync
+	}
+	return false
+}
+
+type SubscribeNewBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SinceSequence uint64                 `protobuf:"varint,1,opt,name=since_sequence,json=sinceSequence,proto3" json:"since_sequence,omitempty"`
+	SinceHeight   uint32                 `protobuf:"varint,2,opt,name=since_height,json=sinceHeight,proto3" json:"since_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeNewBlockRequest) Reset() {
+	*x = SubscribeNewBlockRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeNewBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeNewBlockRequest) ProtoMessage() {}
+
+func (x *SubscribeNewBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeNewBlockRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeNewBlockRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SubscribeNewBlockRequest) GetSinceSequence() uint64 {
+	if x != nil {
+		return x.SinceSequence
+	}
+	return 0
+}
+
+func (x *SubscribeNewBlockRequest) GetSinceHeight() uint32 {
+	if x != nil {
+		return x.SinceHeight
+	}
+	return 0
+}
+
+type DisconnectedBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint32                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Txids         []string               `protobuf:"bytes,3,rep,name=txids,proto3" json:"txids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectedBlock) Reset() {
+	*x = DisconnectedBlock{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectedBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectedBlock) ProtoMessage() {}
+
+func (x *DisconnectedBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectedBlock.ProtoReflect.Descriptor instead.
+func (*DisconnectedBlock) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DisconnectedBlock) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DisconnectedBlock) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *DisconnectedBlock) GetTxids() []string {
+	if x != nil {
+		return x.Txids
+	}
+	return nil
+}
+
+type BlockDisconnected struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// blocks are ordered from the highest
+	Blocks        []*DisconnectedBlock `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockDisconnected) Reset() {
+	*x = BlockDisconnected{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockDisconnected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockDisconnected) ProtoMessage() {}
+
+func (x *BlockDisconnected) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockDisconnected.ProtoReflect.Descriptor instead.
+func (*BlockDisconnected) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BlockDisconnected) GetBlocks() []*DisconnectedBlock {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type NewBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint32                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewBlock) Reset() {
+	*x = NewBlock{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewBlock) ProtoMessage() {}
+
+func (x *NewBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewBlock.ProtoReflect.Descriptor instead.
+func (*NewBlock) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *NewBlock) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *NewBlock) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type NewBlockNotification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// seq is the sequence number of the notification in the journal, 0 if the journal is disabled
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Types that are valid to be assigned to Notification:
+	//
+	//	*NewBlockNotification_Subscribed
+	//	*NewBlockNotification_NewBlock
+	//	*NewBlockNotification_BlockDisconnected
+	Notification  isNewBlockNotification_Notification `protobuf_oneof:"notification"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewBlockNotification) Reset() {
+	*x = NewBlockNotification{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewBlockNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewBlockNotification) ProtoMessage() {}
+
+func (x *NewBlockNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewBlockNotification.ProtoReflect.Descriptor instead.
+func (*NewBlockNotification) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *NewBlockNotification) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *NewBlockNotification) GetNotification() isNewBlockNotification_Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *NewBlockNotification) GetSubscribed() *Subscribed {
+	if x != nil {
+		if x, ok := x.Notification.(*NewBlockNotification_Subscribed); ok {
+			return x.Subscribed
+		}
+	}
+	return nil
+}
+
+func (x *NewBlockNotification) GetNewBlock() *NewBlock {
+	if x != nil {
+		if x, ok := x.Notification.(*NewBlockNotification_NewBlock); ok {
+			return x.NewBlock
+		}
+	}
+	return nil
+}
+
+func (x *NewBlockNotification) GetBlockDisconnected() *BlockDisconnected {
+	if x != nil {
+		if x, ok := x.Notification.(*NewBlockNotification_BlockDisconnected); ok {
+			return x.BlockDisconnected
+		}
+	}
+	return nil
+}
+
+type isNewBlockNotification_Notification interface {
+	isNewBlockNotification_Notification()
+}
+
+type NewBlockNotification_Subscribed struct {
+	Subscribed *Subscribed `protobuf:"bytes,2,opt,name=subscribed,proto3,oneof"`
+}
+
+type NewBlockNotification_NewBlock struct {
+	NewBlock *NewBlock `protobuf:"bytes,3,opt,name=new_block,json=newBlock,proto3,oneof"`
+}
+
+type NewBlockNotification_BlockDisconnected struct {
+	BlockDisconnected *BlockDisconnected `protobuf:"bytes,4,opt,name=block_disconnected,json=blockDisconnected,proto3,oneof"`
+}
+
+func (*NewBlockNotification_Subscribed) isNewBlockNotification_Notification() {}
+
+func (*NewBlockNotification_NewBlock) isNewBlockNotification_Notification() {}
+
+func (*NewBlockNotification_BlockDisconnected) isNewBlockNotification_Notification() {}
+
+type SubscribeNewTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeNewTransactionRequest) Reset() {
+	*x = SubscribeNewTransactionRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeNewTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeNewTransactionRequest) ProtoMessage() {}
+
+func (x *SubscribeNewTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeNewTransactionRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeNewTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{35}
+}
+
+type NewTransactionNotification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Notification:
+	//
+	//	*NewTransactionNotification_Subscribed
+	//	*NewTransactionNotification_Tx
+	Notification  isNewTransactionNotification_Notification `protobuf_oneof:"notification"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewTransactionNotification) Reset() {
+	*x = NewTransactionNotification{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewTransactionNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewTransactionNotification) ProtoMessage() {}
+
+func (x *NewTransactionNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewTransactionNotification.ProtoReflect.Descriptor instead.
+func (*NewTransactionNotification) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *NewTransactionNotification) GetNotification() isNewTransactionNotification_Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *NewTransactionNotification) GetSubscribed() *Subscribed {
+	if x != nil {
+		if x, ok := x.Notification.(*NewTransactionNotification_Subscribed); ok {
+			return x.Subscribed
+		}
+	}
+	return nil
+}
+
+func (x *NewTransactionNotification) GetTx() *Tx {
+	if x != nil {
+		if x, ok := x.Notification.(*NewTransactionNotification_Tx); ok {
+			return x.Tx
+		}
+	}
+	return nil
+}
+
+type isNewTransactionNotification_Notification interface {
+	isNewTransactionNotification_Notification()
+}
+
+type NewTransactionNotification_Subscribed struct {
+	Subscribed *Subscribed `protobuf:"bytes,1,opt,name=subscribed,proto3,oneof"`
+}
+
+type NewTransactionNotification_Tx struct {
+	Tx *Tx `protobuf:"bytes,2,opt,name=tx,proto3,oneof"`
+}
+
+func (*NewTransactionNotification_Subscribed) isNewTransactionNotification_Notification() {}
+
+func (*NewTransactionNotification_Tx) isNewTransactionNotification_Notification() {}
+
+type SubscribeAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	NewBlockTxs   bool                   `protobuf:"varint,2,opt,name=new_block_txs,json=newBlockTxs,proto3" json:"new_block_txs,omitempty"`
+	SinceSequence uint64                 `protobuf:"varint,3,opt,name=since_sequence,json=sinceSequence,proto3" json:"since_sequence,omitempty"`
+	SinceHeight   uint32                 `protobuf:"varint,4,opt,name=since_height,json=sinceHeight,proto3" json:"since_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeAddressesRequest) Reset() {
+	*x = SubscribeAddressesRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeAddressesRequest) ProtoMessage() {}
+
+func (x *SubscribeAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeAddressesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubscribeAddressesRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *SubscribeAddressesRequest) GetNewBlockTxs() bool {
+	if x != nil {
+		return x.NewBlockTxs
+	}
+	return false
+}
+
+func (x *SubscribeAddressesRequest) GetSinceSequence() uint64 {
+	if x != nil {
+		return x.SinceSequence
+	}
+	return 0
+}
+
+func (x *SubscribeAddressesRequest) GetSinceHeight() uint32 {
+	if x != nil {
+		return x.SinceHeight
+	}
+	return 0
+}
+
+type TxReplaced struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxReplaced) Reset() {
+	*x = TxReplaced{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxReplaced) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxReplaced) ProtoMessage() {}
+
+func (x *TxReplaced) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxReplaced.ProtoReflect.Descriptor instead.
+func (*TxReplaced) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *TxReplaced) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *TxReplaced) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type DoubleSpend struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Txid            string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	ConflictingTxid string                 `protobuf:"bytes,2,opt,name=conflicting_txid,json=conflictingTxid,proto3" json:"conflicting_txid,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DoubleSpend) Reset() {
+	*x = DoubleSpend{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoubleSpend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoubleSpend) ProtoMessage() {}
+
+func (x *DoubleSpend) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoubleSpend.ProtoReflect.Descriptor instead.
+func (*DoubleSpend) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DoubleSpend) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *DoubleSpend) GetConflictingTxid() string {
+	if x != nil {
+		return x.ConflictingTxid
+	}
+	return ""
+}
+
+type TxDropped struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Txid            string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	ConflictingTxid string                 `protobuf:"bytes,2,opt,name=conflicting_txid,json=conflictingTxid,proto3" json:"conflicting_txid,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TxDropped) Reset() {
+	*x = TxDropped{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxDropped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxDropped) ProtoMessage() {}
+
+func (x *TxDropped) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxDropped.ProtoReflect.Descriptor instead.
+func (*TxDropped) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *TxDropped) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *TxDropped) GetConflictingTxid() string {
+	if x != nil {
+		return x.ConflictingTxid
+	}
+	return ""
+}
+
+type TxUnconfirmed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Height        uint32                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Hash          string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxUnconfirmed) Reset() {
+	*x = TxUnconfirmed{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxUnconfirmed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxUnconfirmed) ProtoMessage() {}
+
+func (x *TxUnconfirmed) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxUnconfirmed.ProtoReflect.Descriptor instead.
+func (*TxUnconfirmed) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TxUnconfirmed) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *TxUnconfirmed) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *TxUnconfirmed) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type Withdrawal struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Index          uint64                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	ValidatorIndex uint64                 `protobuf:"varint,2,opt,name=validator_index,json=validatorIndex,proto3" json:"validator_index,omitempty"`
+	Address        string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Amount         string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	BlockHeight    uint32                 `protobuf:"varint,5,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockTime      int64                  `protobuf:"varint,6,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	Confirmations  uint32                 `protobuf:"varint,7,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Withdrawal) Reset() {
+	*x = Withdrawal{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Withdrawal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Withdrawal) ProtoMessage() {}
+
+func (x *Withdrawal) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Withdrawal.ProtoReflect.Descriptor instead.
+func (*Withdrawal) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Withdrawal) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetValidatorIndex() uint64 {
+	if x != nil {
+		return x.ValidatorIndex
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetBlockHeight() uint32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetBlockTime() int64 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type AddressNotification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// seq is the sequence number of the notification in the journal, 0 if the journal is disabled
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// address is the subscribed address the notification belongs to
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Types that are valid to be assigned to Notification:
+	//
+	//	*AddressNotification_Subscribed
+	//	*AddressNotification_Tx
+	//	*AddressNotification_TxReplaced
+	//	*AddressNotification_DoubleSpend
+	//	*AddressNotification_TxDropped
+	//	*AddressNotification_TxUnconfirmed
+	//	*AddressNotification_Withdrawal
+	Notification  isAddressNotification_Notification `protobuf_oneof:"notification"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressNotification) Reset() {
+	*x = AddressNotification{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressNotification) ProtoMessage() {}
+
+func (x *AddressNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressNotification.ProtoReflect.Descriptor instead.
+func (*AddressNotification) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AddressNotification) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AddressNotification) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AddressNotification) GetNotification() isAddressNotification_Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *AddressNotification) GetSubscribed() *Subscribed {
+	if x != nil {
+		if x, ok := x.Notification.(*AddressNotification_Subscribed); ok {
+			return x.Subscribed
+		}
+	}
+	return nil
+}
+
+func (x *AddressNotification) GetTx() *Tx {
+	if x != nil {
+		if x, ok := x.Notification.(*AddressNotification_Tx); ok {
+			return x.Tx
+		}
+	}
+	return nil
+}
+
+func (x *AddressNotification) GetTxReplaced() *TxReplaced {
+	if x != nil {
+		if x, ok := x.Notification.(*AddressNotification_TxReplaced); ok {
+			return x.TxReplaced
+		}
+	}
+	return nil
+}
+
+func (x *AddressNotification) GetDoubleSpend() *DoubleSpend {
+	if x != nil {
+		if x, ok := x.Notification.(*AddressNotification_DoubleSpend); ok {
+			return x.DoubleSpend
+		}
+	}
+	return nil
+}
+
+func (x *AddressNotification) GetTxDropped() *TxDropped {
+	if x != nil {
+		if x, ok := x.Notification.(*AddressNotification_TxDropped); ok {
+			return x.TxDropped
+		}
+	}
+	return nil
+}
+
+func (x *AddressNotification) GetTxUnconfirmed() *TxUnconfirmed {
+	if x != nil {
+		if x, ok := x.Notification.(*AddressNotification_TxUnconfirmed); ok {
+			return x.TxUnconfirmed
+		}
+	}
+	return nil
+}
+
+func (x *AddressNotification) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		if x, ok := x.Notification.(*AddressNotification_Withdrawal); ok {
+			return x.Withdrawal
+		}
+	}
+	return nil
+}
+
+type isAddressNotification_Notification interface {
+	isAddressNotification_Notification()
+}
+
+type AddressNotification_Subscribed struct {
+	Subscribed *Subscribed `protobuf:"bytes,3,opt,name=subscribed,proto3,oneof"`
+}
+
+type AddressNotification_Tx struct {
+	Tx *Tx `protobuf:"bytes,4,opt,name=tx,proto3,oneof"`
+}
+
+type AddressNotification_TxReplaced struct {
+	TxReplaced *TxReplaced `protobuf:"bytes,5,opt,name=tx_replaced,json=txReplaced,proto3,oneof"`
+}
+
+type AddressNotification_DoubleSpend struct {
+	DoubleSpend *DoubleSpend `protobuf:"bytes,6,opt,name=double_spend,json=doubleSpend,proto3,oneof"`
+}
+
+type AddressNotification_TxDropped struct {
+	TxDropped *TxDropped `protobuf:"bytes,7,opt,name=tx_dropped,json=txDropped,proto3,oneof"`
+}
+
+type AddressNotification_TxUnconfirmed struct {
+	TxUnconfirmed *TxUnconfirmed `protobuf:"bytes,8,opt,name=tx_unconfirmed,json=txUnconfirmed,proto3,oneof"`
+}
+
+type AddressNotification_Withdrawal struct {
+	Withdrawal *Withdrawal `protobuf:"bytes,9,opt,name=withdrawal,proto3,oneof"`
+}
+
+func (*AddressNotification_Subscribed) isAddressNotification_Notification() {}
+
+func (*AddressNotification_Tx) isAddressNotification_Notification() {}
+
+func (*AddressNotification_TxReplaced) isAddressNotification_Notification() {}
+
+func (*AddressNotification_DoubleSpend) isAddressNotification_Notification() {}
+
+func (*AddressNotification_TxDropped) isAddressNotification_Notification() {}
+
+func (*AddressNotification_TxUnconfirmed) isAddressNotification_Notification() {}
+
+func (*AddressNotification_Withdrawal) isAddressNotification_Notification() {}
+
+type SubscribeFiatRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Tokens        []string               `protobuf:"bytes,2,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeFiatRatesRequest) Reset() {
+	*x = SubscribeFiatRatesRequest{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeFiatRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeFiatRatesRequest) ProtoMessage() {}
+
+func (x *SubscribeFiatRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeFiatRatesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeFiatRatesRequest) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SubscribeFiatRatesRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SubscribeFiatRatesRequest) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type FiatRates struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rates map[string]float32     `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
+	// token_rates are the rates of the subscribed tokens in the subscribed currency
+	TokenRates    map[string]float32 `protobuf:"bytes,2,rep,name=token_rates,json=tokenRates,proto3" json:"token_rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FiatRates) Reset() {
+	*x = FiatRates{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FiatRates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FiatRates) ProtoMessage() {}
+
+func (x *FiatRates) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FiatRates.ProtoReflect.Descriptor instead.
+func (*FiatRates) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *FiatRates) GetRates() map[string]float32 {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *FiatRates) GetTokenRates() map[string]float32 {
+	if x != nil {
+		return x.TokenRates
+	}
+	return nil
+}
+
+type FiatRatesNotification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Notification:
+	//
+	//	*FiatRatesNotification_Subscribed
+	//	*FiatRatesNotification_Rates
+	Notification  isFiatRatesNotification_Notification `protobuf_oneof:"notification"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FiatRatesNotification) Reset() {
+	*x = FiatRatesNotification{}
+	mi := &file_grpcapi_blockbook_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FiatRatesNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FiatRatesNotification) ProtoMessage() {}
+
+func (x *FiatRatesNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcapi_blockbook_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FiatRatesNotification.ProtoReflect.Descriptor instead.
+func (*FiatRatesNotification) Descriptor() ([]byte, []int) {
+	return file_grpcapi_blockbook_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *FiatRatesNotification) GetNotification() isFiatRatesNotification_Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *FiatRatesNotification) GetSubscribed() *Subscribed {
+	if x != nil {
+		if x, ok := x.Notification.(*FiatRatesNotification_Subscribed); ok {
+			return x.Subscribed
+		}
+	}
+	return nil
+}
+
+func (x *FiatRatesNotification) GetRates() *FiatRates {
+	if x != nil {
+		if x, ok := x.Notification.(*FiatRatesNotification_Rates); ok {
+			return x.Rates
+		}
+	}
+	return nil
+}
+
+type isFiatRatesNotification_Notification interface {
+	isFiatRatesNotification_Notification()
+}
+
+type FiatRatesNotification_Subscribed struct {
+	Subscribed *Subscribed `protobuf:"bytes,1,opt,name=subscribed,proto3,oneof"`
+}
+
+type FiatRatesNotification_Rates struct {
+	Rates *FiatRates `protobuf:"bytes,2,opt,name=rates,proto3,oneof"`
+}
+
+func (*FiatRatesNotification_Subscribed) isFiatRatesNotification_Notification() {}
+
+func (*FiatRatesNotification_Rates) isFiatRatesNotification_Notification() {}
+
+var File_grpcapi_blockbook_proto protoreflect.FileDescriptor
+
+const file_grpcapi_blockbook_proto_rawDesc = "" +
+	"\n" +
+	"\x17grpcapi/blockbook.proto\x12\tblockbook\x1a\x1cgoogle/protobuf/struct.proto\"\x10\n" +
+	"\x0eGetInfoRequest\"t\n" +
+	"\vBackendInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1e\n" +
+	"\n" +
+	"subversion\x18\x02 \x01(\tR\n" +
+	"subversion\x12+\n" +
+	"\x11consensus_version\x18\x03 \x01(\tR\x10consensusVersion\"\xb1\x02\n" +
+	"\x04Info\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bshortcut\x18\x02 \x01(\tR\bshortcut\x12\x18\n" +
+	"\anetwork\x18\x03 \x01(\tR\anetwork\x12\x1a\n" +
+	"\bdecimals\x18\x04 \x01(\x05R\bdecimals\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12\x1f\n" +
+	"\vbest_height\x18\x06 \x01(\x03R\n" +
+	"bestHeight\x12\x1b\n" +
+	"\tbest_hash\x18\a \x01(\tR\bbestHash\x12\x1f\n" +
+	"\vblock0_hash\x18\b \x01(\tR\n" +
+	"block0Hash\x12\x18\n" +
+	"\atestnet\x18\t \x01(\bR\atestnet\x120\n" +
+	"\abackend\x18\n" +
+	" \x01(\v2\x16.blockbook.BackendInfoR\abackend\"\xf8\x02\n" +
+	"\x15GetAccountInfoRequest\x12\x1e\n" +
+	"\n" +
+	"descriptor\x18\x01 \x01(\tR\n" +
+	"descriptor\x12\x18\n" +
+	"\adetails\x18\x02 \x01(\tR\adetails\x12\x16\n" +
+	"\x06tokens\x18\x03 \x01(\tR\x06tokens\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x1f\n" +
+	"\vfrom_height\x18\a \x01(\x05R\n" +
+	"fromHeight\x12\x1b\n" +
+	"\tto_height\x18\b \x01(\x05R\btoHeight\x12'\n" +
+	"\x0fcontract_filter\x18\t \x01(\tR\x0econtractFilter\x12-\n" +
+	"\x12secondary_currency\x18\n" +
+	" \x01(\tR\x11secondaryCurrency\x12\x10\n" +
+	"\x03gap\x18\v \x01(\x05R\x03gap\x12\x1c\n" +
+	"\tprotocols\x18\f \x03(\tR\tprotocols\"\xbd\x03\n" +
+	"\x05Token\x12\x1a\n" +
+	"\bstandard\x18\x01 \x01(\tR\bstandard\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x1a\n" +
+	"\bcontract\x18\x04 \x01(\tR\bcontract\x12\x1c\n" +
+	"\ttransfers\x18\x05 \x01(\x05R\ttransfers\x12\x16\n" +
+	"\x06symbol\x18\x06 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\a \x01(\x05R\bdecimals\x12\x18\n" +
+	"\abalance\x18\b \x01(\tR\abalance\x12\x1d\n" +
+	"\n" +
+	"base_value\x18\t \x01(\x01R\tbaseValue\x12'\n" +
+	"\x0fsecondary_value\x18\n" +
+	" \x01(\x01R\x0esecondaryValue\x12\x10\n" +
+	"\x03ids\x18\v \x03(\tR\x03ids\x12H\n" +
+	"\x12multi_token_values\x18\f \x03(\v2\x1a.blockbook.MultiTokenValueR\x10multiTokenValues\x12%\n" +
+	"\x0etotal_received\x18\r \x01(\tR\rtotalReceived\x12\x1d\n" +
+	"\n" +
+	"total_sent\x18\x0e \x01(\tR\ttotalSent\"\xe4\x06\n" +
+	"\aAddress\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1f\n" +
+	"\vtotal_pages\x18\x02 \x01(\x05R\n" +
+	"totalPages\x12\"\n" +
+	"\ritems_on_page\x18\x03 \x01(\x05R\vitemsOnPage\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x18\n" +
+	"\abalance\x18\x05 \x01(\tR\abalance\x12%\n" +
+	"\x0etotal_received\x18\x06 \x01(\tR\rtotalReceived\x12\x1d\n" +
+	"\n" +
+	"total_sent\x18\a \x01(\tR\ttotalSent\x12/\n" +
+	"\x13unconfirmed_balance\x18\b \x01(\tR\x12unconfirmedBalance\x12'\n" +
+	"\x0funconfirmed_txs\x18\t \x01(\x05R\x0eunconfirmedTxs\x12\x10\n" +
+	"\x03txs\x18\n" +
+	" \x01(\x05R\x03txs\x12\"\n" +
+	"\rnon_token_txs\x18\v \x01(\x05R\vnonTokenTxs\x12!\n" +
+	"\finternal_txs\x18\f \x01(\x05R\vinternalTxs\x121\n" +
+	"\ftransactions\x18\r \x03(\v2\r.blockbook.TxR\ftransactions\x12\x14\n" +
+	"\x05txids\x18\x0e \x03(\tR\x05txids\x12\x1f\n" +
+	"\vnext_cursor\x18\x0f \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x10 \x01(\tR\n" +
+	"prevCursor\x12\x14\n" +
+	"\x05nonce\x18\x11 \x01(\tR\x05nonce\x12\x1f\n" +
+	"\vused_tokens\x18\x12 \x01(\x05R\n" +
+	"usedTokens\x12(\n" +
+	"\x06tokens\x18\x13 \x03(\v2\x10.blockbook.TokenR\x06tokens\x12'\n" +
+	"\x0fsecondary_value\x18\x14 \x01(\x01R\x0esecondaryValue\x12*\n" +
+	"\x11tokens_base_value\x18\x15 \x01(\x01R\x0ftokensBaseValue\x124\n" +
+	"\x16tokens_secondary_value\x18\x16 \x01(\x01R\x14tokensSecondaryValue\x12(\n" +
+	"\x10total_base_value\x18\x17 \x01(\x01R\x0etotalBaseValue\x122\n" +
+	"\x15total_secondary_value\x18\x18 \x01(\x01R\x13totalSecondaryValue\"\x81\x02\n" +
+	"\x03Vin\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x12\n" +
+	"\x04vout\x18\x02 \x01(\rR\x04vout\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x03R\bsequence\x12\f\n" +
+	"\x01n\x18\x04 \x01(\x05R\x01n\x12\x1c\n" +
+	"\taddresses\x18\x05 \x03(\tR\taddresses\x12\x1d\n" +
+	"\n" +
+	"is_address\x18\x06 \x01(\bR\tisAddress\x12\x15\n" +
+	"\x06is_own\x18\a \x01(\bR\x05isOwn\x12\x14\n" +
+	"\x05value\x18\b \x01(\tR\x05value\x12\x10\n" +
+	"\x03hex\x18\t \x01(\tR\x03hex\x12\x10\n" +
+	"\x03asm\x18\n" +
+	" \x01(\tR\x03asm\x12\x1a\n" +
+	"\bcoinbase\x18\v \x01(\tR\bcoinbase\"\xb0\x02\n" +
+	"\x04Vout\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\f\n" +
+	"\x01n\x18\x02 \x01(\x05R\x01n\x12\x14\n" +
+	"\x05spent\x18\x03 \x01(\bR\x05spent\x12\x1e\n" +
+	"\vspent_tx_id\x18\x04 \x01(\tR\tspentTxId\x12\x1f\n" +
+	"\vspent_index\x18\x05 \x01(\x05R\n" +
+	"spentIndex\x12!\n" +
+	"\fspent_height\x18\x06 \x01(\x05R\vspentHeight\x12\x10\n" +
+	"\x03hex\x18\a \x01(\tR\x03hex\x12\x10\n" +
+	"\x03asm\x18\b \x01(\tR\x03asm\x12\x1c\n" +
+	"\taddresses\x18\t \x03(\tR\taddresses\x12\x1d\n" +
+	"\n" +
+	"is_address\x18\n" +
+	" \x01(\bR\tisAddress\x12\x15\n" +
+	"\x06is_own\x18\v \x01(\bR\x05isOwn\x12\x12\n" +
+	"\x04type\x18\f \x01(\tR\x04type\"7\n" +
+	"\x0fMultiTokenValue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x93\x02\n" +
+	"\rTokenTransfer\x12\x1a\n" +
+	"\bstandard\x18\x01 \x01(\tR\bstandard\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1a\n" +
+	"\bcontract\x18\x04 \x01(\tR\bcontract\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x06 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\a \x01(\x05R\bdecimals\x12\x14\n" +
+	"\x05value\x18\b \x01(\tR\x05value\x12H\n" +
+	"\x12multi_token_values\x18\t \x03(\v2\x1a.blockbook.MultiTokenValueR\x10multiTokenValues\"\xa2\x03\n" +
+	"\x10EthereumSpecific\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\x04R\x05nonce\x12\x1b\n" +
+	"\tgas_limit\x18\x04 \x01(\tR\bgasLimit\x12\x19\n" +
+	"\bgas_used\x18\x05 \x01(\tR\agasUsed\x12\x1b\n" +
+	"\tgas_price\x18\x06 \x01(\tR\bgasPrice\x12.\n" +
+	"\x13effective_gas_price\x18\a \x01(\tR\x11effectiveGasPrice\x126\n" +
+	"\x18max_priority_fee_per_gas\x18\b \x01(\tR\x14maxPriorityFeePerGas\x12%\n" +
+	"\x0fmax_fee_per_gas\x18\t \x01(\tR\fmaxFeePerGas\x12'\n" +
+	"\x10base_fee_per_gas\x18\n" +
+	" \x01(\tR\rbaseFeePerGas\x12)\n" +
+	"\x10created_contract\x18\v \x01(\tR\x0fcreatedContract\x12\x12\n" +
+	"\x04data\x18\f \x01(\tR\x04data\"\x9a\x06\n" +
+	"\x02Tx\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x1b\n" +
+	"\tlock_time\x18\x03 \x01(\rR\blockTime\x12 \n" +
+	"\x03vin\x18\x04 \x03(\v2\x0e.blockbook.VinR\x03vin\x12#\n" +
+	"\x04vout\x18\x05 \x03(\v2\x0f.blockbook.VoutR\x04vout\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x06 \x01(\tR\tblockHash\x12!\n" +
+	"\fblock_height\x18\a \x01(\x05R\vblockHeight\x12$\n" +
+	"\rconfirmations\x18\b \x01(\rR\rconfirmations\x126\n" +
+	"\x17confirmation_eta_blocks\x18\t \x01(\rR\x15confirmationEtaBlocks\x128\n" +
+	"\x18confirmation_eta_seconds\x18\n" +
+	" \x01(\x03R\x16confirmationEtaSeconds\x12\x1d\n" +
+	"\n" +
+	"block_time\x18\v \x01(\x03R\tblockTime\x12\x12\n" +
+	"\x04size\x18\f \x01(\x05R\x04size\x12\x14\n" +
+	"\x05vsize\x18\r \x01(\x05R\x05vsize\x12\x14\n" +
+	"\x05value\x18\x0e \x01(\tR\x05value\x12\x19\n" +
+	"\bvalue_in\x18\x0f \x01(\tR\avalueIn\x12\x12\n" +
+	"\x04fees\x18\x10 \x01(\tR\x04fees\x12\x10\n" +
+	"\x03hex\x18\x11 \x01(\tR\x03hex\x12\x10\n" +
+	"\x03rbf\x18\x12 \x01(\bR\x03rbf\x12A\n" +
+	"\x0ftoken_transfers\x18\x13 \x03(\v2\x18.blockbook.TokenTransferR\x0etokenTransfers\x12H\n" +
+	"\x11ethereum_specific\x18\x14 \x01(\v2\x1b.blockbook.EthereumSpecificR\x10ethereumSpecific\x12\x1f\n" +
+	"\vreplaced_by\x18\x15 \x01(\tR\n" +
+	"replacedBy\x12\x1a\n" +
+	"\breplaces\x18\x16 \x03(\tR\breplaces\x12,\n" +
+	"\x12coin_specific_data\x18\x17 \x01(\fR\x10coinSpecificData\"7\n" +
+	"\x15GetAccountUtxoRequest\x12\x1e\n" +
+	"\n" +
+	"descriptor\x18\x01 \x01(\tR\n" +
+	"descriptor\"\xe9\x01\n" +
+	"\x04Utxo\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x12\n" +
+	"\x04vout\x18\x02 \x01(\x05R\x04vout\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12$\n" +
+	"\rconfirmations\x18\x05 \x01(\x05R\rconfirmations\x12\x18\n" +
+	"\aaddress\x18\x06 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04path\x18\a \x01(\tR\x04path\x12\x1b\n" +
+	"\tlock_time\x18\b \x01(\rR\blockTime\x12\x1a\n" +
+	"\bcoinbase\x18\t \x01(\bR\bcoinbase\".\n" +
+	"\x05Utxos\x12%\n" +
+	"\x05utxos\x18\x01 \x03(\v2\x0f.blockbook.UtxoR\x05utxos\"+\n" +
+	"\x15GetTransactionRequest\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\"R\n" +
+	"\x0fGetBlockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x89\x04\n" +
+	"\x05Block\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1f\n" +
+	"\vtotal_pages\x18\x02 \x01(\x05R\n" +
+	"totalPages\x12\"\n" +
+	"\ritems_on_page\x18\x03 \x01(\x05R\vitemsOnPage\x12\x12\n" +
+	"\x04hash\x18\x04 \x01(\tR\x04hash\x12.\n" +
+	"\x13previous_block_hash\x18\x05 \x01(\tR\x11previousBlockHash\x12&\n" +
+	"\x0fnext_block_hash\x18\x06 \x01(\tR\rnextBlockHash\x12\x16\n" +
+	"\x06height\x18\a \x01(\rR\x06height\x12$\n" +
+	"\rconfirmations\x18\b \x01(\x05R\rconfirmations\x12\x12\n" +
+	"\x04size\x18\t \x01(\x05R\x04size\x12\x12\n" +
+	"\x04time\x18\n" +
+	" \x01(\x03R\x04time\x12\x18\n" +
+	"\aversion\x18\v \x01(\tR\aversion\x12\x1f\n" +
+	"\vmerkle_root\x18\f \x01(\tR\n" +
+	"merkleRoot\x12\x14\n" +
+	"\x05nonce\x18\r \x01(\tR\x05nonce\x12\x12\n" +
+	"\x04bits\x18\x0e \x01(\tR\x04bits\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x0f \x01(\tR\n" +
+	"difficulty\x12\x14\n" +
+	"\x05txids\x18\x10 \x03(\tR\x05txids\x12\x19\n" +
+	"\btx_count\x18\x11 \x01(\x05R\atxCount\x12\x1f\n" +
+	"\x03txs\x18\x12 \x03(\v2\r.blockbook.TxR\x03txs\"\xab\x01\n" +
+	"\x18GetBalanceHistoryRequest\x12\x1e\n" +
+	"\n" +
+	"descriptor\x18\x01 \x01(\tR\n" +
+	"descriptor\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x04 \x03(\tR\n" +
+	"currencies\x12\x10\n" +
+	"\x03gap\x18\x05 \x01(\x05R\x03gap\x12\x19\n" +
+	"\bgroup_by\x18\x06 \x01(\rR\agroupBy\"\xb4\x02\n" +
+	"\x0eBalanceHistory\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\rR\x04time\x12\x10\n" +
+	"\x03txs\x18\x02 \x01(\rR\x03txs\x12\x1a\n" +
+	"\breceived\x18\x03 \x01(\tR\breceived\x12\x12\n" +
+	"\x04sent\x18\x04 \x01(\tR\x04sent\x12 \n" +
+	"\fsent_to_self\x18\x05 \x01(\tR\n" +
+	"sentToSelf\x12:\n" +
+	"\x05rates\x18\x06 \x03(\v2$.blockbook.BalanceHistory.RatesEntryR\x05rates\x12\x12\n" +
+	"\x04txid\x18\a \x01(\tR\x04txid\x12 \n" +
+	"\vwithdrawals\x18\b \x01(\rR\vwithdrawals\x1a8\n" +
+	"\n" +
+	"RatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"K\n" +
+	"\x10BalanceHistories\x127\n" +
+	"\thistories\x18\x01 \x03(\v2\x19.blockbook.BalanceHistoryR\thistories\"a\n" +
+	"\x12EstimateFeeRequest\x12\x16\n" +
+	"\x06blocks\x18\x01 \x03(\x05R\x06blocks\x123\n" +
+	"\bspecific\x18\x02 \x01(\v2\x17.google.protobuf.StructR\bspecific\"\xd5\x01\n" +
+	"\n" +
+	"Eip1559Fee\x12%\n" +
+	"\x0fmax_fee_per_gas\x18\x01 \x01(\tR\fmaxFeePerGas\x126\n" +
+	"\x18max_priority_fee_per_gas\x18\x02 \x01(\tR\x14maxPriorityFeePerGas\x123\n" +
+	"\x16min_wait_time_estimate\x18\x03 \x01(\x05R\x13minWaitTimeEstimate\x123\n" +
+	"\x16max_wait_time_estimate\x18\x04 \x01(\x05R\x13maxWaitTimeEstimate\"\x99\x02\n" +
+	"\vEip1559Fees\x12'\n" +
+	"\x10base_fee_per_gas\x18\x01 \x01(\tR\rbaseFeePerGas\x12'\n" +
+	"\x03low\x18\x02 \x01(\v2\x15.blockbook.Eip1559FeeR\x03low\x12-\n" +
+	"\x06medium\x18\x03 \x01(\v2\x15.blockbook.Eip1559FeeR\x06medium\x12)\n" +
+	"\x04high\x18\x04 \x01(\v2\x15.blockbook.Eip1559FeeR\x04high\x12/\n" +
+	"\ainstant\x18\x05 \x01(\v2\x15.blockbook.Eip1559FeeR\ainstant\x12-\n" +
+	"\x12network_congestion\x18\x06 \x01(\x01R\x11networkCongestion\"e\n" +
+	"\rFeeConfidence\x12\x16\n" +
+	"\x06blocks\x18\x01 \x01(\x05R\x06blocks\x12\x10\n" +
+	"\x03low\x18\x02 \x01(\tR\x03low\x12\x16\n" +
+	"\x06medium\x18\x03 \x01(\tR\x06medium\x12\x12\n" +
+	"\x04high\x18\x04 \x01(\tR\x04high\"\xed\x01\n" +
+	"\vFeeEstimate\x12\x1c\n" +
+	"\n" +
+	"fee_per_tx\x18\x01 \x01(\tR\bfeePerTx\x12 \n" +
+	"\ffee_per_unit\x18\x02 \x01(\tR\n" +
+	"feePerUnit\x12\x1b\n" +
+	"\tfee_limit\x18\x03 \x01(\tR\bfeeLimit\x12\x15\n" +
+	"\x06l1_fee\x18\x04 \x01(\tR\x05l1Fee\x120\n" +
+	"\aeip1559\x18\x05 \x01(\v2\x16.blockbook.Eip1559FeesR\aeip1559\x128\n" +
+	"\n" +
+	"confidence\x18\x06 \x01(\v2\x18.blockbook.FeeConfidenceR\n" +
+	"confidence\"K\n" +
+	"\x13EstimateFeeResponse\x124\n" +
+	"\testimates\x18\x01 \x03(\v2\x16.blockbook.FeeEstimateR\testimates\"b\n" +
+	"\x16SendTransactionRequest\x12\x10\n" +
+	"\x03hex\x18\x01 \x01(\tR\x03hex\x126\n" +
+	"\x17disable_alternative_rpc\x18\x02 \x01(\bR\x15disableAlternativeRpc\"-\n" +
+	"\x17SendTransactionResponse\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\"\\\n" +
+	"\n" +
+	"Subscribed\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x1a\n" +
+	"\breplayed\x18\x02 \x01(\x05R\breplayed\x12\x16\n" +
+	"\x06resync\x18\x03 \x01(\bR\x06resync\"d\n" +
+	"\x18SubscribeNewBlockRequest\x12%\n" +
+	"\x0esince_sequence\x18\x01 \x01(\x04R\rsinceSequence\x12!\n" +
+	"\fsince_height\x18\x02 \x01(\rR\vsinceHeight\"U\n" +
+	"\x11DisconnectedBlock\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\rR\x06height\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x14\n" +
+	"\x05txids\x18\x03 \x03(\tR\x05txids\"I\n" +
+	"\x11BlockDisconnected\x124\n" +
+	"\x06blocks\x18\x01 \x03(\v2\x1c.blockbook.DisconnectedBlockR\x06blocks\"6\n" +
+	"\bNewBlock\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\rR\x06height\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\"\xf4\x01\n" +
+	"\x14NewBlockNotification\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x127\n" +
+	"\n" +
+	"subscribed\x18\x02 \x01(\v2\x15.blockbook.SubscribedH\x00R\n" +
+	"subscribed\x122\n" +
+	"\tnew_block\x18\x03 \x01(\v2\x13.blockbook.NewBlockH\x00R\bnewBlock\x12M\n" +
+	"\x12block_disconnected\x18\x04 \x01(\v2\x1c.blockbook.BlockDisconnectedH\x00R\x11blockDisconnectedB\x0e\n" +
+	"\fnotification\" \n" +
+	"\x1eSubscribeNewTransactionRequest\"\x86\x01\n" +
+	"\x1aNewTransactionNotification\x127\n" +
+	"\n" +
+	"subscribed\x18\x01 \x01(\v2\x15.blockbook.SubscribedH\x00R\n" +
+	"subscribed\x12\x1f\n" +
+	"\x02tx\x18\x02 \x01(\v2\r.blockbook.TxH\x00R\x02txB\x0e\n" +
+	"\fnotification\"\xa7\x01\n" +
+	"\x19SubscribeAddressesRequest\x12\x1c\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\x12\"\n" +
+	"\rnew_block_txs\x18\x02 \x01(\bR\vnewBlockTxs\x12%\n" +
+	"\x0esince_sequence\x18\x03 \x01(\x04R\rsinceSequence\x12!\n" +
+	"\fsince_height\x18\x04 \x01(\rR\vsinceHeight\"A\n" +
+	"\n" +
+	"TxReplaced\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"L\n" +
+	"\vDoubleSpend\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12)\n" +
+	"\x10conflicting_txid\x18\x02 \x01(\tR\x0fconflictingTxid\"J\n" +
+	"\tTxDropped\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12)\n" +
+	"\x10conflicting_txid\x18\x02 \x01(\tR\x0fconflictingTxid\"O\n" +
+	"\rTxUnconfirmed\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\rR\x06height\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\"\xe5\x01\n" +
+	"\n" +
+	"Withdrawal\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x04R\x05index\x12'\n" +
+	"\x0fvalidator_index\x18\x02 \x01(\x04R\x0evalidatorIndex\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12!\n" +
+	"\fblock_height\x18\x05 \x01(\rR\vblockHeight\x12\x1d\n" +
+	"\n" +
+	"block_time\x18\x06 \x01(\x03R\tblockTime\x12$\n" +
+	"\rconfirmations\x18\a \x01(\rR\rconfirmations\"\xd5\x03\n" +
+	"\x13AddressNotification\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x127\n" +
+	"\n" +
+	"subscribed\x18\x03 \x01(\v2\x15.blockbook.SubscribedH\x00R\n" +
+	"subscribed\x12\x1f\n" +
+	"\x02tx\x18\x04 \x01(\v2\r.blockbook.TxH\x00R\x02tx\x128\n" +
+	"\vtx_replaced\x18\x05 \x01(\v2\x15.blockbook.TxReplacedH\x00R\n" +
+	"txReplaced\x12;\n" +
+	"\fdouble_spend\x18\x06 \x01(\v2\x16.blockbook.DoubleSpendH\x00R\vdoubleSpend\x125\n" +
+	"\n" +
+	"tx_dropped\x18\a \x01(\v2\x14.blockbook.TxDroppedH\x00R\ttxDropped\x12A\n" +
+	"\x0etx_unconfirmed\x18\b \x01(\v2\x18.blockbook.TxUnconfirmedH\x00R\rtxUnconfirmed\x127\n" +
+	"\n" +
+	"withdrawal\x18\t \x01(\v2\x15.blockbook.WithdrawalH\x00R\n" +
+	"withdrawalB\x0e\n" +
+	"\fnotification\"O\n" +
+	"\x19SubscribeFiatRatesRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06tokens\x18\x02 \x03(\tR\x06tokens\"\x82\x02\n" +
+	"\tFiatRates\x125\n" +
+	"\x05rates\x18\x01 \x03(\v2\x1f.blockbook.FiatRates.RatesEntryR\x05rates\x12E\n" +
+	"\vtoken_rates\x18\x02 \x03(\v2$.blockbook.FiatRates.TokenRatesEntryR\n" +
+	"tokenRates\x1a8\n" +
+	"\n" +
+	"RatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\x1a=\n" +
+	"\x0fTokenRatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"\x8e\x01\n" +
+	"\x15FiatRatesNotification\x127\n" +
+	"\n" +
+	"subscribed\x18\x01 \x01(\v2\x15.blockbook.SubscribedH\x00R\n" +
+	"subscribed\x12,\n" +
+	"\x05rates\x18\x02 \x01(\v2\x14.blockbook.FiatRatesH\x00R\x05ratesB\x0e\n" +
+	"\fnotification2\xd6\a\n" +
+	"\tBlockbook\x125\n" +
+	"\aGetInfo\x12\x19.blockbook.GetInfoRequest\x1a\x0f.blockbook.Info\x12F\n" +
+	"\x0eGetAccountInfo\x12 .blockbook.GetAccountInfoRequest\x1a\x12.blockbook.Address\x12D\n" +
+	"\x0eGetAccountUtxo\x12 .blockbook.GetAccountUtxoRequest\x1a\x10.blockbook.Utxos\x12A\n" +
+	"\x0eGetTransaction\x12 .blockbook.GetTransactionRequest\x1a\r.blockbook.Tx\x128\n" +
+	"\bGetBlock\x12\x1a.blockbook.GetBlockRequest\x1a\x10.blockbook.Block\x12U\n" +
+	"\x11GetBalanceHistory\x12#.blockbook.GetBalanceHistoryRequest\x1a\x1b.blockbook.BalanceHistories\x12L\n" +
+	"\vEstimateFee\x12\x1d.blockbook.EstimateFeeRequest\x1a\x1e.blockbook.EstimateFeeResponse\x12X\n" +
+	"\x0fSendTransaction\x12!.blockbook.SendTransactionRequest\x1a\".blockbook.SendTransactionResponse\x12[\n" +
+	"\x11SubscribeNewBlock\x12#.blockbook.SubscribeNewBlockRequest\x1a\x1f.blockbook.NewBlockNotification0\x01\x12m\n" +
+	"\x17SubscribeNewTransaction\x12).blockbook.SubscribeNewTransactionRequest\x1a%.blockbook.NewTransactionNotification0\x01\x12\\\n" +
+	"\x12SubscribeAddresses\x12$.blockbook.SubscribeAddressesRequest\x1a\x1e.blockbook.AddressNotification0\x01\x12^\n" +
+	"\x12SubscribeFiatRates\x12$.blockbook.SubscribeFiatRatesRequest\x1a .blockbook.FiatRatesNotification0\x01B%Z#github.com/trezor/blockbook/grpcapib\x06proto3"
+
+var (
+	file_grpcapi_blockbook_proto_rawDescOnce sync.Once
+	file_grpcapi_blockbook_proto_rawDescData []byte
+)
+
+func file_grpcapi_blockbook_proto_rawDescGZIP() []byte {
+	file_grpcapi_blockbook_proto_rawDescOnce.Do(func() {
+		file_grpcapi_blockbook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_grpcapi_blockbook_proto_rawDesc), len(file_grpcapi_blockbook_proto_rawDesc)))
+	})
+	return file_grpcapi_blockbook_proto_rawDescData
+}
+
+var file_grpcapi_blockbook_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_grpcapi_blockbook_proto_goTypes = []any{
+	(*GetInfoRequest)(nil),                 // 0: blockbook.GetInfoRequest
+	(*BackendInfo)(nil),                    // 1: blockbook.BackendInfo
+	(*Info)(nil),                           // 2: blockbook.Info
+	(*GetAccountInfoRequest)(nil),          // 3: blockbook.GetAccountInfoRequest
+	(*Token)(nil),                          // 4: blockbook.Token
+	(*Address)(nil),                        // 5: blockbook.Address
+	(*Vin)(nil),                            // 6: blockbook.Vin
+	(*Vout)(nil),                           // 7: blockbook.Vout
+	(*MultiTokenValue)(nil),                // 8: blockbook.MultiTokenValue
+	(*TokenTransfer)(nil),                  // 9: blockbook.TokenTransfer
+	(*EthereumSpecific)(nil),               // 10: blockbook.EthereumSpecific
+	(*Tx)(nil),                             // 11: blockbook.Tx
+	(*GetAccountUtxoRequest)(nil),          // 12: blockbook.GetAccountUtxoRequest
+	(*Utxo)(nil),                           // 13: blockbook.Utxo
+	(*Utxos)(nil),                          // 14: blockbook.Utxos
+	(*GetTransactionRequest)(nil),          // 15: blockbook.GetTransactionRequest
+	(*GetBlockRequest)(nil),                // 16: blockbook.GetBlockRequest
+	(*Block)(nil),                          // 17: blockbook.Block
+	(*GetBalanceHistoryRequest)(nil),       // 18: blockbook.GetBalanceHistoryRequest
+	(*BalanceHistory)(nil),                 // 19: blockbook.BalanceHistory
+	(*BalanceHistories)(nil),               // 20: blockbook.BalanceHistories
+	(*EstimateFeeRequest)(nil),             // 21: blockbook.EstimateFeeRequest
+	(*Eip1559Fee)(nil),                     // 22: blockbook.Eip1559Fee
+	(*Eip1559Fees)(nil),                    // 23: blockbook.Eip1559Fees
+	(*FeeConfidence)(nil),                  // 24: blockbook.FeeConfidence
+	(*FeeEstimate)(nil),                    // 25: blockbook.FeeEstimate
+	(*EstimateFeeResponse)(nil),            // 26: blockbook.EstimateFeeResponse
+	(*SendTransactionRequest)(nil),         // 27: blockbook.SendTransactionRequest
+	(*SendTransactionResponse)(nil),        // 28: blockbook.SendTransactionResponse
+	(*Subscribed)(nil),                     // 29: blockbook.Subscribed
+	(*SubscribeNewBlockRequest)(nil),       // 30: blockbook.SubscribeNewBlockRequest
+	(*DisconnectedBlock)(nil),              // 31: blockbook.DisconnectedBlock
+	(*BlockDisconnected)(nil),              // 32: blockbook.BlockDisconnected
+	(*NewBlock)(nil),                       // 33: blockbook.NewBlock
+	(*NewBlockNotification)(nil),           // 34: blockbook.NewBlockNotification
+	(*SubscribeNewTransactionRequest)(nil), // 35: blockbook.SubscribeNewTransactionRequest
+	(*NewTransactionNotification)(nil),     // 36: blockbook.NewTransactionNotification
+	(*SubscribeAddressesRequest)(nil),      // 37: blockbook.SubscribeAddressesRequest
+	(*TxReplaced)(nil),                     // 38: blockbook.TxReplaced
+	(*DoubleSpend)(nil),                    // 39: blockbook.DoubleSpend
+	(*TxDropped)(nil),                      // 40: blockbook.TxDropped
+	(*TxUnconfirmed)(nil),                  // 41: blockbook.TxUnconfirmed
+	(*Withdrawal)(nil),                     // 42: blockbook.Withdrawal
+	(*AddressNotification)(nil),            // 43: blockbook.AddressNotification
+	(*SubscribeFiatRatesRequest)(nil),      // 44: blockbook.SubscribeFiatRatesRequest
+	(*FiatRates)(nil),                      // 45: blockbook.FiatRates
+	(*FiatRatesNotification)(nil),          // 46: blockbook.FiatRatesNotification
+	nil,                                    // 47: blockbook.BalanceHistory.RatesEntry
+	nil,                                    // 48: blockbook.FiatRates.RatesEntry
+	nil,                                    // 49: blockbook.FiatRates.TokenRatesEntry
+	(*structpb.Struct)(nil),                // 50: google.protobuf.Struct
+}
+var file_grpcapi_blockbook_proto_depIdxs = []int32{
+	1,  // 0: blockbook.Info.backend:type_name -> blockbook.BackendInfo
+	8,  // 1: blockbook.Token.multi_token_values:type_name -> blockbook.MultiTokenValue
+	11, // 2: blockbook.Address.transactions:type_name -> blockbook.Tx
+	4,  // 3: blockbook.Address.tokens:type_name -> blockbook.Token
+	8,  // 4: blockbook.TokenTransfer.multi_token_values:type_name -> blockbook.MultiTokenValue
+	6,  // 5: blockbook.Tx.vin:type_name -> blockbook.Vin
+	7,  // 6: blockbook.Tx.vout:type_name -> blockbook.Vout
+	9,  // 7: blockbook.Tx.token_transfers:type_name -> blockbook.TokenTransfer
+	10, // 8: blockbook.Tx.ethereum_specific:type_name -> blockbook.EthereumSpecific
+	13, // 9: blockbook.Utxos.utxos:type_name -> blockbook.Utxo
+	11, // 10: blockbook.Block.txs:type_name -> blockbook.Tx
+	47, // 11: blockbook.BalanceHistory.rates:type_name -> blockbook.BalanceHistory.RatesEntry
+	19, // 12: blockbook.BalanceHistories.histories:type_name -> blockbook.BalanceHistory
+	50, // 13: blockbook.EstimateFeeRequest.specific:type_name -> google.protobuf.Struct
+	22, // 14: blockbook.Eip1559Fees.low:type_name -> blockbook.Eip1559Fee
+	22, // 15: blockbook.Eip1559Fees.medium:type_name -> blockbook.Eip1559Fee
+	22, // 16: blockbook.Eip1559Fees.high:type_name -> blockbook.Eip1559Fee
+	22, // 17: blockbook.Eip1559Fees.instant:type_name -> blockbook.Eip1559Fee
+	23, // 18: blockbook.FeeEstimate.eip1559:type_name -> blockbook.Eip1559Fees
+	24, // 19: blockbook.FeeEstimate.confidence:type_name -> blockbook.FeeConfidence
+	25, // 20: blockbook.EstimateFeeResponse.estimates:type_name -> blockbook.FeeEstimate
+	31, // 21: blockbook.BlockDisconnected.blocks:type_name -> blockbook.DisconnectedBlock
+	29, // 22: blockbook.NewBlockNotification.subscribed:type_name -> blockbook.Subscribed
+	33, // 23: blockbook.NewBlockNotification.new_block:type_name -> blockbook.NewBlock
+	32, // 24: blockbook.NewBlockNotification.block_disconnected:type_name -> blockbook.BlockDisconnected
+	29, // 25: blockbook.NewTransactionNotification.subscribed:type_name -> blockbook.Subscribed
+	11, // 26: blockbook.NewTransactionNotification.tx:type_name -> blockbook.Tx
+	29, // 27: blockbook.AddressNotification.subscribed:type_name -> blockbook.Subscribed
+	11, // 28: blockbook.AddressNotification.tx:type_name -> blockbook.Tx
+	38, // 29: blockbook.AddressNotification.tx_replaced:type_name -> blockbook.TxReplaced
+	39, // 30: blockbook.AddressNotification.double_spend:type_name -> blockbook.DoubleSpend
+	40, // 31: blockbook.AddressNotification.tx_dropped:type_name -> blockbook.TxDropped
+	41, // 32: blockbook.AddressNotification.tx_unconfirmed:type_name -> blockbook.TxUnconfirmed
+	42, // 33: blockbook.AddressNotification.withdrawal:type_name -> blockbook.Withdrawal
+	48, // 34: blockbook.FiatRates.rates:type_name -> blockbook.FiatRates.RatesEntry
+	49, // 35: blockbook.FiatRates.token_rates:type_name -> blockbook.FiatRates.TokenRatesEntry
+	29, // 36: blockbook.FiatRatesNotification.subscribed:type_name -> blockbook.Subscribed
+	45, // 37: blockbook.FiatRatesNotification.rates:type_name -> blockbook.FiatRates
+	0,  // 38: blockbook.Blockbook.GetInfo:input_type -> blockbook.GetInfoRequest
+	3,  // 39: blockbook.Blockbook.GetAccountInfo:input_type -> blockbook.GetAccountInfoRequest
+	12, // 40: blockbook.Blockbook.GetAccountUtxo:input_type -> blockbook.GetAccountUtxoRequest
+	15, // 41: blockbook.Blockbook.GetTransaction:input_type -> blockbook.GetTransactionRequest
+	16, // 42: blockbook.Blockbook.GetBlock:input_type -> blockbook.GetBlockRequest
+	18, // 43: blockbook.Blockbook.GetBalanceHistory:input_type -> blockbook.GetBalanceHistoryRequest
+	21, // 44: blockbook.Blockbook.EstimateFee:input_type -> blockbook.EstimateFeeRequest
+	27, // 45: blockbook.Blockbook.SendTransaction:input_type -> blockbook.SendTransactionRequest
+	30, // 46: blockbook.Blockbook.SubscribeNewBlock:input_type -> blockbook.SubscribeNewBlockRequest
+	35, // 47: blockbook.Blockbook.SubscribeNewTransaction:input_type -> blockbook.SubscribeNewTransactionRequest
+	37, // 48: blockbook.Blockbook.SubscribeAddresses:input_type -> blockbook.SubscribeAddressesRequest
+	44, // 49: blockbook.Blockbook.SubscribeFiatRates:input_type -> blockbook.SubscribeFiatRatesRequest
+	2,  // 50: blockbook.Blockbook.GetInfo:output_type -> blockbook.Info
+	5,  // 51: blockbook.Blockbook.GetAccountInfo:output_type -> blockbook.Address
+	14, // 52: blockbook.Blockbook.GetAccountUtxo:output_type -> blockbook.Utxos
+	11, // 53: blockbook.Blockbook.GetTransaction:output_type -> blockbook.Tx
+	17, // 54: blockbook.Blockbook.GetBlock:output_type -> blockbook.Block
+	20, // 55: blockbook.Blockbook.GetBalanceHistory:output_type -> blockbook.BalanceHistories
+	26, // 56: blockbook.Blockbook.EstimateFee:output_type -> blockbook.EstimateFeeResponse
+	28, // 57: blockbook.Blockbook.SendTransaction:output_type -> blockbook.SendTransactionResponse
+	34, // 58: blockbook.Blockbook.SubscribeNewBlock:output_type -> blockbook.NewBlockNotification
+	36, // 59: blockbook.Blockbook.SubscribeNewTransaction:output_type -> blockbook.NewTransactionNotification
+	43, // 60: blockbook.Blockbook.SubscribeAddresses:output_type -> blockbook.AddressNotification
+	46, // 61: blockbook.Blockbook.SubscribeFiatRates:output_type -> blockbook.FiatRatesNotification
+	50, // [50:62] is the sub-list for method output_type
+	38, // [38:50] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
+}
+
+func init() { file_grpcapi_blockbook_proto_init() }
+func file_grpcapi_blockbook_proto_init() {
+	if File_grpcapi_blockbook_proto != nil {
+		return
+	}
+	file_grpcapi_blockbook_proto_msgTypes[34].OneofWrappers = []any{
+		(*NewBlockNotification_Subscribed)(nil),
+		(*NewBlockNotification_NewBlock)(nil),
+		(*NewBlockNotification_BlockDisconnected)(nil),
+	}
+	file_grpcapi_blockbook_proto_msgTypes[36].OneofWrappers = []any{
+		(*NewTransactionNotification_Subscribed)(nil),
+		(*NewTransactionNotification_Tx)(nil),
+	}
+	file_grpcapi_blockbook_proto_msgTypes[43].OneofWrappers = []any{
+		(*AddressNotification_Subscribed)(nil),
+		(*AddressNotification_Tx)(nil),
+		(*AddressNotification_TxReplaced)(nil),
+		(*AddressNotification_DoubleSpend)(nil),
+		(*AddressNotification_TxDropped)(nil),
+		(*AddressNotification_TxUnconfirmed)(nil),
+		(*AddressNotification_Withdrawal)(nil),
+	}
+	file_grpcapi_blockbook_proto_msgTypes[46].OneofWrappers = []any{
+		(*FiatRatesNotification_Subscribed)(nil),
+		(*FiatRatesNotification_Rates)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_grpcapi_blockbook_proto_rawDesc), len(file_grpcapi_blockbook_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   50,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_grpcapi_blockbook_proto_goTypes,
+		DependencyIndexes: file_grpcapi_blockbook_proto_depIdxs,
+		MessageInfos:      file_grpcapi_blockbook_proto_msgTypes,
+	}.Build()
+	File_grpcapi_blockbook_proto = out.File
+	file_grpcapi_blockbook_proto_goTypes = nil
+	file_grpcapi_blockbook_proto_depIdxs = nil
+}
@@ -0,0 +1,584 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: grpcapi/blockbook.proto
+
+// The gRPC interface of Blockbook mirrors the methods of the websocket API, the messages mirror the types of the api
+// package with the same field names in snake case. The amounts are decimal strings in the base units of the coin,
+// the same as in the JSON API, since they do not fit into 64-bit integers on Ethereum-type chains.
+//
+// bchain.ProtoTransaction (bchain/tx.proto) is the storage format of the Bitcoin-type transactions in the index,
+// it does not carry the normalized data of the API (values of the inputs, fees, confirmations, token transfers),
+// therefore the transactions are defined here again.
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Blockbook_GetInfo_FullMethodName                 = "/blockbook.Blockbook/GetInfo"
+	Blockbook_GetAccountInfo_FullMethodName          = "/blockbook.Blockbook/GetAccountInfo"
+	Blockbook_GetAccountUtxo_FullMethodName          = "/blockbook.Blockbook/GetAccountUtxo"
+	Blockbook_GetTransaction_FullMethodName          = "/blockbook.Blockbook/GetTransaction"
+	Blockbook_GetBlock_FullMethodName                = "/blockbook.Blockbook/GetBlock"
+	Blockbook_GetBalanceHistory_FullMethodName       = "/blockbook.Blockbook/GetBalanceHistory"
+	Blockbook_EstimateFee_FullMethodName             = "/blockbook.Blockbook/EstimateFee"
+	Blockbook_SendTransaction_FullMethodName         = "/blockbook.Blockbook/SendTransaction"
+	Blockbook_SubscribeNewBlock_FullMethodName       = "/blockbook.Blockbook/SubscribeNewBlock"
+	Blockbook_SubscribeNewTransaction_FullMethodName = "/blockbook.Blockbook/SubscribeNewTransaction"
+	Blockbook_SubscribeAddresses_FullMethodName      = "/blockbook.Blockbook/SubscribeAddresses"
+	Blockbook_SubscribeFiatRates_FullMethodName      = "/blockbook.Blockbook/SubscribeFiatRates"
+)
+
+// BlockbookClient is the client API for Blockbook service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BlockbookClient interface {
+	// GetInfo returns the state of Blockbook and of its backend, like the websocket method getInfo
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*Info, error)
+	// GetAccountInfo returns the balances and the transactions of an address or an xpub, like getAccountInfo
+	GetAccountInfo(ctx context.Context, in *GetAccountInfoRequest, opts ...grpc.CallOption) (*Address, error)
+	// GetAccountUtxo returns the unspent outputs of an address or an xpub, like getAccountUtxo
+	GetAccountUtxo(ctx context.Context, in *GetAccountUtxoRequest, opts ...grpc.CallOption) (*Utxos, error)
+	// GetTransaction returns a transaction, like getTransaction
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Tx, error)
+	// GetBlock returns a page of the transactions of a block, like getBlock
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// GetBalanceHistory returns the balance history of an address or an xpub, like getBalanceHistory
+	GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*BalanceHistories, error)
+	// EstimateFee estimates the fees for the confirmation targets, like estimateFee
+	EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error)
+	// SendTransaction broadcasts a transaction, like sendTransaction
+	SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	// SubscribeNewBlock streams the new blocks and the reorgs, like subscribeNewBlock
+	SubscribeNewBlock(ctx context.Context, in *SubscribeNewBlockRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewBlockNotification], error)
+	// SubscribeNewTransaction streams all new mempool transactions, like subscribeNewTransaction
+	SubscribeNewTransaction(ctx context.Context, in *SubscribeNewTransactionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewTransactionNotification], error)
+	// SubscribeAddresses streams the transactions and the mempool changes of the addresses, like subscribeAddresses
+	SubscribeAddresses(ctx context.Context, in *SubscribeAddressesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AddressNotification], error)
+	// SubscribeFiatRates streams the fiat rates, like subscribeFiatRates
+	SubscribeFiatRates(ctx context.Context, in *SubscribeFiatRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FiatRatesNotification], error)
+}
+
+type blockbookClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockbookClient(cc grpc.ClientConnInterface) BlockbookClient {
+	return &blockbookClient{cc}
+}
+
+func (c *blockbookClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*Info, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Info)
+	err := c.cc.Invoke(ctx, Blockbook_GetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetAccountInfo(ctx context.Context, in *GetAccountInfoRequest, opts ...grpc.CallOption) (*Address, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Address)
+	err := c.cc.Invoke(ctx, Blockbook_GetAccountInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetAccountUtxo(ctx context.Context, in *GetAccountUtxoRequest, opts ...grpc.CallOption) (*Utxos, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Utxos)
+	err := c.cc.Invoke(ctx, Blockbook_GetAccountUtxo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Tx, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tx)
+	err := c.cc.Invoke(ctx, Blockbook_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, Blockbook_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*BalanceHistories, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceHistories)
+	err := c.cc.Invoke(ctx, Blockbook_GetBalanceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EstimateFeeResponse)
+	err := c.cc.Invoke(ctx, Blockbook_EstimateFee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendTransactionResponse)
+	err := c.cc.Invoke(ctx, Blockbook_SendTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) SubscribeNewBlock(ctx context.Context, in *SubscribeNewBlockRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewBlockNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blockbook_ServiceDesc.Streams[0], Blockbook_SubscribeNewBlock_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeNewBlockRequest, NewBlockNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blockbook_SubscribeNewBlockClient = grpc.ServerStreamingClient[NewBlockNotification]
+
+func (c *blockbookClient) SubscribeNewTransaction(ctx context.Context, in *SubscribeNewTransactionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewTransactionNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blockbook_ServiceDesc.Streams[1], Blockbook_SubscribeNewTransaction_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeNewTransactionRequest, NewTransactionNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blockbook_SubscribeNewTransactionClient = grpc.ServerStreamingClient[NewTransactionNotification]
+
+func (c *blockbookClient) SubscribeAddresses(ctx context.Context, in *SubscribeAddressesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AddressNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blockbook_ServiceDesc.Streams[2], Blockbook_SubscribeAddresses_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeAddressesRequest, AddressNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blockbook_SubscribeAddressesClient = grpc.ServerStreamingClient[AddressNotification]
+
+func (c *blockbookClient) SubscribeFiatRates(ctx context.Context, in *SubscribeFiatRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FiatRatesNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Blockbook_ServiceDesc.Streams[3], Blockbook_SubscribeFiatRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeFiatRatesRequest, FiatRatesNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blockbook_SubscribeFiatRatesClient = grpc.ServerStreamingClient[FiatRatesNotification]
+
+// BlockbookServer is the server API for Blockbook service.
+// All implementations must embed UnimplementedBlockbookServer
+// for forward compatibility.
+type BlockbookServer interface {
+	// GetInfo returns the state of Blockbook and of its backend, like the websocket method getInfo
+	GetInfo(context.Context, *GetInfoRequest) (*Info, error)
+	// GetAccountInfo returns the balances and the transactions of an address or an xpub, like getAccountInfo
+	GetAccountInfo(context.Context, *GetAccountInfoRequest) (*Address, error)
+	// GetAccountUtxo returns the unspent outputs of an address or an xpub, like getAccountUtxo
+	GetAccountUtxo(context.Context, *GetAccountUtxoRequest) (*Utxos, error)
+	// GetTransaction returns a transaction, like getTransaction
+	GetTransaction(context.Context, *GetTransactionRequest) (*Tx, error)
+	// GetBlock returns a page of the transactions of a block, like getBlock
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	// GetBalanceHistory returns the balance history of an address or an xpub, like getBalanceHistory
+	GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*BalanceHistories, error)
+	// EstimateFee estimates the fees for the confirmation targets, like estimateFee
+	EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error)
+	// SendTransaction broadcasts a transaction, like sendTransaction
+	SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error)
+	// SubscribeNewBlock streams the new blocks and the reorgs, like subscribeNewBlock
+	SubscribeNewBlock(*SubscribeNewBlockRequest, grpc.ServerStreamingServer[NewBlockNotification]) error
+	// SubscribeNewTransaction streams all new mempool transactions, like subscribeNewTransaction
+	SubscribeNewTransaction(*SubscribeNewTransactionRequest, grpc.ServerStreamingServer[NewTransactionNotification]) error
+	// SubscribeAddresses streams the transactions and the mempool changes of the addresses, like subscribeAddresses
+	SubscribeAddresses(*SubscribeAddressesRequest, grpc.ServerStreamingServer[AddressNotification]) error
+	// SubscribeFiatRates streams the fiat rates, like subscribeFiatRates
+	SubscribeFiatRates(*SubscribeFiatRatesRequest, grpc.ServerStreamingServer[FiatRatesNotification]) error
+	mustEmbedUnimplementedBlockbookServer()
+}
+
+// UnimplementedBlockbookServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBlockbookServer struct{}
+
+func (UnimplementedBlockbookServer) GetInfo(context.Context, *GetInfoRequest) (*Info, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedBlockbookServer) GetAccountInfo(context.Context, *GetAccountInfoRequest) (*Address, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountInfo not implemented")
+}
+func (UnimplementedBlockbookServer) GetAccountUtxo(context.Context, *GetAccountUtxoRequest) (*Utxos, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountUtxo not implemented")
+}
+func (UnimplementedBlockbookServer) GetTransaction(context.Context, *GetTransactionRequest) (*Tx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedBlockbookServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedBlockbookServer) GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*BalanceHistories, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceHistory not implemented")
+}
+func (UnimplementedBlockbookServer) EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateFee not implemented")
+}
+func (UnimplementedBlockbookServer) SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
+}
+func (UnimplementedBlockbookServer) SubscribeNewBlock(*SubscribeNewBlockRequest, grpc.ServerStreamingServer[NewBlockNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNewBlock not implemented")
+}
+func (UnimplementedBlockbookServer) SubscribeNewTransaction(*SubscribeNewTransactionRequest, grpc.ServerStreamingServer[NewTransactionNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNewTransaction not implemented")
+}
+func (UnimplementedBlockbookServer) SubscribeAddresses(*SubscribeAddressesRequest, grpc.ServerStreamingServer[AddressNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAddresses not implemented")
+}
+func (UnimplementedBlockbookServer) SubscribeFiatRates(*SubscribeFiatRatesRequest, grpc.ServerStreamingServer[FiatRatesNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeFiatRates not implemented")
+}
+func (UnimplementedBlockbookServer) mustEmbedUnimplementedBlockbookServer() {}
+func (UnimplementedBlockbookServer) testEmbeddedByValue()                   {}
+
+// UnsafeBlockbookServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlockbookServer will
+// result in compilation errors.
+type UnsafeBlockbookServer interface {
+	mustEmbedUnimplementedBlockbookServer()
+}
+
+func RegisterBlockbookServer(s grpc.ServiceRegistrar, srv BlockbookServer) {
+	// If the following call pancis, it indicates UnimplementedBlockbookServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Blockbook_ServiceDesc, srv)
+}
+
+func _Blockbook_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blockbook_GetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetAccountInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetAccountInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blockbook_GetAccountInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetAccountInfo(ctx, req.(*GetAccountInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetAccountUtxo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountUtxoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetAccountUtxo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blockbook_GetAccountUtxo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetAccountUtxo(ctx, req.(*GetAccountUtxoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blockbook_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blockbook_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetBalanceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetBalanceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blockbook_GetBalanceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetBalanceHistory(ctx, req.(*GetBalanceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).EstimateFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blockbook_EstimateFee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).SendTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Blockbook_SendTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).SendTransaction(ctx, req.(*SendTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_SubscribeNewBlock_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeNewBlockRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockbookServer).SubscribeNewBlock(m, &grpc.GenericServerStream[SubscribeNewBlockRequest, NewBlockNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blockbook_SubscribeNewBlockServer = grpc.ServerStreamingServer[NewBlockNotification]
+
+func _Blockbook_SubscribeNewTransaction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeNewTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockbookServer).SubscribeNewTransaction(m, &grpc.GenericServerStream[SubscribeNewTransactionRequest, NewTransactionNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blockbook_SubscribeNewTransactionServer = grpc.ServerStreamingServer[NewTransactionNotification]
+
+func _Blockbook_SubscribeAddresses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAddressesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockbookServer).SubscribeAddresses(m, &grpc.GenericServerStream[SubscribeAddressesRequest, AddressNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blockbook_SubscribeAddressesServer = grpc.ServerStreamingServer[AddressNotification]
+
+func _Blockbook_SubscribeFiatRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeFiatRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockbookServer).SubscribeFiatRates(m, &grpc.GenericServerStream[SubscribeFiatRatesRequest, FiatRatesNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Blockbook_SubscribeFiatRatesServer = grpc.ServerStreamingServer[FiatRatesNotification]
+
+// Blockbook_ServiceDesc is the grpc.ServiceDesc for Blockbook service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Blockbook_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blockbook.Blockbook",
+	HandlerType: (*BlockbookServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _Blockbook_GetInfo_Handler,
+		},
+		{
+			MethodName: "GetAccountInfo",
+			Handler:    _Blockbook_GetAccountInfo_Handler,
+		},
+		{
+			MethodName: "GetAccountUtxo",
+			Handler:    _Blockbook_GetAccountUtxo_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _Blockbook_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _Blockbook_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBalanceHistory",
+			Handler:    _Blockbook_GetBalanceHistory_Handler,
+		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    _Blockbook_EstimateFee_Handler,
+		},
+		{
+			MethodName: "SendTransaction",
+			Handler:    _Blockbook_SendTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNewBlock",
+			Handler:       _Blockbook_SubscribeNewBlock_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeNewTransaction",
+			Handler:       _Blockbook_SubscribeNewTransaction_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeAddresses",
+			Handler:       _Blockbook_SubscribeAddresses_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeFiatRates",
+			Handler:       _Blockbook_SubscribeFiatRates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "grpcapi/blockbook.proto",
+}
@@ -0,0 +1,403 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/common"
+	"github.com/trezor/blockbook/db"
+)
+
+// API keys identify the tenants of the public interfaces, typically partner
+// apps serving many users behind a shared NAT, which would otherwise compete
+// for the per-IP limits. A request presenting a known key is limited by the
+// limits of the key instead of the limits of its client IP; a request with an
+// unknown key is refused. The keys are managed through the internal
+// /admin/api-keys interface and persisted in the database.
+const (
+	apiKeyHeader     = "X-Api-Key"
+	apiKeyQueryParam = "apikey"
+	// apiKeyLimitPrefix namespaces the limiter keys of the API keys, it cannot
+	// collide with the IP based keys
+	apiKeyLimitPrefix = "apikey:"
+	apiKeySecretBytes = 24
+)
+
+const (
+	apiKeyRejectInvalidKey       = "invalid_key"
+	apiKeyRejectMethodNotAllowed = "method_not_allowed"
+	apiKeyRejectSubscriptions    = "subscription_limit"
+)
+
+var errInvalidApiKey = errors.New("Invalid API key")
+
+var apiKeyIDRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// requestApiKey returns the API key presented by the request in the X-Api-Key
+// header or the apikey query parameter (for the clients which cannot set
+// headers, like the browser websocket), nil if the request has none
+func requestApiKey(is *common.InternalState, r *http.Request) (*common.ApiKey, error) {
+	secret := r.Header.Get(apiKeyHeader)
+	if secret == "" && r.URL != nil {
+		secret = r.URL.Query().Get(apiKeyQueryParam)
+	}
+	if secret == "" {
+		return nil, nil
+	}
+	var k *common.ApiKey
+	if is != nil {
+		k = is.GetApiKeys().BySecret(secret)
+	}
+	if k == nil {
+		return nil, errInvalidApiKey
+	}
+	return k, nil
+}
+
+// apiKeyID returns the id of the API key, empty if there is no key
+func apiKeyID(k *common.ApiKey) string {
+	if k == nil {
+		return ""
+	}
+	return k.ID
+}
+
+func apiKeyLimitKey(id string) string {
+	return apiKeyLimitPrefix + id
+}
+
+func observeApiKeyRequest(metrics *common.Metrics, id, iface string) {
+	if metrics != nil {
+		metrics.ApiKeyRequests.With(common.Labels{"key": id, "interface": iface}).Inc()
+	}
+}
+
+// observeApiKeyRejection counts a rejection by the limits of the key, the
+// requests with an unknown key are counted with an empty id
+func observeApiKeyRejection(metrics *common.Metrics, id, reason string) {
+	if metrics != nil {
+		metrics.ApiKeyRejections.With(common.Labels{"key": id, "reason": reason}).Inc()
+	}
+}
+
+// acceptConnection applies the connection limits to a new websocket
+// connection or stream: the limit of the API key if the client presented one,
+// the per-IP limits otherwise. It returns the limiter key which must be passed
+// to releaseConnection when the connection closes.
+func (s *WebsocketServer) acceptConnection(ip, ipKey string, apiKey *common.ApiKey) (string, bool) {
+	limitKey := ipKey
+	if apiKey != nil {
+		limitKey = apiKeyLimitKey(apiKey.ID)
+	}
+	if s.websocketLimiter != nil {
+		var ok bool
+		var reason string
+		if apiKey != nil {
+			// the reconnects of the users of a tenant are not limited, only the number of its connections
+			ok, reason = s.websocketLimiter.acceptLimited(limitKey, apiKey.MaxWebsocketConnections, 0, time.Now())
+		} else {
+			ok, reason = s.websocketLimiter.accept(ipKey, time.Now())
+		}
+		if !ok {
+			if s.metrics != nil {
+				s.metrics.WebsocketConnectionRejections.With(common.Labels{"reason": reason}).Inc()
+			}
+			if apiKey != nil {
+				observeApiKeyRejection(s.metrics, apiKey.ID, reason)
+				glog.Warning("Websocket connection rejected, ", ip, ", API key ", apiKey.ID, ", ", reason)
+			} else {
+				glog.Warning("Websocket connection rejected, ", ip, ", ", reason)
+			}
+			return "", false
+		}
+	}
+	if apiKey != nil && s.metrics != nil {
+		s.metrics.ApiKeyWebsocketConnections.With(common.Labels{"key": apiKey.ID}).Inc()
+	}
+	return limitKey, true
+}
+
+// releaseConnection releases the connection accepted by acceptConnection
+func (s *WebsocketServer) releaseConnection(limitKey string) {
+	if s.websocketLimiter != nil {
+		s.websocketLimiter.release(limitKey, time.Now())
+	}
+	if id, ok := strings.CutPrefix(limitKey, apiKeyLimitPrefix); ok && s.metrics != nil {
+		s.metrics.ApiKeyWebsocketConnections.With(common.Labels{"key": id}).Dec()
+	}
+}
+
+// checkApiKeyMethod checks that the API key of the channel, if any, may call
+// the method. The current key is looked up on each call, so that a change of
+// the key by the admin applies to the open connections as well.
+func (s *WebsocketServer) checkApiKeyMethod(c *websocketChannel, method string) error {
+	if c.apiKeyID == "" {
+		return nil
+	}
+	k := s.is.GetApiKeys().ByID(c.apiKeyID)
+	if k == nil {
+		observeApiKeyRejection(s.metrics, c.apiKeyID, apiKeyRejectInvalidKey)
+		return api.NewAPIError(errInvalidApiKey.Error(), true)
+	}
+	observeApiKeyRequest(s.metrics, k.ID, "websocket")
+	if !k.MethodAllowed(method) {
+		observeApiKeyRejection(s.metrics, k.ID, apiKeyRejectMethodNotAllowed)
+		return api.NewAPIError("Method "+method+" not allowed by the API key", true)
+	}
+	return nil
+}
+
+// checkApiKeySubscriptions checks that replacing the address subscriptions of
+// the channel by count addresses keeps its API key, if any, within the limit
+// of the subscribed addresses. addressSubscriptionsLock must be held by the caller.
+func (s *WebsocketServer) checkApiKeySubscriptions(c *websocketChannel, count int) error {
+	if c.apiKeyID == "" {
+		return nil
+	}
+	k := s.is.GetApiKeys().ByID(c.apiKeyID)
+	if k == nil || k.MaxSubscriptions <= 0 {
+		return nil
+	}
+	if s.apiKeySubscriptions[c.apiKeyID]-len(c.addrDescs)+count > k.MaxSubscriptions {
+		observeApiKeyRejection(s.metrics, k.ID, apiKeyRejectSubscriptions)
+		return api.NewAPIError("Limit of subscribed addresses of the API key exceeded", true)
+	}
+	return nil
+}
+
+// addApiKeySubscriptions updates the count of the addresses subscribed by the
+// API key of the channel. addressSubscriptionsLock must be held by the caller.
+func (s *WebsocketServer) addApiKeySubscriptions(c *websocketChannel, delta int) {
+	if c.apiKeyID == "" || delta == 0 {
+		return
+	}
+	if s.apiKeySubscriptions == nil {
+		s.apiKeySubscriptions = make(map[string]int)
+	}
+	n := s.apiKeySubscriptions[c.apiKeyID] + delta
+	if n > 0 {
+		s.apiKeySubscriptions[c.apiKeyID] = n
+	} else {
+		delete(s.apiKeySubscriptions, c.apiKeyID)
+	}
+	if s.metrics != nil {
+		s.metrics.ApiKeySubscriptions.With(common.Labels{"key": c.apiKeyID}).Set(float64(n))
+	}
+}
+
+// initApiKeys loads and publishes the API keys snapshot if none exists yet,
+// see initRpcCallAllowlists
+func initApiKeys(d *db.RocksDB, is *common.InternalState) error {
+	if is.GetApiKeys() != nil {
+		return nil
+	}
+	var keys []*common.ApiKey
+	if d != nil {
+		var err error
+		if keys, err = d.GetApiKeys(); err != nil {
+			return err
+		}
+	}
+	if is.InitApiKeys(common.NewApiKeys(keys)) && len(keys) > 0 {
+		glog.Info("Loaded ", len(keys), " API keys")
+	}
+	return nil
+}
+
+// apiKeyStore persists the API keys; *db.RocksDB in production, replaceable
+// in tests to exercise storage failures.
+type apiKeyStore interface {
+	StoreApiKey(k *common.ApiKey) error
+	DeleteApiKey(id string) error
+}
+
+// apiKeyRequest is the JSON body of the create and update requests of the
+// /admin/api-keys/<ID> endpoint, a zero limit means unlimited
+type apiKeyRequest struct {
+	RateLimit               int      `json:"rateLimit"`
+	RateWindow              string   `json:"rateWindow"`
+	Burst                   int      `json:"burst"`
+	MaxConcurrent           int      `json:"maxConcurrent"`
+	MaxWebsocketConnections int      `json:"maxWebsocketConnections"`
+	MaxSubscriptions        int      `json:"maxSubscriptions"`
+	AllowedMethods          []string `json:"allowedMethods"`
+	DeniedMethods           []string `json:"deniedMethods"`
+}
+
+// apiKeyResponse is the JSON shape returned by the /admin/api-keys/<ID>
+// endpoint; the secret Key is returned only when the key is created
+type apiKeyResponse struct {
+	ID                      string    `json:"id"`
+	Key                     string    `json:"key,omitempty"`
+	RateLimit               int       `json:"rateLimit"`
+	RateWindow              string    `json:"rateWindow"`
+	Burst                   int       `json:"burst"`
+	MaxConcurrent           int       `json:"maxConcurrent"`
+	MaxWebsocketConnections int       `json:"maxWebsocketConnections"`
+	MaxSubscriptions        int       `json:"maxSubscriptions"`
+	AllowedMethods          []string  `json:"allowedMethods,omitempty"`
+	DeniedMethods           []string  `json:"deniedMethods,omitempty"`
+	Created                 time.Time `json:"created"`
+	Updated                 time.Time `json:"updated"`
+}
+
+func newApiKeyResponse(k *common.ApiKey) *apiKeyResponse {
+	return &apiKeyResponse{
+		ID:                      k.ID,
+		RateLimit:               k.RateLimit,
+		RateWindow:              k.RateWindow.String(),
+		Burst:                   k.Burst,
+		MaxConcurrent:           k.MaxConcurrent,
+		MaxWebsocketConnections: k.MaxWebsocketConnections,
+		MaxSubscriptions:        k.MaxSubscriptions,
+		AllowedMethods:          k.AllowedMethods,
+		DeniedMethods:           k.DeniedMethods,
+		Created:                 k.Created,
+		Updated:                 k.Updated,
+	}
+}
+
+// parseApiKeyRequest validates the body of a create or update request and
+// applies the limits to the key
+func parseApiKeyRequest(r *http.Request, k *common.ApiKey) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return api.NewAPIError("Cannot get request body", true)
+	}
+	var req apiKeyRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return api.NewAPIError("Invalid request body: "+err.Error(), true)
+	}
+	if req.RateLimit < 0 || req.Burst < 0 || req.MaxConcurrent < 0 || req.MaxWebsocketConnections < 0 || req.MaxSubscriptions < 0 {
+		return api.NewAPIError("Limits must not be negative", true)
+	}
+	rateWindow := defaultRestUIRateWindow
+	if req.RateWindow != "" {
+		rateWindow, err = time.ParseDuration(req.RateWindow)
+		if err != nil || rateWindow <= 0 {
+			return api.NewAPIError("Invalid rateWindow "+req.RateWindow+", expecting a positive duration like \"1m\"", true)
+		}
+	}
+	if req.RateLimit > 0 && req.Burst <= 0 {
+		return api.NewAPIError("burst must be positive when rateLimit is set", true)
+	}
+	for _, m := range append(append([]string(nil), req.AllowedMethods...), req.DeniedMethods...) {
+		if _, ok := requestHandlers[m]; !ok {
+			return api.NewAPIError("Unknown websocket method "+m, true)
+		}
+	}
+	k.RateLimit = req.RateLimit
+	k.RateWindow = rateWindow
+	k.Burst = req.Burst
+	k.MaxConcurrent = req.MaxConcurrent
+	k.MaxWebsocketConnections = req.MaxWebsocketConnections
+	k.MaxSubscriptions = req.MaxSubscriptions
+	k.AllowedMethods = req.AllowedMethods
+	k.DeniedMethods = req.DeniedMethods
+	return nil
+}
+
+// apiApiKey handles GET/POST/PUT/DELETE of a single API key at
+// /admin/api-keys/<ID>; a GET of the bare collection path returns all keys.
+// POST creates the key and returns its secret, which is not stored and cannot
+// be read again; PUT replaces the limits of an existing key.
+func (s *InternalServer) apiApiKey(r *http.Request, apiVersion int) (interface{}, error) {
+	id := urlPathSegment(r)
+	if id == "" && r.Method == http.MethodGet {
+		keys := s.is.GetApiKeys().List()
+		rv := make([]*apiKeyResponse, len(keys))
+		for i, k := range keys {
+			rv[i] = newApiKeyResponse(k)
+		}
+		return rv, nil
+	}
+	if !apiKeyIDRegex.MatchString(id) {
+		return nil, api.NewAPIError("Invalid API key id, expecting 1-64 characters A-Z, a-z, 0-9, _ or -", true)
+	}
+	s.apiKeysMux.Lock()
+	defer s.apiKeysMux.Unlock()
+	keys := s.is.GetApiKeys()
+	if keys == nil {
+		return nil, errors.New("API keys not initialized")
+	}
+	old := keys.ByID(id)
+	switch r.Method {
+	case http.MethodGet:
+		if old == nil {
+			return nil, api.NewAPIError("API key not found", true)
+		}
+		return newApiKeyResponse(old), nil
+	case http.MethodPost:
+		if old != nil {
+			return nil, api.NewAPIError("API key "+id+" already exists, use PUT to change its limits", true)
+		}
+		return s.createApiKey(keys, id, r)
+	case http.MethodPut:
+		if old == nil {
+			return nil, api.NewAPIError("API key not found", true)
+		}
+		return s.updateApiKey(keys, old, r)
+	case http.MethodDelete:
+		if old == nil {
+			return nil, api.NewAPIError("API key not found", true)
+		}
+		if err := s.apiKeys.DeleteApiKey(id); err != nil {
+			glog.Error("admin: deleting API key ", id, " failed: ", err)
+			return nil, api.NewAPIError("Cannot delete API key "+id+": "+err.Error(), false)
+		}
+		s.is.SetApiKeys(keys.Without(id))
+		glog.Info("admin: API key ", id, " deleted, client ", r.RemoteAddr)
+		return newApiKeyResponse(old), nil
+	}
+	return nil, api.NewAPIError("Unsupported method "+r.Method, true)
+}
+
+// createApiKey generates the secret of a new key, persists the key and only
+// then publishes it, like updateRuntimeSetting
+func (s *InternalServer) createApiKey(keys *common.ApiKeys, id string, r *http.Request) (interface{}, error) {
+	now := time.Now().UTC()
+	k := &common.ApiKey{ID: id, Created: now, Updated: now}
+	if err := parseApiKeyRequest(r, k); err != nil {
+		return nil, err
+	}
+	b := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	secret := hex.EncodeToString(b)
+	k.SecretHash = common.ApiKeySecretHash(secret)
+	if err := s.apiKeys.StoreApiKey(k); err != nil {
+		glog.Error("admin: storing API key ", id, " failed: ", err)
+		return nil, api.NewAPIError("Cannot store API key "+id+": "+err.Error(), false)
+	}
+	s.is.SetApiKeys(keys.With(k))
+	glog.Info("admin: API key ", id, " created, client ", r.RemoteAddr)
+	rv := newApiKeyResponse(k)
+	rv.Key = secret
+	return rv, nil
+}
+
+func (s *InternalServer) updateApiKey(keys *common.ApiKeys, old *common.ApiKey, r *http.Request) (interface{}, error) {
+	// the published keys are immutable, the update is made on a copy
+	k := *old
+	if err := parseApiKeyRequest(r, &k); err != nil {
+		return nil, err
+	}
+	k.Updated = time.Now().UTC()
+	if err := s.apiKeys.StoreApiKey(&k); err != nil {
+		glog.Error("admin: storing API key ", k.ID, " failed: ", err)
+		return nil, api.NewAPIError("Cannot store API key "+k.ID+": "+err.Error(), false)
+	}
+	s.is.SetApiKeys(keys.With(&k))
+	glog.Info("admin: API key ", k.ID, " updated, client ", r.RemoteAddr)
+	return newApiKeyResponse(&k), nil
+}
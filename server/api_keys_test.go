@@ -0,0 +1,307 @@
+//go:build unittest
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/common"
+)
+
+// memoryApiKeyStore keeps the API keys in memory, fail makes every write fail
+type memoryApiKeyStore struct {
+	keys map[string]common.ApiKey
+	fail bool
+}
+
+func (m *memoryApiKeyStore) StoreApiKey(k *common.ApiKey) error {
+	if m.fail {
+		return errors.New("store failed")
+	}
+	m.keys[k.ID] = *k
+	return nil
+}
+
+func (m *memoryApiKeyStore) DeleteApiKey(id string) error {
+	if m.fail {
+		return errors.New("delete failed")
+	}
+	delete(m.keys, id)
+	return nil
+}
+
+func doApiKeyRequest(t *testing.T, handler http.HandlerFunc, method, id, body string) (int, string) {
+	t.Helper()
+	var rdr io.Reader
+	if body != "" {
+		rdr = strings.NewReader(body)
+	}
+	r := httptest.NewRequest(method, "/admin/api-keys/"+id, rdr)
+	w := httptest.NewRecorder()
+	handler(w, r)
+	return w.Code, w.Body.String()
+}
+
+// The subtests of TestApiKeysAdminAPI share the store and the snapshot and depend on running in order.
+func TestApiKeysAdminAPI(t *testing.T) {
+	store := &memoryApiKeyStore{keys: make(map[string]common.ApiKey)}
+	is := &common.InternalState{}
+	if err := initApiKeys(nil, is); err != nil {
+		t.Fatal(err)
+	}
+	s := &InternalServer{
+		htmlTemplates: htmlTemplates[InternalTemplateData]{debug: true},
+		is:            is,
+		apiKeys:       store,
+	}
+	handler := s.jsonHandler(s.apiApiKey, 0)
+	var secret string
+
+	t.Run("POST creates the key", func(t *testing.T) {
+		code, body := doApiKeyRequest(t, handler, http.MethodPost, "partner", `{"rateLimit":600,"burst":100,"maxWebsocketConnections":2,"maxSubscriptions":10,"deniedMethods":["rpcCall"]}`)
+		if code != http.StatusOK {
+			t.Fatalf("got %d %s, want 200", code, body)
+		}
+		var resp apiKeyResponse
+		if err := json.Unmarshal([]byte(body), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Key == "" || resp.RateLimit != 600 || resp.RateWindow != "1m0s" || resp.Burst != 100 {
+			t.Fatalf("got %+v, want the secret and the limits", resp)
+		}
+		secret = resp.Key
+		k := is.GetApiKeys().BySecret(secret)
+		if k == nil || k.ID != "partner" || k.MethodAllowed("rpcCall") || !k.MethodAllowed("getInfo") {
+			t.Fatalf("published key %+v does not match", k)
+		}
+		if stored := store.keys["partner"]; stored.SecretHash != k.SecretHash || strings.Contains(body, stored.SecretHash) {
+			t.Fatalf("stored key %+v does not match or its hash was returned", stored)
+		}
+	})
+
+	t.Run("POST of an existing key is rejected", func(t *testing.T) {
+		if code, body := doApiKeyRequest(t, handler, http.MethodPost, "partner", `{}`); code != http.StatusBadRequest || !strings.Contains(body, "already exists") {
+			t.Fatalf("got %d %s, want 400 already exists", code, body)
+		}
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		for _, tt := range []struct{ method, id, body, want string }{
+			{http.MethodPost, "bad.id", `{}`, "Invalid API key id"},
+			{http.MethodPost, "other", `{"rateLimit":10}`, "burst must be positive"},
+			{http.MethodPost, "other", `{"rateWindow":"-1m"}`, "Invalid rateWindow"},
+			{http.MethodPost, "other", `{"maxSubscriptions":-1}`, "must not be negative"},
+			{http.MethodPost, "other", `{"allowedMethods":["noSuchMethod"]}`, "Unknown websocket method"},
+			{http.MethodPut, "other", `{}`, "not found"},
+			{http.MethodDelete, "other", ``, "not found"},
+		} {
+			if code, body := doApiKeyRequest(t, handler, tt.method, tt.id, tt.body); code != http.StatusBadRequest || !strings.Contains(body, tt.want) {
+				t.Fatalf("%s %s %s: got %d %s, want 400 %s", tt.method, tt.id, tt.body, code, body, tt.want)
+			}
+		}
+		if _, found := store.keys["other"]; found || is.GetApiKeys().ByID("other") != nil {
+			t.Fatal("invalid request created a key")
+		}
+	})
+
+	t.Run("PUT replaces the limits and keeps the secret", func(t *testing.T) {
+		code, body := doApiKeyRequest(t, handler, http.MethodPut, "partner", `{"rateLimit":60,"rateWindow":"10s","burst":5,"allowedMethods":["getInfo"]}`)
+		if code != http.StatusOK || strings.Contains(body, `"key"`) {
+			t.Fatalf("got %d %s, want 200 without the secret", code, body)
+		}
+		k := is.GetApiKeys().BySecret(secret)
+		if k == nil || k.RateLimit != 60 || k.RateWindow != 10*time.Second || k.MaxSubscriptions != 0 || k.MethodAllowed("getBlock") {
+			t.Fatalf("published key %+v does not match", k)
+		}
+	})
+
+	t.Run("failed store does not publish", func(t *testing.T) {
+		store.fail = true
+		defer func() { store.fail = false }()
+		if code, body := doApiKeyRequest(t, handler, http.MethodPost, "other", `{}`); code != http.StatusInternalServerError {
+			t.Fatalf("got %d %s, want 500", code, body)
+		}
+		if code, body := doApiKeyRequest(t, handler, http.MethodDelete, "partner", ``); code != http.StatusInternalServerError {
+			t.Fatalf("got %d %s, want 500", code, body)
+		}
+		if is.GetApiKeys().ByID("other") != nil || is.GetApiKeys().ByID("partner") == nil {
+			t.Fatal("failed write changed the published keys")
+		}
+	})
+
+	t.Run("GET lists the keys without secrets", func(t *testing.T) {
+		code, body := doApiKeyRequest(t, handler, http.MethodGet, "", "")
+		var keys []apiKeyResponse
+		if err := json.Unmarshal([]byte(body), &keys); err != nil || code != http.StatusOK {
+			t.Fatalf("got %d %s, %v", code, body, err)
+		}
+		if len(keys) != 1 || keys[0].ID != "partner" || keys[0].Key != "" {
+			t.Fatalf("got %+v, want the partner key without the secret", keys)
+		}
+	})
+
+	t.Run("DELETE revokes the key", func(t *testing.T) {
+		if code, body := doApiKeyRequest(t, handler, http.MethodDelete, "partner", ``); code != http.StatusOK {
+			t.Fatalf("got %d %s, want 200", code, body)
+		}
+		r := httptest.NewRequest(http.MethodGet, "/api/v2/", nil)
+		r.Header.Set(apiKeyHeader, secret)
+		if _, err := requestApiKey(is, r); err != errInvalidApiKey {
+			t.Fatalf("requestApiKey() err = %v, want %v", err, errInvalidApiKey)
+		}
+		if _, found := store.keys["partner"]; found {
+			t.Fatal("deleted key is still stored")
+		}
+	})
+}
+
+func newTestApiKeyState(keys ...*common.ApiKey) *common.InternalState {
+	is := &common.InternalState{}
+	is.SetApiKeys(common.NewApiKeys(keys))
+	return is
+}
+
+func TestRequestApiKey(t *testing.T) {
+	k := &common.ApiKey{ID: "partner", SecretHash: common.ApiKeySecretHash("s3cret")}
+	is := newTestApiKeyState(k)
+	tests := []struct {
+		name    string
+		url     string
+		header  string
+		want    *common.ApiKey
+		wantErr error
+	}{
+		{name: "no key", url: "/api/v2/"},
+		{name: "header", url: "/api/v2/", header: "s3cret", want: k},
+		{name: "query parameter", url: "/websocket?apikey=s3cret", want: k},
+		{name: "header wins", url: "/websocket?apikey=other", header: "s3cret", want: k},
+		{name: "unknown key", url: "/api/v2/", header: "other", wantErr: errInvalidApiKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.header != "" {
+				r.Header.Set(apiKeyHeader, tt.header)
+			}
+			got, err := requestApiKey(is, r)
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("requestApiKey() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRestUIRateLimiterApiKey(t *testing.T) {
+	k := &common.ApiKey{ID: "partner", SecretHash: common.ApiKeySecretHash("s3cret"), RateLimit: 60, RateWindow: time.Minute, Burst: 3}
+	limiter := newTestRestUIRateLimiter()
+	limiter.rateLimit = 1
+	limiter.is = newTestApiKeyState(k)
+	handler := limiter.wrapPublic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), "/")
+	do := func(key string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/v2/block/1", nil)
+		r.RemoteAddr = "198.51.100.7:1234"
+		if key != "" {
+			r.Header.Set(apiKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+	// the key has its own budget, independent of the budget of the IP address
+	if code := do(""); code != http.StatusNoContent {
+		t.Fatalf("request without a key = %d, want 204", code)
+	}
+	if code := do(""); code != http.StatusTooManyRequests {
+		t.Fatalf("second request without a key = %d, want 429", code)
+	}
+	for i := 0; i < 3; i++ {
+		if code := do("s3cret"); code != http.StatusNoContent {
+			t.Fatalf("request %d with the key = %d, want 204", i, code)
+		}
+	}
+	if code := do("s3cret"); code != http.StatusTooManyRequests {
+		t.Fatalf("request over the burst of the key = %d, want 429", code)
+	}
+	if code := do("wrong"); code != http.StatusUnauthorized {
+		t.Fatalf("request with an unknown key = %d, want 401", code)
+	}
+}
+
+func TestWebsocketApiKeyLimits(t *testing.T) {
+	k := &common.ApiKey{ID: "partner", SecretHash: common.ApiKeySecretHash("s3cret"), MaxWebsocketConnections: 2, MaxSubscriptions: 3, DeniedMethods: []string{"rpcCall"}}
+	s := &WebsocketServer{
+		is:               newTestApiKeyState(k),
+		websocketLimiter: newWebsocketConnectionLimiter(),
+	}
+
+	var limitKeys []string
+	for i := 0; i < 2; i++ {
+		limitKey, ok := s.acceptConnection("192.0.2.1", "192.0.2.1", k)
+		if !ok || limitKey != apiKeyLimitKey("partner") {
+			t.Fatalf("connection %d = %q, %v, want accepted by the key", i, limitKey, ok)
+		}
+		limitKeys = append(limitKeys, limitKey)
+	}
+	if _, ok := s.acceptConnection("192.0.2.2", "192.0.2.2", k); ok {
+		t.Fatal("connection over the limit of the key accepted")
+	}
+	// the clients without the key are limited by their address
+	if limitKey, ok := s.acceptConnection("192.0.2.1", "192.0.2.1", nil); !ok || limitKey != "192.0.2.1" {
+		t.Fatalf("connection without the key = %q, %v, want accepted by the address", limitKey, ok)
+	}
+	s.releaseConnection(limitKeys[0])
+	if _, ok := s.acceptConnection("192.0.2.2", "192.0.2.2", k); !ok {
+		t.Fatal("connection of the key not accepted after a release")
+	}
+
+	c1 := &websocketChannel{apiKeyID: "partner"}
+	c2 := &websocketChannel{apiKeyID: "partner"}
+	if err := s.checkApiKeyMethod(c1, "rpcCall"); err == nil {
+		t.Error("denied method allowed")
+	}
+	if err := s.checkApiKeyMethod(c1, "getInfo"); err != nil {
+		t.Errorf("checkApiKeyMethod(getInfo) = %v", err)
+	}
+	if err := s.checkApiKeyMethod(&websocketChannel{}, "rpcCall"); err != nil {
+		t.Errorf("checkApiKeyMethod() without the key = %v", err)
+	}
+	if err := s.checkApiKeyMethod(&websocketChannel{apiKeyID: "deleted"}, "getInfo"); err == nil {
+		t.Error("method of a deleted key allowed")
+	}
+
+	// the subscribed addresses of all connections of the key count to its limit
+	subscribe := func(c *websocketChannel, addrDescs ...string) error {
+		if err := s.checkApiKeySubscriptions(c, len(addrDescs)); err != nil {
+			return err
+		}
+		s.addApiKeySubscriptions(c, -len(c.addrDescs))
+		c.addrDescs = addrDescs
+		s.addApiKeySubscriptions(c, len(addrDescs))
+		return nil
+	}
+	if err := subscribe(c1, "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := subscribe(c2, "c", "d"); err == nil {
+		t.Fatal("subscriptions over the limit of the key accepted")
+	}
+	if err := subscribe(c2, "c"); err != nil {
+		t.Fatal(err)
+	}
+	// replacing the subscriptions of a connection frees its previous ones
+	if err := subscribe(c1, "e", "f"); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.apiKeySubscriptions["partner"]; got != 3 {
+		t.Fatalf("subscriptions of the key = %d, want 3", got)
+	}
+}
@@ -0,0 +1,485 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/golang/glog"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/common"
+)
+
+const (
+	// graphqlMaxBodyBytes limits the size of a GraphQL request
+	graphqlMaxBodyBytes    int64 = 64 * 1024
+	graphqlDefaultPageSize       = txsOnPage
+	graphqlMaxPageSize           = 100
+	// graphqlMaxQueryCost limits the estimated cost of a query and the number of loads done by its execution
+	graphqlMaxQueryCost = 2000
+	// graphqlCostPerToken is the cost of a query paid by one token of the REST rate limiter,
+	// the query itself is accepted by the limiter for one token, the rest of its cost is charged before the execution
+	graphqlCostPerToken = 100
+	// graphqlListSizeEstimate is the estimated size of the lists which are not paged (inputs, outputs, tokens)
+	graphqlListSizeEstimate = 10
+	// graphqlXpubCost is the cost of a load of an XPUB descriptor, it derives and loads at least gap addresses
+	graphqlXpubCost = 20
+	// graphqlLoadConcurrency limits the number of the keys of a batch loaded concurrently
+	graphqlLoadConcurrency = 8
+)
+
+// graphqlFieldCost is the cost of a field of the schema
+type graphqlFieldCost struct {
+	// cost of the resolution of the field
+	cost int
+	// itemCost is the cost of each item of the page of a paged field
+	itemCost int
+	// pagedByParent marks a list whose page is given by the arguments of the parent field
+	pagedByParent bool
+}
+
+// graphqlFieldCosts are the costs of the fields loading data in the units of one load of an index entry,
+// a transaction or one backend call, the other fields are free
+var graphqlFieldCosts = map[string]graphqlFieldCost{
+	"Query.address":              {cost: 1},
+	"Query.xpub":                 {cost: graphqlXpubCost},
+	"Query.tx":                   {cost: 1},
+	"Query.block":                {cost: 1, itemCost: 1},
+	"Query.contract":             {cost: 1},
+	"Query.fiatRates":            {cost: 1},
+	"Address.txids":              {cost: 1},
+	"Address.txs":                {cost: 1, itemCost: 1},
+	"Xpub.txids":                 {cost: graphqlXpubCost},
+	"Xpub.txs":                   {cost: graphqlXpubCost, itemCost: 1},
+	"Block.txs":                  {pagedByParent: true},
+	"Vin.prevTx":                 {cost: 1},
+	"Vin.address":                {cost: 1},
+	"Vout.address":               {cost: 1},
+	"Vout.spendingTx":            {cost: 2},
+	"Token.contractInfo":         {cost: 1},
+	"TokenTransfer.contractInfo": {cost: 1},
+}
+
+// graphqlQuery is the GraphQL request in the standard JSON format
+type graphqlQuery struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type graphqlRequestKey struct{}
+
+// graphqlRequest is the state of one GraphQL query shared by its resolvers
+type graphqlRequest struct {
+	api *api.Worker
+	// work counts the loads done by the query, the execution stops loading when it exceeds graphqlMaxQueryCost
+	work          atomic.Int64
+	txs           *graphqlLoader[string, *api.Tx]
+	accounts      *graphqlLoader[graphqlAccountKey, *api.Address]
+	spendingTxids *graphqlLoader[graphqlOutpoint, string]
+	contracts     *graphqlLoader[string, *bchain.ContractInfo]
+}
+
+// graphqlAccountKey is the key of the account loader, the tokens are loaded only if they are selected
+type graphqlAccountKey struct {
+	descriptor string
+	tokens     bool
+}
+
+type graphqlOutpoint struct {
+	txid string
+	n    int
+}
+
+func newGraphqlRequest(w *api.Worker) *graphqlRequest {
+	g := &graphqlRequest{api: w}
+	g.txs = newGraphqlLoader(g.loadTxs)
+	g.accounts = newGraphqlLoader(g.loadAccounts)
+	g.spendingTxids = newGraphqlLoader(g.loadSpendingTxids)
+	g.contracts = newGraphqlLoader(g.loadContracts)
+	return g
+}
+
+func graphqlRequestFromContext(ctx context.Context) *graphqlRequest {
+	return ctx.Value(graphqlRequestKey{}).(*graphqlRequest)
+}
+
+// addWork accounts the loads of the query
+func (g *graphqlRequest) addWork(n int) error {
+	if g.work.Add(int64(n)) > graphqlMaxQueryCost {
+		return api.NewAPIError(fmt.Sprintf("Query cost limit exceeded, max %d", graphqlMaxQueryCost), true)
+	}
+	return nil
+}
+
+// graphqlBatchError returns the error of a batch for all its keys
+func graphqlBatchError[V any](n int, err error) ([]V, []error) {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = err
+	}
+	return make([]V, n), errs
+}
+
+func (g *graphqlRequest) loadTxs(txids []string) ([]*api.Tx, []error) {
+	if err := g.addWork(len(txids)); err != nil {
+		return graphqlBatchError[*api.Tx](len(txids), err)
+	}
+	return loadConcurrently(txids, graphqlLoadConcurrency, func(txid string) (*api.Tx, error) {
+		tx, err := g.api.GetTransaction(txid, false, false, false)
+		return tx, graphqlResolveError("tx", err)
+	})
+}
+
+// loadAccounts loads the balances of the addresses by GetAccountsInfo, which reads the index of a batch by one MultiGet
+func (g *graphqlRequest) loadAccounts(keys []graphqlAccountKey) ([]*api.Address, []error) {
+	if err := g.addWork(len(keys)); err != nil {
+		return graphqlBatchError[*api.Address](len(keys), err)
+	}
+	values := make([]*api.Address, len(keys))
+	errs := make([]error, len(keys))
+	for _, tokens := range []bool{false, true} {
+		var indexes []int
+		var descriptors []string
+		for i := range keys {
+			if keys[i].tokens == tokens {
+				indexes = append(indexes, i)
+				descriptors = append(descriptors, keys[i].descriptor)
+			}
+		}
+		option := api.AccountDetailsBasic
+		if tokens {
+			option = api.AccountDetailsTokenBalances
+		}
+		for from := 0; from < len(descriptors); from += api.MaxAccountsInBatch {
+			to := min(from+api.MaxAccountsInBatch, len(descriptors))
+			filter := &api.AddressFilter{Vout: api.AddressFilterVoutOff, TokensToReturn: api.TokensToReturnNonzeroBalance}
+			ai, err := g.api.GetAccountsInfo(descriptors[from:to], option, filter, 0, "")
+			for j := from; j < to; j++ {
+				i := indexes[j]
+				if err != nil {
+					errs[i] = graphqlResolveError("address", err)
+				} else if e := &ai.Accounts[j-from]; e.Error != "" {
+					errs[i] = api.NewAPIError(e.Error, true)
+				} else {
+					values[i] = e.Account
+				}
+			}
+		}
+	}
+	return values, errs
+}
+
+func (g *graphqlRequest) loadSpendingTxids(outpoints []graphqlOutpoint) ([]string, []error) {
+	if err := g.addWork(len(outpoints)); err != nil {
+		return graphqlBatchError[string](len(outpoints), err)
+	}
+	return loadConcurrently(outpoints, graphqlLoadConcurrency, func(o graphqlOutpoint) (string, error) {
+		txid, err := g.api.GetSpendingTxid(o.txid, o.n)
+		return txid, graphqlResolveError("spendingTx", err)
+	})
+}
+
+func (g *graphqlRequest) loadContracts(contracts []string) ([]*bchain.ContractInfo, []error) {
+	if err := g.addWork(len(contracts)); err != nil {
+		return graphqlBatchError[*bchain.ContractInfo](len(contracts), err)
+	}
+	return loadConcurrently(contracts, graphqlLoadConcurrency, func(contract string) (*bchain.ContractInfo, error) {
+		ci, _, err := g.api.GetContractInfo(contract, bchain.UnknownTokenStandard)
+		return ci, graphqlResolveError("contract", err)
+	})
+}
+
+func (g *graphqlRequest) addressTxids(address string, page, pageSize int) ([]string, error) {
+	if err := g.addWork(1); err != nil {
+		return nil, err
+	}
+	a, err := g.api.GetAddress(address, page, pageSize, api.AccountDetailsTxidHistory, &api.AddressFilter{Vout: api.AddressFilterVoutOff}, "")
+	if err != nil {
+		return nil, graphqlResolveError("txids", err)
+	}
+	return a.Txids, nil
+}
+
+func (g *graphqlRequest) xpub(descriptor string, gap int, tokens bool) (*graphqlXpub, error) {
+	if err := g.addWork(graphqlXpubCost); err != nil {
+		return nil, err
+	}
+	option := api.AccountDetailsBasic
+	if tokens {
+		option = api.AccountDetailsTokenBalances
+	}
+	a, err := g.api.GetXpubAddress(descriptor, 0, 1, option, &api.AddressFilter{Vout: api.AddressFilterVoutOff, TokensToReturn: api.TokensToReturnUsed}, gap, "")
+	if err != nil {
+		return nil, graphqlResolveError("xpub", err)
+	}
+	return &graphqlXpub{account: a, descriptor: descriptor, gap: gap}, nil
+}
+
+func (g *graphqlRequest) xpubTxids(descriptor string, gap, page, pageSize int) ([]string, error) {
+	if err := g.addWork(graphqlXpubCost); err != nil {
+		return nil, err
+	}
+	a, err := g.api.GetXpubAddress(descriptor, page, pageSize, api.AccountDetailsTxidHistory, &api.AddressFilter{Vout: api.AddressFilterVoutOff}, gap, "")
+	if err != nil {
+		return nil, graphqlResolveError("txids", err)
+	}
+	return a.Txids, nil
+}
+
+func (g *graphqlRequest) block(id string, page, pageSize int) (*api.Block, error) {
+	if err := g.addWork(1 + pageSize); err != nil {
+		return nil, err
+	}
+	b, err := g.api.GetBlock(id, page, pageSize)
+	return b, graphqlResolveError("block", err)
+}
+
+func (g *graphqlRequest) fiatRates(currencies []string, timestamp int64, token string) (*api.FiatTicker, error) {
+	if err := g.addWork(1); err != nil {
+		return nil, err
+	}
+	if timestamp == 0 {
+		t, err := g.api.GetCurrentFiatRates(currencies, token)
+		return t, graphqlResolveError("fiatRates", err)
+	}
+	t, err := g.api.GetFiatRatesForTimestamps([]int64{timestamp}, currencies, token)
+	if err != nil {
+		return nil, graphqlResolveError("fiatRates", err)
+	}
+	if len(t.Tickers) == 0 {
+		return nil, nil
+	}
+	if t.Tickers[0].Error != "" {
+		return nil, api.NewAPIError(t.Tickers[0].Error, true)
+	}
+	return &t.Tickers[0], nil
+}
+
+// graphqlCost estimates the cost of the operation of the query. Each field loading data costs its cost
+// in graphqlFieldCosts, the costs of the fields selected by a list are multiplied by the size of the list,
+// which is the page size of the paged lists and graphqlListSizeEstimate for the others.
+func graphqlCost(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) int {
+	var op *ast.OperationDefinition
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, d := range doc.Definitions {
+		switch d := d.(type) {
+		case *ast.OperationDefinition:
+			if op == nil && (operationName == "" || (d.Name != nil && d.Name.Value == operationName)) {
+				op = d
+			}
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		}
+	}
+	if op == nil || op.Operation != ast.OperationTypeQuery {
+		return 0
+	}
+	c := &graphqlCostWalker{fragments: fragments, variables: variables}
+	return c.selectionSet(op.SelectionSet, schema.QueryType(), 0)
+}
+
+type graphqlCostWalker struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	// visited protects against the fragment cycles, they are rejected by the validation anyway
+	visited map[string]bool
+}
+
+func (c *graphqlCostWalker) selectionSet(set *ast.SelectionSet, t *graphql.Object, parentPageSize int) int {
+	if set == nil || t == nil {
+		return 0
+	}
+	cost := 0
+	for _, s := range set.Selections {
+		switch s := s.(type) {
+		case *ast.Field:
+			cost += c.field(s, t, parentPageSize)
+		case *ast.InlineFragment:
+			cost += c.selectionSet(s.SelectionSet, t, parentPageSize)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			if f, ok := c.fragments[name]; ok && !c.visited[name] {
+				if c.visited == nil {
+					c.visited = make(map[string]bool)
+				}
+				c.visited[name] = true
+				cost += c.selectionSet(f.SelectionSet, t, parentPageSize)
+				delete(c.visited, name)
+			}
+		}
+	}
+	return cost
+}
+
+func (c *graphqlCostWalker) field(f *ast.Field, t *graphql.Object, parentPageSize int) int {
+	def, ok := t.Fields()[f.Name.Value]
+	if !ok {
+		return 0
+	}
+	fc := graphqlFieldCosts[t.Name()+"."+f.Name.Value]
+	isList := false
+	fieldType := def.Type
+	for {
+		if nn, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nn.OfType
+		} else if l, ok := fieldType.(*graphql.List); ok {
+			isList = true
+			fieldType = l.OfType
+		} else {
+			break
+		}
+	}
+	pageSize := 0
+	for _, a := range def.Args {
+		if a.Name() == "pageSize" {
+			_, pageSize = graphqlPaging(c.intArgument(f, "page"), c.intArgument(f, "pageSize"))
+			break
+		}
+	}
+	size := 1
+	if isList {
+		switch {
+		case fc.pagedByParent:
+			size = parentPageSize
+		case pageSize > 0:
+			size = pageSize
+		default:
+			size = graphqlListSizeEstimate
+		}
+	}
+	cost := fc.cost + max(size, pageSize)*fc.itemCost
+	if o, ok := fieldType.(*graphql.Object); ok {
+		cost += size * c.selectionSet(f.SelectionSet, o, pageSize)
+	}
+	return cost
+}
+
+// intArgument returns the value of the integer argument given as a literal or a variable, 0 if it is missing
+func (c *graphqlCostWalker) intArgument(f *ast.Field, name string) int {
+	for _, a := range f.Arguments {
+		if a.Name == nil || a.Name.Value != name {
+			continue
+		}
+		switch v := a.Value.(type) {
+		case *ast.IntValue:
+			i, _ := strconv.Atoi(v.Value)
+			return i
+		case *ast.Variable:
+			switch vv := c.variables[v.Name.Value].(type) {
+			case float64:
+				return int(vv)
+			case int:
+				return vv
+			}
+		}
+	}
+	return 0
+}
+
+// readGraphqlQuery reads the query from the JSON body of a POST request or from the parameters of a GET request
+func readGraphqlQuery(r *http.Request) (*graphqlQuery, error) {
+	var q graphqlQuery
+	switch r.Method {
+	case http.MethodGet:
+		q.Query = r.URL.Query().Get("query")
+		q.OperationName = r.URL.Query().Get("operationName")
+		if v := r.URL.Query().Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &q.Variables); err != nil {
+				return nil, api.NewAPIError("Invalid variables", true)
+			}
+		}
+	case http.MethodPost:
+		if r.ContentLength > graphqlMaxBodyBytes {
+			return nil, api.NewAPIError("Request too large", true)
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, graphqlMaxBodyBytes+1))
+		if err != nil {
+			return nil, api.NewAPIError("Missing query", true)
+		}
+		if int64(len(body)) > graphqlMaxBodyBytes {
+			return nil, api.NewAPIError("Request too large", true)
+		}
+		if err := json.Unmarshal(body, &q); err != nil {
+			return nil, api.NewAPIError("Invalid request, expecting a JSON object with query", true)
+		}
+	default:
+		return nil, api.NewAPIError("Unsupported method, use GET or POST", true)
+	}
+	if q.Query == "" {
+		return nil, api.NewAPIError("Missing query", true)
+	}
+	return &q, nil
+}
+
+func writeGraphqlResult(w http.ResponseWriter, status int, result *graphql.Result) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", getContentSecurityPolicy())
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		glog.Warning("json encode ", err)
+	}
+}
+
+func writeGraphqlError(w http.ResponseWriter, status int, message string) {
+	writeGraphqlResult(w, status, &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(message)}})
+}
+
+// graphqlMaxCost returns the maximum cost of a query, the cost must be payable from the burst of the rate limiter
+func (s *PublicServer) graphqlMaxCost() int {
+	if s.restLimiter != nil && s.restLimiter.rateLimit > 0 {
+		return min(graphqlMaxQueryCost, s.restLimiter.burst*graphqlCostPerToken)
+	}
+	return graphqlMaxQueryCost
+}
+
+// apiGraphql executes a GraphQL query. The query is validated and its cost is estimated before the execution,
+// a query over the cost limit is rejected and the cost of the accepted query is charged to the rate limit of the client.
+func (s *PublicServer) apiGraphql(w http.ResponseWriter, r *http.Request) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-graphql"}).Inc()
+	q, err := readGraphqlQuery(r)
+	if err != nil {
+		writeGraphqlError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(q.Query), Name: "GraphQL request"})})
+	if err != nil {
+		writeGraphqlResult(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+	if vr := graphql.ValidateDocument(s.graphqlSchema, doc, nil); !vr.IsValid {
+		writeGraphqlResult(w, http.StatusBadRequest, &graphql.Result{Errors: vr.Errors})
+		return
+	}
+	cost := graphqlCost(s.graphqlSchema, doc, q.OperationName, q.Variables)
+	if maxCost := s.graphqlMaxCost(); cost > maxCost {
+		writeGraphqlError(w, http.StatusBadRequest, fmt.Sprintf("Query cost %d exceeds the limit %d", cost, maxCost))
+		return
+	}
+	if s.restLimiter != nil {
+		if retryAfter, ok := s.restLimiter.chargeRequest(r, math.Ceil(float64(cost)/graphqlCostPerToken)-1); !ok {
+			writeRestUIRateLimitResponse(w, retryAfter)
+			return
+		}
+	}
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        *s.graphqlSchema,
+		AST:           doc,
+		OperationName: q.OperationName,
+		Args:          q.Variables,
+		Context:       context.WithValue(r.Context(), graphqlRequestKey{}, newGraphqlRequest(s.worker(r))),
+	})
+	result.Extensions = map[string]interface{}{"cost": cost}
+	writeGraphqlResult(w, http.StatusOK, result)
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"runtime/debug"
+	"sync"
+
+	"github.com/golang/glog"
+)
+
+// graphqlLoader batches the loads of the keys requested by the resolvers of one level of a GraphQL query, DataLoader style.
+// A resolver registers its key and returns a thunk. The executor runs all resolvers of a level before it calls
+// the thunks of the level, therefore the first called thunk loads all pending keys by one call of the batch function.
+// The loaded values are cached for the rest of the query, every key is loaded at most once.
+type graphqlLoader[K comparable, V any] struct {
+	mux     sync.Mutex
+	batch   func(keys []K) ([]V, []error)
+	pending []K
+	results map[K]*graphqlLoaderResult[V]
+}
+
+type graphqlLoaderResult[V any] struct {
+	value V
+	err   error
+	done  bool
+}
+
+// newGraphqlLoader creates a loader, the batch function returns the values and errors in the order of the keys
+func newGraphqlLoader[K comparable, V any](batch func(keys []K) ([]V, []error)) *graphqlLoader[K, V] {
+	return &graphqlLoader[K, V]{
+		batch:   batch,
+		results: make(map[K]*graphqlLoaderResult[V]),
+	}
+}
+
+// load registers the key and returns the thunk returning its value
+func (l *graphqlLoader[K, V]) load(key K) func() (interface{}, error) {
+	l.mux.Lock()
+	if _, found := l.results[key]; !found {
+		l.results[key] = &graphqlLoaderResult[V]{}
+		l.pending = append(l.pending, key)
+	}
+	l.mux.Unlock()
+	return func() (interface{}, error) {
+		v, err := l.get(key)
+		return v, err
+	}
+}
+
+// get returns the value of the key, loading it together with all pending keys if necessary
+func (l *graphqlLoader[K, V]) get(key K) (V, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	r, found := l.results[key]
+	if !found {
+		r = &graphqlLoaderResult[V]{}
+		l.results[key] = r
+		l.pending = append(l.pending, key)
+	}
+	if !r.done {
+		l.dispatchLocked()
+	}
+	return r.value, r.err
+}
+
+func (l *graphqlLoader[K, V]) dispatchLocked() {
+	keys := l.pending
+	l.pending = nil
+	values, errs := l.batch(keys)
+	for i, k := range keys {
+		r := l.results[k]
+		if i < len(values) {
+			r.value = values[i]
+		}
+		if i < len(errs) {
+			r.err = errs[i]
+		}
+		r.done = true
+	}
+}
+
+// loadConcurrently is a batch function loading the keys one by one by the load function,
+// at most concurrency of them at the same time
+func loadConcurrently[K comparable, V any](keys []K, concurrency int, load func(key K) (V, error)) ([]V, []error) {
+	values := make([]V, len(keys))
+	errs := make([]error, len(keys))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					glog.Error("GraphQL loader recovered from panic: ", rec)
+					debug.PrintStack()
+					errs[i] = errors.New("Internal server error")
+				}
+				<-sem
+				wg.Done()
+			}()
+			values[i], errs[i] = load(keys[i])
+		}(i)
+	}
+	wg.Wait()
+	return values, errs
+}
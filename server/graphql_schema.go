@@ -0,0 +1,599 @@
+package server
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// graphqlXpub is the source of the Xpub type, the paged history needs the descriptor and the gap
+type graphqlXpub struct {
+	account    *api.Address
+	descriptor string
+	gap        int
+}
+
+// graphqlVout is the source of the Vout type, the spending transaction is looked up by the outpoint
+type graphqlVout struct {
+	vout *api.Vout
+	txid string
+}
+
+// graphqlRate is one rate of the FiatRate type
+type graphqlRate struct {
+	currency string
+	rate     float32
+}
+
+func graphqlField[S any](t graphql.Output, description string, get func(s S) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type:        t,
+		Description: description,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			s, ok := p.Source.(S)
+			if !ok {
+				return nil, nil
+			}
+			return get(s), nil
+		},
+	}
+}
+
+// graphqlAmount returns the amount in the base units as a decimal string, nil for a missing amount
+func graphqlAmount(a *api.Amount) interface{} {
+	if a == nil {
+		return nil
+	}
+	return a.String()
+}
+
+// graphqlResolveError hides the internal errors from the clients
+func graphqlResolveError(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if apiErr, ok := err.(*api.APIError); ok && apiErr.Public {
+		return err
+	}
+	glog.Warning("GraphQL ", field, ": ", err)
+	return errors.New("Internal server error")
+}
+
+// graphqlSelects returns true if the field is selected by the resolved field, directly or in a fragment
+func graphqlSelects(info graphql.ResolveInfo, name string) bool {
+	for _, f := range info.FieldASTs {
+		if graphqlSelectionSetSelects(f.SelectionSet, info.Fragments, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func graphqlSelectionSetSelects(set *ast.SelectionSet, fragments map[string]ast.Definition, name string) bool {
+	if set == nil {
+		return false
+	}
+	for _, s := range set.Selections {
+		switch s := s.(type) {
+		case *ast.Field:
+			if s.Name != nil && s.Name.Value == name {
+				return true
+			}
+		case *ast.InlineFragment:
+			if graphqlSelectionSetSelects(s.SelectionSet, fragments, name) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if fd, ok := fragments[s.Name.Value].(*ast.FragmentDefinition); ok && graphqlSelectionSetSelects(fd.SelectionSet, fragments, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// graphqlPaging returns the sanitized page and page size of a paged field,
+// the cost analysis uses the same values as the resolvers
+func graphqlPaging(page, pageSize int) (int, int) {
+	return sanitizeAccountPagingParams(page, pageSize, graphqlDefaultPageSize, graphqlMaxPageSize)
+}
+
+func graphqlPagingArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"page":     &graphql.ArgumentConfig{Type: graphql.Int, Description: "Page of the list, starting from 1."},
+		"pageSize": &graphql.ArgumentConfig{Type: graphql.Int, Description: "Number of items on the page."},
+	}
+}
+
+func graphqlPagingParams(p graphql.ResolveParams) (int, int) {
+	page, _ := p.Args["page"].(int)
+	pageSize, _ := p.Args["pageSize"].(int)
+	return graphqlPaging(page, pageSize)
+}
+
+// graphqlTxList returns the thunks of the transactions, the transactions of all lists of the level are loaded together
+func graphqlTxList(g *graphqlRequest, txids []string) []interface{} {
+	txs := make([]interface{}, len(txids))
+	for i, txid := range txids {
+		txs[i] = g.txs.load(txid)
+	}
+	return txs
+}
+
+// graphqlAccountFields are the fields shared by the Address and Xpub types
+func graphqlAccountFields[S any](account func(s S) *api.Address, tokenType *graphql.Object) graphql.Fields {
+	return graphql.Fields{
+		"balance":            graphqlField(graphql.String, "Confirmed balance in the base units.", func(s S) interface{} { return graphqlAmount(account(s).BalanceSat) }),
+		"totalReceived":      graphqlField(graphql.String, "Total received amount in the base units.", func(s S) interface{} { return graphqlAmount(account(s).TotalReceivedSat) }),
+		"totalSent":          graphqlField(graphql.String, "Total sent amount in the base units.", func(s S) interface{} { return graphqlAmount(account(s).TotalSentSat) }),
+		"unconfirmedBalance": graphqlField(graphql.String, "Balance of the unconfirmed transactions in the base units.", func(s S) interface{} { return graphqlAmount(account(s).UnconfirmedBalanceSat) }),
+		"unconfirmedTxs":     graphqlField(graphql.Int, "Number of unconfirmed transactions.", func(s S) interface{} { return account(s).UnconfirmedTxs }),
+		"txCount":            graphqlField(graphql.Int, "Number of transactions.", func(s S) interface{} { return account(s).Txs }),
+		"tokens": graphqlField(graphql.NewList(tokenType), "Tokens with a nonzero balance, for an XPUB the used derived addresses.", func(s S) interface{} {
+			a := account(s)
+			tokens := make([]*api.Token, len(a.Tokens))
+			for i := range a.Tokens {
+				tokens[i] = &a.Tokens[i]
+			}
+			return tokens
+		}),
+	}
+}
+
+// newGraphqlSchema creates the schema of the GraphQL interface. The resolvers get the state of the query
+// from the context, the schema itself is stateless and shared by all queries.
+func newGraphqlSchema() (graphql.Schema, error) {
+	// the types reference each other (address -> txs -> vout -> address), their fields are created lazily
+	var addressType, txType *graphql.Object
+
+	contractType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Contract",
+		Description: "Token contract.",
+		Fields: graphql.Fields{
+			"contract":          graphqlField(graphql.String, "Contract address.", func(c *bchain.ContractInfo) interface{} { return c.Contract }),
+			"standard":          graphqlField(graphql.String, "Token standard.", func(c *bchain.ContractInfo) interface{} { return string(c.Standard) }),
+			"name":              graphqlField(graphql.String, "Name of the token.", func(c *bchain.ContractInfo) interface{} { return c.Name }),
+			"symbol":            graphqlField(graphql.String, "Symbol of the token.", func(c *bchain.ContractInfo) interface{} { return c.Symbol }),
+			"decimals":          graphqlField(graphql.Int, "Number of decimals of the token.", func(c *bchain.ContractInfo) interface{} { return c.Decimals }),
+			"createdInBlock":    graphqlField(graphql.Int, "Height of the block in which the contract was created.", func(c *bchain.ContractInfo) interface{} { return c.CreatedInBlock }),
+			"destructedInBlock": graphqlField(graphql.Int, "Height of the block in which the contract was destructed.", func(c *bchain.ContractInfo) interface{} { return c.DestructedInBlock }),
+		},
+	})
+
+	// contractInfoField loads the contract by the batched contract loader
+	contractInfoField := func(contract func(s interface{}) string) *graphql.Field {
+		return &graphql.Field{
+			Type:        contractType,
+			Description: "Contract of the token.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				c := contract(p.Source)
+				if c == "" {
+					return nil, nil
+				}
+				return graphqlRequestFromContext(p.Context).contracts.load(c), nil
+			},
+		}
+	}
+
+	// addressField loads the address by the batched account loader
+	addressField := func(address func(s interface{}) string) *graphql.Field {
+		return &graphql.Field{
+			Type:        addressType,
+			Description: "The address, if there is exactly one.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				a := address(p.Source)
+				if a == "" {
+					return nil, nil
+				}
+				return graphqlRequestFromContext(p.Context).accounts.load(graphqlAccountKey{descriptor: a, tokens: graphqlSelects(p.Info, "tokens")}), nil
+			},
+		}
+	}
+
+	// txField loads the transaction by the batched transaction loader
+	txField := func(description string, txid func(s interface{}) string) *graphql.Field {
+		return &graphql.Field{
+			Type:        txType,
+			Description: description,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				t := txid(p.Source)
+				if t == "" {
+					return nil, nil
+				}
+				return graphqlRequestFromContext(p.Context).txs.load(t), nil
+			},
+		}
+	}
+
+	tokenType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Token",
+		Description: "Token of an address or a derived address of an XPUB.",
+		Fields: graphql.Fields{
+			"standard":      graphqlField(graphql.String, "Token standard, XPUBAddress for the derived addresses.", func(t *api.Token) interface{} { return string(t.Standard) }),
+			"name":          graphqlField(graphql.String, "Name of the token or the derived address.", func(t *api.Token) interface{} { return t.Name }),
+			"path":          graphqlField(graphql.String, "Derivation path of the derived address.", func(t *api.Token) interface{} { return t.Path }),
+			"contract":      graphqlField(graphql.String, "Contract address of the token.", func(t *api.Token) interface{} { return t.Contract }),
+			"transfers":     graphqlField(graphql.Int, "Number of transfers.", func(t *api.Token) interface{} { return t.Transfers }),
+			"symbol":        graphqlField(graphql.String, "Symbol of the token.", func(t *api.Token) interface{} { return t.Symbol }),
+			"decimals":      graphqlField(graphql.Int, "Number of decimals of the token.", func(t *api.Token) interface{} { return t.Decimals }),
+			"balance":       graphqlField(graphql.String, "Balance in the base units.", func(t *api.Token) interface{} { return graphqlAmount(t.BalanceSat) }),
+			"totalReceived": graphqlField(graphql.String, "Total received amount in the base units.", func(t *api.Token) interface{} { return graphqlAmount(t.TotalReceivedSat) }),
+			"totalSent":     graphqlField(graphql.String, "Total sent amount in the base units.", func(t *api.Token) interface{} { return graphqlAmount(t.TotalSentSat) }),
+			"contractInfo": contractInfoField(func(s interface{}) string {
+				if t, ok := s.(*api.Token); ok && t.Standard != bchain.XPUBAddressStandard {
+					return t.Contract
+				}
+				return ""
+			}),
+		},
+	})
+
+	tokenTransferType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TokenTransfer",
+		Description: "Token transfer of a transaction.",
+		Fields: graphql.Fields{
+			"standard": graphqlField(graphql.String, "Token standard.", func(t *api.TokenTransfer) interface{} { return string(t.Standard) }),
+			"from":     graphqlField(graphql.String, "Source address.", func(t *api.TokenTransfer) interface{} { return t.From }),
+			"to":       graphqlField(graphql.String, "Destination address.", func(t *api.TokenTransfer) interface{} { return t.To }),
+			"contract": graphqlField(graphql.String, "Contract address of the token.", func(t *api.TokenTransfer) interface{} { return t.Contract }),
+			"name":     graphqlField(graphql.String, "Name of the token.", func(t *api.TokenTransfer) interface{} { return t.Name }),
+			"symbol":   graphqlField(graphql.String, "Symbol of the token.", func(t *api.TokenTransfer) interface{} { return t.Symbol }),
+			"decimals": graphqlField(graphql.Int, "Number of decimals of the token.", func(t *api.TokenTransfer) interface{} { return t.Decimals }),
+			"value":    graphqlField(graphql.String, "Transferred amount in the base units.", func(t *api.TokenTransfer) interface{} { return graphqlAmount(t.Value) }),
+			"contractInfo": contractInfoField(func(s interface{}) string {
+				if t, ok := s.(*api.TokenTransfer); ok {
+					return t.Contract
+				}
+				return ""
+			}),
+		},
+	})
+
+	vinType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Vin",
+		Description: "Transaction input.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"n":         graphqlField(graphql.Int, "Index of the input.", func(v *api.Vin) interface{} { return v.N }),
+				"txid":      graphqlField(graphql.String, "Transaction of the spent output.", func(v *api.Vin) interface{} { return v.Txid }),
+				"vout":      graphqlField(graphql.Int, "Index of the spent output.", func(v *api.Vin) interface{} { return v.Vout }),
+				"addresses": graphqlField(graphql.NewList(graphql.String), "Addresses of the input.", func(v *api.Vin) interface{} { return v.Addresses }),
+				"isAddress": graphqlField(graphql.Boolean, "True if the addresses are standard addresses.", func(v *api.Vin) interface{} { return v.IsAddress }),
+				"value":     graphqlField(graphql.String, "Value in the base units.", func(v *api.Vin) interface{} { return graphqlAmount(v.ValueSat) }),
+				"hex":       graphqlField(graphql.String, "Script of the input.", func(v *api.Vin) interface{} { return v.Hex }),
+				"coinbase":  graphqlField(graphql.String, "Data of a coinbase input.", func(v *api.Vin) interface{} { return v.Coinbase }),
+				"prevTx": txField("Transaction of the spent output.", func(s interface{}) string {
+					if v, ok := s.(*api.Vin); ok {
+						return v.Txid
+					}
+					return ""
+				}),
+				"address": addressField(func(s interface{}) string {
+					if v, ok := s.(*api.Vin); ok && v.IsAddress && len(v.Addresses) == 1 {
+						return v.Addresses[0]
+					}
+					return ""
+				}),
+			}
+		}),
+	})
+
+	voutType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Vout",
+		Description: "Transaction output.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"n":           graphqlField(graphql.Int, "Index of the output.", func(v *graphqlVout) interface{} { return v.vout.N }),
+				"value":       graphqlField(graphql.String, "Value in the base units.", func(v *graphqlVout) interface{} { return graphqlAmount(v.vout.ValueSat) }),
+				"spent":       graphqlField(graphql.Boolean, "True if the output is spent.", func(v *graphqlVout) interface{} { return v.vout.Spent }),
+				"spentTxId":   graphqlField(graphql.String, "Spending transaction, if known from the index.", func(v *graphqlVout) interface{} { return v.vout.SpentTxID }),
+				"spentIndex":  graphqlField(graphql.Int, "Index of the spending input, if known from the index.", func(v *graphqlVout) interface{} { return v.vout.SpentIndex }),
+				"spentHeight": graphqlField(graphql.Int, "Height of the spending transaction, if known from the index.", func(v *graphqlVout) interface{} { return v.vout.SpentHeight }),
+				"addresses":   graphqlField(graphql.NewList(graphql.String), "Addresses of the output.", func(v *graphqlVout) interface{} { return v.vout.Addresses }),
+				"isAddress":   graphqlField(graphql.Boolean, "True if the addresses are standard addresses.", func(v *graphqlVout) interface{} { return v.vout.IsAddress }),
+				"hex":         graphqlField(graphql.String, "Script of the output.", func(v *graphqlVout) interface{} { return v.vout.Hex }),
+				"address": addressField(func(s interface{}) string {
+					if v, ok := s.(*graphqlVout); ok && v.vout.IsAddress && len(v.vout.Addresses) == 1 {
+						return v.vout.Addresses[0]
+					}
+					return ""
+				}),
+				"spendingTx": &graphql.Field{
+					Type:        txType,
+					Description: "Transaction spending the output.",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						v, ok := p.Source.(*graphqlVout)
+						if !ok {
+							return nil, nil
+						}
+						g := graphqlRequestFromContext(p.Context)
+						if v.vout.SpentTxID != "" {
+							return g.txs.load(v.vout.SpentTxID), nil
+						}
+						if !v.vout.Spent {
+							return nil, nil
+						}
+						// without the extended index the spending txids are looked up in a batch first,
+						// the spending transactions are then loaded one by one
+						spendingTxid := g.spendingTxids.load(graphqlOutpoint{txid: v.txid, n: v.vout.N})
+						return func() (interface{}, error) {
+							txid, err := spendingTxid()
+							if err != nil || txid == "" {
+								return nil, err
+							}
+							return g.txs.get(txid.(string))
+						}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	txType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Tx",
+		Description: "Transaction.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"txid":          graphqlField(graphql.String, "Transaction ID.", func(t *api.Tx) interface{} { return t.Txid }),
+				"version":       graphqlField(graphql.Int, "Version of the transaction.", func(t *api.Tx) interface{} { return t.Version }),
+				"lockTime":      graphqlField(graphql.Float, "Locktime of the transaction.", func(t *api.Tx) interface{} { return t.Locktime }),
+				"blockHash":     graphqlField(graphql.String, "Hash of the block of the transaction.", func(t *api.Tx) interface{} { return t.Blockhash }),
+				"blockHeight":   graphqlField(graphql.Int, "Height of the block of the transaction, -1 for unconfirmed transactions.", func(t *api.Tx) interface{} { return t.Blockheight }),
+				"blockTime":     graphqlField(graphql.Float, "Time of the block of the transaction.", func(t *api.Tx) interface{} { return t.Blocktime }),
+				"confirmations": graphqlField(graphql.Int, "Number of confirmations.", func(t *api.Tx) interface{} { return t.Confirmations }),
+				"size":          graphqlField(graphql.Int, "Size in bytes.", func(t *api.Tx) interface{} { return t.Size }),
+				"vsize":         graphqlField(graphql.Int, "Virtual size in bytes.", func(t *api.Tx) interface{} { return t.VSize }),
+				"value":         graphqlField(graphql.String, "Total value of the outputs in the base units.", func(t *api.Tx) interface{} { return graphqlAmount(t.ValueOutSat) }),
+				"valueIn":       graphqlField(graphql.String, "Total value of the inputs in the base units.", func(t *api.Tx) interface{} { return graphqlAmount(t.ValueInSat) }),
+				"fees":          graphqlField(graphql.String, "Fee in the base units.", func(t *api.Tx) interface{} { return graphqlAmount(t.FeesSat) }),
+				"hex":           graphqlField(graphql.String, "Raw transaction.", func(t *api.Tx) interface{} { return t.Hex }),
+				"rbf":           graphqlField(graphql.Boolean, "True if the transaction signals replace-by-fee.", func(t *api.Tx) interface{} { return t.Rbf }),
+				"replacedBy":    graphqlField(graphql.String, "Transaction which replaced this mempool transaction.", func(t *api.Tx) interface{} { return t.ReplacedBy }),
+				"vin": graphqlField(graphql.NewList(vinType), "Inputs of the transaction.", func(t *api.Tx) interface{} {
+					vin := make([]*api.Vin, len(t.Vin))
+					for i := range t.Vin {
+						vin[i] = &t.Vin[i]
+					}
+					return vin
+				}),
+				"vout": graphqlField(graphql.NewList(voutType), "Outputs of the transaction.", func(t *api.Tx) interface{} {
+					vout := make([]*graphqlVout, len(t.Vout))
+					for i := range t.Vout {
+						vout[i] = &graphqlVout{vout: &t.Vout[i], txid: t.Txid}
+					}
+					return vout
+				}),
+				"tokenTransfers": graphqlField(graphql.NewList(tokenTransferType), "Token transfers of the transaction.", func(t *api.Tx) interface{} {
+					tt := make([]*api.TokenTransfer, len(t.TokenTransfers))
+					for i := range t.TokenTransfers {
+						tt[i] = &t.TokenTransfers[i]
+					}
+					return tt
+				}),
+			}
+		}),
+	})
+
+	addressType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Address",
+		Description: "Address with its balance and transaction history.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphqlAccountFields(func(a *api.Address) *api.Address { return a }, tokenType)
+			fields["address"] = graphqlField(graphql.String, "The address.", func(a *api.Address) interface{} { return a.AddrStr })
+			fields["nonce"] = graphqlField(graphql.String, "Nonce of an Ethereum type address.", func(a *api.Address) interface{} { return a.Nonce })
+			fields["txids"] = &graphql.Field{
+				Type:        graphql.NewList(graphql.String),
+				Description: "Transaction IDs of a page of the history, from the newest.",
+				Args:        graphqlPagingArgs(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					a, ok := p.Source.(*api.Address)
+					if !ok {
+						return nil, nil
+					}
+					page, pageSize := graphqlPagingParams(p)
+					return graphqlRequestFromContext(p.Context).addressTxids(a.AddrStr, page, pageSize)
+				},
+			}
+			fields["txs"] = &graphql.Field{
+				Type:        graphql.NewList(txType),
+				Description: "Transactions of a page of the history, from the newest.",
+				Args:        graphqlPagingArgs(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					a, ok := p.Source.(*api.Address)
+					if !ok {
+						return nil, nil
+					}
+					g := graphqlRequestFromContext(p.Context)
+					page, pageSize := graphqlPagingParams(p)
+					txids, err := g.addressTxids(a.AddrStr, page, pageSize)
+					if err != nil {
+						return nil, err
+					}
+					return graphqlTxList(g, txids), nil
+				},
+			}
+			return fields
+		}),
+	})
+
+	xpubType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Xpub",
+		Description: "XPUB descriptor with its balance and transaction history.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphqlAccountFields(func(x *graphqlXpub) *api.Address { return x.account }, tokenType)
+			fields["descriptor"] = graphqlField(graphql.String, "The descriptor.", func(x *graphqlXpub) interface{} { return x.descriptor })
+			fields["usedTokens"] = graphqlField(graphql.Int, "Number of the used derived addresses.", func(x *graphqlXpub) interface{} { return x.account.UsedTokens })
+			fields["txids"] = &graphql.Field{
+				Type:        graphql.NewList(graphql.String),
+				Description: "Transaction IDs of a page of the history, from the newest.",
+				Args:        graphqlPagingArgs(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					x, ok := p.Source.(*graphqlXpub)
+					if !ok {
+						return nil, nil
+					}
+					page, pageSize := graphqlPagingParams(p)
+					return graphqlRequestFromContext(p.Context).xpubTxids(x.descriptor, x.gap, page, pageSize)
+				},
+			}
+			fields["txs"] = &graphql.Field{
+				Type:        graphql.NewList(txType),
+				Description: "Transactions of a page of the history, from the newest.",
+				Args:        graphqlPagingArgs(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					x, ok := p.Source.(*graphqlXpub)
+					if !ok {
+						return nil, nil
+					}
+					g := graphqlRequestFromContext(p.Context)
+					page, pageSize := graphqlPagingParams(p)
+					txids, err := g.xpubTxids(x.descriptor, x.gap, page, pageSize)
+					if err != nil {
+						return nil, err
+					}
+					return graphqlTxList(g, txids), nil
+				},
+			}
+			return fields
+		}),
+	})
+
+	blockType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Block",
+		Description: "Block with a page of its transactions.",
+		Fields: graphql.Fields{
+			"hash":              graphqlField(graphql.String, "Hash of the block.", func(b *api.Block) interface{} { return b.Hash }),
+			"previousBlockHash": graphqlField(graphql.String, "Hash of the previous block.", func(b *api.Block) interface{} { return b.Prev }),
+			"nextBlockHash":     graphqlField(graphql.String, "Hash of the next block.", func(b *api.Block) interface{} { return b.Next }),
+			"height":            graphqlField(graphql.Int, "Height of the block.", func(b *api.Block) interface{} { return b.Height }),
+			"confirmations":     graphqlField(graphql.Int, "Number of confirmations.", func(b *api.Block) interface{} { return b.Confirmations }),
+			"size":              graphqlField(graphql.Int, "Size in bytes.", func(b *api.Block) interface{} { return b.Size }),
+			"time":              graphqlField(graphql.Float, "Time of the block.", func(b *api.Block) interface{} { return b.Time }),
+			"txCount":           graphqlField(graphql.Int, "Number of transactions.", func(b *api.Block) interface{} { return b.TxCount }),
+			"page":              graphqlField(graphql.Int, "Page of the transactions.", func(b *api.Block) interface{} { return b.Page }),
+			"totalPages":        graphqlField(graphql.Int, "Number of the pages of the transactions.", func(b *api.Block) interface{} { return b.TotalPages }),
+			"txs":               graphqlField(graphql.NewList(txType), "Transactions of the page given by the block query.", func(b *api.Block) interface{} { return b.Transactions }),
+		},
+	})
+
+	rateType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Rate",
+		Description: "Exchange rate to a currency.",
+		Fields: graphql.Fields{
+			"currency": graphqlField(graphql.String, "Currency code.", func(r *graphqlRate) interface{} { return r.currency }),
+			"rate":     graphqlField(graphql.Float, "Exchange rate.", func(r *graphqlRate) interface{} { return r.rate }),
+		},
+	})
+
+	fiatRateType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FiatRate",
+		Description: "Exchange rates of the coin or of a token.",
+		Fields: graphql.Fields{
+			"ts": graphqlField(graphql.Float, "Time of the rates.", func(t *api.FiatTicker) interface{} { return t.Timestamp }),
+			"rates": graphqlField(graphql.NewList(rateType), "Rates sorted by the currency.", func(t *api.FiatTicker) interface{} {
+				rates := make([]*graphqlRate, 0, len(t.Rates))
+				for c, r := range t.Rates {
+					rates = append(rates, &graphqlRate{currency: c, rate: r})
+				}
+				sort.Slice(rates, func(i, j int) bool { return rates[i].currency < rates[j].currency })
+				return rates
+			}),
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"address": &graphql.Field{
+				Type:        addressType,
+				Description: "Address by its string.",
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					a, _ := p.Args["address"].(string)
+					return graphqlRequestFromContext(p.Context).accounts.load(graphqlAccountKey{descriptor: a, tokens: graphqlSelects(p.Info, "tokens")}), nil
+				},
+			},
+			"xpub": &graphql.Field{
+				Type:        xpubType,
+				Description: "XPUB descriptor of a Bitcoin type coin.",
+				Args: graphql.FieldConfigArgument{
+					"descriptor": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"gap":        &graphql.ArgumentConfig{Type: graphql.Int, Description: "Gap limit of the derivation."},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					descriptor, _ := p.Args["descriptor"].(string)
+					gap, _ := p.Args["gap"].(int)
+					return graphqlRequestFromContext(p.Context).xpub(descriptor, validateIntValue(gap, 0, 0, maxGapValue), graphqlSelects(p.Info, "tokens"))
+				},
+			},
+			"tx": &graphql.Field{
+				Type:        txType,
+				Description: "Transaction by its ID.",
+				Args: graphql.FieldConfigArgument{
+					"txid": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					txid, _ := p.Args["txid"].(string)
+					return graphqlRequestFromContext(p.Context).txs.load(txid), nil
+				},
+			},
+			"block": &graphql.Field{
+				Type:        blockType,
+				Description: "Block by its hash or height with a page of its transactions.",
+				Args: graphql.FieldConfigArgument{
+					"id":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "Hash or height of the block."},
+					"page":     &graphql.ArgumentConfig{Type: graphql.Int, Description: "Page of the transactions, starting from 1."},
+					"pageSize": &graphql.ArgumentConfig{Type: graphql.Int, Description: "Number of transactions on the page."},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, _ := p.Args["id"].(string)
+					page, pageSize := graphqlPagingParams(p)
+					return graphqlRequestFromContext(p.Context).block(id, page, pageSize)
+				},
+			},
+			"contract": &graphql.Field{
+				Type:        contractType,
+				Description: "Token contract by its address.",
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					c, _ := p.Args["address"].(string)
+					return graphqlRequestFromContext(p.Context).contracts.load(c), nil
+				},
+			},
+			"fiatRates": &graphql.Field{
+				Type:        fiatRateType,
+				Description: "Current exchange rates or the rates at the given time.",
+				Args: graphql.FieldConfigArgument{
+					"currencies": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Currencies of the rates, all currencies if not specified."},
+					"timestamp":  &graphql.ArgumentConfig{Type: graphql.Float, Description: "Unix time of the rates, the current rates if not specified."},
+					"token":      &graphql.ArgumentConfig{Type: graphql.String, Description: "Token contract, the rates of the coin if not specified."},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var currencies []string
+					if list, ok := p.Args["currencies"].([]interface{}); ok {
+						for _, c := range list {
+							if s, ok := c.(string); ok {
+								currencies = append(currencies, strings.ToLower(s))
+							}
+						}
+					}
+					timestamp, _ := p.Args["timestamp"].(float64)
+					token, _ := p.Args["token"].(string)
+					return graphqlRequestFromContext(p.Context).fiatRates(currencies, int64(timestamp), token)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
@@ -0,0 +1,184 @@
+//go:build unittest
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/trezor/blockbook/api"
+)
+
+func TestGraphqlLoader(t *testing.T) {
+	var batches [][]string
+	l := newGraphqlLoader(func(keys []string) ([]int, []error) {
+		batches = append(batches, append([]string(nil), keys...))
+		values := make([]int, len(keys))
+		for i, k := range keys {
+			values[i] = len(k)
+		}
+		return values, nil
+	})
+	a := l.load("a")
+	bb := l.load("bb")
+	a2 := l.load("a")
+	for _, tt := range []struct {
+		thunk func() (interface{}, error)
+		want  int
+	}{{bb, 2}, {a, 1}, {a2, 1}} {
+		v, err := tt.thunk()
+		if err != nil || v != tt.want {
+			t.Errorf("thunk() = %v, %v, want %v", v, err, tt.want)
+		}
+	}
+	// a key registered after the dispatch is loaded by the next batch, a loaded key is not loaded again
+	ccc := l.load("ccc")
+	if v, _ := l.load("bb")(); v != 2 {
+		t.Errorf("cached value = %v, want 2", v)
+	}
+	if v, _ := ccc(); v != 3 {
+		t.Errorf("thunk() = %v, want 3", v)
+	}
+	want := [][]string{{"a", "bb"}, {"ccc"}}
+	if !reflect.DeepEqual(batches, want) {
+		t.Errorf("batches = %v, want %v", batches, want)
+	}
+}
+
+func TestGraphqlCost(t *testing.T) {
+	schema, err := newGraphqlSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		variables map[string]interface{}
+		want      int
+	}{
+		{
+			name:  "scalars",
+			query: `{ address(address: "a") { balance txCount } }`,
+			want:  1,
+		},
+		{
+			name:  "paged txs with nested loads",
+			query: `{ address(address: "a") { txs(pageSize: 10) { txid vout { value spendingTx { txid } } } } }`,
+			// address 1 + txs 1 + 10 transactions + 10 * 10 outputs * spendingTx 2
+			want: 212,
+		},
+		{
+			name:      "variables and fragments",
+			query:     `query Q($size: Int) { tx(txid: "t") { ...Inputs } address(address: "a") { txids(pageSize: $size) } } fragment Inputs on Tx { vin { address { balance } prevTx { txid } } }`,
+			variables: map[string]interface{}{"size": float64(50)},
+			// tx 1 + 10 inputs * (address 1 + prevTx 1) + address 1 + txids 1
+			want: 23,
+		},
+		{
+			name:  "block paged by the block query",
+			query: `{ block(id: "1", pageSize: 5) { hash txs { vin { prevTx { txid } } } } }`,
+			// block 1 + 5 transactions + 5 * 10 inputs * prevTx 1
+			want: 56,
+		},
+		{
+			name:  "page size limited",
+			query: `{ xpub(descriptor: "x") { txs(pageSize: 100000) { txid } } }`,
+			want:  graphqlXpubCost + graphqlXpubCost + graphqlMaxPageSize,
+		},
+		{
+			name:      "selected operation",
+			query:     `query A { tx(txid: "t") { txid } } query B { xpub(descriptor: "x") { balance } }`,
+			operation: "B",
+			want:      graphqlXpubCost,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if vr := graphql.ValidateDocument(&schema, doc, nil); !vr.IsValid {
+				t.Fatalf("invalid query: %v", vr.Errors)
+			}
+			if got := graphqlCost(&schema, doc, tt.operation, tt.variables); got != tt.want {
+				t.Errorf("graphqlCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// graphqlPrefill stores the value to the loader as if it was already loaded
+func graphqlPrefill[K comparable, V any](l *graphqlLoader[K, V], key K, value V) {
+	l.results[key] = &graphqlLoaderResult[V]{value: value, done: true}
+}
+
+func TestGraphqlExecuteNested(t *testing.T) {
+	schema, err := newGraphqlSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGraphqlRequest(nil)
+	graphqlPrefill(g.txs, "t1", &api.Tx{
+		Txid:        "t1",
+		Blockheight: 100,
+		ValueOutSat: (*api.Amount)(big.NewInt(3000)),
+		Vout: []api.Vout{
+			{N: 0, ValueSat: (*api.Amount)(big.NewInt(1000)), Spent: true, SpentTxID: "t2", Addresses: []string{"addr1"}, IsAddress: true},
+			{N: 1, ValueSat: (*api.Amount)(big.NewInt(2000)), Addresses: []string{"addr2"}, IsAddress: true},
+		},
+	})
+	graphqlPrefill(g.txs, "t2", &api.Tx{Txid: "t2", Blockheight: 101, Vin: []api.Vin{{Txid: "t1", Vout: 0, Addresses: []string{"addr1"}, IsAddress: true}}})
+	graphqlPrefill(g.accounts, graphqlAccountKey{descriptor: "addr1"}, &api.Address{AddrStr: "addr1", BalanceSat: (*api.Amount)(big.NewInt(0)), Txs: 2})
+	graphqlPrefill(g.accounts, graphqlAccountKey{descriptor: "addr2"}, &api.Address{AddrStr: "addr2", BalanceSat: (*api.Amount)(big.NewInt(2000)), Txs: 1})
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ tx(txid: "t1") { txid value vout { n value address { address balance } spendingTx { txid vin { prevTx { txid } } } } } }`,
+		Context:       context.WithValue(context.Background(), graphqlRequestKey{}, g),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatal(result.Errors)
+	}
+	got, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"tx":{"txid":"t1","value":"3000","vout":[` +
+		`{"address":{"address":"addr1","balance":"0"},"n":0,"spendingTx":{"txid":"t2","vin":[{"prevTx":{"txid":"t1"}}]},"value":"1000"},` +
+		`{"address":{"address":"addr2","balance":"2000"},"n":1,"spendingTx":null,"value":"2000"}]}}`
+	if string(got) != want {
+		t.Errorf("result = %s, want %s", got, want)
+	}
+}
+
+func TestGraphqlSelects(t *testing.T) {
+	schema, err := newGraphqlSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGraphqlRequest(nil)
+	graphqlPrefill(g.accounts, graphqlAccountKey{descriptor: "a", tokens: true}, &api.Address{AddrStr: "a", Tokens: api.Tokens{{Name: "T", Contract: "c"}}})
+	// the tokens are selected in a fragment, the address must be loaded with the tokens
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ address(address: "a") { address ...T } } fragment T on Address { tokens { name contract } }`,
+		Context:       context.WithValue(context.Background(), graphqlRequestKey{}, g),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatal(result.Errors)
+	}
+	got, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"address":{"address":"a","tokens":[{"contract":"c","name":"T"}]}}`; string(got) != want {
+		t.Errorf("result = %s, want %s", got, want)
+	}
+}
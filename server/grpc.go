@@ -0,0 +1,347 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/common"
+	"github.com/trezor/blockbook/grpcapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer implements the gRPC interface. The unary methods call the same methods of the websocket server
+// as the websocket requests, the subscriptions are channels of the websocket server like the Server-Sent Events streams.
+type grpcServer struct {
+	grpcapi.UnimplementedBlockbookServer
+	ws          *WebsocketServer
+	restLimiter *restUIRateLimiter
+	metrics     *common.Metrics
+}
+
+// ConnectGrpcInterface starts the gRPC server listening on the binding [address]:port,
+// it uses the same certificate files as the public http server
+func (s *PublicServer) ConnectGrpcInterface(binding string) error {
+	g := &grpcServer{
+		ws:          s.websocket,
+		restLimiter: s.restLimiter,
+		metrics:     s.metrics,
+	}
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(g.unaryInterceptor)}
+	if s.certFiles != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.certFiles+".crt", s.certFiles+".key")
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	gs := grpc.NewServer(opts...)
+	grpcapi.RegisterBlockbookServer(gs, g)
+	l, err := net.Listen("tcp", binding)
+	if err != nil {
+		return err
+	}
+	s.grpc = gs
+	go func() {
+		glog.Info("public server: starting gRPC to listen on ", l.Addr())
+		if err := gs.Serve(l); err != nil {
+			glog.Error("gRPC server: ", err)
+		}
+	}()
+	return nil
+}
+
+// shutdownGrpc stops the gRPC server, the streams are ended first, because the graceful stop waits for them
+func (s *PublicServer) shutdownGrpc(ctx context.Context) {
+	s.websocket.closeStreams()
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpc.Stop()
+	}
+}
+
+// grpcRequest creates the http request carrying the peer and the metadata of the call, so that the client
+// is resolved by the same rules as the clients of the http server, including the headers of the trusted proxies
+func grpcRequest(ctx context.Context) *http.Request {
+	r := &http.Request{Header: make(http.Header)}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.RemoteAddr = p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, v := range md {
+			r.Header[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+	return r
+}
+
+// grpcError converts the error to the status of the call, the public api errors are the errors of the arguments
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if apiErr, ok := err.(*api.APIError); ok && apiErr.Public {
+		return status.Error(codes.InvalidArgument, apiErr.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// unaryInterceptor applies the limits of the REST API to the unary calls, charging them by the cost of the websocket method of the same name
+func (g *grpcServer) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := path.Base(info.FullMethod)
+	if g.metrics != nil {
+		g.metrics.ExplorerViews.With(common.Labels{"action": "grpc-" + method}).Inc()
+	}
+	r := grpcRequest(ctx)
+	apiKey, err := requestApiKey(g.ws.is, r)
+	if err != nil {
+		observeApiKeyRejection(g.metrics, "", apiKeyRejectInvalidKey)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	// the unary methods are named like the websocket methods, only capitalized
+	wsMethod := strings.ToLower(method[:1]) + method[1:]
+	if apiKey != nil && !apiKey.MethodAllowed(wsMethod) {
+		observeApiKeyRejection(g.metrics, apiKey.ID, apiKeyRejectMethodNotAllowed)
+		return nil, status.Error(codes.PermissionDenied, "Method "+method+" not allowed by the API key")
+	}
+	if g.restLimiter != nil {
+		work := &api.RequestWork{}
+		release, retryAfter, ok := g.restLimiter.acceptRequest(r, wsMethod, websocketMethodCost(wsMethod), work)
+		if !ok {
+			if retryAfter > 0 {
+				seconds := max(int64(math.Ceil(retryAfter.Seconds())), 1)
+				_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(seconds, 10)))
+			}
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		defer release()
+		ctx = withRequestWork(ctx, work)
+	}
+	res, err := handler(ctx, req)
+	if err != nil {
+		if apiErr, ok := err.(*api.APIError); !ok || !apiErr.Public {
+			glog.Error("gRPC ", method, ": ", errors.ErrorStack(err))
+		}
+	}
+	return res, grpcError(err)
+}
+
+func (g *grpcServer) GetInfo(ctx context.Context, req *grpcapi.GetInfoRequest) (*grpcapi.Info, error) {
+	i, err := g.ws.getInfo()
+	if err != nil {
+		return nil, err
+	}
+	return grpcInfo(i), nil
+}
+
+func (g *grpcServer) GetAccountInfo(ctx context.Context, req *grpcapi.GetAccountInfoRequest) (*grpcapi.Address, error) {
+	a, err := g.ws.getAccountInfo(g.ws.worker(requestWork(ctx)), &WsAccountInfoReq{
+		Descriptor:        req.Descriptor_,
+		Details:           req.Details,
+		Tokens:            req.Tokens,
+		Protocols:         req.Protocols,
+		PageSize:          int(req.PageSize),
+		Page:              int(req.Page),
+		Cursor:            req.Cursor,
+		FromHeight:        int(req.FromHeight),
+		ToHeight:          int(req.ToHeight),
+		ContractFilter:    req.ContractFilter,
+		SecondaryCurrency: req.SecondaryCurrency,
+		Gap:               int(req.Gap),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grpcAddress(a), nil
+}
+
+func (g *grpcServer) GetAccountUtxo(ctx context.Context, req *grpcapi.GetAccountUtxoRequest) (*grpcapi.Utxos, error) {
+	utxos, err := g.ws.getAccountUtxo(g.ws.worker(requestWork(ctx)), req.Descriptor_)
+	if err != nil {
+		return nil, err
+	}
+	return grpcUtxos(utxos), nil
+}
+
+func (g *grpcServer) GetTransaction(ctx context.Context, req *grpcapi.GetTransactionRequest) (*grpcapi.Tx, error) {
+	tx, err := g.ws.getTransaction(g.ws.worker(requestWork(ctx)), req.Txid, false)
+	if err != nil {
+		return nil, err
+	}
+	return grpcTx(tx), nil
+}
+
+func (g *grpcServer) GetBlock(ctx context.Context, req *grpcapi.GetBlockRequest) (*grpcapi.Block, error) {
+	if !g.ws.is.ExtendedIndex {
+		return nil, status.Error(codes.Unimplemented, "Not supported")
+	}
+	b, err := g.ws.getBlock(g.ws.worker(requestWork(ctx)), req.Id, int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	return grpcBlock(b), nil
+}
+
+func (g *grpcServer) GetBalanceHistory(ctx context.Context, req *grpcapi.GetBalanceHistoryRequest) (*grpcapi.BalanceHistories, error) {
+	bhs, err := g.ws.getBalanceHistory(g.ws.worker(requestWork(ctx)), &WsBalanceHistoryReq{
+		Descriptor: req.Descriptor_,
+		From:       req.From,
+		To:         req.To,
+		Currencies: req.Currencies,
+		Gap:        int(req.Gap),
+		GroupBy:    req.GroupBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grpcBalanceHistories(bhs), nil
+}
+
+func (g *grpcServer) EstimateFee(ctx context.Context, req *grpcapi.EstimateFeeRequest) (*grpcapi.EstimateFeeResponse, error) {
+	r := WsEstimateFeeReq{Blocks: make([]int, len(req.Blocks))}
+	for i, b := range req.Blocks {
+		r.Blocks[i] = int(b)
+	}
+	if req.Specific != nil {
+		r.Specific = req.Specific.AsMap()
+	}
+	params, err := json.Marshal(&r)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := g.ws.estimateFee(params)
+	if err != nil {
+		return nil, err
+	}
+	return grpcFeeEstimates(fees.([]WsEstimateFeeRes)), nil
+}
+
+func (g *grpcServer) SendTransaction(ctx context.Context, req *grpcapi.SendTransactionRequest) (*grpcapi.SendTransactionResponse, error) {
+	res, err := g.ws.sendTransaction(req.Hex, req.DisableAlternativeRpc)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcapi.SendTransactionResponse{Txid: res.Result}, nil
+}
+
+// runStream subscribes a new channel of the websocket server by the request and sends its notifications to the stream
+// until the client cancels the call or the channel is closed. The stream counts to the websocket connections of the client.
+func (g *grpcServer) runStream(stream grpc.ServerStream, method string, params interface{}, send func(m *WsRes) error) error {
+	if g.metrics != nil {
+		g.metrics.ExplorerViews.With(common.Labels{"action": "grpc-" + method}).Inc()
+	}
+	b, err := json.Marshal(params)
+	if err != nil {
+		return grpcError(err)
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	c, err := g.ws.openStream(grpcRequest(ctx), cancel)
+	if err != nil {
+		if err == errStreamConnectionLimit {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		} else if err == errInvalidApiKey {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if err := g.ws.subscribeStream(c, &WsReq{ID: method, Method: method, Params: b}); err != nil {
+		g.ws.closeChannel(c, "bad_request")
+		return grpcError(err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			g.ws.closeChannel(c, "client_closed")
+			if stream.Context().Err() == nil {
+				// the stream was ended by the server
+				return status.Error(codes.Unavailable, "Stream closed")
+			}
+			return stream.Context().Err()
+		case m, ok := <-c.out:
+			if !ok {
+				return status.Error(codes.Unavailable, "Stream closed")
+			}
+			err := send(m)
+			c.finalize(m)
+			if err != nil {
+				glog.Error("Error sending gRPC notification to ", c.id, ", ", err)
+				g.ws.closeChannel(c, "write_error")
+				return err
+			}
+		}
+	}
+}
+
+func (g *grpcServer) SubscribeNewBlock(req *grpcapi.SubscribeNewBlockRequest, stream grpc.ServerStreamingServer[grpcapi.NewBlockNotification]) error {
+	return g.runStream(stream, "subscribeNewBlock", &WsSubscribeNewBlockReq{
+		SinceSequence: req.SinceSequence,
+		SinceHeight:   req.SinceHeight,
+	}, func(m *WsRes) error {
+		n, err := grpcNewBlockNotification(m)
+		if err != nil {
+			return err
+		}
+		return stream.Send(n)
+	})
+}
+
+func (g *grpcServer) SubscribeNewTransaction(req *grpcapi.SubscribeNewTransactionRequest, stream grpc.ServerStreamingServer[grpcapi.NewTransactionNotification]) error {
+	return g.runStream(stream, "subscribeNewTransaction", struct{}{}, func(m *WsRes) error {
+		n, err := grpcNewTransactionNotification(m)
+		if err != nil {
+			return err
+		}
+		return stream.Send(n)
+	})
+}
+
+func (g *grpcServer) SubscribeAddresses(req *grpcapi.SubscribeAddressesRequest, stream grpc.ServerStreamingServer[grpcapi.AddressNotification]) error {
+	return g.runStream(stream, "subscribeAddresses", &WsSubscribeAddressesReq{
+		Addresses:     req.Addresses,
+		NewBlockTxs:   req.NewBlockTxs,
+		SinceSequence: req.SinceSequence,
+		SinceHeight:   req.SinceHeight,
+	}, func(m *WsRes) error {
+		n, err := grpcAddressNotification(m)
+		if err != nil {
+			return err
+		}
+		return stream.Send(n)
+	})
+}
+
+func (g *grpcServer) SubscribeFiatRates(req *grpcapi.SubscribeFiatRatesRequest, stream grpc.ServerStreamingServer[grpcapi.FiatRatesNotification]) error {
+	return g.runStream(stream, "subscribeFiatRates", &WsSubscribeFiatRatesReq{
+		Currency: req.Currency,
+		Tokens:   req.Tokens,
+	}, func(m *WsRes) error {
+		n, err := grpcFiatRatesNotification(m)
+		if err != nil {
+			return err
+		}
+		return stream.Send(n)
+	})
+}
@@ -0,0 +1,187 @@
+//go:build unittest
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/grpcapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGrpcAddressNotification(t *testing.T) {
+	tx := &api.Tx{
+		Txid:        "abc",
+		Blockheight: 100,
+		ValueOutSat: (*api.Amount)(big.NewInt(12345)),
+		Vin:         []api.Vin{{Txid: "def", N: 0, Addresses: []string{"addr1"}, IsAddress: true, ValueSat: (*api.Amount)(big.NewInt(20000))}},
+		Vout:        []api.Vout{{N: 0, Addresses: []string{"addr2"}, IsAddress: true, ValueSat: (*api.Amount)(big.NewInt(12345))}},
+	}
+	data := struct {
+		Address string  `json:"address"`
+		Tx      *api.Tx `json:"tx"`
+	}{Address: "addr2", Tx: tx}
+	want := &grpcapi.AddressNotification{
+		Seq:     5,
+		Address: "addr2",
+		Notification: &grpcapi.AddressNotification_Tx{Tx: &grpcapi.Tx{
+			Txid:        "abc",
+			BlockHeight: 100,
+			Value:       "12345",
+			Vin:         []*grpcapi.Vin{{Txid: "def", Addresses: []string{"addr1"}, IsAddress: true, Value: "20000"}},
+			Vout:        []*grpcapi.Vout{{Addresses: []string{"addr2"}, IsAddress: true, Value: "12345"}},
+		}},
+	}
+	got, err := grpcAddressNotification(&WsRes{Data: &data, Seq: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("grpcAddressNotification() = %v, want %v", got, want)
+	}
+	// the replayed notifications come from the journal as JSON
+	b, err := json.Marshal(&data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = grpcAddressNotification(&WsRes{Data: json.RawMessage(b), Seq: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("grpcAddressNotification() of replayed notification = %v, want %v", got, want)
+	}
+
+	got, err = grpcAddressNotification(&WsRes{Data: &WsAddressTxReplaced{Address: "addr2", TxReplaced: &WsTxReplaced{Txid: "abc", ReplacedBy: "ghi"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = &grpcapi.AddressNotification{
+		Address:      "addr2",
+		Notification: &grpcapi.AddressNotification_TxReplaced{TxReplaced: &grpcapi.TxReplaced{Txid: "abc", ReplacedBy: "ghi"}},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("grpcAddressNotification() of replaced tx = %v, want %v", got, want)
+	}
+
+	got, err = grpcAddressNotification(&WsRes{Data: &WsSubscriptionRes{Subscribed: true, Sequence: 9, Replayed: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = &grpcapi.AddressNotification{
+		Notification: &grpcapi.AddressNotification_Subscribed{Subscribed: &grpcapi.Subscribed{Sequence: 9, Replayed: 2}},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("grpcAddressNotification() of subscription = %v, want %v", got, want)
+	}
+}
+
+func TestGrpcNewBlockNotification(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *WsRes
+		want *grpcapi.NewBlockNotification
+	}{
+		{
+			name: "new block",
+			m:    &WsRes{Data: &WsNewBlock{Height: 10, Hash: "h10"}, Seq: 3},
+			want: &grpcapi.NewBlockNotification{
+				Seq:          3,
+				Notification: &grpcapi.NewBlockNotification_NewBlock{NewBlock: &grpcapi.NewBlock{Height: 10, Hash: "h10"}},
+			},
+		},
+		{
+			name: "replayed disconnection",
+			m:    &WsRes{Data: json.RawMessage(`{"blockDisconnected":[{"height":10,"hash":"h10","txids":["a","b"]}]}`), Seq: 4},
+			want: &grpcapi.NewBlockNotification{
+				Seq: 4,
+				Notification: &grpcapi.NewBlockNotification_BlockDisconnected{BlockDisconnected: &grpcapi.BlockDisconnected{
+					Blocks: []*grpcapi.DisconnectedBlock{{Height: 10, Hash: "h10", Txids: []string{"a", "b"}}},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grpcNewBlockNotification(tt.m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("grpcNewBlockNotification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrpcFiatRatesNotification(t *testing.T) {
+	data := struct {
+		Rates      interface{}        `json:"rates"`
+		TokenRates map[string]float32 `json:"tokenRates,omitempty"`
+	}{
+		Rates:      map[string]float32{"usd": 2},
+		TokenRates: map[string]float32{"0x1": 0.5},
+	}
+	got, err := grpcFiatRatesNotification(&WsRes{Data: &data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &grpcapi.FiatRatesNotification{
+		Notification: &grpcapi.FiatRatesNotification_Rates{Rates: &grpcapi.FiatRates{
+			Rates:      map[string]float32{"usd": 2},
+			TokenRates: map[string]float32{"0x1": 0.5},
+		}},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("grpcFiatRatesNotification() = %v, want %v", got, want)
+	}
+}
+
+func TestGrpcRequest(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-real-ip", "1.2.3.4"))
+	r := grpcRequest(ctx)
+	if r.RemoteAddr != "10.0.0.1:5000" {
+		t.Errorf("RemoteAddr = %q, want 10.0.0.1:5000", r.RemoteAddr)
+	}
+	if got := r.Header.Get("X-Real-Ip"); got != "1.2.3.4" {
+		t.Errorf("X-Real-Ip = %q, want 1.2.3.4", got)
+	}
+	ip, _, fromHeader := resolveClientIP(r, nil, nil, false)
+	if ip != "1.2.3.4" || !fromHeader {
+		t.Errorf("resolveClientIP() = %q, %v, want 1.2.3.4 from the header", ip, fromHeader)
+	}
+}
+
+func TestGrpcError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "public api error", err: api.NewAPIError("Invalid address", true), want: codes.InvalidArgument},
+		{name: "private api error", err: api.NewAPIError("db error", false), want: codes.Internal},
+		{name: "other error", err: errors.New("backend error"), want: codes.Internal},
+		{name: "status", err: status.Error(codes.Unimplemented, "Not supported"), want: codes.Unimplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(grpcError(tt.err)); got != tt.want {
+				t.Errorf("grpcError() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if grpcError(nil) != nil {
+		t.Error("grpcError(nil) is not nil")
+	}
+}
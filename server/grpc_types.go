@@ -0,0 +1,464 @@
+package server
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/grpcapi"
+)
+
+// grpcNotification is the union of the notifications of the subscriptions. The notifications are converted to the gRPC
+// messages through their JSON, because some of them are anonymous structs and the replayed ones come from the journal.
+type grpcNotification struct {
+	// new block subscription
+	Height            uint32                `json:"height"`
+	Hash              string                `json:"hash"`
+	BlockDisconnected []WsDisconnectedBlock `json:"blockDisconnected"`
+	// address subscription
+	Address       string                  `json:"address"`
+	Tx            *api.Tx                 `json:"tx"`
+	TxReplaced    *WsTxReplaced           `json:"txReplaced"`
+	DoubleSpend   *WsDoubleSpend          `json:"doubleSpend"`
+	TxDropped     *WsTxDropped            `json:"txDropped"`
+	TxUnconfirmed *WsTxUnconfirmed        `json:"txUnconfirmed"`
+	Withdrawal    *api.EthereumWithdrawal `json:"withdrawal"`
+	// fiat rates subscription
+	Rates      map[string]float32 `json:"rates"`
+	TokenRates map[string]float32 `json:"tokenRates"`
+}
+
+// unmarshalNotification decodes the data of the notification to v
+func unmarshalNotification(data interface{}, v interface{}) error {
+	b, ok := data.(json.RawMessage)
+	if !ok {
+		var err error
+		if b, err = json.Marshal(data); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(b, v)
+}
+
+func bigIntString(i *big.Int) string {
+	if i == nil {
+		return ""
+	}
+	return i.String()
+}
+
+func grpcSubscribed(r *WsSubscriptionRes) *grpcapi.Subscribed {
+	return &grpcapi.Subscribed{
+		Sequence: r.Sequence,
+		Replayed: int32(r.Replayed),
+		Resync:   r.Resync,
+	}
+}
+
+func grpcInfo(i *WsInfoRes) *grpcapi.Info {
+	return &grpcapi.Info{
+		Name:       i.Name,
+		Shortcut:   i.Shortcut,
+		Network:    i.Network,
+		Decimals:   int32(i.Decimals),
+		Version:    i.Version,
+		BestHeight: int64(i.BestHeight),
+		BestHash:   i.BestHash,
+		Block0Hash: i.Block0Hash,
+		Testnet:    i.Testnet,
+		Backend: &grpcapi.BackendInfo{
+			Version:          i.Backend.Version,
+			Subversion:       i.Backend.Subversion,
+			ConsensusVersion: i.Backend.ConsensusVersion,
+		},
+	}
+}
+
+func grpcMultiTokenValues(values []api.MultiTokenValue) []*grpcapi.MultiTokenValue {
+	if len(values) == 0 {
+		return nil
+	}
+	r := make([]*grpcapi.MultiTokenValue, len(values))
+	for i := range values {
+		r[i] = &grpcapi.MultiTokenValue{
+			Id:    values[i].Id.String(),
+			Value: values[i].Value.String(),
+		}
+	}
+	return r
+}
+
+func grpcTx(tx *api.Tx) *grpcapi.Tx {
+	if tx == nil {
+		return nil
+	}
+	r := &grpcapi.Tx{
+		Txid:                   tx.Txid,
+		Version:                tx.Version,
+		LockTime:               tx.Locktime,
+		Vin:                    make([]*grpcapi.Vin, len(tx.Vin)),
+		Vout:                   make([]*grpcapi.Vout, len(tx.Vout)),
+		BlockHash:              tx.Blockhash,
+		BlockHeight:            int32(tx.Blockheight),
+		Confirmations:          tx.Confirmations,
+		ConfirmationEtaBlocks:  tx.ConfirmationETABlocks,
+		ConfirmationEtaSeconds: tx.ConfirmationETASeconds,
+		BlockTime:              tx.Blocktime,
+		Size:                   int32(tx.Size),
+		Vsize:                  int32(tx.VSize),
+		Value:                  tx.ValueOutSat.String(),
+		ValueIn:                tx.ValueInSat.String(),
+		Fees:                   tx.FeesSat.String(),
+		Hex:                    tx.Hex,
+		Rbf:                    tx.Rbf,
+		ReplacedBy:             tx.ReplacedBy,
+		Replaces:               tx.Replaces,
+		CoinSpecificData:       tx.CoinSpecificData,
+	}
+	for i := range tx.Vin {
+		vin := &tx.Vin[i]
+		r.Vin[i] = &grpcapi.Vin{
+			Txid:      vin.Txid,
+			Vout:      vin.Vout,
+			Sequence:  vin.Sequence,
+			N:         int32(vin.N),
+			Addresses: vin.Addresses,
+			IsAddress: vin.IsAddress,
+			IsOwn:     vin.IsOwn,
+			Value:     vin.ValueSat.String(),
+			Hex:       vin.Hex,
+			Asm:       vin.Asm,
+			Coinbase:  vin.Coinbase,
+		}
+	}
+	for i := range tx.Vout {
+		vout := &tx.Vout[i]
+		r.Vout[i] = &grpcapi.Vout{
+			Value:       vout.ValueSat.String(),
+			N:           int32(vout.N),
+			Spent:       vout.Spent,
+			SpentTxId:   vout.SpentTxID,
+			SpentIndex:  int32(vout.SpentIndex),
+			SpentHeight: int32(vout.SpentHeight),
+			Hex:         vout.Hex,
+			Asm:         vout.Asm,
+			Addresses:   vout.Addresses,
+			IsAddress:   vout.IsAddress,
+			IsOwn:       vout.IsOwn,
+			Type:        vout.Type,
+		}
+	}
+	if len(tx.TokenTransfers) > 0 {
+		r.TokenTransfers = make([]*grpcapi.TokenTransfer, len(tx.TokenTransfers))
+		for i := range tx.TokenTransfers {
+			tt := &tx.TokenTransfers[i]
+			r.TokenTransfers[i] = &grpcapi.TokenTransfer{
+				Standard:         string(tt.Standard),
+				From:             tt.From,
+				To:               tt.To,
+				Contract:         tt.Contract,
+				Name:             tt.Name,
+				Symbol:           tt.Symbol,
+				Decimals:         int32(tt.Decimals),
+				Value:            tt.Value.String(),
+				MultiTokenValues: grpcMultiTokenValues(tt.MultiTokenValues),
+			}
+		}
+	}
+	if es := tx.EthereumSpecific; es != nil {
+		r.EthereumSpecific = &grpcapi.EthereumSpecific{
+			Status:               int32(es.Status),
+			Error:                es.Error,
+			Nonce:                es.Nonce,
+			GasLimit:             bigIntString(es.GasLimit),
+			GasUsed:              bigIntString(es.GasUsed),
+			GasPrice:             es.GasPrice.String(),
+			EffectiveGasPrice:    es.EffectiveGasPrice.String(),
+			MaxPriorityFeePerGas: es.MaxPriorityFeePerGas.String(),
+			MaxFeePerGas:         es.MaxFeePerGas.String(),
+			BaseFeePerGas:        es.BaseFeePerGas.String(),
+			CreatedContract:      es.CreatedContract,
+			Data:                 es.Data,
+		}
+	}
+	return r
+}
+
+func grpcTxs(txs []*api.Tx) []*grpcapi.Tx {
+	if len(txs) == 0 {
+		return nil
+	}
+	r := make([]*grpcapi.Tx, len(txs))
+	for i := range txs {
+		r[i] = grpcTx(txs[i])
+	}
+	return r
+}
+
+func grpcAddress(a *api.Address) *grpcapi.Address {
+	r := &grpcapi.Address{
+		Page:                 int32(a.Page),
+		TotalPages:           int32(a.TotalPages),
+		ItemsOnPage:          int32(a.ItemsOnPage),
+		Address:              a.AddrStr,
+		Balance:              a.BalanceSat.String(),
+		TotalReceived:        a.TotalReceivedSat.String(),
+		TotalSent:            a.TotalSentSat.String(),
+		UnconfirmedBalance:   a.UnconfirmedBalanceSat.String(),
+		UnconfirmedTxs:       int32(a.UnconfirmedTxs),
+		Txs:                  int32(a.Txs),
+		NonTokenTxs:          int32(a.NonTokenTxs),
+		InternalTxs:          int32(a.InternalTxs),
+		Transactions:         grpcTxs(a.Transactions),
+		Txids:                a.Txids,
+		NextCursor:           a.NextCursor,
+		PrevCursor:           a.PrevCursor,
+		Nonce:                a.Nonce,
+		UsedTokens:           int32(a.UsedTokens),
+		SecondaryValue:       a.SecondaryValue,
+		TokensBaseValue:      a.TokensBaseValue,
+		TokensSecondaryValue: a.TokensSecondaryValue,
+		TotalBaseValue:       a.TotalBaseValue,
+		TotalSecondaryValue:  a.TotalSecondaryValue,
+	}
+	if len(a.Tokens) > 0 {
+		r.Tokens = make([]*grpcapi.Token, len(a.Tokens))
+		for i := range a.Tokens {
+			t := &a.Tokens[i]
+			var ids []string
+			if len(t.Ids) > 0 {
+				ids = make([]string, len(t.Ids))
+				for j := range t.Ids {
+					ids[j] = t.Ids[j].String()
+				}
+			}
+			r.Tokens[i] = &grpcapi.Token{
+				Standard:         string(t.Standard),
+				Name:             t.Name,
+				Path:             t.Path,
+				Contract:         t.Contract,
+				Transfers:        int32(t.Transfers),
+				Symbol:           t.Symbol,
+				Decimals:         int32(t.Decimals),
+				Balance:          t.BalanceSat.String(),
+				BaseValue:        t.BaseValue,
+				SecondaryValue:   t.SecondaryValue,
+				Ids:              ids,
+				MultiTokenValues: grpcMultiTokenValues(t.MultiTokenValues),
+				TotalReceived:    t.TotalReceivedSat.String(),
+				TotalSent:        t.TotalSentSat.String(),
+			}
+		}
+	}
+	return r
+}
+
+func grpcUtxos(utxos api.Utxos) *grpcapi.Utxos {
+	r := &grpcapi.Utxos{Utxos: make([]*grpcapi.Utxo, len(utxos))}
+	for i := range utxos {
+		u := &utxos[i]
+		r.Utxos[i] = &grpcapi.Utxo{
+			Txid:          u.Txid,
+			Vout:          u.Vout,
+			Value:         u.AmountSat.String(),
+			Height:        int32(u.Height),
+			Confirmations: int32(u.Confirmations),
+			Address:       u.Address,
+			Path:          u.Path,
+			LockTime:      u.Locktime,
+			Coinbase:      u.Coinbase,
+		}
+	}
+	return r
+}
+
+func grpcBlock(b *api.Block) *grpcapi.Block {
+	return &grpcapi.Block{
+		Page:              int32(b.Page),
+		TotalPages:        int32(b.TotalPages),
+		ItemsOnPage:       int32(b.ItemsOnPage),
+		Hash:              b.Hash,
+		PreviousBlockHash: b.Prev,
+		NextBlockHash:     b.Next,
+		Height:            b.Height,
+		Confirmations:     int32(b.Confirmations),
+		Size:              int32(b.Size),
+		Time:              b.Time,
+		Version:           string(b.Version),
+		MerkleRoot:        b.MerkleRoot,
+		Nonce:             b.Nonce,
+		Bits:              b.Bits,
+		Difficulty:        b.Difficulty,
+		Txids:             b.Txids,
+		TxCount:           int32(b.TxCount),
+		Txs:               grpcTxs(b.Transactions),
+	}
+}
+
+func grpcBalanceHistories(bhs api.BalanceHistories) *grpcapi.BalanceHistories {
+	r := &grpcapi.BalanceHistories{Histories: make([]*grpcapi.BalanceHistory, len(bhs))}
+	for i := range bhs {
+		bh := &bhs[i]
+		r.Histories[i] = &grpcapi.BalanceHistory{
+			Time:        bh.Time,
+			Txs:         bh.Txs,
+			Received:    bh.ReceivedSat.String(),
+			Sent:        bh.SentSat.String(),
+			SentToSelf:  bh.SentToSelfSat.String(),
+			Rates:       bh.FiatRates,
+			Txid:        bh.Txid,
+			Withdrawals: bh.Withdrawals,
+		}
+	}
+	return r
+}
+
+func grpcEip1559Fee(f *api.Eip1559Fee) *grpcapi.Eip1559Fee {
+	if f == nil {
+		return nil
+	}
+	return &grpcapi.Eip1559Fee{
+		MaxFeePerGas:         f.MaxFeePerGas.String(),
+		MaxPriorityFeePerGas: f.MaxPriorityFeePerGas.String(),
+		MinWaitTimeEstimate:  int32(f.MinWaitTimeEstimate),
+		MaxWaitTimeEstimate:  int32(f.MaxWaitTimeEstimate),
+	}
+}
+
+func grpcFeeEstimates(fees []WsEstimateFeeRes) *grpcapi.EstimateFeeResponse {
+	r := &grpcapi.EstimateFeeResponse{Estimates: make([]*grpcapi.FeeEstimate, len(fees))}
+	for i := range fees {
+		f := &fees[i]
+		e := &grpcapi.FeeEstimate{
+			FeePerTx:   f.FeePerTx,
+			FeePerUnit: f.FeePerUnit,
+			FeeLimit:   f.FeeLimit,
+			L1Fee:      f.L1Fee,
+		}
+		if f.Eip1559 != nil {
+			e.Eip1559 = &grpcapi.Eip1559Fees{
+				BaseFeePerGas:     f.Eip1559.BaseFeePerGas.String(),
+				Low:               grpcEip1559Fee(f.Eip1559.Low),
+				Medium:            grpcEip1559Fee(f.Eip1559.Medium),
+				High:              grpcEip1559Fee(f.Eip1559.High),
+				Instant:           grpcEip1559Fee(f.Eip1559.Instant),
+				NetworkCongestion: f.Eip1559.NetworkCongestion,
+			}
+		}
+		if f.Confidence != nil {
+			e.Confidence = &grpcapi.FeeConfidence{
+				Blocks: int32(f.Confidence.Blocks),
+				Low:    f.Confidence.Low,
+				Medium: f.Confidence.Medium,
+				High:   f.Confidence.High,
+			}
+		}
+		r.Estimates[i] = e
+	}
+	return r
+}
+
+// grpcNewBlockNotification converts the notification of the new block subscription
+func grpcNewBlockNotification(m *WsRes) (*grpcapi.NewBlockNotification, error) {
+	r := &grpcapi.NewBlockNotification{Seq: m.Seq}
+	if s, ok := m.Data.(*WsSubscriptionRes); ok {
+		r.Notification = &grpcapi.NewBlockNotification_Subscribed{Subscribed: grpcSubscribed(s)}
+		return r, nil
+	}
+	var n grpcNotification
+	if err := unmarshalNotification(m.Data, &n); err != nil {
+		return nil, err
+	}
+	if n.BlockDisconnected != nil {
+		d := &grpcapi.BlockDisconnected{Blocks: make([]*grpcapi.DisconnectedBlock, len(n.BlockDisconnected))}
+		for i := range n.BlockDisconnected {
+			b := &n.BlockDisconnected[i]
+			d.Blocks[i] = &grpcapi.DisconnectedBlock{Height: b.Height, Hash: b.Hash, Txids: b.Txids}
+		}
+		r.Notification = &grpcapi.NewBlockNotification_BlockDisconnected{BlockDisconnected: d}
+	} else {
+		r.Notification = &grpcapi.NewBlockNotification_NewBlock{NewBlock: &grpcapi.NewBlock{Height: n.Height, Hash: n.Hash}}
+	}
+	return r, nil
+}
+
+// grpcNewTransactionNotification converts the notification of the new transaction subscription
+func grpcNewTransactionNotification(m *WsRes) (*grpcapi.NewTransactionNotification, error) {
+	r := &grpcapi.NewTransactionNotification{}
+	if s, ok := m.Data.(*WsSubscriptionRes); ok {
+		r.Notification = &grpcapi.NewTransactionNotification_Subscribed{Subscribed: grpcSubscribed(s)}
+		return r, nil
+	}
+	var tx api.Tx
+	if err := unmarshalNotification(m.Data, &tx); err != nil {
+		return nil, err
+	}
+	r.Notification = &grpcapi.NewTransactionNotification_Tx{Tx: grpcTx(&tx)}
+	return r, nil
+}
+
+// grpcAddressNotification converts the notification of the address subscription
+func grpcAddressNotification(m *WsRes) (*grpcapi.AddressNotification, error) {
+	r := &grpcapi.AddressNotification{Seq: m.Seq}
+	if s, ok := m.Data.(*WsSubscriptionRes); ok {
+		r.Notification = &grpcapi.AddressNotification_Subscribed{Subscribed: grpcSubscribed(s)}
+		return r, nil
+	}
+	var n grpcNotification
+	if err := unmarshalNotification(m.Data, &n); err != nil {
+		return nil, err
+	}
+	r.Address = n.Address
+	switch {
+	case n.Tx != nil:
+		r.Notification = &grpcapi.AddressNotification_Tx{Tx: grpcTx(n.Tx)}
+	case n.TxReplaced != nil:
+		r.Notification = &grpcapi.AddressNotification_TxReplaced{TxReplaced: &grpcapi.TxReplaced{
+			Txid:       n.TxReplaced.Txid,
+			ReplacedBy: n.TxReplaced.ReplacedBy,
+		}}
+	case n.DoubleSpend != nil:
+		r.Notification = &grpcapi.AddressNotification_DoubleSpend{DoubleSpend: &grpcapi.DoubleSpend{
+			Txid:            n.DoubleSpend.Txid,
+			ConflictingTxid: n.DoubleSpend.ConflictingTxid,
+		}}
+	case n.TxDropped != nil:
+		r.Notification = &grpcapi.AddressNotification_TxDropped{TxDropped: &grpcapi.TxDropped{
+			Txid:            n.TxDropped.Txid,
+			ConflictingTxid: n.TxDropped.ConflictingTxid,
+		}}
+	case n.TxUnconfirmed != nil:
+		r.Notification = &grpcapi.AddressNotification_TxUnconfirmed{TxUnconfirmed: &grpcapi.TxUnconfirmed{
+			Txid:   n.TxUnconfirmed.Txid,
+			Height: n.TxUnconfirmed.Height,
+			Hash:   n.TxUnconfirmed.Hash,
+		}}
+	case n.Withdrawal != nil:
+		w := n.Withdrawal
+		r.Notification = &grpcapi.AddressNotification_Withdrawal{Withdrawal: &grpcapi.Withdrawal{
+			Index:          w.Index,
+			ValidatorIndex: w.ValidatorIndex,
+			Address:        w.Address,
+			Amount:         w.AmountSat.String(),
+			BlockHeight:    w.BlockHeight,
+			BlockTime:      w.BlockTime,
+			Confirmations:  w.Confirmations,
+		}}
+	}
+	return r, nil
+}
+
+// grpcFiatRatesNotification converts the notification of the fiat rates subscription
+func grpcFiatRatesNotification(m *WsRes) (*grpcapi.FiatRatesNotification, error) {
+	r := &grpcapi.FiatRatesNotification{}
+	if s, ok := m.Data.(*WsSubscriptionRes); ok {
+		r.Notification = &grpcapi.FiatRatesNotification_Subscribed{Subscribed: grpcSubscribed(s)}
+		return r, nil
+	}
+	var n grpcNotification
+	if err := unmarshalNotification(m.Data, &n); err != nil {
+		return nil, err
+	}
+	r.Notification = &grpcapi.FiatRatesNotification_Rates{Rates: &grpcapi.FiatRates{Rates: n.Rates, TokenRates: n.TokenRates}}
+	return r, nil
+}
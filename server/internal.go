@@ -24,6 +24,7 @@ import (
 	"github.com/trezor/blockbook/common"
 	"github.com/trezor/blockbook/db"
 	"github.com/trezor/blockbook/fiat"
+	"github.com/trezor/blockbook/fourbyte"
 )
 
 // InternalServer is handle to internal http server
@@ -51,6 +52,10 @@ type InternalServer struct {
 	// persistence backend of those writes (the RocksDB in production).
 	runtimeSettingsMux sync.Mutex
 	runtimeSettings    runtimeSettingStore
+	// apiKeysMux serializes the API key writes the same way, apiKeys is their
+	// persistence backend
+	apiKeysMux sync.Mutex
+	apiKeys    apiKeyStore
 }
 
 // NewInternalServer creates new internal http interface to blockbook and returns its handle
@@ -85,6 +90,7 @@ func NewInternalServer(binding, certFiles string, db *db.RocksDB, chain bchain.B
 		is:              is,
 		api:             api,
 		runtimeSettings: db,
+		apiKeys:         db,
 	}
 	s.htmlTemplates.newTemplateData = s.newTemplateData
 	s.htmlTemplates.newTemplateDataWithError = s.newTemplateDataWithError
@@ -124,10 +130,15 @@ func NewInternalServer(binding, certFiles string, db *db.RocksDB, chain bchain.B
 	}
 	serveMux.HandleFunc(adminPath+"/runtime-settings", s.requireAdminAuth(s.htmlTemplateHandler(s.runtimeSettingsPage)))
 	serveMux.HandleFunc(adminPath+"/runtime-settings/", s.requireAdminAuth(s.jsonHandler(s.apiRuntimeSetting, 0)))
+	if err := initApiKeys(db, is); err != nil {
+		return nil, err
+	}
+	serveMux.HandleFunc(adminPath+"/api-keys/", s.requireAdminAuth(s.jsonHandler(s.apiApiKey, 0)))
 	if s.chainParser.GetChainType() == bchain.ChainEthereumType {
 		serveMux.HandleFunc(adminPath+"/internal-data-errors", s.requireAdminAuth(s.htmlTemplateHandler(s.internalDataErrors)))
 		serveMux.HandleFunc(adminPath+"/contract-info", s.requireAdminAuth(s.htmlTemplateHandler(s.contractInfoPage)))
 		serveMux.HandleFunc(adminPath+"/contract-info/", s.requireAdminAuth(s.jsonHandler(s.apiContractInfo, 0)))
+		serveMux.HandleFunc(adminPath+"/fourbyte-signatures", s.requireAdminAuth(s.jsonHandler(s.apiFourByteSignatures, 0)))
 	}
 	return s, nil
 }
@@ -519,3 +530,35 @@ func (s *InternalServer) deleteContractInfo(address string, r *http.Request) (in
 	}
 	return &contractInfoDeleteResponse{Contract: address, Deleted: purged != nil, Purged: purged}, nil
 }
+
+// fourByteSignaturesImportResponse is the JSON shape returned by POST /admin/fourbyte-signatures.
+type fourByteSignaturesImportResponse struct {
+	Imported int                             `json:"imported"`
+	State    *bchain.FourByteSignaturesState `json:"state"`
+}
+
+// apiFourByteSignatures returns the state of the 4byte signatures import (GET) or imports
+// a signatures bundle sent as the request body (POST), for deployments without access
+// to the 4byte API.
+func (s *InternalServer) apiFourByteSignatures(r *http.Request, apiVersion int) (interface{}, error) {
+	switch r.Method {
+	case http.MethodGet:
+		state, err := s.db.GetFourByteSignaturesState()
+		if err != nil {
+			return nil, api.NewAPIError(err.Error(), true)
+		}
+		return state, nil
+	case http.MethodPost, http.MethodPut:
+		imported, err := fourbyte.ImportBundle(s.db, r.Body)
+		if err != nil {
+			return nil, api.NewAPIError("Error importing signatures bundle after "+strconv.Itoa(imported)+" new signatures: "+err.Error(), true)
+		}
+		glog.Infof("admin: imported %d new 4byte signatures, client %s", imported, r.RemoteAddr)
+		state, err := s.db.GetFourByteSignaturesState()
+		if err != nil {
+			return nil, api.NewAPIError(err.Error(), true)
+		}
+		return &fourByteSignaturesImportResponse{Imported: imported, State: state}, nil
+	}
+	return nil, api.NewAPIError("Unsupported method "+r.Method, true)
+}
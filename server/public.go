@@ -21,11 +21,13 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/graphql-go/graphql"
 	"github.com/trezor/blockbook/api"
 	"github.com/trezor/blockbook/bchain"
 	"github.com/trezor/blockbook/common"
 	"github.com/trezor/blockbook/db"
 	"github.com/trezor/blockbook/fiat"
+	"google.golang.org/grpc"
 )
 
 const txsOnPage = 25
@@ -48,6 +50,8 @@ const maxGapValue = 10000
 const maxSafePagingOffset = 1000000000
 const maxAccountHistoryPagingOffset = 100000
 const maxSendTxBodyBytes int64 = 8 * 1024 * 1024
+const maxSimulateTxBodyBytes int64 = 1024 * 1024
+const maxAccountsBodyBytes int64 = 256 * 1024
 
 const secondaryCoinCookieName = "secondary_coin"
 const templatesDir = "./static/templates"
@@ -91,11 +95,18 @@ type PublicServer struct {
 	fiatRates           *fiat.FiatRates
 	useSatsAmountFormat bool
 	isFullInterface     bool
+	// broadcastTracker tracks the sent transactions, nil if the tracking is disabled
+	broadcastTracker *api.BroadcastTracker
+	chainEvents      *api.ChainEventLog
+	// grpc serves the gRPC interface, nil if it is not enabled
+	grpc          *grpc.Server
+	graphqlSchema *graphql.Schema
 }
 
 // NewPublicServer creates new public server http interface to blockbook and returns its handle
 // only basic functionality is mapped, to map all functions, call
 func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, explorerURL string, metrics *common.Metrics, is *common.InternalState, fiatRates *fiat.FiatRates, debugMode bool) (*PublicServer, error) {
+	chainEvents := api.NewChainEventLog(db)
 
 	api, err := api.NewWorker(db, chain, mempool, txCache, metrics, is, fiatRates)
 	if err != nil {
@@ -107,9 +118,14 @@ func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bch
 		return nil, err
 	}
 
+	graphqlSchema, err := newGraphqlSchema()
+	if err != nil {
+		return nil, err
+	}
+
 	addr, path := splitBinding(binding)
 	serveMux := http.NewServeMux()
-	restLimiter, err := newRestUIRateLimiter(is.GetNetwork(), metrics)
+	restLimiter, err := newRestUIRateLimiter(is, metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -133,6 +149,9 @@ func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bch
 		MaxHeaderBytes:    httpMaxHeaderBytes,
 	}
 
+	// the event and gRPC streams do not end by themselves, they must be closed for the shutdown to complete
+	https.RegisterOnShutdown(websocket.closeStreams)
+
 	s := &PublicServer{
 		htmlTemplates: htmlTemplates[TemplateData]{
 			metrics: metrics,
@@ -155,6 +174,8 @@ func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bch
 		is:                  is,
 		fiatRates:           fiatRates,
 		useSatsAmountFormat: chain.GetChainParser().GetChainType() == bchain.ChainBitcoinType && chain.GetChainParser().AmountDecimals() == 8,
+		chainEvents:         chainEvents,
+		graphqlSchema:       &graphqlSchema,
 	}
 	s.htmlTemplates.newTemplateData = s.newTemplateData
 	s.htmlTemplates.newTemplateDataWithError = s.newTemplateDataWithError
@@ -253,6 +274,7 @@ func (s *PublicServer) ConnectFullPublicInterface() {
 	serveMux.HandleFunc(path+"api/v2/tx-specific/", s.jsonHandler(s.apiTxSpecific, apiV2))
 	serveMux.HandleFunc(path+"api/v2/tx/", s.jsonHandler(s.apiTx, apiV2))
 	serveMux.HandleFunc(path+"api/v2/address/", s.jsonHandler(s.apiAddress, apiV2))
+	serveMux.HandleFunc(path+"api/v2/addresses/", s.jsonHandler(s.apiAddresses, apiV2))
 	serveMux.HandleFunc(path+"api/v2/contract/", s.jsonHandler(s.apiContract, apiV2))
 	serveMux.HandleFunc(path+"api/v2/xpub/", s.jsonHandler(s.apiXpub, apiV2))
 	serveMux.HandleFunc(path+"api/v2/utxo/", s.jsonHandler(s.apiUtxo, apiV2))
@@ -260,13 +282,24 @@ func (s *PublicServer) ConnectFullPublicInterface() {
 	serveMux.HandleFunc(path+"api/v2/rawblock/", s.jsonHandler(s.apiBlockRaw, apiDefault))
 	serveMux.HandleFunc(path+"api/v2/sendtx/", s.jsonHandler(s.apiSendTx, apiV2))
 	serveMux.HandleFunc(path+"api/v2/estimatefee/", s.jsonHandler(s.apiEstimateFee, apiV2))
+	serveMux.HandleFunc(path+"api/v2/simulatetx/", s.jsonHandler(s.apiSimulateTx, apiV2))
 	serveMux.HandleFunc(path+"api/v2/feestats/", s.jsonHandler(s.apiFeeStats, apiV2))
+	serveMux.HandleFunc(path+"api/v2/mempool/blocks", s.jsonHandler(s.apiMempoolBlocks, apiV2))
+	serveMux.HandleFunc(path+"api/v2/mempool/histogram", s.jsonHandler(s.apiMempoolHistogram, apiV2))
+	serveMux.HandleFunc(path+"api/v2/mempool/feebump/", s.jsonHandler(s.apiMempoolFeeBump, apiV2))
+	serveMux.HandleFunc(path+"api/v2/mempool/dropped/", s.jsonHandler(s.apiMempoolDropped, apiV2))
+	serveMux.HandleFunc(path+"api/v2/broadcast/", s.jsonHandler(s.apiBroadcast, apiV2))
+	serveMux.HandleFunc(path+"api/v2/chain-events", s.jsonHandler(s.apiChainEvents, apiV2))
 	serveMux.HandleFunc(path+"api/v2/balancehistory/", s.jsonHandler(s.apiBalanceHistory, apiDefault))
 	serveMux.HandleFunc(path+"api/v2/tickers/", s.jsonHandler(s.apiTickers, apiV2))
 	serveMux.HandleFunc(path+"api/v2/multi-tickers/", s.jsonHandler(s.apiMultiTickers, apiV2))
 	serveMux.HandleFunc(path+"api/v2/tickers-list/", s.jsonHandler(s.apiAvailableVsCurrencies, apiV2))
 	// websocket interface
 	serveMux.Handle(path+"websocket", s.websocket.GetHandler())
+	// the same subscriptions as Server-Sent Events
+	serveMux.HandleFunc(path+"api/v2/events", s.apiEvents)
+	// GraphQL queries over the same data as the REST API
+	serveMux.HandleFunc(path+"api/v2/graphql", s.apiGraphql)
 	s.isFullInterface = true
 }
 
@@ -278,6 +311,9 @@ func (s *PublicServer) IsFullInterface() bool {
 // Close closes the server
 func (s *PublicServer) Close() error {
 	glog.Infof("public server: closing")
+	if s.grpc != nil {
+		s.grpc.Stop()
+	}
 	return s.https.Close()
 }
 
@@ -285,9 +321,13 @@ func (s *PublicServer) Close() error {
 // hijacked WebSocket connections, so after the HTTP listener stops we also
 // drain the WebSocket server's in-flight DB-touching goroutines; otherwise a
 // long getAccountInfo can race rocksdb_close in cgo and SIGSEGV the process.
+// The gRPC server is stopped before the drain for the same reason.
 func (s *PublicServer) Shutdown(ctx context.Context) error {
 	glog.Infof("public server: shutdown")
 	httpErr := s.https.Shutdown(ctx)
+	if s.grpc != nil {
+		s.shutdownGrpc(ctx)
+	}
 	wsErr := s.websocket.Shutdown(ctx)
 	if httpErr != nil {
 		return httpErr
@@ -297,19 +337,93 @@ func (s *PublicServer) Shutdown(ctx context.Context) error {
 
 // OnNewBlock notifies users subscribed to bitcoind/hashblock about new block
 func (s *PublicServer) OnNewBlock(block *bchain.Block) {
+	s.api.ResetResultCache()
 	s.websocket.OnNewBlock(block)
 }
 
+// OnDisconnectBlocks logs the blocks disconnected by a reorg and notifies the websocket subscribers
+func (s *PublicServer) OnDisconnectBlocks(blocks []bchain.DisconnectedBlock) {
+	s.api.ResetResultCache()
+	s.chainEvents.OnDisconnectBlocks(blocks)
+	s.websocket.OnDisconnectBlocks(blocks)
+}
+
 // OnNewFiatRatesTicker notifies users subscribed to bitcoind/fiatrates about new ticker
 func (s *PublicServer) OnNewFiatRatesTicker(ticker *common.CurrencyRatesTicker) {
 	s.websocket.OnNewFiatRatesTicker(ticker)
 }
 
+// OnMempoolResync recomputes the mempool projection and notifies the users subscribed to it
+func (s *PublicServer) OnMempoolResync() {
+	s.websocket.OnMempoolResync()
+}
+
 // OnNewTx notifies users subscribed to notification about new tx
 func (s *PublicServer) OnNewTx(tx *bchain.MempoolTx) {
+	s.api.InvalidateResultCacheForTx(tx)
 	s.websocket.OnNewTx(tx)
 }
 
+// OnTxReplaced notifies users subscribed to the addresses of the replaced mempool transaction
+func (s *PublicServer) OnTxReplaced(r *bchain.TxReplacement) {
+	s.api.InvalidateResultCache(r.AddrDescs)
+	s.websocket.OnTxReplaced(r)
+}
+
+// OnTxDropped notifies users subscribed to the addresses of the transaction dropped from mempool
+func (s *PublicServer) OnTxDropped(d *bchain.TxDropped) {
+	s.api.InvalidateResultCache(d.AddrDescs)
+	s.websocket.OnTxDropped(d)
+}
+
+// SetBroadcastTracker enables the tracking of the transactions sent through the public and websocket interfaces
+func (s *PublicServer) SetBroadcastTracker(t *api.BroadcastTracker) {
+	s.broadcastTracker = t
+	s.websocket.broadcastTracker = t
+}
+
+// EnableNotificationJournal numbers the websocket notifications and keeps the last size of them for the replay
+// to the reconnected clients, the notifications of an address are journaled for the retention period after its last
+// subscriber left. The journal stored on shutdown is loaded and continued.
+func (s *PublicServer) EnableNotificationJournal(size int, retention time.Duration) {
+	j := newWsJournal(size, retention)
+	p, err := s.db.LoadWsJournal()
+	if err != nil {
+		glog.Error("LoadWsJournal ", err)
+	}
+	// the stored journal is deleted so that its sequence numbers are not reused after a crash
+	if err := s.db.DeleteWsJournal(); err != nil {
+		glog.Error("DeleteWsJournal ", err)
+	}
+	_, bestHash, err := s.db.GetBestBlock()
+	if err != nil {
+		glog.Error("GetBestBlock ", err)
+	}
+	j.restore(p, bestHash)
+	s.websocket.journal = j
+	glog.Info("websocket notification journal enabled, size ", size, ", last sequence number ", j.sequence())
+}
+
+// StoreNotificationJournal stores the journal of the websocket notifications, so that it is continued after the restart
+func (s *PublicServer) StoreNotificationJournal() error {
+	if s.websocket.journal == nil {
+		return nil
+	}
+	_, bestHash, err := s.db.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	return s.db.StoreWsJournal(s.websocket.journal.persisted(bestHash))
+}
+
+// sendRawTransaction sends the transaction to the backend, tracking it if the tracking is enabled
+func (s *PublicServer) sendRawTransaction(hex string) (string, error) {
+	if s.broadcastTracker != nil {
+		return s.broadcastTracker.SendRawTransaction(hex, false)
+	}
+	return s.chain.SendRawTransaction(hex, false)
+}
+
 func (s *PublicServer) txRedirect(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, joinURL(s.explorerURL, r.URL.Path), http.StatusFound)
 	s.metrics.ExplorerViews.With(common.Labels{"action": "tx-redirect"}).Inc()
@@ -969,10 +1083,15 @@ func (s *PublicServer) explorerTx(w http.ResponseWriter, r *http.Request) (tpl,
 	s.metrics.ExplorerViews.With(common.Labels{"action": "tx"}).Inc()
 	if i := strings.LastIndexByte(r.URL.Path, '/'); i > 0 {
 		txid := r.URL.Path[i+1:]
-		tx, err = s.api.GetTransaction(txid, false, true)
+		tx, err = s.worker(r).GetTransaction(txid, false, true, false)
 		if err != nil {
 			return errorTpl, nil, err
 		}
+		// the data of a replaced transaction no longer known to the backend are not available, show its replacement
+		if tx.ReplacedBy != "" && len(tx.Vin) == 0 && len(tx.Vout) == 0 {
+			http.Redirect(w, r, joinURL("/tx/", tx.ReplacedBy), http.StatusFound)
+			return noTpl, nil, nil
+		}
 	}
 	data := s.newTemplateData(r)
 	data.Tx = tx
@@ -988,7 +1107,7 @@ func (s *PublicServer) explorerSpendingTx(w http.ResponseWriter, r *http.Request
 		tx := parts[len(parts)-2]
 		n, ec := strconv.Atoi(parts[len(parts)-1])
 		if ec == nil {
-			spendingTx, err := s.api.GetSpendingTxid(tx, n)
+			spendingTx, err := s.worker(r).GetSpendingTxid(tx, n)
 			if err == nil && spendingTx != "" {
 				http.Redirect(w, r, joinURL("/tx/", spendingTx), http.StatusFound)
 				return noTpl, nil, nil
@@ -1107,6 +1226,8 @@ func (s *PublicServer) getAddressQueryParams(r *http.Request, accountDetails api
 	gap := validateIntParam(r.URL.Query().Get("gap"), 0, 0, maxGapValue)
 	contract := r.URL.Query().Get("contract")
 	withConfirmedNonce, _ := strconv.ParseBool(r.URL.Query().Get("confirmedNonce"))
+	withDiagnostics, _ := strconv.ParseBool(r.URL.Query().Get("diagnostics"))
+	withDelegation, _ := strconv.ParseBool(r.URL.Query().Get("delegation"))
 	return page, pageSize, accountDetails, &api.AddressFilter{
 		Vout:               voutFilter,
 		TokensToReturn:     tokensToReturn,
@@ -1115,6 +1236,9 @@ func (s *PublicServer) getAddressQueryParams(r *http.Request, accountDetails api
 		Contract:           contract,
 		Protocols:          parseProtocolsQuery(r.URL.Query()["protocols"]),
 		WithConfirmedNonce: withConfirmedNonce,
+		WithDiagnostics:    withDiagnostics,
+		WithDelegation:     withDelegation,
+		Cursor:             r.URL.Query().Get("cursor"),
 	}, filterParam, gap
 }
 
@@ -1129,9 +1253,11 @@ func (s *PublicServer) explorerAddress(w http.ResponseWriter, r *http.Request) (
 	}
 	s.metrics.ExplorerViews.With(common.Labels{"action": "address"}).Inc()
 	page, _, _, filter, filterParam, _ := s.getAddressQueryParams(r, api.AccountDetailsTxHistoryLight, txsOnPage)
+	// the address page always shows the EIP-7702 delegation
+	filter.WithDelegation = true
 	// do not allow details to be changed by query params
 	data := s.newTemplateData(r)
-	address, err := s.api.GetAddress(addressParam, page, txsOnPage, api.AccountDetailsTxHistoryLight, filter, strings.ToLower(data.SecondaryCoin))
+	address, err := s.worker(r).GetAddress(addressParam, page, txsOnPage, api.AccountDetailsTxHistoryLight, filter, strings.ToLower(data.SecondaryCoin))
 	if err != nil {
 		return errorTpl, nil, err
 	}
@@ -1156,7 +1282,7 @@ func (s *PublicServer) explorerNftDetail(w http.ResponseWriter, r *http.Request)
 	}
 	tokenId := parts[len(parts)-1]
 	contract := parts[len(parts)-2]
-	uri, ci, err := s.api.GetEthereumTokenURI(contract, tokenId)
+	uri, ci, err := s.worker(r).GetEthereumTokenURI(contract, tokenId)
 	s.metrics.ExplorerViews.With(common.Labels{"action": "nftDetail"}).Inc()
 	if err != nil {
 		return errorTpl, nil, api.NewAPIError(err.Error(), true)
@@ -1184,7 +1310,7 @@ func (s *PublicServer) explorerXpub(w http.ResponseWriter, r *http.Request) (tpl
 	// do not allow txsOnPage and details to be changed by query params
 	page, _, _, filter, filterParam, gap := s.getAddressQueryParams(r, api.AccountDetailsTxHistoryLight, txsOnPage)
 	data := s.newTemplateData(r)
-	address, err := s.api.GetXpubAddress(xpub, page, txsOnPage, api.AccountDetailsTxHistoryLight, filter, gap, strings.ToLower(data.SecondaryCoin))
+	address, err := s.worker(r).GetXpubAddress(xpub, page, txsOnPage, api.AccountDetailsTxHistoryLight, filter, gap, strings.ToLower(data.SecondaryCoin))
 	if err != nil {
 		if err == api.ErrUnsupportedXpub {
 			err = api.NewAPIError("XPUB functionality is not supported", true)
@@ -1208,7 +1334,7 @@ func (s *PublicServer) explorerBlocks(w http.ResponseWriter, r *http.Request) (t
 	var err error
 	s.metrics.ExplorerViews.With(common.Labels{"action": "blocks"}).Inc()
 	page := validateIntParam(r.URL.Query().Get("page"), 0, 0, maxPageNumber)
-	blocks, err = s.api.GetBlocks(page, blocksOnPage)
+	blocks, err = s.worker(r).GetBlocks(page, blocksOnPage)
 	if err != nil {
 		return errorTpl, nil, err
 	}
@@ -1225,7 +1351,7 @@ func (s *PublicServer) explorerBlock(w http.ResponseWriter, r *http.Request) (tp
 	s.metrics.ExplorerViews.With(common.Labels{"action": "block"}).Inc()
 	if i := strings.LastIndexByte(r.URL.Path, '/'); i > 0 {
 		page := validateIntParam(r.URL.Query().Get("page"), 0, 0, maxPageNumber)
-		block, err = s.api.GetBlock(r.URL.Path[i+1:], page, txsOnPage)
+		block, err = s.worker(r).GetBlock(r.URL.Path[i+1:], page, txsOnPage)
 		if err != nil {
 			return errorTpl, nil, err
 		}
@@ -1246,7 +1372,7 @@ func (s *PublicServer) explorerIndex(w http.ResponseWriter, r *http.Request) (tp
 	var si *api.SystemInfo
 	var err error
 	s.metrics.ExplorerViews.With(common.Labels{"action": "index"}).Inc()
-	si, err = s.api.GetSystemInfo(false)
+	si, err = s.worker(r).GetSystemInfo(false)
 	if err != nil {
 		return errorTpl, nil, err
 	}
@@ -1280,22 +1406,22 @@ func (s *PublicServer) explorerSearch(w http.ResponseWriter, r *http.Request) (t
 				}
 			}
 		}
-		address, err = s.api.GetXpubAddress(q, 0, 1, api.AccountDetailsBasic, &api.AddressFilter{Vout: api.AddressFilterVoutOff}, 0, "")
+		address, err = s.worker(r).GetXpubAddress(q, 0, 1, api.AccountDetailsBasic, &api.AddressFilter{Vout: api.AddressFilterVoutOff}, 0, "")
 		if err == nil {
 			http.Redirect(w, r, joinURL("/xpub/", url.QueryEscape(address.AddrStr)), http.StatusFound)
 			return noTpl, nil, nil
 		}
-		block, err = s.api.GetBlock(q, 0, 1)
+		block, err = s.worker(r).GetBlock(q, 0, 1)
 		if err == nil {
 			http.Redirect(w, r, joinURL("/block/", block.Hash), http.StatusFound)
 			return noTpl, nil, nil
 		}
-		tx, err = s.api.GetTransaction(q, false, false)
+		tx, err = s.worker(r).GetTransaction(q, false, false, false)
 		if err == nil {
 			http.Redirect(w, r, joinURL("/tx/", tx.Txid), http.StatusFound)
 			return noTpl, nil, nil
 		}
-		address, err = s.api.GetAddress(q, 0, 1, api.AccountDetailsBasic, &api.AddressFilter{Vout: api.AddressFilterVoutOff}, "")
+		address, err = s.worker(r).GetAddress(q, 0, 1, api.AccountDetailsBasic, &api.AddressFilter{Vout: api.AddressFilterVoutOff}, "")
 		if err == nil {
 			http.Redirect(w, r, joinURL("/address/", address.AddrStr), http.StatusFound)
 			return noTpl, nil, nil
@@ -1314,7 +1440,7 @@ func (s *PublicServer) explorerSendTx(w http.ResponseWriter, r *http.Request) (t
 		}
 		hex := r.FormValue("hex")
 		if len(hex) > 0 {
-			res, err := s.chain.SendRawTransaction(hex, false)
+			res, err := s.sendRawTransaction(hex)
 			if err != nil {
 				data.SendTxHex = hex
 				data.Error = &api.APIError{Text: err.Error(), Public: true}
@@ -1331,7 +1457,7 @@ func (s *PublicServer) explorerMempool(w http.ResponseWriter, r *http.Request) (
 	var err error
 	s.metrics.ExplorerViews.With(common.Labels{"action": "mempool"}).Inc()
 	page := validateIntParam(r.URL.Query().Get("page"), 0, 0, maxPageNumber)
-	mempoolTxids, err = s.api.GetMempool(page, mempoolTxsOnPage)
+	mempoolTxids, err = s.worker(r).GetMempool(page, mempoolTxsOnPage)
 	if err != nil {
 		return errorTpl, nil, err
 	}
@@ -1405,7 +1531,7 @@ func (s *PublicServer) apiIndex(r *http.Request, apiVersion int) (interface{}, e
 		return nil, api.NewAPIError("Service unavailable", false)
 	}
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-index"}).Inc()
-	return s.api.GetSystemInfo(false)
+	return s.worker(r).GetSystemInfo(false)
 }
 
 func (s *PublicServer) apiBlockIndex(r *http.Request, apiVersion int) (interface{}, error) {
@@ -1538,9 +1664,17 @@ func (s *PublicServer) apiTx(r *http.Request, apiVersion int) (interface{}, erro
 			return nil, api.NewAPIError("Parameter 'spending' cannot be converted to boolean", true)
 		}
 	}
-	tx, err = s.api.GetTransaction(txid, spendingTxs, false)
+	mempoolPackage := false
+	p = r.URL.Query().Get("mempoolPackage")
+	if len(p) > 0 {
+		mempoolPackage, err = strconv.ParseBool(p)
+		if err != nil {
+			return nil, api.NewAPIError("Parameter 'mempoolPackage' cannot be converted to boolean", true)
+		}
+	}
+	tx, err = s.worker(r).GetTransaction(txid, spendingTxs, false, mempoolPackage)
 	if err == nil && apiVersion == apiV1 {
-		return s.api.TxToV1(tx), nil
+		return s.worker(r).TxToV1(tx), nil
 	}
 	return tx, err
 }
@@ -1555,7 +1689,7 @@ func (s *PublicServer) apiRawTx(r *http.Request, apiVersion int) (interface{}, e
 		return "", api.NewAPIError("Missing txid", true)
 	}
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-raw-tx"}).Inc()
-	return s.api.GetRawTransaction(txid)
+	return s.worker(r).GetRawTransaction(txid)
 }
 
 func (s *PublicServer) apiTxSpecific(r *http.Request, apiVersion int) (interface{}, error) {
@@ -1590,17 +1724,46 @@ func (s *PublicServer) apiAddress(r *http.Request, apiVersion int) (interface{},
 	var err error
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-address"}).Inc()
 	page, pageSize, details, filter, _, _ := s.getAddressQueryParams(r, api.AccountDetailsTxidHistory, txsInAPI)
-	if err := s.api.ValidateProtocolsForChain(filter.Protocols); err != nil {
+	if err := s.worker(r).ValidateProtocolsForChain(filter.Protocols); err != nil {
 		return nil, err
 	}
 	secondaryCoin := strings.ToLower(r.URL.Query().Get("secondary"))
-	address, err = s.api.GetAddress(addressParam, page, pageSize, details, filter, secondaryCoin)
+	address, err = s.worker(r).GetAddress(addressParam, page, pageSize, details, filter, secondaryCoin)
 	if err == nil && apiVersion == apiV1 {
-		return s.api.AddressToV1(address), nil
+		return s.worker(r).AddressToV1(address), nil
 	}
 	return address, err
 }
 
+// apiAddresses returns the balances and tokens of the addresses and XPUB descriptors posted as a JSON object {"descriptors":[...]}
+func (s *PublicServer) apiAddresses(r *http.Request, apiVersion int) (interface{}, error) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-addresses"}).Inc()
+	if r.Method != http.MethodPost {
+		return nil, api.NewAPIError("Missing addresses, use POST with a JSON body", true)
+	}
+	if r.ContentLength > maxAccountsBodyBytes {
+		return nil, api.NewAPIError("Request too large", true)
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxAccountsBodyBytes+1))
+	if err != nil || len(body) == 0 {
+		return nil, api.NewAPIError("Missing addresses", true)
+	}
+	if int64(len(body)) > maxAccountsBodyBytes {
+		return nil, api.NewAPIError("Request too large", true)
+	}
+	var req struct {
+		Descriptors []string `json:"descriptors"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, api.NewAPIError("Invalid request, expecting a JSON object with descriptors", true)
+	}
+	_, _, details, filter, _, gap := s.getAddressQueryParams(r, api.AccountDetailsBasic, txsInAPI)
+	if err := s.worker(r).ValidateProtocolsForChain(filter.Protocols); err != nil {
+		return nil, err
+	}
+	return s.worker(r).GetAccountsInfo(req.Descriptors, details, filter, gap, strings.ToLower(r.URL.Query().Get("secondary")))
+}
+
 func (s *PublicServer) apiContract(r *http.Request, apiVersion int) (interface{}, error) {
 	var contract string
 	i := strings.LastIndex(r.URL.Path, "contract/")
@@ -1611,7 +1774,7 @@ func (s *PublicServer) apiContract(r *http.Request, apiVersion int) (interface{}
 		return nil, api.NewAPIError("Missing contract", true)
 	}
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-contract"}).Inc()
-	return s.api.GetContractInfoData(contract, strings.ToLower(r.URL.Query().Get("currency")), parseProtocolsQuery(r.URL.Query()["protocols"]))
+	return s.worker(r).GetContractInfoData(contract, strings.ToLower(r.URL.Query().Get("currency")), parseProtocolsQuery(r.URL.Query()["protocols"]))
 }
 
 func (s *PublicServer) apiXpub(r *http.Request, apiVersion int) (interface{}, error) {
@@ -1628,9 +1791,9 @@ func (s *PublicServer) apiXpub(r *http.Request, apiVersion int) (interface{}, er
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-xpub"}).Inc()
 	page, pageSize, details, filter, _, gap := s.getAddressQueryParams(r, api.AccountDetailsTxidHistory, txsInAPI)
 	secondaryCoin := strings.ToLower(r.URL.Query().Get("secondary"))
-	address, err = s.api.GetXpubAddress(xpub, page, pageSize, details, filter, gap, secondaryCoin)
+	address, err = s.worker(r).GetXpubAddress(xpub, page, pageSize, details, filter, gap, secondaryCoin)
 	if err == nil && apiVersion == apiV1 {
-		return s.api.AddressToV1(address), nil
+		return s.worker(r).AddressToV1(address), nil
 	}
 	if err == api.ErrUnsupportedXpub {
 		err = api.NewAPIError("XPUB functionality is not supported", true)
@@ -1652,15 +1815,15 @@ func (s *PublicServer) apiUtxo(r *http.Request, apiVersion int) (interface{}, er
 			}
 		}
 		gap := validateIntParam(r.URL.Query().Get("gap"), 0, 0, maxGapValue)
-		utxo, err = s.api.GetXpubUtxo(desc, onlyConfirmed, gap)
+		utxo, err = s.worker(r).GetXpubUtxo(desc, onlyConfirmed, gap)
 		if err == nil {
 			s.metrics.ExplorerViews.With(common.Labels{"action": "api-xpub-utxo"}).Inc()
 		} else {
-			utxo, err = s.api.GetAddressUtxo(desc, onlyConfirmed)
+			utxo, err = s.worker(r).GetAddressUtxo(desc, onlyConfirmed)
 			s.metrics.ExplorerViews.With(common.Labels{"action": "api-address-utxo"}).Inc()
 		}
 		if err == nil && apiVersion == apiV1 {
-			return s.api.AddressUtxoToV1(utxo), nil
+			return s.worker(r).AddressUtxoToV1(utxo), nil
 		}
 	}
 	return utxo, err
@@ -1696,7 +1859,7 @@ func (s *PublicServer) apiBalanceHistory(r *http.Request, apiVersion int) (inter
 		if fiat != "" {
 			fiatArray = []string{fiat}
 		}
-		history, err = s.api.GetXpubBalanceHistory(r.URL.Path[i+1:], fromTimestamp, toTimestamp, fiatArray, gap, uint32(groupBy), s.is.BalanceHistoryMaxTxsREST, api.BalanceHistoryTransportREST)
+		history, err = s.worker(r).GetXpubBalanceHistory(r.URL.Path[i+1:], fromTimestamp, toTimestamp, fiatArray, gap, uint32(groupBy), s.is.BalanceHistoryMaxTxsREST, api.BalanceHistoryTransportREST)
 		if err == nil {
 			s.metrics.ExplorerViews.With(common.Labels{"action": "api-xpub-balancehistory"}).Inc()
 		} else if apiErr, ok := err.(*api.APIError); ok && apiErr.Public {
@@ -1705,7 +1868,7 @@ func (s *PublicServer) apiBalanceHistory(r *http.Request, apiVersion int) (inter
 			// address, which would mask it with an address-parse error.
 			return history, err
 		} else {
-			history, err = s.api.GetBalanceHistory(r.URL.Path[i+1:], fromTimestamp, toTimestamp, fiatArray, uint32(groupBy), s.is.BalanceHistoryMaxTxsREST, api.BalanceHistoryTransportREST)
+			history, err = s.worker(r).GetBalanceHistory(r.URL.Path[i+1:], fromTimestamp, toTimestamp, fiatArray, uint32(groupBy), s.is.BalanceHistoryMaxTxsREST, api.BalanceHistoryTransportREST)
 			s.metrics.ExplorerViews.With(common.Labels{"action": "api-address-balancehistory"}).Inc()
 		}
 	}
@@ -1718,9 +1881,9 @@ func (s *PublicServer) apiBlock(r *http.Request, apiVersion int) (interface{}, e
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-block"}).Inc()
 	if i := strings.LastIndexByte(r.URL.Path, '/'); i > 0 {
 		page := validateIntParam(r.URL.Query().Get("page"), 0, 0, maxPageNumber)
-		block, err = s.api.GetBlock(r.URL.Path[i+1:], page, txsInAPI)
+		block, err = s.worker(r).GetBlock(r.URL.Path[i+1:], page, txsInAPI)
 		if err == nil && apiVersion == apiV1 {
-			return s.api.BlockToV1(block), nil
+			return s.worker(r).BlockToV1(block), nil
 		}
 	}
 	return block, err
@@ -1731,7 +1894,7 @@ func (s *PublicServer) apiBlockRaw(r *http.Request, apiVersion int) (interface{}
 	var err error
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-block-raw"}).Inc()
 	if i := strings.LastIndexByte(r.URL.Path, '/'); i > 0 {
-		block, err = s.api.GetBlockRaw(r.URL.Path[i+1:])
+		block, err = s.worker(r).GetBlockRaw(r.URL.Path[i+1:])
 	}
 	return block, err
 }
@@ -1741,11 +1904,103 @@ func (s *PublicServer) apiFeeStats(r *http.Request, apiVersion int) (interface{}
 	var err error
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-feestats"}).Inc()
 	if i := strings.LastIndexByte(r.URL.Path, '/'); i > 0 {
-		feeStats, err = s.api.GetFeeStats(r.URL.Path[i+1:])
+		feeStats, err = s.worker(r).GetFeeStats(r.URL.Path[i+1:])
 	}
 	return feeStats, err
 }
 
+func (s *PublicServer) apiMempoolBlocks(r *http.Request, apiVersion int) (interface{}, error) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-mempool-blocks"}).Inc()
+	blocks, _, err := s.websocket.GetMempoolProjection()
+	return blocks, err
+}
+
+func (s *PublicServer) apiMempoolHistogram(r *http.Request, apiVersion int) (interface{}, error) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-mempool-histogram"}).Inc()
+	_, histogram, err := s.websocket.GetMempoolProjection()
+	return histogram, err
+}
+
+func (s *PublicServer) apiMempoolFeeBump(r *http.Request, apiVersion int) (interface{}, error) {
+	var txid string
+	i := strings.LastIndexByte(r.URL.Path, '/')
+	if i > 0 {
+		txid = r.URL.Path[i+1:]
+	}
+	if len(txid) == 0 {
+		return nil, api.NewAPIError("Missing txid", true)
+	}
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-mempool-feebump"}).Inc()
+	q := r.URL.Query()
+	feeRate, err := strconv.ParseFloat(q.Get("feeRate"), 64)
+	if err != nil {
+		return nil, api.NewAPIError("Parameter 'feeRate' cannot be converted to number", true)
+	}
+	var outputs []int
+	if o := q.Get("outputs"); o != "" {
+		for _, n := range strings.Split(o, ",") {
+			v, err := strconv.Atoi(strings.TrimSpace(n))
+			if err != nil {
+				return nil, api.NewAPIError("Parameter 'outputs' must be a comma separated list of output indexes", true)
+			}
+			outputs = append(outputs, v)
+		}
+	}
+	var childVSize int64
+	if c := q.Get("childVSize"); c != "" {
+		if childVSize, err = strconv.ParseInt(c, 10, 64); err != nil {
+			return nil, api.NewAPIError("Parameter 'childVSize' cannot be converted to number", true)
+		}
+	}
+	return s.worker(r).GetFeeBump(txid, feeRate, outputs, childVSize)
+}
+
+func (s *PublicServer) apiMempoolDropped(r *http.Request, apiVersion int) (interface{}, error) {
+	var txid string
+	i := strings.LastIndexByte(r.URL.Path, '/')
+	if i > 0 {
+		txid = r.URL.Path[i+1:]
+	}
+	if len(txid) == 0 {
+		return nil, api.NewAPIError("Missing txid", true)
+	}
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-mempool-dropped"}).Inc()
+	return s.worker(r).GetDroppedTx(txid)
+}
+
+// apiBroadcast returns the status of the transaction sent through Blockbook
+func (s *PublicServer) apiBroadcast(r *http.Request, apiVersion int) (interface{}, error) {
+	var txid string
+	i := strings.LastIndexByte(r.URL.Path, '/')
+	if i > 0 {
+		txid = r.URL.Path[i+1:]
+	}
+	if len(txid) == 0 {
+		return nil, api.NewAPIError("Missing txid", true)
+	}
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-broadcast"}).Inc()
+	if s.broadcastTracker == nil {
+		return nil, api.NewAPIError("Tracking of sent transactions is disabled", true)
+	}
+	return s.broadcastTracker.GetBroadcastTx(txid)
+}
+
+// apiEvents streams the notifications of the subscriptions given by the query parameters as Server-Sent Events
+func (s *PublicServer) apiEvents(w http.ResponseWriter, r *http.Request) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-events"}).Inc()
+	s.websocket.serveEvents(w, r)
+}
+
+// apiChainEvents returns the connections and disconnections of the blocks since the block of the client
+func (s *PublicServer) apiChainEvents(r *http.Request, apiVersion int) (interface{}, error) {
+	since, err := strconv.ParseUint(r.URL.Query().Get("since"), 10, 32)
+	if err != nil {
+		return nil, api.NewAPIError("Missing or invalid parameter since", true)
+	}
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-chain-events"}).Inc()
+	return s.chainEvents.GetChainEvents(uint32(since), r.URL.Query().Get("hash"))
+}
+
 type resultSendTransaction struct {
 	Result string `json:"result"`
 }
@@ -1781,7 +2036,7 @@ func (s *PublicServer) apiSendTx(r *http.Request, apiVersion int) (interface{},
 		}
 	}
 	if len(hex) > 0 {
-		res.Result, err = s.chain.SendRawTransaction(hex, false)
+		res.Result, err = s.sendRawTransaction(hex)
 		if err != nil {
 			return nil, api.NewAPIError(err.Error(), true)
 		}
@@ -1790,6 +2045,29 @@ func (s *PublicServer) apiSendTx(r *http.Request, apiVersion int) (interface{},
 	return nil, api.NewAPIError("Missing tx blob", true)
 }
 
+// apiSimulateTx simulates the Ethereum-type transaction posted as a JSON object without broadcasting it
+func (s *PublicServer) apiSimulateTx(r *http.Request, apiVersion int) (interface{}, error) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-simulatetx"}).Inc()
+	if r.Method != http.MethodPost {
+		return nil, api.NewAPIError("Missing transaction, use POST with a JSON body", true)
+	}
+	if r.ContentLength > maxSimulateTxBodyBytes {
+		return nil, api.NewAPIError("Transaction too large", true)
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSimulateTxBodyBytes+1))
+	if err != nil || len(body) == 0 {
+		return nil, api.NewAPIError("Missing transaction", true)
+	}
+	if int64(len(body)) > maxSimulateTxBodyBytes {
+		return nil, api.NewAPIError("Transaction too large", true)
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal(body, &params); err != nil {
+		return nil, api.NewAPIError("Invalid transaction, expecting a JSON object", true)
+	}
+	return s.worker(r).SimulateEthereumTx(params, r.URL.Query().Get("block"))
+}
+
 // apiAvailableVsCurrencies returns a list of available versus currencies
 func (s *PublicServer) apiAvailableVsCurrencies(r *http.Request, apiVersion int) (interface{}, error) {
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-tickers-list"}).Inc()
@@ -1799,7 +2077,7 @@ func (s *PublicServer) apiAvailableVsCurrencies(r *http.Request, apiVersion int)
 		return nil, api.NewAPIError("Parameter \"timestamp\" is not a valid Unix timestamp.", true)
 	}
 	token := r.URL.Query().Get("token")
-	result, err := s.api.GetAvailableVsCurrencies(timestamp, token)
+	result, err := s.worker(r).GetAvailableVsCurrencies(timestamp, token)
 	return result, err
 }
 
@@ -1818,7 +2096,7 @@ func (s *PublicServer) apiTickers(r *http.Request, apiVersion int) (interface{},
 	if block := r.URL.Query().Get("block"); block != "" {
 		// Get tickers for specified block height or block hash
 		s.metrics.ExplorerViews.With(common.Labels{"action": "api-tickers-block"}).Inc()
-		result, err = s.api.GetFiatRatesForBlockID(block, currencies, token)
+		result, err = s.worker(r).GetFiatRatesForBlockID(block, currencies, token)
 	} else if timestampString := r.URL.Query().Get("timestamp"); timestampString != "" {
 		// Get tickers for specified timestamp
 		s.metrics.ExplorerViews.With(common.Labels{"action": "api-tickers-date"}).Inc()
@@ -1828,7 +2106,7 @@ func (s *PublicServer) apiTickers(r *http.Request, apiVersion int) (interface{},
 			return nil, api.NewAPIError("Parameter 'timestamp' is not a valid Unix timestamp.", true)
 		}
 
-		resultTickers, err := s.api.GetFiatRatesForTimestamps([]int64{timestamp}, currencies, token)
+		resultTickers, err := s.worker(r).GetFiatRatesForTimestamps([]int64{timestamp}, currencies, token)
 		if err != nil {
 			return nil, err
 		}
@@ -1836,7 +2114,7 @@ func (s *PublicServer) apiTickers(r *http.Request, apiVersion int) (interface{},
 	} else {
 		// No parameters - get the latest available ticker
 		s.metrics.ExplorerViews.With(common.Labels{"action": "api-tickers-last"}).Inc()
-		result, err = s.api.GetCurrentFiatRates(currencies, token)
+		result, err = s.worker(r).GetCurrentFiatRates(currencies, token)
 	}
 	if err != nil {
 		return nil, err
@@ -1885,7 +2163,7 @@ func (s *PublicServer) apiMultiTickers(r *http.Request, apiVersion int) (interfa
 				return nil, api.NewAPIError("Parameter 'timestamp' does not contain a valid Unix timestamp.", true)
 			}
 		}
-		resultTickers, err := s.api.GetFiatRatesForTimestamps(t, currencies, token)
+		resultTickers, err := s.worker(r).GetFiatRatesForTimestamps(t, currencies, token)
 		if err != nil {
 			return nil, err
 		}
@@ -1919,6 +2197,22 @@ func (s *PublicServer) apiEstimateFee(r *http.Request, apiVersion int) (interfac
 				}
 			}
 			var fee big.Int
+			provider := r.URL.Query().Get("provider")
+			if provider == "mempool" {
+				estimate, err := s.chain.EstimateMempoolFee(blocks)
+				if err != nil {
+					return nil, err
+				}
+				if conservative {
+					fee = estimate.High
+				} else {
+					fee = estimate.Medium
+				}
+				res.Result = s.chainParser.AmountToDecimalString(&fee)
+				return res, nil
+			} else if len(provider) > 0 {
+				return nil, api.NewAPIError("Unknown fee provider "+provider, true)
+			}
 			fee, err = s.chain.EstimateSmartFee(blocks, conservative)
 			if err != nil {
 				fee, err = s.chain.EstimateFee(blocks)
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/common"
+)
+
+// The requests are charged to the rate-limit budget of the client by their cost,
+// not by their count. Each REST route and websocket method has a base cost taken
+// when the request is accepted; a client without the base cost in its budget is
+// rejected. When the request finishes, the work it actually did (as counted by the
+// api.Worker) is converted to tokens and deducted too, so a request which scanned
+// a large xpub or loaded many transactions throttles the following requests of its
+// client instead of costing the same as a block-index lookup.
+const (
+	defaultRequestCost = 1
+	// the work worth one token
+	costDBReadsPerToken          = 200
+	costDerivedAddressesPerToken = 20
+	costBackendCallsPerToken     = 10
+	// otherRequestCostLabel is the metrics label of the routes missing in restUIRouteCosts
+	otherRequestCostLabel = "other"
+	// sseRoute is the route of the Server-Sent Events streams
+	sseRoute = "events"
+)
+
+// restUIRouteCosts are the base costs of the public routes by the first path segment
+// after the base path and the optional api/, api/v1/ or api/v2/ prefix, so that an
+// explorer page and the API call with the same data cost the same
+var restUIRouteCosts = map[string]float64{
+	"":               defaultRequestCost,
+	"address":        defaultRequestCost,
+	"addresses":      2,
+	"balancehistory": 2,
+	"block":          defaultRequestCost,
+	"block-filters":  defaultRequestCost,
+	"block-index":    defaultRequestCost,
+	"blocks":         defaultRequestCost,
+	"broadcast":      defaultRequestCost,
+	"chain-events":   defaultRequestCost,
+	"contract":       defaultRequestCost,
+	"estimatefee":    defaultRequestCost,
+	"events":         defaultRequestCost,
+	"feestats":       defaultRequestCost,
+	"graphql":        defaultRequestCost,
+	"mempool":        defaultRequestCost,
+	"multi-tickers":  defaultRequestCost,
+	"nft":            defaultRequestCost,
+	"rawblock":       2,
+	"rawtx":          defaultRequestCost,
+	"search":         defaultRequestCost,
+	"sendtx":         defaultRequestCost,
+	"simulatetx":     2,
+	"spending":       defaultRequestCost,
+	"tickers":        defaultRequestCost,
+	"tickers-list":   defaultRequestCost,
+	"tx":             defaultRequestCost,
+	"tx-specific":    defaultRequestCost,
+	"utxo":           defaultRequestCost,
+	"xpub":           2,
+}
+
+// websocketMethodCosts are the base costs of the websocket methods (and of the
+// gRPC unary calls named like them) which differ from defaultRequestCost
+var websocketMethodCosts = map[string]float64{
+	"getAccountsInfo":     2,
+	"getBalanceHistory":   2,
+	"getBlockFilters":     2,
+	"getMempoolFilters":   2,
+	"simulateTransaction": 2,
+}
+
+// restUIRouteCost returns the metrics label and the base cost of the public route
+func restUIRouteCost(reqPath, basePath string) (route string, cost float64) {
+	rel := strings.TrimPrefix(strings.TrimPrefix(reqPath, basePath), "/")
+	if rel == "api" || strings.HasPrefix(rel, "api/") {
+		rel = strings.TrimPrefix(strings.TrimPrefix(rel, "api"), "/")
+		if strings.HasPrefix(rel, "v1/") || strings.HasPrefix(rel, "v2/") {
+			rel = rel[3:]
+		}
+	}
+	route, _, _ = strings.Cut(rel, "/")
+	cost, found := restUIRouteCosts[route]
+	if !found {
+		return otherRequestCostLabel, defaultRequestCost
+	}
+	if route == "" {
+		route = "index"
+	}
+	return route, cost
+}
+
+// websocketMethodCost returns the base cost of the websocket method
+func websocketMethodCost(method string) float64 {
+	if cost, found := websocketMethodCosts[method]; found {
+		return cost
+	}
+	return defaultRequestCost
+}
+
+// requestWorkCost converts the work done by a request to tokens
+func requestWorkCost(work *api.RequestWork) float64 {
+	return float64(work.DBReads())/costDBReadsPerToken +
+		float64(work.DerivedAddresses())/costDerivedAddressesPerToken +
+		float64(work.BackendCalls())/costBackendCallsPerToken
+}
+
+func observeRequestCost(metrics *common.Metrics, iface, method string, cost float64) {
+	if metrics != nil {
+		metrics.RequestCost.With(common.Labels{"interface": iface, "method": method}).Observe(cost)
+	}
+}
+
+type requestWorkKey struct{}
+
+// withRequestWork returns the context carrying the work counter of the request
+func withRequestWork(ctx context.Context, work *api.RequestWork) context.Context {
+	return context.WithValue(ctx, requestWorkKey{}, work)
+}
+
+// requestWork returns the work counter of the request, nil if the request is not charged by its work
+func requestWork(ctx context.Context) *api.RequestWork {
+	work, _ := ctx.Value(requestWorkKey{}).(*api.RequestWork)
+	return work
+}
+
+// worker returns the api worker counting its work to the work counter of the request
+func (s *PublicServer) worker(r *http.Request) *api.Worker {
+	return s.api.WithWork(requestWork(r.Context()))
+}
+
+// worker returns the api worker counting its work to the given work counter
+func (s *WebsocketServer) worker(work *api.RequestWork) *api.Worker {
+	return s.api.WithWork(work)
+}
+
+// channelApiKey returns the current state of the API key of the channel, nil for the clients without a key
+func (s *WebsocketServer) channelApiKey(c *websocketChannel) *common.ApiKey {
+	if c.apiKeyID == "" {
+		return nil
+	}
+	return s.is.GetApiKeys().ByID(c.apiKeyID)
+}
+
+// acceptRequestCost takes the base cost of the method from the cost budget of the client of the channel.
+// The returned work counter collects the work of the request for chargeRequestWork, it is nil
+// for the clients which are not cost limited.
+func (s *WebsocketServer) acceptRequestCost(c *websocketChannel, method string) (*api.RequestWork, error) {
+	if !c.costLimited {
+		return nil, nil
+	}
+	apiKey := s.channelApiKey(c)
+	if ok, retryAfter := s.costLimiter.take(c.ipKey, apiKey, websocketMethodCost(method), time.Now()); !ok {
+		if s.metrics != nil {
+			s.metrics.WebsocketCostLimitRejections.With(common.Labels{"method": method}).Inc()
+		}
+		if apiKey != nil {
+			observeApiKeyRejection(s.metrics, apiKey.ID, restUIRejectRequestRate)
+		}
+		seconds := max(int64(math.Ceil(retryAfter.Seconds())), 1)
+		return nil, api.NewAPIError(fmt.Sprintf("Rate limit exceeded, retry after %d seconds", seconds), true)
+	}
+	return &api.RequestWork{}, nil
+}
+
+// chargeRequestWork deducts the cost of the work done by the request from the cost budget of the client of the channel
+func (s *WebsocketServer) chargeRequestWork(c *websocketChannel, method string, work *api.RequestWork) {
+	if work == nil {
+		return
+	}
+	cost := requestWorkCost(work)
+	s.costLimiter.deduct(c.ipKey, s.channelApiKey(c), cost, time.Now())
+	observeRequestCost(s.metrics, "websocket", method, websocketMethodCost(method)+cost)
+}
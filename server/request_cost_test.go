@@ -0,0 +1,198 @@
+//go:build unittest
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/common"
+)
+
+func TestRestUIRouteCost(t *testing.T) {
+	tests := []struct {
+		path      string
+		basePath  string
+		wantRoute string
+		wantCost  float64
+	}{
+		{path: "/", basePath: "/", wantRoute: "index", wantCost: 1},
+		{path: "/api/", basePath: "/", wantRoute: "index", wantCost: 1},
+		{path: "/api/v2/block-index/100", basePath: "/", wantRoute: "block-index", wantCost: 1},
+		{path: "/api/v2/xpub/xpub6C", basePath: "/", wantRoute: "xpub", wantCost: 2},
+		{path: "/api/xpub/xpub6C", basePath: "/", wantRoute: "xpub", wantCost: 2},
+		{path: "/xpub/xpub6C", basePath: "/", wantRoute: "xpub", wantCost: 2},
+		{path: "/api/v1/address/1A1z", basePath: "/", wantRoute: "address", wantCost: 1},
+		{path: "/btc/api/v2/balancehistory/1A1z", basePath: "/btc/", wantRoute: "balancehistory", wantCost: 2},
+		{path: "/btc/blocks", basePath: "/btc", wantRoute: "blocks", wantCost: 1},
+		{path: "/api/v2/unknown/x", basePath: "/", wantRoute: otherRequestCostLabel, wantCost: 1},
+		{path: "/apix/", basePath: "/", wantRoute: otherRequestCostLabel, wantCost: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			route, cost := restUIRouteCost(tt.path, tt.basePath)
+			if route != tt.wantRoute || cost != tt.wantCost {
+				t.Errorf("restUIRouteCost(%q, %q) = %q, %v, want %q, %v", tt.path, tt.basePath, route, cost, tt.wantRoute, tt.wantCost)
+			}
+		})
+	}
+	if websocketMethodCost("getBalanceHistory") != 2 || websocketMethodCost("getInfo") != defaultRequestCost {
+		t.Error("unexpected websocket method cost")
+	}
+	if cost := requestWorkCost(nil); cost != 0 {
+		t.Errorf("requestWorkCost(nil) = %v, want 0", cost)
+	}
+}
+
+func TestRestUITokenBucketDeduct(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var b restUITokenBucket
+	// 60 tokens per minute, one per second
+	if ok, _ := b.take(now, 5, 60, time.Minute, 10); !ok {
+		t.Fatal("take of 5 tokens rejected")
+	}
+	// the work of the request drives the bucket into debt, bounded by one burst
+	b.deduct(now, 100, 60, time.Minute, 10)
+	if b.tokens != -10 {
+		t.Fatalf("tokens = %v after deduct, want -10", b.tokens)
+	}
+	ok, retryAfter := b.take(now, 1, 60, time.Minute, 10)
+	if ok {
+		t.Fatal("take accepted with the bucket in debt")
+	}
+	if retryAfter != 11*time.Second {
+		t.Fatalf("retryAfter = %v, want 11s", retryAfter)
+	}
+	if ok, _ := b.take(now.Add(11*time.Second), 1, 60, time.Minute, 10); !ok {
+		t.Fatal("take rejected after the debt was refilled")
+	}
+	// nothing is deducted without a rate limit
+	b.deduct(now.Add(11*time.Second), 100, 0, time.Minute, 10)
+	if b.tokens != 0 {
+		t.Fatalf("tokens = %v after deduct without a rate limit, want 0", b.tokens)
+	}
+}
+
+func TestRestUIRateLimiterRequestCost(t *testing.T) {
+	limiter := newTestRestUIRateLimiter()
+	limiter.rateLimit = 10
+	limiter.burst = 10
+	now := time.Unix(1700000000, 0)
+
+	// an xpub request takes its base cost of 2 tokens
+	for i := 0; i < 5; i++ {
+		if decision := limiter.acceptCost("192.0.2.1", "192.0.2.1", false, nil, 2, now); !decision.accepted {
+			t.Fatalf("request %d = %+v, want accepted", i, decision)
+		}
+		limiter.release("192.0.2.1", now)
+	}
+	if decision := limiter.acceptCost("192.0.2.1", "192.0.2.1", false, nil, 2, now); decision.accepted || decision.reason != restUIRejectRequestRate {
+		t.Fatalf("request over the budget = %+v, want rejected by request rate", decision)
+	}
+
+	// the work of a finished request is deducted, so the client waits for the refill of the debt
+	if decision := limiter.acceptCost("192.0.2.2", "192.0.2.2", false, nil, 1, now); !decision.accepted {
+		t.Fatalf("request = %+v, want accepted", decision)
+	}
+	limiter.deduct("192.0.2.2", nil, 50, now)
+	limiter.release("192.0.2.2", now)
+	decision := limiter.acceptCost("192.0.2.2", "192.0.2.2", false, nil, 1, now.Add(time.Minute))
+	if decision.accepted {
+		t.Fatal("request accepted while its client is in debt")
+	}
+	if decision.retryAfter <= 0 || decision.retryAfter > time.Minute {
+		t.Fatalf("retryAfter = %v, want up to a minute", decision.retryAfter)
+	}
+	// other clients are not affected
+	if decision := limiter.acceptCost("192.0.2.3", "192.0.2.3", false, nil, 1, now); !decision.accepted {
+		t.Fatalf("request of another client = %+v, want accepted", decision)
+	}
+	// a deduct of an untracked client is ignored
+	limiter.deduct("192.0.2.4", nil, 50, now)
+	if limiter.clients["192.0.2.4"] != nil {
+		t.Fatal("deduct created the state of an untracked client")
+	}
+}
+
+func TestWebsocketCostLimiter(t *testing.T) {
+	l := &websocketCostLimiter{clients: make(map[string]*websocketClientCost), rateLimit: 60, rateWindow: time.Minute, burst: 4}
+	now := time.Unix(1700000000, 0)
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.take("192.0.2.1", nil, 2, now); !ok {
+			t.Fatalf("take %d rejected", i)
+		}
+	}
+	if ok, retryAfter := l.take("192.0.2.1", nil, 2, now); ok || retryAfter != 2*time.Second {
+		t.Fatalf("take over the budget = %v, %v, want rejected for 2s", ok, retryAfter)
+	}
+	l.deduct("192.0.2.1", nil, 10, now)
+	if ok, retryAfter := l.take("192.0.2.1", nil, 1, now); ok || retryAfter != 5*time.Second {
+		t.Fatalf("take in debt = %v, %v, want rejected for 5s", ok, retryAfter)
+	}
+
+	// the clients with an API key have the budget of the key, unlimited without a rate limit
+	k := &common.ApiKey{ID: "partner", RateLimit: 600, RateWindow: time.Minute, Burst: 100}
+	for i := 0; i < 50; i++ {
+		if ok, _ := l.take(apiKeyLimitKey("partner"), k, 2, now); !ok {
+			t.Fatalf("take %d of the key rejected", i)
+		}
+	}
+	if ok, _ := l.take(apiKeyLimitKey("partner"), k, 2, now); ok {
+		t.Fatal("take over the budget of the key accepted")
+	}
+	if ok, _ := l.take(apiKeyLimitKey("unlimited"), &common.ApiKey{ID: "unlimited"}, 1000, now); !ok {
+		t.Fatal("take of a key without a rate limit rejected")
+	}
+
+	l.sweep(now.Add(websocketConnectionLimiterTTL + time.Second))
+	if len(l.clients) != 0 {
+		t.Fatalf("sweep left %d clients", len(l.clients))
+	}
+
+	var none *websocketCostLimiter
+	if ok, _ := none.take("192.0.2.1", nil, 1000, now); !ok {
+		t.Fatal("nil limiter rejected")
+	}
+	none.deduct("192.0.2.1", nil, 1000, now)
+	none.sweep(now)
+}
+
+func TestWebsocketRequestCost(t *testing.T) {
+	s := &WebsocketServer{
+		is:          newTestApiKeyState(&common.ApiKey{ID: "partner", RateLimit: 2, RateWindow: time.Hour, Burst: 2}),
+		costLimiter: &websocketCostLimiter{clients: make(map[string]*websocketClientCost), rateLimit: 4, rateWindow: time.Hour, burst: 4},
+	}
+
+	c := &websocketChannel{ipKey: "192.0.2.1", costLimited: true}
+	for i := 0; i < 2; i++ {
+		work, err := s.acceptRequestCost(c, "getBalanceHistory")
+		if err != nil || work == nil {
+			t.Fatalf("request %d = %v, %v, want accepted with a work counter", i, work, err)
+		}
+		s.chargeRequestWork(c, "getBalanceHistory", work)
+	}
+	_, err := s.acceptRequestCost(c, "getInfo")
+	if err == nil || !strings.HasPrefix(err.Error(), "Rate limit exceeded") {
+		t.Fatalf("request over the budget = %v, want rate limit error", err)
+	}
+	if apiErr, ok := err.(*api.APIError); !ok || !apiErr.Public {
+		t.Fatalf("error %T is not a public api error", err)
+	}
+
+	// the channels of a local peer without an attribution header are not charged
+	if work, err := s.acceptRequestCost(&websocketChannel{ipKey: "192.0.2.1"}, "getInfo"); work != nil || err != nil {
+		t.Fatalf("request of a not limited channel = %v, %v, want nil work", work, err)
+	}
+	s.chargeRequestWork(&websocketChannel{ipKey: "192.0.2.1"}, "getInfo", nil)
+
+	// the channels of an API key use the budget of the key
+	k := &websocketChannel{ipKey: apiKeyLimitKey("partner"), apiKeyID: "partner", costLimited: true}
+	if _, err := s.acceptRequestCost(k, "getBalanceHistory"); err != nil {
+		t.Fatalf("request of the key = %v, want accepted", err)
+	}
+	if _, err := s.acceptRequestCost(k, "getInfo"); err == nil {
+		t.Fatal("request over the budget of the key accepted")
+	}
+}
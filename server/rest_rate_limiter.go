@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/trezor/blockbook/api"
 	"github.com/trezor/blockbook/common"
 )
 
@@ -47,6 +49,15 @@ const (
 	restUIRejectIPBlocked          = "ip_blocked"
 )
 
+// restUIClient identifies the client of a request for the limiter
+type restUIClient struct {
+	ipKey     string
+	blockKey  string
+	blockable bool
+	// apiKey is the API key presented by the client, its limits replace the limits of the limiter
+	apiKey *common.ApiKey
+}
+
 type restUILimiterConfig struct {
 	rateLimit       int
 	rateWindow      time.Duration
@@ -65,6 +76,7 @@ type restUIRateLimiter struct {
 	lastCleanup        time.Time
 	capWarned          bool
 	metrics            *common.Metrics
+	is                 *common.InternalState
 	rateLimit          int
 	rateWindow         time.Duration
 	burst              int
@@ -106,8 +118,8 @@ type restUILimitDecision struct {
 	shouldLog bool
 }
 
-func newRestUIRateLimiter(network string, metrics *common.Metrics) (*restUIRateLimiter, error) {
-	cfg, err := readRestUILimiterConfig(network)
+func newRestUIRateLimiter(is *common.InternalState, metrics *common.Metrics) (*restUIRateLimiter, error) {
+	cfg, err := readRestUILimiterConfig(is.GetNetwork())
 	if err != nil {
 		return nil, err
 	}
@@ -118,6 +130,7 @@ func newRestUIRateLimiter(network string, metrics *common.Metrics) (*restUIRateL
 	l := &restUIRateLimiter{
 		clients:            make(map[string]*restUIClientLimit),
 		metrics:            metrics,
+		is:                 is,
 		rateLimit:          cfg.rateLimit,
 		rateWindow:         cfg.rateWindow,
 		burst:              cfg.burst,
@@ -239,46 +252,108 @@ func (l *restUIRateLimiter) wrapPublic(next http.Handler, basePath string) http.
 			next.ServeHTTP(w, r)
 			return
 		}
-		ip, blockSafe, fromHeader := resolveClientIP(r, l.trustedProxies, l.cloudflarePrefixes, l.trustPseudoIPv6)
-		if !fromHeader && isLocalOrTrustedProxyIP(ip, l.trustedProxies) {
-			// Request came straight from the operator's own loopback/LAN/trusted proxy
-			// with no client-attribution header: the key would be a shared infrastructure
-			// address, so limiting it would throttle the whole deployment as one client.
-			l.localBypassWarn.Do(func() {
-				glog.Info("REST/UI request from local/trusted peer ", ip,
-					" without a client attribution header; such requests are not rate limited")
-			})
-			next.ServeHTTP(w, r)
+		if _, err := requestApiKey(l.is, r); err != nil {
+			observeApiKeyRejection(l.metrics, "", apiKeyRejectInvalidKey)
+			writeRestUIErrorResponse(w, http.StatusUnauthorized, err.Error())
 			return
 		}
-		ipKey := rateLimitKey(ip)
-		// blockKey keeps IPv6 at the full /128 so a temporary block never takes
-		// out a whole shared /64 (rate limiting still aggregates to /64 via
-		// ipKey). For IPv4 the two keys are identical.
-		bKey := ""
-		blockable := false
-		if l.blockDuration > 0 {
-			bKey = blockKey(ip)
-			blockable = blockSafe && isBlockableKey(ip, l.trustedProxies, l.cloudflarePrefixes)
-		}
-		decision := l.accept(ipKey, bKey, blockable, time.Now())
-		if !decision.accepted {
-			l.observeRejection(decision.reason)
-			if decision.shouldLog {
-				glog.Warning("REST/UI request rejected, ", ipKey, ", ", decision.reason)
-			}
-			writeRestUIRateLimitResponse(w, decision.retryAfter)
+		route, cost := restUIRouteCost(r.URL.Path, basePath)
+		work := &api.RequestWork{}
+		release, retryAfter, ok := l.acceptRequest(r, route, cost, work)
+		if !ok {
+			writeRestUIRateLimitResponse(w, retryAfter)
 			return
 		}
-		if !decision.untracked {
-			// Wrap the release so time.Now() is evaluated when the handler
-			// finishes, not when the defer is registered.
-			defer func() { l.release(ipKey, time.Now()) }()
+		if route == sseRoute {
+			// a Server-Sent Events stream is charged only when it is accepted, the open streams are limited
+			// by the connection limit of the websocket server and do not hold a slot of the concurrent requests
+			release()
+			next.ServeHTTP(w, r)
+			return
 		}
-		next.ServeHTTP(w, r)
+		// release evaluates time.Now() when the handler finishes, not when the defer is registered
+		defer release()
+		next.ServeHTTP(w, r.WithContext(withRequestWork(r.Context(), work)))
 	})
 }
 
+// acceptRequest applies the limits to the client of the request, taking the base cost of its route from the
+// request-rate budget of the client. A rejected request gets the time after which the client may retry, an accepted
+// one the release function which must be called when the request is finished; it deducts the cost of the work counted
+// to work by the handler.
+func (l *restUIRateLimiter) acceptRequest(r *http.Request, route string, cost float64, work *api.RequestWork) (release func(), retryAfter time.Duration, ok bool) {
+	c, limited := l.client(r)
+	if !limited {
+		return func() {}, 0, true
+	}
+	if c.apiKey != nil {
+		observeApiKeyRequest(l.metrics, c.apiKey.ID, "rest")
+	}
+	decision := l.acceptCost(c.ipKey, c.blockKey, c.blockable, c.apiKey, cost, time.Now())
+	if !decision.accepted {
+		l.observeRejection(c, decision)
+		return nil, decision.retryAfter, false
+	}
+	if decision.untracked {
+		return func() {}, 0, true
+	}
+	return func() {
+		now := time.Now()
+		workCost := requestWorkCost(work)
+		l.deduct(c.ipKey, c.apiKey, workCost, now)
+		l.release(c.ipKey, now)
+		observeRequestCost(l.metrics, "rest", route, cost+workCost)
+	}, 0, true
+}
+
+// client identifies the client of the request by its API key or by its IP address,
+// limited is false for the requests which are not rate limited at all
+func (l *restUIRateLimiter) client(r *http.Request) (c restUIClient, limited bool) {
+	// an unknown key is refused before the limiter (wrapPublic), here it is a key deleted in the meantime
+	if apiKey, _ := requestApiKey(l.is, r); apiKey != nil {
+		return restUIClient{ipKey: apiKeyLimitKey(apiKey.ID), apiKey: apiKey}, true
+	}
+	ip, blockSafe, fromHeader := resolveClientIP(r, l.trustedProxies, l.cloudflarePrefixes, l.trustPseudoIPv6)
+	if !fromHeader && isLocalOrTrustedProxyIP(ip, l.trustedProxies) {
+		// Request came straight from the operator's own loopback/LAN/trusted proxy
+		// with no client-attribution header: the key would be a shared infrastructure
+		// address, so limiting it would throttle the whole deployment as one client.
+		l.localBypassWarn.Do(func() {
+			glog.Info("REST/UI request from local/trusted peer ", ip,
+				" without a client attribution header; such requests are not rate limited")
+		})
+		return c, false
+	}
+	c.ipKey = rateLimitKey(ip)
+	// blockKey keeps IPv6 at the full /128 so a temporary block never takes
+	// out a whole shared /64 (rate limiting still aggregates to /64 via
+	// ipKey). For IPv4 the two keys are identical.
+	if l.blockDuration > 0 {
+		c.blockKey = blockKey(ip)
+		c.blockable = blockSafe && isBlockableKey(ip, l.trustedProxies, l.cloudflarePrefixes)
+	}
+	return c, true
+}
+
+// chargeRequest takes additional tokens from the request-rate budget of the client of an already accepted
+// request which does more work than an ordinary request (e.g. a GraphQL query by its cost).
+// A request which the client cannot pay for is rejected and takes no tokens.
+func (l *restUIRateLimiter) chargeRequest(r *http.Request, tokens float64) (retryAfter time.Duration, ok bool) {
+	if tokens <= 0 {
+		return 0, true
+	}
+	c, limited := l.client(r)
+	if !limited {
+		return 0, true
+	}
+	decision := l.charge(c.ipKey, c.blockKey, c.blockable, c.apiKey, tokens, time.Now())
+	if !decision.accepted {
+		l.observeRejection(c, decision)
+		return decision.retryAfter, false
+	}
+	return 0, true
+}
+
 func isRateLimitedRoute(reqPath, basePath string) bool {
 	if !strings.HasPrefix(reqPath, basePath) {
 		return false
@@ -303,7 +378,20 @@ func isRateLimitedRoute(reqPath, basePath string) bool {
 	return true
 }
 
-func (l *restUIRateLimiter) accept(ipKey, blockKey string, blockable bool, now time.Time) restUILimitDecision {
+// limits returns the limits of the client, the limits of its API key if it has one
+func (l *restUIRateLimiter) limits(apiKey *common.ApiKey) (rateLimit int, rateWindow time.Duration, burst, maxConcurrent int) {
+	if apiKey != nil {
+		return apiKey.RateLimit, apiKey.RateWindow, apiKey.Burst, apiKey.MaxConcurrent
+	}
+	return l.rateLimit, l.rateWindow, l.burst, l.maxConcurrent
+}
+
+func (l *restUIRateLimiter) accept(ipKey, blockKey string, blockable bool, apiKey *common.ApiKey, now time.Time) restUILimitDecision {
+	return l.acceptCost(ipKey, blockKey, blockable, apiKey, defaultRequestCost, now)
+}
+
+// acceptCost accepts a request which takes the given number of tokens from the request-rate budget
+func (l *restUIRateLimiter) acceptCost(ipKey, blockKey string, blockable bool, apiKey *common.ApiKey, tokens float64, now time.Time) restUILimitDecision {
 	l.mux.Lock()
 	defer l.mux.Unlock()
 
@@ -323,8 +411,10 @@ func (l *restUIRateLimiter) accept(ipKey, blockKey string, blockable bool, now t
 		}
 	}
 
+	rateLimit, rateWindow, burst, maxConcurrent := l.limits(apiKey)
 	client := l.clients[ipKey]
-	if client == nil {
+	// the API keys are few and managed by the admin, they are tracked over the cap
+	if client == nil && apiKey == nil {
 		if len(l.clients) >= restUIMaxTrackedClients {
 			l.sweepLocked(now)
 		}
@@ -336,12 +426,14 @@ func (l *restUIRateLimiter) accept(ipKey, blockKey string, blockable bool, now t
 			}
 			return restUILimitDecision{accepted: true, untracked: true}
 		}
+	}
+	if client == nil {
 		client = &restUIClientLimit{}
 		l.clients[ipKey] = client
 	}
 	client.lastSeen = now
 
-	if l.maxConcurrent > 0 && client.active >= l.maxConcurrent {
+	if maxConcurrent > 0 && client.active >= maxConcurrent {
 		l.recordBreachLocked(blockKey, blockable, now)
 		return restUILimitDecision{
 			reason:    restUIRejectConcurrentRequests,
@@ -349,8 +441,8 @@ func (l *restUIRateLimiter) accept(ipKey, blockKey string, blockable bool, now t
 		}
 	}
 
-	if l.rateLimit > 0 {
-		ok, retryAfter := client.bucket.allow(now, l.rateLimit, l.rateWindow, l.burst)
+	if rateLimit > 0 {
+		ok, retryAfter := client.bucket.take(now, tokens, rateLimit, rateWindow, burst)
 		if !ok {
 			l.recordBreachLocked(blockKey, blockable, now)
 			return restUILimitDecision{
@@ -365,6 +457,43 @@ func (l *restUIRateLimiter) accept(ipKey, blockKey string, blockable bool, now t
 	return restUILimitDecision{accepted: true}
 }
 
+func (l *restUIRateLimiter) charge(ipKey, blockKey string, blockable bool, apiKey *common.ApiKey, tokens float64, now time.Time) restUILimitDecision {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	rateLimit, rateWindow, burst, _ := l.limits(apiKey)
+	client := l.clients[ipKey]
+	// the client of an untracked request (tracking-cap fail-open) is not charged either
+	if client == nil || rateLimit <= 0 {
+		return restUILimitDecision{accepted: true}
+	}
+	client.lastSeen = now
+	ok, retryAfter := client.bucket.take(now, tokens, rateLimit, rateWindow, burst)
+	if !ok {
+		l.recordBreachLocked(blockKey, blockable, now)
+		return restUILimitDecision{
+			reason:     restUIRejectRequestRate,
+			retryAfter: retryAfter,
+			shouldLog:  client.shouldLogRejection(restUIRejectRequestRate, now),
+		}
+	}
+	return restUILimitDecision{accepted: true}
+}
+
+// deduct takes the tokens of the work already done by an accepted request, the budget of the client may go into debt
+func (l *restUIRateLimiter) deduct(ipKey string, apiKey *common.ApiKey, tokens float64, now time.Time) {
+	if tokens <= 0 {
+		return
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	rateLimit, rateWindow, burst, _ := l.limits(apiKey)
+	if client := l.clients[ipKey]; client != nil {
+		client.bucket.deduct(now, tokens, rateLimit, rateWindow, burst)
+	}
+}
+
 func (l *restUIRateLimiter) release(ipKey string, now time.Time) {
 	l.mux.Lock()
 	defer l.mux.Unlock()
@@ -413,9 +542,39 @@ func (l *restUIRateLimiter) recordBreachLocked(blockKey string, blockable bool,
 }
 
 func (b *restUITokenBucket) allow(now time.Time, rateLimit int, rateWindow time.Duration, burst int) (bool, time.Duration) {
+	return b.take(now, 1, rateLimit, rateWindow, burst)
+}
+
+// take consumes the given number of tokens from the bucket, if there are not enough tokens,
+// nothing is consumed and the time after which the tokens are available is returned
+func (b *restUITokenBucket) take(now time.Time, tokens float64, rateLimit int, rateWindow time.Duration, burst int) (bool, time.Duration) {
 	if rateLimit <= 0 {
 		return true, 0
 	}
+	ratePerSecond := b.refill(now, rateLimit, rateWindow, burst)
+	if b.tokens >= tokens {
+		b.tokens -= tokens
+		return true, 0
+	}
+	if ratePerSecond <= 0 {
+		return false, rateWindow
+	}
+	return false, time.Duration(math.Ceil((tokens - b.tokens) / ratePerSecond * float64(time.Second)))
+}
+
+// deduct consumes the tokens of the work which was already done, so unlike take it never refuses. The bucket may go
+// into debt of at most one burst, which delays the following requests until it is refilled; the bound keeps a single
+// request from locking its client out for longer than the time to refill two bursts.
+func (b *restUITokenBucket) deduct(now time.Time, tokens float64, rateLimit int, rateWindow time.Duration, burst int) {
+	if rateLimit <= 0 || tokens <= 0 {
+		return
+	}
+	b.refill(now, rateLimit, rateWindow, burst)
+	b.tokens = math.Max(-float64(burst), b.tokens-tokens)
+}
+
+// refill adds the tokens accrued since the last refill and returns the refill rate in tokens per second
+func (b *restUITokenBucket) refill(now time.Time, rateLimit int, rateWindow time.Duration, burst int) float64 {
 	if b.lastRefill.IsZero() {
 		b.tokens = float64(burst)
 		b.lastRefill = now
@@ -426,14 +585,7 @@ func (b *restUITokenBucket) allow(now time.Time, rateLimit int, rateWindow time.
 	ratePerSecond := float64(rateLimit) / rateWindow.Seconds()
 	b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.lastRefill).Seconds()*ratePerSecond)
 	b.lastRefill = now
-	if b.tokens >= 1 {
-		b.tokens--
-		return true, 0
-	}
-	if ratePerSecond <= 0 {
-		return false, rateWindow
-	}
-	return false, time.Duration(math.Ceil((1 - b.tokens) / ratePerSecond * float64(time.Second)))
+	return ratePerSecond
 }
 
 func trimTimes(times []time.Time, cutoff time.Time) []time.Time {
@@ -505,9 +657,15 @@ func (l *restUIRateLimiter) runMaintenance(interval time.Duration) {
 	}
 }
 
-func (l *restUIRateLimiter) observeRejection(reason string) {
+func (l *restUIRateLimiter) observeRejection(c restUIClient, decision restUILimitDecision) {
 	if l.metrics != nil {
-		l.metrics.RestUIRateLimitRejections.With(common.Labels{"reason": reason}).Inc()
+		l.metrics.RestUIRateLimitRejections.With(common.Labels{"reason": decision.reason}).Inc()
+	}
+	if c.apiKey != nil {
+		observeApiKeyRejection(l.metrics, c.apiKey.ID, decision.reason)
+	}
+	if decision.shouldLog {
+		glog.Warning("REST/UI request rejected, ", c.ipKey, ", ", decision.reason)
 	}
 }
 
@@ -528,8 +686,6 @@ func (client *restUIClientLimit) shouldLogRejection(reason string, now time.Time
 }
 
 func writeRestUIRateLimitResponse(w http.ResponseWriter, retryAfter time.Duration) {
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.Header().Set("Content-Security-Policy", getContentSecurityPolicy())
 	if retryAfter > 0 {
 		seconds := int64(math.Ceil(retryAfter.Seconds()))
 		if seconds < 1 {
@@ -537,8 +693,17 @@ func writeRestUIRateLimitResponse(w http.ResponseWriter, retryAfter time.Duratio
 		}
 		w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
 	}
-	w.WriteHeader(http.StatusTooManyRequests)
-	if _, err := w.Write([]byte("{\"error\":\"rate limit exceeded\"}\n")); err != nil {
-		glog.Warning("write REST/UI rate-limit response: ", err)
+	writeRestUIErrorResponse(w, http.StatusTooManyRequests, "rate limit exceeded")
+}
+
+func writeRestUIErrorResponse(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", getContentSecurityPolicy())
+	w.WriteHeader(status)
+	b, _ := json.Marshal(struct {
+		Error string `json:"error"`
+	}{message})
+	if _, err := w.Write(append(b, '\n')); err != nil {
+		glog.Warning("write REST/UI error response: ", err)
 	}
 }
@@ -379,7 +379,7 @@ func TestRestUIRateLimiterTemporaryBlock(t *testing.T) {
 	limiter.burst = 1
 	limiter.blockDuration = time.Minute
 
-	if decision := limiter.accept("192.0.2.10", "192.0.2.10", true, now); !decision.accepted {
+	if decision := limiter.accept("192.0.2.10", "192.0.2.10", true, nil, now); !decision.accepted {
 		t.Fatalf("first decision = %+v, want accepted", decision)
 	}
 	limiter.release("192.0.2.10", now)
@@ -388,12 +388,12 @@ func TestRestUIRateLimiterTemporaryBlock(t *testing.T) {
 	var last time.Time
 	for i := 0; i < restUIBreachBlockThreshold; i++ {
 		last = now.Add(time.Duration(i) * restUIBreachMinSpacing)
-		decision := limiter.accept("192.0.2.10", "192.0.2.10", true, last)
+		decision := limiter.accept("192.0.2.10", "192.0.2.10", true, nil, last)
 		if decision.accepted || decision.reason != restUIRejectRequestRate {
 			t.Fatalf("breach %d decision = %+v, want request-rate rejection", i, decision)
 		}
 	}
-	decision := limiter.accept("192.0.2.10", "192.0.2.10", true, last.Add(time.Second))
+	decision := limiter.accept("192.0.2.10", "192.0.2.10", true, nil, last.Add(time.Second))
 	if decision.accepted || decision.reason != restUIRejectIPBlocked {
 		t.Fatalf("blocked decision = %+v, want ip_blocked", decision)
 	}
@@ -404,12 +404,12 @@ func TestRestUIRateLimiterTemporaryBlock(t *testing.T) {
 	limiter.rateLimit = 1
 	limiter.burst = 1
 	limiter.blockDuration = time.Minute
-	if decision := limiter.accept("192.0.2.11", "192.0.2.11", true, now); !decision.accepted {
+	if decision := limiter.accept("192.0.2.11", "192.0.2.11", true, nil, now); !decision.accepted {
 		t.Fatalf("first burst decision = %+v, want accepted", decision)
 	}
 	limiter.release("192.0.2.11", now)
 	for i := 0; i < 3*restUIBreachBlockThreshold; i++ {
-		decision := limiter.accept("192.0.2.11", "192.0.2.11", true, now)
+		decision := limiter.accept("192.0.2.11", "192.0.2.11", true, nil, now)
 		if decision.accepted || decision.reason != restUIRejectRequestRate {
 			t.Fatalf("burst rejection %d decision = %+v, want request-rate rejection", i, decision)
 		}
@@ -422,12 +422,12 @@ func TestRestUIRateLimiterTemporaryBlock(t *testing.T) {
 	limiter.rateLimit = 1
 	limiter.burst = 1
 	limiter.blockDuration = time.Minute
-	if decision := limiter.accept("127.0.0.1", "127.0.0.1", false, now); !decision.accepted {
+	if decision := limiter.accept("127.0.0.1", "127.0.0.1", false, nil, now); !decision.accepted {
 		t.Fatalf("first unblockable decision = %+v, want accepted", decision)
 	}
 	limiter.release("127.0.0.1", now)
 	for i := 0; i < restUIBreachBlockThreshold+1; i++ {
-		decision = limiter.accept("127.0.0.1", "127.0.0.1", false, now.Add(time.Duration(i)*restUIBreachMinSpacing))
+		decision = limiter.accept("127.0.0.1", "127.0.0.1", false, nil, now.Add(time.Duration(i)*restUIBreachMinSpacing))
 		if decision.accepted || decision.reason != restUIRejectRequestRate {
 			t.Fatalf("unblockable breach %d decision = %+v, want request-rate rejection", i, decision)
 		}
@@ -455,25 +455,25 @@ func TestRestUIRateLimiterIPv6BlockIsPer128(t *testing.T) {
 
 	// Consume the single shared token, then drive separate breach episodes that
 	// are all attributed to b1.
-	if d := limiter.accept(key64, b1, true, now); !d.accepted {
+	if d := limiter.accept(key64, b1, true, nil, now); !d.accepted {
 		t.Fatalf("first decision = %+v, want accepted", d)
 	}
 	limiter.release(key64, now)
 	var last time.Time
 	for i := 0; i < restUIBreachBlockThreshold; i++ {
 		last = now.Add(time.Duration(i) * restUIBreachMinSpacing)
-		if d := limiter.accept(key64, b1, true, last); d.accepted || d.reason != restUIRejectRequestRate {
+		if d := limiter.accept(key64, b1, true, nil, last); d.accepted || d.reason != restUIRejectRequestRate {
 			t.Fatalf("breach %d decision = %+v, want request-rate rejection", i, d)
 		}
 	}
 	after := last.Add(time.Second)
-	if d := limiter.accept(key64, b1, true, after); d.accepted || d.reason != restUIRejectIPBlocked {
+	if d := limiter.accept(key64, b1, true, nil, after); d.accepted || d.reason != restUIRejectIPBlocked {
 		t.Fatalf("b1 decision = %+v, want ip_blocked", d)
 	}
 
 	// A different /128 in the same /64 must NOT inherit the block: it is still
 	// subject to the shared /64 rate limit (request_rate) but never ip_blocked.
-	if d := limiter.accept(key64, b2, true, after); d.reason == restUIRejectIPBlocked {
+	if d := limiter.accept(key64, b2, true, nil, after); d.reason == restUIRejectIPBlocked {
 		t.Fatalf("b2 (same /64) wrongly ip_blocked: %+v", d)
 	}
 	if c := limiter.clients[b2]; c != nil && c.blockedUntil.After(after) {
@@ -578,10 +578,10 @@ func TestRestUIRateLimiterReleaseIsPerKey(t *testing.T) {
 	limiter := newTestRestUIRateLimiter()
 	limiter.maxConcurrent = 2
 	now := time.Unix(1_700_000_000, 0)
-	if decision := limiter.accept("192.0.2.20", "192.0.2.20", true, now); !decision.accepted {
+	if decision := limiter.accept("192.0.2.20", "192.0.2.20", true, nil, now); !decision.accepted {
 		t.Fatalf("accept = %+v", decision)
 	}
-	if decision := limiter.accept("192.0.2.20", "192.0.2.20", true, now); !decision.accepted {
+	if decision := limiter.accept("192.0.2.20", "192.0.2.20", true, nil, now); !decision.accepted {
 		t.Fatalf("second accept = %+v", decision)
 	}
 	limiter.release("192.0.2.20", now)
@@ -675,7 +675,7 @@ func TestRestUIRateLimiterTrackingCap(t *testing.T) {
 		limiter.clients[strconv.Itoa(i)] = &restUIClientLimit{lastSeen: now}
 	}
 
-	decision := limiter.accept("192.0.2.99", "192.0.2.99", true, now)
+	decision := limiter.accept("192.0.2.99", "192.0.2.99", true, nil, now)
 	if !decision.accepted || !decision.untracked {
 		t.Fatalf("decision at cap = %+v, want accepted and untracked", decision)
 	}
@@ -686,10 +686,92 @@ func TestRestUIRateLimiterTrackingCap(t *testing.T) {
 	limiter.release("192.0.2.99", now)
 
 	// already-tracked keys stay limited at the cap
-	if decision := limiter.accept("0", "0", true, now); !decision.accepted {
+	if decision := limiter.accept("0", "0", true, nil, now); !decision.accepted {
 		t.Fatalf("tracked accept = %+v, want accepted", decision)
 	}
-	if decision := limiter.accept("0", "0", true, now); decision.accepted {
+	if decision := limiter.accept("0", "0", true, nil, now); decision.accepted {
 		t.Fatalf("tracked second accept = %+v, want rejected", decision)
 	}
 }
+
+func TestRestUIRateLimiterChargeRequest(t *testing.T) {
+	limiter := newTestRestUIRateLimiter()
+	limiter.rateLimit = 10
+	limiter.burst = 10
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/api/v2/graphql", nil)
+	req.RemoteAddr = "192.0.2.40:12345"
+
+	release, _, ok := limiter.acceptRequest(req, "graphql", defaultRequestCost, nil)
+	if !ok {
+		t.Fatal("request rejected")
+	}
+	release()
+	if _, ok := limiter.chargeRequest(req, 6); !ok {
+		t.Fatal("charge of 6 tokens rejected")
+	}
+	// 3 tokens are left, a charge which cannot be paid takes nothing
+	retryAfter, ok := limiter.chargeRequest(req, 5)
+	if ok {
+		t.Fatal("charge of 5 tokens accepted")
+	}
+	if retryAfter <= 0 {
+		t.Fatalf("retryAfter = %v, want positive", retryAfter)
+	}
+	if _, ok := limiter.chargeRequest(req, 3); !ok {
+		t.Fatal("charge of the remaining 3 tokens rejected")
+	}
+	if _, _, ok := limiter.acceptRequest(req, "graphql", defaultRequestCost, nil); ok {
+		t.Fatal("request accepted with an empty bucket")
+	}
+
+	// requests from a local peer without an attribution header are not charged
+	local := httptest.NewRequest(http.MethodPost, "http://example.com/api/v2/graphql", nil)
+	local.RemoteAddr = "127.0.0.1:12345"
+	if _, ok := limiter.chargeRequest(local, 100); !ok {
+		t.Fatal("local request charged")
+	}
+}
+
+func TestRestUIRateLimiterSseStreamDoesNotHoldConcurrencySlot(t *testing.T) {
+	limiter := newTestRestUIRateLimiter()
+	limiter.maxConcurrent = 1
+	limiter.rateLimit = 2
+	limiter.burst = 2
+	streaming := make(chan struct{})
+	closeStream := make(chan struct{})
+	done := make(chan struct{})
+	handler := limiter.wrapPublic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events") {
+			close(streaming)
+			<-closeStream
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}), "/")
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/api/v2/events?newBlock=true", nil)
+	req.RemoteAddr = "192.0.2.9:12345"
+	go func() {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		close(done)
+	}()
+	<-streaming
+
+	// the open stream does not block the other requests of the client
+	req = httptest.NewRequest(http.MethodGet, "http://example.com/api/v2/status", nil)
+	req.RemoteAddr = "192.0.2.9:12345"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("request during the stream status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	// but the stream was charged at accept
+	req = httptest.NewRequest(http.MethodGet, "http://example.com/api/v2/status", nil)
+	req.RemoteAddr = "192.0.2.9:12345"
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("request over the rate status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	close(closeStream)
+	<-done
+}
@@ -0,0 +1,314 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/trezor/blockbook/api"
+)
+
+// sseKeepAlivePeriod is the period of the comments sent to keep the idle Server-Sent Events streams open through the proxies
+const sseKeepAlivePeriod = 30 * time.Second
+
+var (
+	errStreamConnectionLimit = errors.New("Too many connections")
+	errStreamShuttingDown    = errors.New("Server shutting down")
+)
+
+// names of the Server-Sent Events, the notifications of a subscription are sent as the events named by the subscription
+const (
+	sseNewBlockEvent       = "newBlock"
+	sseNewTransactionEvent = "newTransaction"
+	sseAddressesEvent      = "addresses"
+	sseFiatRatesEvent      = "fiatRates"
+	sseSubscribedEvent     = "subscribed"
+)
+
+// SseSubscriptionRes is sent as the 'subscribed' event of the Server-Sent Events stream for each requested subscription.
+type SseSubscriptionRes struct {
+	Subscription string `json:"subscription" ts_type:"'newBlock' | 'newTransaction' | 'addresses' | 'fiatRates'" ts_doc:"Subscription the response belongs to, also the name of the events carrying its notifications."`
+	WsSubscriptionRes
+}
+
+// parseSseSubscriptions converts the query parameters of the Server-Sent Events request to the websocket subscription requests
+func parseSseSubscriptions(r *http.Request) ([]*WsReq, error) {
+	q := r.URL.Query()
+	flag := func(name string) (bool, error) {
+		v := q.Get(name)
+		if v == "" {
+			return false, nil
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, api.NewAPIError("Invalid parameter "+name, true)
+		}
+		return b, nil
+	}
+	// the stream is resumed after the last received event, browsers send its id in the header on the reconnect
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = q.Get("lastEventId")
+	}
+	var sinceSequence uint64
+	if lastEventID != "" {
+		var err error
+		if sinceSequence, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			return nil, api.NewAPIError("Invalid Last-Event-ID", true)
+		}
+	}
+	var reqs []*WsReq
+	add := func(id, method string, params interface{}) error {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		reqs = append(reqs, &WsReq{ID: id, Method: method, Params: b})
+		return nil
+	}
+	if b, err := flag("newBlock"); err != nil {
+		return nil, err
+	} else if b {
+		if err = add(sseNewBlockEvent, "subscribeNewBlock", &WsSubscribeNewBlockReq{SinceSequence: sinceSequence}); err != nil {
+			return nil, err
+		}
+	}
+	if b, err := flag("newTransaction"); err != nil {
+		return nil, err
+	} else if b {
+		if err = add(sseNewTransactionEvent, "subscribeNewTransaction", struct{}{}); err != nil {
+			return nil, err
+		}
+	}
+	if addresses := q.Get("addresses"); addresses != "" {
+		newBlockTxs, err := flag("newBlockTxs")
+		if err != nil {
+			return nil, err
+		}
+		if err = add(sseAddressesEvent, "subscribeAddresses", &WsSubscribeAddressesReq{
+			Addresses:     strings.Split(addresses, ","),
+			NewBlockTxs:   newBlockTxs,
+			SinceSequence: sinceSequence,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if b, err := flag("fiatRates"); err != nil {
+		return nil, err
+	} else if b {
+		var tokens []string
+		if t := q.Get("tokens"); t != "" {
+			tokens = strings.Split(t, ",")
+		}
+		if err = add(sseFiatRatesEvent, "subscribeFiatRates", &WsSubscribeFiatRatesReq{Currency: q.Get("currency"), Tokens: tokens}); err != nil {
+			return nil, err
+		}
+	}
+	if len(reqs) == 0 {
+		return nil, api.NewAPIError("No subscription requested", true)
+	}
+	return reqs, nil
+}
+
+// writeSseEvent writes the notification as a Server-Sent Event named by the subscription, the sequence number is its id
+func writeSseEvent(w io.Writer, m *WsRes) error {
+	event, data := m.ID, m.Data
+	if r, ok := data.(*WsSubscriptionRes); ok {
+		event, data = sseSubscribedEvent, &SseSubscriptionRes{Subscription: m.ID, WsSubscriptionRes: *r}
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 0, len(b)+64)
+	if m.Seq != 0 {
+		buf = append(buf, "id: "...)
+		buf = strconv.AppendUint(buf, m.Seq, 10)
+		buf = append(buf, '\n')
+	}
+	buf = append(buf, "event: "...)
+	buf = append(buf, event...)
+	buf = append(buf, "\ndata: "...)
+	buf = append(buf, b...)
+	buf = append(buf, "\n\n"...)
+	_, err = w.Write(buf)
+	return err
+}
+
+func writeSseError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{message}); err != nil {
+		glog.Warning("json encode ", err)
+	}
+}
+
+// serveEvents streams the notifications of the subscriptions given by the query parameters as Server-Sent Events.
+// The stream is a channel of the websocket server without the websocket connection, it shares the subscriptions,
+// the notification journal and the per-client connection limit with the websocket clients.
+func (s *WebsocketServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeSseError(w, http.StatusMethodNotAllowed, ErrorMethodNotAllowed.Error())
+		return
+	}
+	reqs, err := parseSseSubscriptions(r)
+	if err != nil {
+		writeSseError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	c, err := s.openStream(r, cancel)
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if err == errStreamConnectionLimit {
+			status = http.StatusTooManyRequests
+		} else if err == errInvalidApiKey {
+			status = http.StatusUnauthorized
+		}
+		writeSseError(w, status, err.Error())
+		return
+	}
+	for _, req := range reqs {
+		if err := s.subscribeStream(c, req); err != nil {
+			s.closeChannel(c, "bad_request")
+			if apiErr, ok := err.(*api.APIError); ok && apiErr.Public {
+				writeSseError(w, http.StatusBadRequest, apiErr.Error())
+			} else {
+				glog.Error("Event stream ", req.Method, " error: ", err)
+				writeSseError(w, http.StatusBadRequest, "Invalid subscription "+req.ID)
+			}
+			return
+		}
+	}
+	s.sseOutputLoop(ctx, w, c)
+}
+
+// sseOutputLoop writes the notifications queued for the channel until the client disconnects or the channel is closed
+func (s *WebsocketServer) sseOutputLoop(ctx context.Context, w http.ResponseWriter, c *websocketChannel) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("recovered from panic: ", r, ", ", c.id)
+			s.closeChannel(c, "panic")
+		}
+	}()
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	// disable the response buffering of nginx
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	// the stream outlives the write timeout of the server, the deadline is extended for each write instead
+	_ = rc.SetWriteDeadline(time.Now().Add(defaultTimeout))
+	if err := rc.Flush(); err != nil {
+		s.closeChannel(c, "write_error")
+		return
+	}
+	keepAlive := time.NewTicker(sseKeepAlivePeriod)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			s.closeChannel(c, "client_closed")
+			return
+		case m, ok := <-c.out:
+			if !ok {
+				return
+			}
+			_ = rc.SetWriteDeadline(time.Now().Add(defaultTimeout))
+			err = writeSseEvent(w, m)
+			c.finalize(m)
+		case <-keepAlive.C:
+			_ = rc.SetWriteDeadline(time.Now().Add(defaultTimeout))
+			_, err = w.Write([]byte(": keep-alive\n\n"))
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			glog.Error("Error sending event to ", c.id, ", ", err)
+			s.closeChannel(c, "write_error")
+			return
+		}
+	}
+}
+
+// openStream creates and registers the channel of a stream without the websocket connection, the Server-Sent Events
+// stream or the gRPC subscription of the request. The stream counts to the connection limit of the client
+// or of its API key, cancel ends it on close.
+func (s *WebsocketServer) openStream(r *http.Request, cancel context.CancelFunc) (*websocketChannel, error) {
+	apiKey, err := requestApiKey(s.is, r)
+	if err != nil {
+		observeApiKeyRejection(s.metrics, "", apiKeyRejectInvalidKey)
+		return nil, err
+	}
+	ip, _, _ := resolveClientIP(r, s.trustedProxyPrefixes, s.cloudflarePrefixes, s.trustPseudoIPv6)
+	ipKey, ok := s.acceptConnection(ip, rateLimitKey(ip), apiKey)
+	if !ok {
+		return nil, errStreamConnectionLimit
+	}
+	c := &websocketChannel{
+		id:            atomic.AddUint64(&connectionCounter, 1),
+		out:           make(chan *WsRes, outChannelSize),
+		ip:            ip,
+		ipKey:         ipKey,
+		apiKeyID:      apiKeyID(apiKey),
+		requestHeader: r.Header,
+		alive:         true,
+		streamCancel:  cancel,
+	}
+	if s.is.WsGetAccountInfoLimit > 0 {
+		c.getAddressInfoDescriptors = make(map[string]struct{})
+	}
+	if !s.registerChannel(c) {
+		s.releaseConnection(ipKey)
+		return nil, errStreamShuttingDown
+	}
+	s.onConnect(c)
+	return c, nil
+}
+
+// subscribeStream runs the subscription request on the channel of a stream. Without the notification journal
+// the subscription does not send the response itself, it is sent here so that each subscription of a stream is confirmed.
+func (s *WebsocketServer) subscribeStream(c *websocketChannel, req *WsReq) error {
+	if err := s.checkApiKeyMethod(c, req.Method); err != nil {
+		return err
+	}
+	rv, err := requestHandlers[req.Method](s, c, req)
+	if err != nil {
+		return err
+	}
+	if m, ok := rv.(*subscriptionResponseMessage); ok && !m.Subscribed {
+		return api.NewAPIError(m.Message, true)
+	}
+	if rv != nil {
+		c.DataOut(&WsRes{ID: req.ID, Data: &WsSubscriptionRes{Subscribed: true}})
+	}
+	return nil
+}
+
+// closeStreams ends the Server-Sent Events and gRPC streams, they would block the shutdown of the servers otherwise
+func (s *WebsocketServer) closeStreams() {
+	s.shutdownMu.Lock()
+	var streams []*websocketChannel
+	for c := range s.activeChannels {
+		if c.streamCancel != nil {
+			streams = append(streams, c)
+		}
+	}
+	s.shutdownMu.Unlock()
+	for _, c := range streams {
+		s.closeChannel(c, "server_shutdown")
+	}
+}
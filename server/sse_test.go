@@ -0,0 +1,93 @@
+//go:build unittest
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseSseSubscriptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		lastEventID string
+		want        []string
+		wantParams  []string
+		wantErr     bool
+	}{
+		{
+			name:       "blocks and addresses resumed by the query",
+			url:        "/api/v2/events?newBlock=true&addresses=a,b&newBlockTxs=1&lastEventId=42",
+			want:       []string{"newBlock subscribeNewBlock", "addresses subscribeAddresses"},
+			wantParams: []string{`{"sinceSequence":42}`, `{"addresses":["a","b"],"newBlockTxs":true,"sinceSequence":42}`},
+		},
+		{
+			name:        "resumed by the header",
+			url:         "/api/v2/events?newBlock=true&lastEventId=1",
+			lastEventID: "7",
+			want:        []string{"newBlock subscribeNewBlock"},
+			wantParams:  []string{`{"sinceSequence":7}`},
+		},
+		{
+			name:       "transactions and fiat rates",
+			url:        "/api/v2/events?newTransaction=true&fiatRates=true&currency=usd&tokens=x,y",
+			want:       []string{"newTransaction subscribeNewTransaction", "fiatRates subscribeFiatRates"},
+			wantParams: []string{`{}`, `{"currency":"usd","tokens":["x","y"]}`},
+		},
+		{name: "disabled subscription", url: "/api/v2/events?newBlock=false", wantErr: true},
+		{name: "no subscription", url: "/api/v2/events", wantErr: true},
+		{name: "invalid flag", url: "/api/v2/events?newBlock=yes", wantErr: true},
+		{name: "invalid last event id", url: "/api/v2/events?newBlock=true", lastEventID: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.url, nil)
+			if tt.lastEventID != "" {
+				r.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			reqs, err := parseSseSubscriptions(r)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSseSubscriptions() = %+v, want error", reqs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reqs) != len(tt.want) {
+				t.Fatalf("parseSseSubscriptions() returned %d requests, want %d", len(reqs), len(tt.want))
+			}
+			for i := range reqs {
+				if got := reqs[i].ID + " " + reqs[i].Method; got != tt.want[i] {
+					t.Errorf("request %d = %s, want %s", i, got, tt.want[i])
+				}
+				if got := string(reqs[i].Params); got != tt.wantParams[i] {
+					t.Errorf("request %d params = %s, want %s", i, got, tt.wantParams[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWriteSseEvent(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeSseEvent(&buf, &WsRes{ID: "newBlock", Data: json.RawMessage(`{"height":1}`), Seq: 12}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeSseEvent(&buf, &WsRes{ID: "fiatRates", Data: map[string]float64{"usd": 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeSseEvent(&buf, &WsRes{ID: "addresses", Data: &WsSubscriptionRes{Subscribed: true, Sequence: 12, Resync: true}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "id: 12\nevent: newBlock\ndata: {\"height\":1}\n\n" +
+		"event: fiatRates\ndata: {\"usd\":1}\n\n" +
+		"event: subscribed\ndata: {\"subscription\":\"addresses\",\"subscribed\":true,\"sequence\":12,\"resync\":true}\n\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeSseEvent() wrote\n%s\nwant\n%s", got, want)
+	}
+}
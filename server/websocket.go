@@ -25,6 +25,7 @@ import (
 	"github.com/trezor/blockbook/common"
 	"github.com/trezor/blockbook/db"
 	"github.com/trezor/blockbook/fiat"
+	"golang.org/x/sync/singleflight"
 )
 
 const upgradeFailed = "Upgrade failed: "
@@ -54,6 +55,9 @@ const maxWebsocketSubscribeAddressesWithNewBlockTxs = 100
 const maxWebsocketSubscribeFiatRatesTokens = 1000
 const websocketLogPreviewBytes = 256
 
+// mempoolProjectionPeriod limits how often the mempool projection is recomputed, the mempool can resync every second
+const mempoolProjectionPeriod = 5 * time.Second
+
 // allRates is a special "currency" parameter that means all available currencies
 const allFiatRates = "!ALL!"
 
@@ -65,9 +69,11 @@ var (
 )
 
 // websocketChannel is a single client connection. ipKey is the per-IP rate-limit
-// key (IPv6 aggregated to /64); blockKey is the IP-blocklist key (full /128) kept
+// key (IPv6 aggregated to /64), or the limiter key of the API key for the clients
+// with an API key (apiKeyID is the id of the key); blockKey is the IP-blocklist key (full /128) kept
 // narrower so a hard block does not take out a whole shared /64; blockable records
-// whether blockKey is safe to add to the blocklist; messageRate is the per-connection
+// whether blockKey is safe to add to the blocklist; costLimited whether the requests
+// are charged to the cost budget of ipKey; messageRate is the per-connection
 // message counter (nil when disabled). All are touched only by ServeHTTP/inputLoop.
 type websocketChannel struct {
 	id                           uint64
@@ -78,6 +84,8 @@ type websocketChannel struct {
 	mempoolFiltersSlots          chan struct{} // semaphore capping in-flight getMempoolFilters responses, see maxWebsocketMempoolFiltersResponses
 	ip                           string
 	ipKey                        string
+	apiKeyID                     string
+	costLimited                  bool
 	blockKey                     string
 	blockable                    bool
 	messageRate                  *connMessageRate
@@ -88,6 +96,8 @@ type websocketChannel struct {
 	addrDescs                    []string // subscribed address descriptors as strings
 	getAddressInfoDescriptorsMux sync.Mutex
 	getAddressInfoDescriptors    map[string]struct{}
+	// streamCancel ends the Server-Sent Events or gRPC stream, which has no websocket connection, nil for the websocket clients
+	streamCancel context.CancelFunc
 }
 
 type addressDetails struct {
@@ -116,6 +126,8 @@ type WebsocketServer struct {
 	newTransactionSubscriptionsLock sync.Mutex
 	addressSubscriptions            map[string]map[*websocketChannel]*addressDetails
 	addressSubscriptionsLock        sync.Mutex
+	// apiKeySubscriptions counts the addresses subscribed by the connections of each API key, guarded by addressSubscriptionsLock
+	apiKeySubscriptions map[string]int
 	// newBlockTxsSubscriptionCount is a fast-path guard for OnNewBlock.
 	// It tracks how many address subscriptions requested newBlockTxs=true.
 	newBlockTxsSubscriptionCount int
@@ -148,6 +160,8 @@ type WebsocketServer struct {
 	// (ipBlockDuration > 0). When false, all block checks/sweeps are skipped.
 	ipBlockEnabled   bool
 	websocketLimiter *websocketConnectionLimiter
+	// costLimiter keeps the per-client cost budgets of the requests
+	costLimiter *websocketCostLimiter
 	// Shutdown coordination: protects shuttingDown + activeChannels and gates
 	// trackWork so RocksDB cannot be closed while a WS goroutine is mid-read.
 	shutdownMu     sync.Mutex
@@ -155,6 +169,23 @@ type WebsocketServer struct {
 	activeChannels map[*websocketChannel]struct{}
 	activeRequests int
 	requestWg      sync.WaitGroup
+	// subscribers of the projection of the mempool into blocks and the last projection, recomputed after the mempool resyncs
+	mempoolBlocksSubscriptions     map[*websocketChannel]string
+	mempoolBlocksSubscriptionsLock sync.Mutex
+	mempoolProjectionLock          sync.Mutex
+	mempoolBlocks                  *api.MempoolBlocks
+	mempoolHistogram               *api.MempoolFeeHistogram
+	mempoolProjectionTime          time.Time
+	mempoolProjectionRunning       atomic.Bool
+	mempoolProjectionFirst         singleflight.Group
+	// blockGasLimit is the gas limit of the last block of Ethereum type chains, used for the mempool projection
+	blockGasLimit atomic.Int64
+	// blockBaseFee is the base fee of the last block of Ethereum type chains, used for the mempool projection
+	blockBaseFee atomic.Pointer[big.Int]
+	// broadcastTracker tracks the sent transactions, nil if the tracking is disabled
+	broadcastTracker *api.BroadcastTracker
+	// journal numbers the notifications and keeps them for the replay after reconnect, nil if the journal is disabled
+	journal *wsJournal
 }
 
 // NewWebsocketServer creates new websocket interface to blockbook and returns its handle
@@ -183,6 +214,7 @@ func NewWebsocketServer(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.
 		addressSubscriptions:        make(map[string]map[*websocketChannel]*addressDetails),
 		fiatRatesSubscriptions:      make(map[string]map[*websocketChannel]string),
 		fiatRatesTokenSubscriptions: make(map[*websocketChannel][]string),
+		mempoolBlocksSubscriptions:  make(map[*websocketChannel]string),
 		websocketLimiter:            newWebsocketConnectionLimiter(),
 		activeChannels:              make(map[*websocketChannel]struct{}),
 	}
@@ -201,6 +233,9 @@ func NewWebsocketServer(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.
 	if err := initRpcCallAllowlists(db, is); err != nil {
 		return nil, err
 	}
+	if err := initApiKeys(db, is); err != nil {
+		return nil, err
+	}
 	clientIPCfg, err := readClientIPConfig(is.GetNetwork())
 	if err != nil {
 		return nil, err
@@ -225,6 +260,9 @@ func NewWebsocketServer(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.
 	if err := s.configurePendingRequestsLimit(is.GetNetwork()); err != nil {
 		return nil, err
 	}
+	if err := s.configureCostLimit(is.GetNetwork()); err != nil {
+		return nil, err
+	}
 	if s.metrics != nil {
 		s.metrics.WebsocketNewBlockTxsSubscriptions.Set(0)
 		s.metrics.WebsocketUniqueIPs.Set(0)
@@ -305,14 +343,21 @@ func (s *WebsocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Server shutting down", http.StatusServiceUnavailable)
 		return
 	}
-	ip, blockSafe, _ := resolveClientIP(r, s.trustedProxyPrefixes, s.cloudflarePrefixes, s.trustPseudoIPv6)
+	ip, blockSafe, fromHeader := resolveClientIP(r, s.trustedProxyPrefixes, s.cloudflarePrefixes, s.trustPseudoIPv6)
 	ipKey := rateLimitKey(ip)
+	apiKey, err := requestApiKey(s.is, r)
+	if err != nil {
+		observeApiKeyRejection(s.metrics, "", apiKeyRejectInvalidKey)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
 	// blockKey/blockable are computed only when the IP blocklist is enabled (skips the
 	// O(prefixes) isBlockableKey scan otherwise). blockKey keeps IPv6 at the full /128
 	// so a block never takes out a shared /64 (ipKey still aggregates to /64).
+	// The clients with an API key share the address of their tenant, they are never blocked.
 	bKey := ""
 	blockable := false
-	if s.ipBlockEnabled {
+	if s.ipBlockEnabled && apiKey == nil {
 		bKey = blockKey(ip)
 		blockable = blockSafe && isBlockableKey(ip, s.trustedProxyPrefixes, s.cloudflarePrefixes)
 	}
@@ -320,7 +365,7 @@ func (s *WebsocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Reject keys that are on the temporary IP blocklist before doing any
 	// upgrade work. Checked ahead of the connection limiter so a blocked client
 	// cannot keep consuming attempt slots.
-	if s.ipBlockEnabled {
+	if s.ipBlockEnabled && apiKey == nil {
 		if blocked, rejected := s.is.IsWsIPBlocked(bKey, time.Now()); blocked {
 			if s.metrics != nil {
 				s.metrics.WebsocketBlockedConnections.Inc()
@@ -336,24 +381,14 @@ func (s *WebsocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	limited := false
-	if s.websocketLimiter != nil {
-		ok, reason := s.websocketLimiter.accept(ipKey, time.Now())
-		if !ok {
-			if s.metrics != nil {
-				s.metrics.WebsocketConnectionRejections.With(common.Labels{"reason": reason}).Inc()
-			}
-			glog.Warning("Websocket connection rejected, ", ip, ", ", reason)
-			http.Error(w, "Too many websocket connections", http.StatusTooManyRequests)
-			return
-		}
-		limited = true
+	ipKey, ok := s.acceptConnection(ip, ipKey, apiKey)
+	if !ok {
+		http.Error(w, "Too many websocket connections", http.StatusTooManyRequests)
+		return
 	}
 	conn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		if limited {
-			s.websocketLimiter.release(ipKey, time.Now())
-		}
+		s.releaseConnection(ipKey)
 		http.Error(w, upgradeFailed+err.Error(), http.StatusServiceUnavailable)
 		return
 	}
@@ -368,6 +403,9 @@ func (s *WebsocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if s.pendingRequestsLimit > 0 {
 		pendingRequests = make(chan struct{}, s.pendingRequestsLimit)
 	}
+	// like the REST limits, the cost budget does not apply to a local peer without an attribution header,
+	// which would put all clients of the deployment to one budget
+	costLimited := apiKey != nil || fromHeader || !isLocalOrTrustedProxyIP(ip, s.trustedProxyPrefixes)
 	c := &websocketChannel{
 		id:                  atomic.AddUint64(&connectionCounter, 1),
 		conn:                conn,
@@ -376,6 +414,8 @@ func (s *WebsocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		mempoolFiltersSlots: make(chan struct{}, maxWebsocketMempoolFiltersResponses),
 		ip:                  ip,
 		ipKey:               ipKey,
+		apiKeyID:            apiKeyID(apiKey),
+		costLimited:         costLimited,
 		blockKey:            bKey,
 		blockable:           blockable,
 		requestHeader:       r.Header,
@@ -393,9 +433,7 @@ func (s *WebsocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	if !s.registerChannel(c) {
 		conn.Close()
-		if limited {
-			s.websocketLimiter.release(ipKey, time.Now())
-		}
+		s.releaseConnection(ipKey)
 		return
 	}
 	go s.inputLoop(c)
@@ -497,7 +535,7 @@ func (s *WebsocketServer) closeChannel(c *websocketChannel, reason string) bool
 		if s.metrics != nil {
 			s.metrics.WebsocketChannelCloses.With(common.Labels{"reason": closeReason}).Inc()
 		}
-		c.conn.Close()
+		c.closeConn()
 		s.onDisconnect(c)
 		return true
 	}
@@ -523,6 +561,15 @@ func (c *websocketChannel) CloseOut(reason string) (bool, string) {
 	return false, ""
 }
 
+// closeConn closes the websocket connection or ends the stream
+func (c *websocketChannel) closeConn() {
+	if c.streamCancel != nil {
+		c.streamCancel()
+		return
+	}
+	c.conn.Close()
+}
+
 func (c *websocketChannel) DataOut(data *WsRes) {
 	c.aliveLock.Lock()
 	defer c.aliveLock.Unlock()
@@ -539,7 +586,7 @@ func (c *websocketChannel) DataOut(data *WsRes) {
 		}
 		// close the connection but do not call CloseOut - would call duplicate c.aliveLock.Lock
 		// CloseOut will be called because the closed connection will cause break in the inputLoop
-		c.conn.Close()
+		c.closeConn()
 	}
 	// Not enqueued (overflow or dead connection): the response never reaches the
 	// out pipeline, so release any slot it held here.
@@ -708,9 +755,8 @@ func (s *WebsocketServer) onDisconnect(c *websocketChannel) {
 	s.unsubscribeNewTransaction(c)
 	s.unsubscribeAddresses(c)
 	s.unsubscribeFiatRates(c)
-	if s.websocketLimiter != nil {
-		s.websocketLimiter.release(c.ipKey, time.Now())
-	}
+	s.unsubscribeMempoolBlocks(c)
+	s.releaseConnection(c.ipKey)
 	s.unregisterChannel(c)
 	glog.Info("Client disconnected ", c.id, ", ", c.ip)
 	s.metrics.WebsocketConnectionRequests.Observe(float64(atomic.LoadUint64(&c.requests)))
@@ -734,7 +780,30 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 					return
 				}
 			}
-			rv, err = s.getAccountInfo(r)
+			rv, err = s.getAccountInfo(s.worker(req.work), r)
+		}
+		return
+	},
+	"getAccountsInfo": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
+		r := WsAccountsInfoReq{}
+		err = json.Unmarshal(req.Params, &r)
+		if err == nil {
+			if s.is.WsGetAccountInfoLimit > 0 {
+				c.getAddressInfoDescriptorsMux.Lock()
+				for _, d := range r.Descriptors {
+					c.getAddressInfoDescriptors[d] = struct{}{}
+				}
+				l := len(c.getAddressInfoDescriptors)
+				c.getAddressInfoDescriptorsMux.Unlock()
+				if l > s.is.WsGetAccountInfoLimit {
+					if s.closeChannel(c, "limit_exceeded") {
+						glog.Info("Client ", c.id, " exceeded getAddressInfo limit, ", c.ip)
+						s.is.AddWsLimitExceedingIP(c.ip)
+					}
+					return
+				}
+			}
+			rv, err = s.getAccountsInfo(s.worker(req.work), &r)
 		}
 		return
 	},
@@ -764,8 +833,7 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 		r := WsBlockReq{}
 		err = json.Unmarshal(req.Params, &r)
 		if err == nil {
-			r.Page, r.PageSize = sanitizePagingParams(r.Page, r.PageSize, txsInAPI, maxWebsocketBlockPageSize)
-			rv, err = s.getBlock(r.Id, r.Page, r.PageSize)
+			rv, err = s.getBlock(s.worker(req.work), r.Id, r.Page, r.PageSize)
 		}
 		return
 	},
@@ -773,7 +841,7 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 		r := WsAccountUtxoReq{}
 		err = json.Unmarshal(req.Params, &r)
 		if err == nil {
-			rv, err = s.getAccountUtxo(r.Descriptor)
+			rv, err = s.getAccountUtxo(s.worker(req.work), r.Descriptor)
 		}
 		return
 	},
@@ -781,23 +849,7 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 		r := WsBalanceHistoryReq{}
 		err = json.Unmarshal(req.Params, &r)
 		if err == nil {
-			if r.From <= 0 {
-				r.From = 0
-			}
-			if r.To <= 0 {
-				r.To = 0
-			}
-			if r.GroupBy <= 0 {
-				r.GroupBy = 3600
-			}
-			rv, err = s.api.GetXpubBalanceHistory(r.Descriptor, r.From, r.To, r.Currencies, r.Gap, r.GroupBy, s.is.BalanceHistoryMaxTxsWS, api.BalanceHistoryTransportWS)
-			if apiErr, ok := err.(*api.APIError); ok && apiErr.Public {
-				// A public error from the xpub path (e.g. the range spans too many
-				// transactions) is definitive for a valid xpub; do not retry as an
-				// address, which would mask it with an address-parse error.
-			} else if err != nil {
-				rv, err = s.api.GetBalanceHistory(r.Descriptor, r.From, r.To, r.Currencies, r.GroupBy, s.is.BalanceHistoryMaxTxsWS, api.BalanceHistoryTransportWS)
-			}
+			rv, err = s.getBalanceHistory(s.worker(req.work), &r)
 		}
 		return
 	},
@@ -805,7 +857,7 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 		r := WsTransactionReq{}
 		err = json.Unmarshal(req.Params, &r)
 		if err == nil {
-			rv, err = s.getTransaction(r.Txid)
+			rv, err = s.getTransaction(s.worker(req.work), r.Txid, r.MempoolPackage)
 		}
 		return
 	},
@@ -856,6 +908,14 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 		}
 		return
 	},
+	"simulateTransaction": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
+		r := WsSimulateTransactionReq{}
+		err = json.Unmarshal(req.Params, &r)
+		if err == nil {
+			rv, err = s.api.SimulateEthereumTx(r.Tx, r.Block)
+		}
+		return
+	},
 	"rpcCall": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
 		r := WsRpcCallReq{}
 		err = json.Unmarshal(req.Params, &r)
@@ -865,7 +925,13 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 		return
 	},
 	"subscribeNewBlock": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
-		return s.subscribeNewBlock(c, req)
+		var r WsSubscribeNewBlockReq
+		if len(req.Params) > 0 {
+			if err = json.Unmarshal(req.Params, &r); err != nil {
+				return nil, api.NewAPIError("Invalid subscribeNewBlock params", true)
+			}
+		}
+		return s.subscribeNewBlock(c, &r, req)
 	},
 	"unsubscribeNewBlock": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
 		return s.unsubscribeNewBlock(c)
@@ -877,9 +943,9 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 		return s.unsubscribeNewTransaction(c)
 	},
 	"subscribeAddresses": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
-		ad, nbtxs, err := s.unmarshalAddresses(req.Params)
+		ad, r, err := s.unmarshalAddresses(req.Params)
 		if err == nil {
-			rv, err = s.subscribeAddresses(c, ad, nbtxs, req)
+			rv, err = s.subscribeAddresses(c, ad, r, req)
 		}
 		return
 	},
@@ -902,6 +968,12 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *WsRe
 	"unsubscribeFiatRates": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
 		return s.unsubscribeFiatRates(c)
 	},
+	"subscribeMempoolBlocks": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
+		return s.subscribeMempoolBlocks(c, req)
+	},
+	"unsubscribeMempoolBlocks": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
+		return s.unsubscribeMempoolBlocks(c)
+	},
 	"ping": func(s *WebsocketServer, c *websocketChannel, req *WsReq) (rv interface{}, err error) {
 		r := struct{}{}
 		return r, nil
@@ -970,6 +1042,13 @@ func (s *WebsocketServer) onRequest(c *websocketChannel, req *WsReq) {
 		s.metrics.WebsocketReqDuration.With(common.Labels{"method": methodLabel}).Observe(float64(time.Since(t)) / 1e3) // in microseconds
 	}()
 	if ok {
+		if err = s.checkApiKeyMethod(c, req.Method); err != nil {
+			e := resultError{}
+			e.Error.Message = err.Error()
+			data = e
+			s.metrics.WebsocketRequests.With(common.Labels{"method": methodLabel, "status": "failure"}).Inc()
+			return
+		}
 		if req.Method == "getMempoolFilters" {
 			if !c.acquireMempoolFiltersSlot() {
 				e := resultError{}
@@ -981,7 +1060,15 @@ func (s *WebsocketServer) onRequest(c *websocketChannel, req *WsReq) {
 			}
 			release = c.releaseMempoolFiltersSlot
 		}
+		if req.work, err = s.acceptRequestCost(c, req.Method); err != nil {
+			e := resultError{}
+			e.Error.Message = err.Error()
+			data = e
+			s.metrics.WebsocketRequests.With(common.Labels{"method": methodLabel, "status": "failure"}).Inc()
+			return
+		}
 		data, err = f(s, c, req)
+		s.chargeRequestWork(c, req.Method, req.work)
 		if err == nil {
 			glog.V(1).Info("Client ", c.id, " onRequest ", req.Method, " success")
 			s.metrics.WebsocketRequests.With(common.Labels{"method": methodLabel, "status": "success"}).Inc()
@@ -1010,8 +1097,8 @@ func unmarshalGetAccountInfoRequest(params []byte) (*WsAccountInfoReq, error) {
 	return &r, nil
 }
 
-func (s *WebsocketServer) getAccountInfo(req *WsAccountInfoReq) (res *api.Address, err error) {
-	if err := s.api.ValidateProtocolsForChain(req.Protocols); err != nil {
+func (s *WebsocketServer) getAccountInfo(w *api.Worker, req *WsAccountInfoReq) (res *api.Address, err error) {
+	if err := w.ValidateProtocolsForChain(req.Protocols); err != nil {
 		return nil, err
 	}
 	var opt api.AccountDetails
@@ -1046,30 +1133,67 @@ func (s *WebsocketServer) getAccountInfo(req *WsAccountInfoReq) (res *api.Addres
 		TokensToReturn:     tokensToReturn,
 		Protocols:          req.Protocols,
 		WithConfirmedNonce: req.ConfirmedNonce,
+		WithDiagnostics:    req.Diagnostics,
+		WithDelegation:     req.Delegation,
+		Cursor:             req.Cursor,
 	}
 	req.Page, req.PageSize = sanitizeAccountPagingParams(req.Page, req.PageSize, txsOnPage, txsInAPI)
 	req.Gap = validateIntValue(req.Gap, 0, 0, maxGapValue)
-	a, err := s.api.GetXpubAddress(req.Descriptor, req.Page, req.PageSize, opt, &filter, req.Gap, strings.ToLower(req.SecondaryCurrency))
+	a, err := w.GetXpubAddress(req.Descriptor, req.Page, req.PageSize, opt, &filter, req.Gap, strings.ToLower(req.SecondaryCurrency))
 	if err != nil {
-		return s.api.GetAddress(req.Descriptor, req.Page, req.PageSize, opt, &filter, strings.ToLower(req.SecondaryCurrency))
+		return w.GetAddress(req.Descriptor, req.Page, req.PageSize, opt, &filter, strings.ToLower(req.SecondaryCurrency))
 	}
 	return a, nil
 }
 
+func (s *WebsocketServer) getAccountsInfo(w *api.Worker, req *WsAccountsInfoReq) (*api.AccountsInfo, error) {
+	if err := w.ValidateProtocolsForChain(req.Protocols); err != nil {
+		return nil, err
+	}
+	var opt api.AccountDetails
+	switch req.Details {
+	case "tokens":
+		opt = api.AccountDetailsTokens
+	case "tokenBalances":
+		opt = api.AccountDetailsTokenBalances
+	case "", "basic":
+		opt = api.AccountDetailsBasic
+	default:
+		return nil, api.NewAPIError("Unsupported details '"+req.Details+"', use basic, tokens or tokenBalances", true)
+	}
+	var tokensToReturn api.TokensToReturn
+	switch req.Tokens {
+	case "used":
+		tokensToReturn = api.TokensToReturnUsed
+	case "nonzero":
+		tokensToReturn = api.TokensToReturnNonzeroBalance
+	default:
+		tokensToReturn = api.TokensToReturnDerived
+	}
+	filter := api.AddressFilter{
+		Contract:       req.ContractFilter,
+		Vout:           api.AddressFilterVoutOff,
+		TokensToReturn: tokensToReturn,
+		Protocols:      req.Protocols,
+	}
+	gap := validateIntValue(req.Gap, 0, 0, maxGapValue)
+	return w.GetAccountsInfo(req.Descriptors, opt, &filter, gap, strings.ToLower(req.SecondaryCurrency))
+}
+
 func (s *WebsocketServer) getContractInfo(contract string, currency string, protocols []string) (*api.ContractInfoResult, error) {
 	return s.api.GetContractInfoData(contract, currency, protocols)
 }
 
-func (s *WebsocketServer) getAccountUtxo(descriptor string) (api.Utxos, error) {
-	utxo, err := s.api.GetXpubUtxo(descriptor, false, 0)
+func (s *WebsocketServer) getAccountUtxo(w *api.Worker, descriptor string) (api.Utxos, error) {
+	utxo, err := w.GetXpubUtxo(descriptor, false, 0)
 	if err != nil {
-		return s.api.GetAddressUtxo(descriptor, false)
+		return w.GetAddressUtxo(descriptor, false)
 	}
 	return utxo, nil
 }
 
-func (s *WebsocketServer) getTransaction(txid string) (*api.Tx, error) {
-	return s.api.GetTransaction(txid, false, false)
+func (s *WebsocketServer) getTransaction(w *api.Worker, txid string, mempoolPackage bool) (*api.Tx, error) {
+	return w.GetTransaction(txid, false, false, mempoolPackage)
 }
 
 func (s *WebsocketServer) getTransactionSpecific(txid string) (interface{}, error) {
@@ -1112,12 +1236,32 @@ func (s *WebsocketServer) getBlockHash(height int) (*WsBlockHashRes, error) {
 	}, nil
 }
 
-func (s *WebsocketServer) getBlock(id string, page, pageSize int) (interface{}, error) {
-	block, err := s.api.GetBlock(id, page, pageSize)
-	if err != nil {
+func (s *WebsocketServer) getBlock(w *api.Worker, id string, page, pageSize int) (*api.Block, error) {
+	page, pageSize = sanitizePagingParams(page, pageSize, txsInAPI, maxWebsocketBlockPageSize)
+	return w.GetBlock(id, page, pageSize)
+}
+
+// getBalanceHistory returns the balance history of the xpub or, if the descriptor is not an xpub, of the address
+func (s *WebsocketServer) getBalanceHistory(w *api.Worker, r *WsBalanceHistoryReq) (api.BalanceHistories, error) {
+	if r.From <= 0 {
+		r.From = 0
+	}
+	if r.To <= 0 {
+		r.To = 0
+	}
+	if r.GroupBy <= 0 {
+		r.GroupBy = 3600
+	}
+	bh, err := w.GetXpubBalanceHistory(r.Descriptor, r.From, r.To, r.Currencies, r.Gap, r.GroupBy, s.is.BalanceHistoryMaxTxsWS, api.BalanceHistoryTransportWS)
+	if apiErr, ok := err.(*api.APIError); ok && apiErr.Public {
+		// A public error from the xpub path (e.g. the range spans too many
+		// transactions) is definitive for a valid xpub; do not retry as an
+		// address, which would mask it with an address-parse error.
 		return nil, err
+	} else if err != nil {
+		return w.GetBalanceHistory(r.Descriptor, r.From, r.To, r.Currencies, r.GroupBy, s.is.BalanceHistoryMaxTxsWS, api.BalanceHistoryTransportWS)
 	}
-	return block, nil
+	return bh, nil
 }
 
 func eip1559FeesToApi(fee *bchain.Eip1559Fee) *api.Eip1559Fee {
@@ -1129,6 +1273,7 @@ func eip1559FeesToApi(fee *bchain.Eip1559Fee) *api.Eip1559Fee {
 	apiFee.MaxPriorityFeePerGas = (*api.Amount)(fee.MaxPriorityFeePerGas)
 	apiFee.MaxWaitTimeEstimate = fee.MaxWaitTimeEstimate
 	apiFee.MinWaitTimeEstimate = fee.MinWaitTimeEstimate
+	apiFee.MaxFeePerBlobGas = (*api.Amount)(fee.MaxFeePerBlobGas)
 	return &apiFee
 }
 
@@ -1169,6 +1314,20 @@ func (s *WebsocketServer) estimateFee(params []byte) (interface{}, error) {
 		}
 		feePerTx := new(big.Int)
 		feePerTx.Mul(&fee, new(big.Int).SetUint64(gas))
+		// the L1 data fee is best effort, a failing L1 fee call must not break the L2 estimate
+		l1Fee, err := s.chain.EthereumTypeEstimateL1Fee(r.Specific)
+		if err != nil {
+			glog.Warning("estimateFee: EthereumTypeEstimateL1Fee error ", err)
+			l1Fee = nil
+		}
+		var sl1 string
+		if l1Fee != nil && l1Fee.L1Fee != nil {
+			sl1 = l1Fee.L1Fee.String()
+			// rollups charging the L1 data fee in L2 gas have it already included in the gas estimate
+			if l1Fee.GasUsedForL1 == nil {
+				feePerTx.Add(feePerTx, l1Fee.L1Fee)
+			}
+		}
 		eip1559, err := s.chain.EthereumTypeGetEip1559Fees()
 		if err != nil {
 			return nil, err
@@ -1187,11 +1346,13 @@ func (s *WebsocketServer) estimateFee(params []byte) (interface{}, error) {
 			eip1559Api.LatestPriorityFeeRange = eip1559FeeRangeToApi(eip1559.LatestPriorityFeeRange)
 			eip1559Api.HistoricalBaseFeeRange = eip1559FeeRangeToApi(eip1559.HistoricalBaseFeeRange)
 			eip1559Api.HistoricalPriorityFeeRange = eip1559FeeRangeToApi(eip1559.HistoricalPriorityFeeRange)
+			eip1559Api.BaseFeePerBlobGas = (*api.Amount)(eip1559.BaseFeePerBlobGas)
 		}
 		for i := range r.Blocks {
 			res[i].FeePerUnit = fee.String()
 			res[i].FeeLimit = sg
 			res[i].FeePerTx = feePerTx.String()
+			res[i].L1Fee = sl1
 			res[i].Eip1559 = eip1559Api
 		}
 	} else {
@@ -1211,10 +1372,33 @@ func (s *WebsocketServer) estimateFee(params []byte) (interface{}, error) {
 				txSize = int(f)
 			}
 		}
+		provider, _ := r.Specific["provider"].(string)
+		if provider != "" && provider != "mempool" {
+			return nil, api.NewAPIError("Unknown fee provider "+provider, true)
+		}
 		for i, b := range r.Blocks {
-			fee, err := s.api.EstimateFee(b, conservative)
-			if err != nil {
-				return nil, err
+			var fee big.Int
+			if provider == "mempool" {
+				estimate, err := s.chain.EstimateMempoolFee(b)
+				if err != nil {
+					return nil, err
+				}
+				if conservative {
+					fee = estimate.High
+				} else {
+					fee = estimate.Medium
+				}
+				res[i].Confidence = &WsFeeConfidence{
+					Blocks: estimate.Blocks,
+					Low:    estimate.Low.String(),
+					Medium: estimate.Medium.String(),
+					High:   estimate.High.String(),
+				}
+			} else {
+				fee, err = s.api.EstimateFee(b, conservative)
+				if err != nil {
+					return nil, err
+				}
 			}
 			res[i].FeePerUnit = fee.String()
 			if txSize > 0 {
@@ -1240,7 +1424,12 @@ func (s *WebsocketServer) longTermFeeRate() (res interface{}, err error) {
 }
 
 func (s *WebsocketServer) sendTransaction(tx string, disableAlternativeRPC bool) (res resultSendTransaction, err error) {
-	txid, err := s.chain.SendRawTransaction(tx, disableAlternativeRPC)
+	var txid string
+	if s.broadcastTracker != nil {
+		txid, err = s.broadcastTracker.SendRawTransaction(tx, disableAlternativeRPC)
+	} else {
+		txid, err = s.chain.SendRawTransaction(tx, disableAlternativeRPC)
+	}
 	if err != nil {
 		return res, err
 	}
@@ -1385,12 +1574,18 @@ type subscriptionResponseMessage struct {
 	Message    string `json:"message"`
 }
 
-func (s *WebsocketServer) subscribeNewBlock(c *websocketChannel, req *WsReq) (res interface{}, err error) {
+func (s *WebsocketServer) subscribeNewBlock(c *websocketChannel, r *WsSubscribeNewBlockReq, req *WsReq) (res interface{}, err error) {
 	s.newBlockSubscriptionsLock.Lock()
 	defer s.newBlockSubscriptionsLock.Unlock()
 	s.newBlockSubscriptions[c] = req.ID
 	s.metrics.WebsocketSubscribes.With(common.Labels{"method": "subscribeNewBlock"}).Set(float64(len(s.newBlockSubscriptions)))
-	return &subscriptionResponse{true}, nil
+	if s.journal == nil {
+		return &subscriptionResponse{true}, nil
+	}
+	s.replayJournal(c, req.ID, r.SinceSequence, r.SinceHeight, nil, false, func(e *db.WsJournalEvent) bool {
+		return e.Kind == wsJournalNewBlock || e.Kind == wsJournalBlockDisconnected
+	})
+	return nil, nil
 }
 
 func (s *WebsocketServer) unsubscribeNewBlock(c *websocketChannel) (res interface{}, err error) {
@@ -1401,6 +1596,22 @@ func (s *WebsocketServer) unsubscribeNewBlock(c *websocketChannel) (res interfac
 	return &subscriptionResponse{false}, nil
 }
 
+func (s *WebsocketServer) subscribeMempoolBlocks(c *websocketChannel, req *WsReq) (res interface{}, err error) {
+	s.mempoolBlocksSubscriptionsLock.Lock()
+	defer s.mempoolBlocksSubscriptionsLock.Unlock()
+	s.mempoolBlocksSubscriptions[c] = req.ID
+	s.metrics.WebsocketSubscribes.With(common.Labels{"method": "subscribeMempoolBlocks"}).Set(float64(len(s.mempoolBlocksSubscriptions)))
+	return &subscriptionResponse{true}, nil
+}
+
+func (s *WebsocketServer) unsubscribeMempoolBlocks(c *websocketChannel) (res interface{}, err error) {
+	s.mempoolBlocksSubscriptionsLock.Lock()
+	defer s.mempoolBlocksSubscriptionsLock.Unlock()
+	delete(s.mempoolBlocksSubscriptions, c)
+	s.metrics.WebsocketSubscribes.With(common.Labels{"method": "subscribeMempoolBlocks"}).Set(float64(len(s.mempoolBlocksSubscriptions)))
+	return &subscriptionResponse{false}, nil
+}
+
 func (s *WebsocketServer) subscribeNewTransaction(c *websocketChannel, req *WsReq) (res interface{}, err error) {
 	s.newTransactionSubscriptionsLock.Lock()
 	defer s.newTransactionSubscriptionsLock.Unlock()
@@ -1423,28 +1634,28 @@ func (s *WebsocketServer) unsubscribeNewTransaction(c *websocketChannel) (res in
 	return &subscriptionResponse{false}, nil
 }
 
-func (s *WebsocketServer) unmarshalAddresses(params []byte) ([]string, bool, error) {
+func (s *WebsocketServer) unmarshalAddresses(params []byte) ([]string, *WsSubscribeAddressesReq, error) {
 	r := WsSubscribeAddressesReq{}
 	err := json.Unmarshal(params, &r)
 	if err != nil {
-		return nil, false, api.NewAPIError("Invalid subscribeAddresses params", true)
+		return nil, nil, api.NewAPIError("Invalid subscribeAddresses params", true)
 	}
 	limit := maxWebsocketSubscribeAddresses
 	if r.NewBlockTxs {
 		limit = maxWebsocketSubscribeAddressesWithNewBlockTxs
 	}
 	if len(r.Addresses) > limit {
-		return nil, false, api.NewAPIError("addresses max "+strconv.Itoa(limit), true)
+		return nil, nil, api.NewAPIError("addresses max "+strconv.Itoa(limit), true)
 	}
 	rv := make([]string, 0, len(r.Addresses))
 	for _, a := range r.Addresses {
 		ad, err := s.chainParser.GetAddrDescFromAddress(a)
 		if err != nil {
-			return nil, false, api.NewAPIError("Invalid address "+strconv.Quote(a)+", "+err.Error(), true)
+			return nil, nil, api.NewAPIError("Invalid address "+strconv.Quote(a)+", "+err.Error(), true)
 		}
 		rv = append(rv, string(ad))
 	}
-	return deduplicateAddressDescriptors(rv), r.NewBlockTxs, nil
+	return deduplicateAddressDescriptors(rv), &r, nil
 }
 
 func deduplicateAddressDescriptors(addrDesc []string) []string {
@@ -1466,6 +1677,7 @@ func deduplicateAddressDescriptors(addrDesc []string) []string {
 // doUnsubscribeAddresses removes all address subscriptions for a channel.
 // addressSubscriptionsLock must be held by the caller.
 func (s *WebsocketServer) doUnsubscribeAddresses(c *websocketChannel) {
+	s.addApiKeySubscriptions(c, -len(c.addrDescs))
 	for _, ads := range c.addrDescs {
 		sa, e := s.addressSubscriptions[ads]
 		if e {
@@ -1474,6 +1686,9 @@ func (s *WebsocketServer) doUnsubscribeAddresses(c *websocketChannel) {
 					if details.publishNewBlockTxs {
 						s.newBlockTxsSubscriptionCount--
 					}
+					if s.journal != nil {
+						s.journal.unsubscribe(ads, details.publishNewBlockTxs)
+					}
 					delete(sa, c)
 				}
 			}
@@ -1488,10 +1703,15 @@ func (s *WebsocketServer) doUnsubscribeAddresses(c *websocketChannel) {
 // subscribeAddresses replaces previous address subscriptions for the channel.
 // If newBlockTxs is enabled, the channel receives both mempool notifications and
 // confirmed notifications detected from newly connected blocks.
-func (s *WebsocketServer) subscribeAddresses(c *websocketChannel, addrDesc []string, newBlockTxs bool, req *WsReq) (res interface{}, err error) {
+// If the journal is enabled, the notifications missed since the position passed by the client are replayed.
+func (s *WebsocketServer) subscribeAddresses(c *websocketChannel, addrDesc []string, r *WsSubscribeAddressesReq, req *WsReq) (res interface{}, err error) {
+	newBlockTxs := r.NewBlockTxs
 	addrDesc = deduplicateAddressDescriptors(addrDesc)
 	s.addressSubscriptionsLock.Lock()
 	defer s.addressSubscriptionsLock.Unlock()
+	if err := s.checkApiKeySubscriptions(c, len(addrDesc)); err != nil {
+		return nil, err
+	}
 	// unsubscribe all previous subscriptions
 	s.doUnsubscribeAddresses(c)
 	for _, ads := range addrDesc {
@@ -1507,11 +1727,53 @@ func (s *WebsocketServer) subscribeAddresses(c *websocketChannel, addrDesc []str
 		if newBlockTxs {
 			s.newBlockTxsSubscriptionCount++
 		}
+		if s.journal != nil {
+			s.journal.subscribe(ads, newBlockTxs)
+		}
 	}
 	c.addrDescs = addrDesc
+	s.addApiKeySubscriptions(c, len(addrDesc))
 	s.metrics.WebsocketSubscribes.With(common.Labels{"method": "subscribeAddresses"}).Set(float64(len(s.addressSubscriptions)))
 	s.metrics.WebsocketNewBlockTxsSubscriptions.Set(float64(s.newBlockTxsSubscriptionCount))
-	return &subscriptionResponse{true}, nil
+	if s.journal == nil {
+		return &subscriptionResponse{true}, nil
+	}
+	subscribed := make(map[string]struct{}, len(addrDesc))
+	for _, ads := range addrDesc {
+		subscribed[ads] = struct{}{}
+	}
+	s.replayJournal(c, req.ID, r.SinceSequence, r.SinceHeight, addrDesc, newBlockTxs, func(e *db.WsJournalEvent) bool {
+		if e.Kind == wsJournalNewBlock || e.Kind == wsJournalBlockDisconnected || (e.Kind == wsJournalAddressNewBlock && !newBlockTxs) {
+			return false
+		}
+		_, ok := subscribed[string(e.AddrDesc)]
+		return ok
+	})
+	return nil, nil
+}
+
+// replayJournal sends the subscription response followed by the journaled notifications the client missed since the
+// position it passed, or the resync flag if they are not available. It must be called under the lock of the subscription,
+// so that the replayed notifications are sent before the new ones.
+func (s *WebsocketServer) replayJournal(c *websocketChannel, id string, sinceSequence uint64, sinceHeight uint32, addrDescs []string, newBlockTxs bool, match func(e *db.WsJournalEvent) bool) {
+	res := &WsSubscriptionRes{Subscribed: true}
+	var events []db.WsJournalEvent
+	if sinceSequence == 0 && sinceHeight == 0 {
+		res.Sequence = s.journal.sequence()
+	} else {
+		var ok bool
+		events, res.Sequence, ok = s.journal.replay(sinceSequence, sinceHeight, addrDescs, newBlockTxs, match)
+		res.Resync = !ok
+		res.Replayed = len(events)
+	}
+	c.DataOut(&WsRes{ID: id, Data: res})
+	for i := range events {
+		c.DataOut(&WsRes{
+			ID:   id,
+			Data: json.RawMessage(events[i].Data),
+			Seq:  events[i].Seq,
+		})
+	}
 }
 
 // unsubscribeAddresses unsubscribes all address subscriptions by this channel
@@ -1598,13 +1860,19 @@ func newBlockNotification(block *bchain.Block) *WsNewBlock {
 // value. Last-value semantics: it sweeps catch-up blocks and settles on the tip. Non-EVM and
 // pre-London blocks (no EthereumBlockSpecificData with gas set) are skipped.
 func (s *WebsocketServer) observeNewBlockGas(block *bchain.Block) {
-	if s.metrics == nil {
-		return
-	}
 	bsd, ok := block.CoinSpecificData.(*bchain.EthereumBlockSpecificData)
 	if !ok || bsd == nil {
 		return
 	}
+	if bsd.GasLimit != nil && bsd.GasLimit.IsInt64() {
+		s.blockGasLimit.Store(bsd.GasLimit.Int64())
+	}
+	if bsd.BaseFeePerGas != nil {
+		s.blockBaseFee.Store(bsd.BaseFeePerGas)
+	}
+	if s.metrics == nil {
+		return
+	}
 	if s.metrics.EthBlockGasUsedRatio != nil && bsd.GasUsed != nil && bsd.GasLimit != nil && bsd.GasLimit.Sign() > 0 {
 		ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(bsd.GasUsed), new(big.Float).SetInt(bsd.GasLimit)).Float64()
 		s.metrics.EthBlockGasUsedRatio.Set(ratio)
@@ -1619,10 +1887,15 @@ func (s *WebsocketServer) onNewBlockAsync(block *bchain.Block) {
 	s.newBlockSubscriptionsLock.Lock()
 	defer s.newBlockSubscriptionsLock.Unlock()
 	data := newBlockNotification(block)
+	var seq uint64
+	if s.journal != nil {
+		seq = s.journal.record(wsJournalNewBlock, block.Height, "", data)
+	}
 	for c, id := range s.newBlockSubscriptions {
 		c.DataOut(&WsRes{
 			ID:   id,
 			Data: data,
+			Seq:  seq,
 		})
 	}
 	s.metrics.WebsocketNewBlockNotifications.Add(float64(len(s.newBlockSubscriptions)))
@@ -1778,15 +2051,42 @@ func (s *WebsocketServer) publishNewBlockTxsByAddr(block *bchain.Block) {
 	}
 }
 
+// publishNewBlockWithdrawalsByAddr emits notifications about the beacon chain withdrawals
+// of the connected block to the subscribed addresses credited by them
+func (s *WebsocketServer) publishNewBlockWithdrawalsByAddr(block *bchain.Block) {
+	bsd, ok := block.CoinSpecificData.(*bchain.EthereumBlockSpecificData)
+	if !ok || bsd == nil {
+		return
+	}
+	for i := range bsd.Withdrawals {
+		bw := &bsd.Withdrawals[i]
+		addrDesc, err := s.chainParser.GetAddrDescFromAddress(bw.Address)
+		if err != nil || len(addrDesc) == 0 {
+			continue
+		}
+		amount := bw.Amount
+		s.sendOnNewWithdrawalAddr(string(addrDesc), &api.EthereumWithdrawal{
+			Index:          bw.Index,
+			ValidatorIndex: bw.ValidatorIndex,
+			Address:        bw.Address,
+			AmountSat:      (*api.Amount)(&amount),
+			BlockHeight:    block.Height,
+			BlockTime:      block.Time,
+			Confirmations:  1,
+		})
+	}
+}
+
 // OnNewBlock is a callback that broadcasts info about new block to subscribed clients
 func (s *WebsocketServer) OnNewBlock(block *bchain.Block) {
+	s.api.ResetResultCache()
 	// Synchronous and before the async dispatch: OnNewBlock is called in monotonic height order, so
 	// the push-path gas gauges never get an older block's value written after a newer one's.
 	s.observeNewBlockGas(block)
 	s.addressSubscriptionsLock.Lock()
 	defer s.addressSubscriptionsLock.Unlock()
 	go s.onNewBlockAsync(block)
-	if s.newBlockTxsSubscriptionCount > 0 {
+	if s.newBlockTxsSubscriptionCount > 0 || (s.journal != nil && s.journal.coversNewBlockTxs()) {
 		// Skip per-tx address matching when nobody opted into newBlockTxs.
 		if ok, _ := s.trackWork(); ok {
 			go func() {
@@ -1795,6 +2095,101 @@ func (s *WebsocketServer) OnNewBlock(block *bchain.Block) {
 			}()
 		}
 	}
+	// the withdrawals are notified to all subscribers of the credited addresses, not only to those with newBlockTxs
+	if s.chainParser.GetChainType() == bchain.ChainEthereumType && (len(s.addressSubscriptions) > 0 || s.journal != nil) {
+		if ok, _ := s.trackWork(); ok {
+			go func() {
+				defer s.workDone()
+				s.publishNewBlockWithdrawalsByAddr(block)
+			}()
+		}
+	}
+}
+
+// updateMempoolProjection projects the current mempool into blocks and stores the result
+func (s *WebsocketServer) updateMempoolProjection() (*api.MempoolBlocks, *api.MempoolFeeHistogram, error) {
+	blocks, histogram, err := s.api.GetMempoolProjection(s.blockGasLimit.Load(), s.blockBaseFee.Load())
+	if err != nil {
+		return nil, nil, err
+	}
+	s.mempoolProjectionLock.Lock()
+	defer s.mempoolProjectionLock.Unlock()
+	s.mempoolBlocks = blocks
+	s.mempoolHistogram = histogram
+	s.mempoolProjectionTime = time.Now()
+	return blocks, histogram, nil
+}
+
+// GetMempoolProjection returns the last projection of the mempool into blocks. If it is older than mempoolProjectionPeriod,
+// which happens when nobody is subscribed to the mempool blocks, one recomputation is started in the background.
+// The first projection is computed once for all concurrent callers.
+func (s *WebsocketServer) GetMempoolProjection() (*api.MempoolBlocks, *api.MempoolFeeHistogram, error) {
+	s.mempoolProjectionLock.Lock()
+	blocks, histogram := s.mempoolBlocks, s.mempoolHistogram
+	recent := time.Since(s.mempoolProjectionTime) < mempoolProjectionPeriod
+	s.mempoolProjectionLock.Unlock()
+	if blocks != nil {
+		if !recent {
+			s.startMempoolProjection()
+		}
+		return blocks, histogram, nil
+	}
+	type projection struct {
+		blocks    *api.MempoolBlocks
+		histogram *api.MempoolFeeHistogram
+	}
+	v, err, _ := s.mempoolProjectionFirst.Do("", func() (interface{}, error) {
+		blocks, histogram, err := s.updateMempoolProjection()
+		return projection{blocks, histogram}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	p := v.(projection)
+	return p.blocks, p.histogram, nil
+}
+
+// OnMempoolResync recomputes the projection of the mempool into blocks and notifies the subscribers,
+// the projection is recomputed at most once per mempoolProjectionPeriod and only if somebody is subscribed
+func (s *WebsocketServer) OnMempoolResync() {
+	s.mempoolBlocksSubscriptionsLock.Lock()
+	subscribed := len(s.mempoolBlocksSubscriptions) > 0
+	s.mempoolBlocksSubscriptionsLock.Unlock()
+	if !subscribed {
+		return
+	}
+	s.mempoolProjectionLock.Lock()
+	recent := time.Since(s.mempoolProjectionTime) < mempoolProjectionPeriod
+	s.mempoolProjectionLock.Unlock()
+	if !recent {
+		s.startMempoolProjection()
+	}
+}
+
+// startMempoolProjection recomputes the projection of the mempool in the background and notifies the subscribers,
+// it does nothing if a recomputation is already running
+func (s *WebsocketServer) startMempoolProjection() {
+	if !s.mempoolProjectionRunning.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer s.mempoolProjectionRunning.Store(false)
+		blocks, histogram, err := s.updateMempoolProjection()
+		if err != nil {
+			glog.Error("startMempoolProjection ", err)
+			return
+		}
+		data := &WsMempoolBlocks{Blocks: blocks, Histogram: histogram}
+		s.mempoolBlocksSubscriptionsLock.Lock()
+		defer s.mempoolBlocksSubscriptionsLock.Unlock()
+		for c, id := range s.mempoolBlocksSubscriptions {
+			c.DataOut(&WsRes{
+				ID:   id,
+				Data: data,
+			})
+		}
+		glog.V(2).Info("broadcasting mempool blocks to ", len(s.mempoolBlocksSubscriptions), " channels")
+	}()
 }
 
 func (s *WebsocketServer) sendOnNewTx(tx *api.Tx) {
@@ -1826,6 +2221,7 @@ func (s *WebsocketServer) sendOnNewTxAddr(stringAddressDescriptor string, tx *ap
 		}
 		s.addressSubscriptionsLock.Lock()
 		defer s.addressSubscriptionsLock.Unlock()
+		seq := s.journalAddrNotification(stringAddressDescriptor, newBlockTx, &data)
 		as, ok := s.addressSubscriptions[stringAddressDescriptor]
 		if ok {
 			source := "mempool"
@@ -1844,6 +2240,7 @@ func (s *WebsocketServer) sendOnNewTxAddr(stringAddressDescriptor string, tx *ap
 				c.DataOut(&WsRes{
 					ID:   details.requestID,
 					Data: &data,
+					Seq:  seq,
 				})
 			}
 			glog.Info("broadcasting new tx ", tx.Txid, ", addr ", addr[0], " to ", len(as), " channels")
@@ -1851,6 +2248,44 @@ func (s *WebsocketServer) sendOnNewTxAddr(stringAddressDescriptor string, tx *ap
 	}
 }
 
+// sendOnNewWithdrawalAddr sends the withdrawal to all subscribers of the address, the withdrawals
+// do not pass through the mempool and their confirmation is the only notification of the balance change
+func (s *WebsocketServer) sendOnNewWithdrawalAddr(stringAddressDescriptor string, withdrawal *api.EthereumWithdrawal) {
+	data := struct {
+		Address    string                  `json:"address"`
+		Withdrawal *api.EthereumWithdrawal `json:"withdrawal"`
+	}{
+		Address:    withdrawal.Address,
+		Withdrawal: withdrawal,
+	}
+	s.addressSubscriptionsLock.Lock()
+	defer s.addressSubscriptionsLock.Unlock()
+	seq := s.journalAddrNotification(stringAddressDescriptor, false, &data)
+	for c, details := range s.addressSubscriptions[stringAddressDescriptor] {
+		if s.metrics != nil {
+			s.metrics.WebsocketAddrNotifications.With(common.Labels{"source": "new_block"}).Inc()
+		}
+		c.DataOut(&WsRes{
+			ID:   details.requestID,
+			Data: &data,
+			Seq:  seq,
+		})
+	}
+}
+
+// journalAddrNotification journals the notification of the address if the notifications of the address are journaled
+// and returns its sequence number, 0 otherwise. The addressSubscriptionsLock must be held by the caller.
+func (s *WebsocketServer) journalAddrNotification(stringAddressDescriptor string, newBlockTx bool, data interface{}) uint64 {
+	if s.journal == nil || !s.journal.covers(stringAddressDescriptor, newBlockTx) {
+		return 0
+	}
+	kind := wsJournalAddress
+	if newBlockTx {
+		kind = wsJournalAddressNewBlock
+	}
+	return s.journal.record(kind, 0, stringAddressDescriptor, data)
+}
+
 func (s *WebsocketServer) getNewTxSubscriptions(vins []bchain.MempoolVin, vouts []bchain.Vout, tokenTransfers bchain.TokenTransfers, internalTransfers []bchain.EthereumInternalTransfer, newBlockTxsOnly bool) map[string]struct{} {
 	// check if there is any subscription in inputs, outputs and transfers
 	candidates := make(map[string]struct{})
@@ -1902,6 +2337,11 @@ func (s *WebsocketServer) getNewTxSubscriptions(vins []bchain.MempoolVin, vouts
 	s.addressSubscriptionsLock.Lock()
 	defer s.addressSubscriptionsLock.Unlock()
 	for sad := range candidates {
+		// the notifications of the addresses without subscribers are journaled for the clients which reconnect
+		if s.journal != nil && s.journal.covers(sad, newBlockTxsOnly) {
+			subscribed[sad] = struct{}{}
+			continue
+		}
 		as, ok := s.addressSubscriptions[sad]
 		if !ok || len(as) == 0 {
 			continue
@@ -1934,6 +2374,7 @@ func (s *WebsocketServer) onNewTxAsync(tx *bchain.MempoolTx, subscribed map[stri
 
 // OnNewTx is a callback that broadcasts info about a tx affecting subscribed address
 func (s *WebsocketServer) OnNewTx(tx *bchain.MempoolTx) {
+	s.api.InvalidateResultCacheForTx(tx)
 	subscribed := s.getNewTxSubscriptions(tx.Vin, tx.Vout, tx.TokenTransfers, nil, false)
 	if len(s.newTransactionSubscriptions) > 0 || len(subscribed) > 0 {
 		if ok, _ := s.trackWork(); ok {
@@ -1945,6 +2386,157 @@ func (s *WebsocketServer) OnNewTx(tx *bchain.MempoolTx) {
 	}
 }
 
+// OnTxReplaced is a callback that notifies the subscribers of the addresses of a replaced mempool transaction,
+// the receiving addresses not paid by the replacing transaction are notified about the double spend
+func (s *WebsocketServer) OnTxReplaced(r *bchain.TxReplacement) {
+	s.api.InvalidateResultCache(r.AddrDescs)
+	subscribed := s.getAddrDescSubscriptions(r.AddrDescs)
+	doubleSpent := s.getAddrDescSubscriptions(r.DoubleSpentAddrDescs)
+	if len(subscribed) > 0 || len(doubleSpent) > 0 {
+		if ok, _ := s.trackWork(); ok {
+			go func() {
+				defer s.workDone()
+				for _, addrDesc := range subscribed {
+					s.sendAddrNotification(addrDesc, "tx_replaced", func(address string) interface{} {
+						return &WsAddressTxReplaced{Address: address, TxReplaced: &WsTxReplaced{Txid: r.Txid, ReplacedBy: r.ReplacedBy}}
+					})
+				}
+				for _, addrDesc := range doubleSpent {
+					s.sendAddrNotification(addrDesc, "double_spend", func(address string) interface{} {
+						return &WsAddressDoubleSpend{Address: address, DoubleSpend: &WsDoubleSpend{Txid: r.Txid, ConflictingTxid: r.ReplacedBy}}
+					})
+				}
+				glog.Info("broadcasting replaced tx ", r.Txid, ", ", len(subscribed), " addresses, ", len(doubleSpent), " double spent")
+			}()
+		}
+	}
+}
+
+// OnTxDropped is a callback that notifies the subscribers of the addresses of a transaction which left the mempool
+// without being confirmed, the receiving addresses not paid by the conflicting transaction are notified about the double spend
+func (s *WebsocketServer) OnTxDropped(d *bchain.TxDropped) {
+	s.api.InvalidateResultCache(d.AddrDescs)
+	subscribed := s.getAddrDescSubscriptions(d.AddrDescs)
+	doubleSpent := s.getAddrDescSubscriptions(d.DoubleSpentAddrDescs)
+	if len(subscribed) > 0 || len(doubleSpent) > 0 {
+		if ok, _ := s.trackWork(); ok {
+			go func() {
+				defer s.workDone()
+				for _, addrDesc := range subscribed {
+					s.sendAddrNotification(addrDesc, "tx_dropped", func(address string) interface{} {
+						return &WsAddressTxDropped{Address: address, TxDropped: &WsTxDropped{Txid: d.Txid, ConflictingTxid: d.ConflictingTxid}}
+					})
+				}
+				for _, addrDesc := range doubleSpent {
+					s.sendAddrNotification(addrDesc, "double_spend", func(address string) interface{} {
+						return &WsAddressDoubleSpend{Address: address, DoubleSpend: &WsDoubleSpend{Txid: d.Txid, ConflictingTxid: d.ConflictingTxid}}
+					})
+				}
+				glog.Info("broadcasting dropped tx ", d.Txid, ", ", len(subscribed), " addresses, ", len(doubleSpent), " double spent")
+			}()
+		}
+	}
+}
+
+// OnDisconnectBlocks is a callback that notifies the subscribers of new blocks about the blocks disconnected by a reorg
+// and the subscribers of the addresses about their transactions which lost the confirmation. The blocks and the transactions
+// are announced synchronously, so that the subscribers receive them before the blocks and transactions of the new chain.
+func (s *WebsocketServer) OnDisconnectBlocks(blocks []bchain.DisconnectedBlock) {
+	s.api.ResetResultCache()
+	data := &WsBlockDisconnected{BlockDisconnected: make([]WsDisconnectedBlock, len(blocks))}
+	var addrDescs []bchain.AddressDescriptor
+	for i := range blocks {
+		b := &blocks[i]
+		d := &data.BlockDisconnected[i]
+		d.Height = b.Height
+		d.Hash = b.Hash
+		d.Txids = make([]string, len(b.Txs))
+		for j := range b.Txs {
+			d.Txids[j] = b.Txs[j].Txid
+			addrDescs = append(addrDescs, b.Txs[j].AddrDescs...)
+		}
+	}
+	s.newBlockSubscriptionsLock.Lock()
+	var seq uint64
+	if s.journal != nil && len(blocks) > 0 {
+		seq = s.journal.record(wsJournalBlockDisconnected, blocks[len(blocks)-1].Height, "", data)
+	}
+	for c, id := range s.newBlockSubscriptions {
+		c.DataOut(&WsRes{
+			ID:   id,
+			Data: data,
+			Seq:  seq,
+		})
+	}
+	glog.Info("broadcasting ", len(blocks), " disconnected blocks to ", len(s.newBlockSubscriptions), " channels")
+	s.newBlockSubscriptionsLock.Unlock()
+
+	subscribed := s.getAddrDescSubscriptions(addrDescs)
+	if len(subscribed) == 0 {
+		return
+	}
+	isSubscribed := make(map[string]struct{}, len(subscribed))
+	for _, ad := range subscribed {
+		isSubscribed[ad] = struct{}{}
+	}
+	notified := 0
+	for i := range blocks {
+		b := &blocks[i]
+		for j := range b.Txs {
+			tx := &b.Txs[j]
+			for _, ad := range tx.AddrDescs {
+				if _, ok := isSubscribed[string(ad)]; !ok {
+					continue
+				}
+				s.sendAddrNotification(string(ad), "tx_unconfirmed", func(address string) interface{} {
+					return &WsAddressTxUnconfirmed{Address: address, TxUnconfirmed: &WsTxUnconfirmed{Txid: tx.Txid, Height: b.Height, Hash: b.Hash}}
+				})
+				notified++
+			}
+		}
+	}
+	glog.Info("broadcasting ", notified, " unconfirmed txs of subscribed addresses")
+}
+
+// getAddrDescSubscriptions returns the address descriptors which have subscribers or whose notifications are journaled
+func (s *WebsocketServer) getAddrDescSubscriptions(addrDescs []bchain.AddressDescriptor) []string {
+	subscribed := make([]string, 0, len(addrDescs))
+	s.addressSubscriptionsLock.Lock()
+	defer s.addressSubscriptionsLock.Unlock()
+	for _, addrDesc := range addrDescs {
+		if len(s.addressSubscriptions[string(addrDesc)]) > 0 || (s.journal != nil && s.journal.covers(string(addrDesc), false)) {
+			subscribed = append(subscribed, string(addrDesc))
+		}
+	}
+	return subscribed
+}
+
+// sendAddrNotification sends the notification created for the address to the subscribers of the address descriptor
+func (s *WebsocketServer) sendAddrNotification(stringAddressDescriptor string, source string, data func(address string) interface{}) {
+	addr, _, err := s.chainParser.GetAddressesFromAddrDesc(bchain.AddressDescriptor(stringAddressDescriptor))
+	if err != nil {
+		glog.Error("GetAddressesFromAddrDesc error ", err, " for ", stringAddressDescriptor)
+		return
+	}
+	if len(addr) != 1 {
+		return
+	}
+	d := data(addr[0])
+	s.addressSubscriptionsLock.Lock()
+	defer s.addressSubscriptionsLock.Unlock()
+	seq := s.journalAddrNotification(stringAddressDescriptor, false, d)
+	for c, details := range s.addressSubscriptions[stringAddressDescriptor] {
+		if s.metrics != nil {
+			s.metrics.WebsocketAddrNotifications.With(common.Labels{"source": source}).Inc()
+		}
+		c.DataOut(&WsRes{
+			ID:   details.requestID,
+			Data: d,
+			Seq:  seq,
+		})
+	}
+}
+
 func (s *WebsocketServer) broadcastTicker(currency string, rates map[string]float32, ticker *common.CurrencyRatesTicker) {
 	as, ok := s.fiatRatesSubscriptions[currency]
 	if ok && len(as) > 0 {
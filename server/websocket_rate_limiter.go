@@ -2,6 +2,7 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"strings"
 	"sync"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/gorilla/websocket"
+	"github.com/trezor/blockbook/common"
 )
 
 const maxWebsocketConnectionAttemptsPerIP = 64
@@ -25,6 +27,16 @@ const defaultWsMessageRateLimit = 2500
 const defaultWsMessageRateWindow = 10 * time.Minute
 const defaultWsIPBlockDuration = 12 * time.Hour
 
+// Per-client cost budget defaults of the websocket requests. Each request takes the
+// cost of its method and of the work it did (see request_cost.go) from a token
+// bucket shared by all connections of the client key; a request of a client with
+// an exhausted budget is answered with an error, the connection stays open. The
+// default allows a sustained cost of 2500 per 10 minutes, far above a Trezor Suite
+// session, which mostly does requests of the base cost.
+const defaultWsCostLimit = 2500
+const defaultWsCostWindow = 10 * time.Minute
+const defaultWsCostBurst = 2500
+
 // messageRateWindowBuckets is the number of sub-buckets the message rate window
 // is divided into. It bounds per-connection memory (a fixed array, independent
 // of the limit) and sets the sliding-window resolution: with a 10-minute window
@@ -88,6 +100,113 @@ func (s *WebsocketServer) configurePendingRequestsLimit(network string) error {
 	return nil
 }
 
+// websocketCostLimiter keeps the cost budgets of the websocket clients, keyed
+// like the connection limiter. The clients with an API key use the rate and
+// burst of the key instead of the configured ones. A nil limiter limits nothing.
+type websocketCostLimiter struct {
+	mux        sync.Mutex
+	clients    map[string]*websocketClientCost
+	rateLimit  int
+	rateWindow time.Duration
+	burst      int
+}
+
+type websocketClientCost struct {
+	bucket   restUITokenBucket
+	lastSeen time.Time
+}
+
+// configureCostLimit reads the per-client cost budget config from the
+// environment, applying defaults (see docs/env.md for the vars).
+func (s *WebsocketServer) configureCostLimit(network string) error {
+	prefix := strings.ToUpper(network)
+	l := &websocketCostLimiter{clients: make(map[string]*websocketClientCost)}
+	var err error
+	if l.rateLimit, err = parseNonNegativeIntEnv(prefix+"_WS_COST_LIMIT", defaultWsCostLimit); err != nil {
+		return err
+	}
+	if l.rateWindow, err = parsePositiveDurationEnv(prefix+"_WS_COST_WINDOW", defaultWsCostWindow); err != nil {
+		return err
+	}
+	if l.burst, err = parseNonNegativeIntEnv(prefix+"_WS_COST_BURST", defaultWsCostBurst); err != nil {
+		return err
+	}
+	if l.rateLimit > 0 && l.burst <= 0 {
+		return fmt.Errorf("%s_WS_COST_BURST: invalid value %d (want a positive integer when the cost limit is enabled)", prefix, l.burst)
+	}
+	s.costLimiter = l
+	if l.rateLimit > 0 {
+		glog.Infof("Websocket per-client cost limit: %d / %s; burst: %d", l.rateLimit, l.rateWindow, l.burst)
+	} else {
+		glog.Info("Websocket per-client cost limit disabled")
+	}
+	return nil
+}
+
+// limits returns the rate and burst of the cost budget of the client, the limits of its API key if it has one
+func (l *websocketCostLimiter) limits(apiKey *common.ApiKey) (rateLimit int, rateWindow time.Duration, burst int) {
+	if apiKey != nil {
+		return apiKey.RateLimit, apiKey.RateWindow, apiKey.Burst
+	}
+	return l.rateLimit, l.rateWindow, l.burst
+}
+
+// take takes the tokens from the budget of the client key; if there are not
+// enough tokens, nothing is taken and the time after which the tokens are
+// available is returned
+func (l *websocketCostLimiter) take(key string, apiKey *common.ApiKey, tokens float64, now time.Time) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	rateLimit, rateWindow, burst := l.limits(apiKey)
+	if rateLimit <= 0 {
+		return true, 0
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	client := l.clients[key]
+	if client == nil {
+		client = &websocketClientCost{}
+		l.clients[key] = client
+	}
+	client.lastSeen = now
+	return client.bucket.take(now, tokens, rateLimit, rateWindow, burst)
+}
+
+// deduct takes the tokens of the work already done from the budget of the
+// client key, the budget may go into debt
+func (l *websocketCostLimiter) deduct(key string, apiKey *common.ApiKey, tokens float64, now time.Time) {
+	if l == nil {
+		return
+	}
+	rateLimit, rateWindow, burst := l.limits(apiKey)
+	if rateLimit <= 0 || tokens <= 0 {
+		return
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if client := l.clients[key]; client != nil {
+		client.lastSeen = now
+		client.bucket.deduct(now, tokens, rateLimit, rateWindow, burst)
+	}
+}
+
+// sweep evicts the budgets idle for longer than the refill of a full bucket
+// could take, an evicted client starts again with a full bucket
+func (l *websocketCostLimiter) sweep(now time.Time) {
+	if l == nil {
+		return
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	ttl := max(2*l.rateWindow, websocketConnectionLimiterTTL)
+	for key, client := range l.clients {
+		if now.Sub(client.lastSeen) > ttl {
+			delete(l.clients, key)
+		}
+	}
+}
+
 func newWebsocketConnectionLimiter() *websocketConnectionLimiter {
 	return &websocketConnectionLimiter{
 		clients: make(map[string]*websocketClientLimit),
@@ -95,26 +214,34 @@ func newWebsocketConnectionLimiter() *websocketConnectionLimiter {
 }
 
 func (l *websocketConnectionLimiter) accept(ip string, now time.Time) (bool, string) {
+	return l.acceptLimited(ip, maxWebsocketConnectionsPerIP, maxWebsocketConnectionAttemptsPerIP, now)
+}
+
+// acceptLimited accepts a connection of the client key within the given limits
+// of active connections and of connection attempts per websocketConnectionAttemptWindow,
+// a zero limit is not applied
+func (l *websocketConnectionLimiter) acceptLimited(key string, maxConnections, maxAttempts int, now time.Time) (bool, string) {
 	l.mux.Lock()
 	defer l.mux.Unlock()
 
 	l.cleanupLocked(now)
-	client := l.clients[ip]
+	client := l.clients[key]
 	if client == nil {
 		client = &websocketClientLimit{}
-		l.clients[ip] = client
+		l.clients[key] = client
 	}
 	client.lastSeen = now
 	client.trimAttempts(now)
 
-	if client.active >= maxWebsocketConnectionsPerIP {
+	if maxConnections > 0 && client.active >= maxConnections {
 		return false, "connection_limit"
 	}
-	if len(client.attempts) >= maxWebsocketConnectionAttemptsPerIP {
-		return false, "connection_attempt_limit"
+	if maxAttempts > 0 {
+		if len(client.attempts) >= maxAttempts {
+			return false, "connection_attempt_limit"
+		}
+		client.attempts = append(client.attempts, now)
 	}
-
-	client.attempts = append(client.attempts, now)
 	client.active++
 	return true, ""
 }
@@ -160,14 +287,15 @@ func (l *websocketConnectionLimiter) sweep(now time.Time) {
 }
 
 // stats returns the number of distinct client IPs that currently hold at least
-// one active websocket connection and the largest per-IP connection count. The
+// one active websocket connection and the largest per-IP connection count, the
+// connections with an API key are not counted. The
 // snapshot is taken under the limiter lock; idle entries retained for the TTL
 // window are skipped so the numbers track live connections, not recent history.
 func (l *websocketConnectionLimiter) stats() (uniqueActiveIPs int, maxConnectionsPerIP int) {
 	l.mux.Lock()
 	defer l.mux.Unlock()
-	for _, client := range l.clients {
-		if client.active <= 0 {
+	for key, client := range l.clients {
+		if client.active <= 0 || strings.HasPrefix(key, apiKeyLimitPrefix) {
 			continue
 		}
 		uniqueActiveIPs++
@@ -187,6 +315,7 @@ func (s *WebsocketServer) runWebsocketLimiterMaintenance(interval time.Duration)
 	defer ticker.Stop()
 	for now := range ticker.C {
 		s.websocketLimiter.sweep(now)
+		s.costLimiter.sweep(now)
 		blockedIPs := 0
 		if s.ipBlockEnabled {
 			blockedIPs = s.is.SweepWsBlockedIPs(now)
@@ -1167,11 +1167,11 @@ func TestUnmarshalAddressesDeduplicatesDescriptors(t *testing.T) {
 	}
 
 	s := &WebsocketServer{chainParser: parser}
-	addresses, newBlockTxs, err := s.unmarshalAddresses(params)
+	addresses, r, err := s.unmarshalAddresses(params)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if newBlockTxs {
+	if r.NewBlockTxs {
 		t.Fatal("newBlockTxs = true, want false")
 	}
 	if len(addresses) != 1 {
@@ -1632,3 +1632,99 @@ func TestWebsocketShutdownIsIdempotent(t *testing.T) {
 		t.Fatalf("second Shutdown() = %v, want nil", err)
 	}
 }
+
+func TestOnDisconnectBlocksNotifiesSubscribers(t *testing.T) {
+	parser, _ := setupChain(t)
+	s := &WebsocketServer{
+		chainParser:           parser,
+		newBlockSubscriptions: make(map[*websocketChannel]string),
+		addressSubscriptions:  make(map[string]map[*websocketChannel]*addressDetails),
+		journal:               newWsJournal(10, time.Minute),
+	}
+	addr1, err := parser.GetAddrDescFromAddress(dbtestdata.Addr1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr2, err := parser.GetAddrDescFromAddress(dbtestdata.Addr2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockSubscriber := &websocketChannel{out: make(chan *WsRes, 2), alive: true}
+	addrSubscriber := &websocketChannel{out: make(chan *WsRes, 2), alive: true}
+	s.newBlockSubscriptions[blockSubscriber] = "blocks"
+	s.addressSubscriptions[string(addr1)] = map[*websocketChannel]*addressDetails{addrSubscriber: {requestID: "addresses"}}
+
+	s.OnDisconnectBlocks([]bchain.DisconnectedBlock{
+		{Height: 101, Hash: "hash101", Txs: []bchain.DisconnectedTx{{Txid: "tx2", AddrDescs: []bchain.AddressDescriptor{addr2}}}},
+		{Height: 100, Hash: "hash100", Txs: []bchain.DisconnectedTx{{Txid: "tx1", AddrDescs: []bchain.AddressDescriptor{addr1, addr2}}}},
+	})
+
+	// the notifications are sent before OnDisconnectBlocks returns, before the blocks of the new chain
+
+	if len(blockSubscriber.out) != 1 {
+		t.Fatalf("block subscriber received %d messages, want 1", len(blockSubscriber.out))
+	}
+	res := <-blockSubscriber.out
+	blocks, ok := res.Data.(*WsBlockDisconnected)
+	if !ok || res.ID != "blocks" || res.Seq != 1 || len(blocks.BlockDisconnected) != 2 {
+		t.Fatalf("block subscriber received %+v", res)
+	}
+	if b := blocks.BlockDisconnected[1]; b.Height != 100 || b.Hash != "hash100" || len(b.Txids) != 1 || b.Txids[0] != "tx1" {
+		t.Errorf("disconnected block %+v", b)
+	}
+	if len(addrSubscriber.out) != 1 {
+		t.Fatalf("address subscriber received %d messages, want 1", len(addrSubscriber.out))
+	}
+	res = <-addrSubscriber.out
+	tx, ok := res.Data.(*WsAddressTxUnconfirmed)
+	if !ok || res.ID != "addresses" || tx.Address != dbtestdata.Addr1 || tx.TxUnconfirmed.Txid != "tx1" || tx.TxUnconfirmed.Height != 100 {
+		t.Fatalf("address subscriber received %+v", res.Data)
+	}
+}
+
+func TestSendOnNewWithdrawalAddrNotifiesAllSubscribers(t *testing.T) {
+	s := &WebsocketServer{
+		addressSubscriptions: make(map[string]map[*websocketChannel]*addressDetails),
+	}
+	addrDesc := "withdrawal-address"
+	plain := &websocketChannel{out: make(chan *WsRes, 1), alive: true}
+	newBlockTxs := &websocketChannel{out: make(chan *WsRes, 1), alive: true}
+	s.addressSubscriptions[addrDesc] = map[*websocketChannel]*addressDetails{
+		plain:       {requestID: "plain"},
+		newBlockTxs: {requestID: "newBlockTxs", publishNewBlockTxs: true},
+	}
+
+	s.sendOnNewWithdrawalAddr(addrDesc, &api.EthereumWithdrawal{Address: "0xabc", BlockHeight: 100})
+
+	// the withdrawals do not pass through the mempool, the subscribers without newBlockTxs are notified too
+	for _, c := range []*websocketChannel{plain, newBlockTxs} {
+		if len(c.out) != 1 {
+			t.Fatalf("subscriber received %d messages, want 1", len(c.out))
+		}
+	}
+}
+
+func TestOnMempoolResyncWithoutSubscribers(t *testing.T) {
+	// without subscribers the projection is not computed (the server has no api to compute it with)
+	s := &WebsocketServer{mempoolBlocksSubscriptions: make(map[*websocketChannel]string)}
+	s.OnMempoolResync()
+	if s.mempoolProjectionRunning.Load() || !s.mempoolProjectionTime.IsZero() {
+		t.Error("mempool projection computed without subscribers")
+	}
+}
+
+func TestGetMempoolProjectionServesStaleProjection(t *testing.T) {
+	// a recomputation is already running, the stale projection is returned without computing another one
+	blocks := &api.MempoolBlocks{}
+	histogram := &api.MempoolFeeHistogram{}
+	s := &WebsocketServer{
+		mempoolBlocks:         blocks,
+		mempoolHistogram:      histogram,
+		mempoolProjectionTime: time.Now().Add(-2 * mempoolProjectionPeriod),
+	}
+	s.mempoolProjectionRunning.Store(true)
+	gotBlocks, gotHistogram, err := s.GetMempoolProjection()
+	if err != nil || gotBlocks != blocks || gotHistogram != histogram {
+		t.Fatalf("GetMempoolProjection() = %v, %v, %v, want the last projection", gotBlocks, gotHistogram, err)
+	}
+}
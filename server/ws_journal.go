@@ -0,0 +1,272 @@
+package server
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/db"
+)
+
+// kinds of the journaled websocket notifications
+const (
+	// wsJournalNewBlock is the notification of the subscribeNewBlock subscribers
+	wsJournalNewBlock byte = iota
+	// wsJournalAddress is the notification sent to all subscribers of the address
+	wsJournalAddress
+	// wsJournalAddressNewBlock is the confirmed notification sent only to the subscribers of the address with newBlockTxs
+	wsJournalAddressNewBlock
+	// wsJournalBlockDisconnected is the notification of the subscribeNewBlock subscribers about the blocks disconnected by a reorg
+	wsJournalBlockDisconnected
+)
+
+// wsJournalCoverage tracks since when the notifications of an address are journaled
+type wsJournalCoverage struct {
+	// since is the last sequence number before the journaling of the address started
+	since       uint64
+	subscribers int
+	// expires is the end of the journaling after the last subscriber left
+	expires time.Time
+	// the confirmed notifications are journaled only while there is a subscriber with newBlockTxs
+	newBlockTxs            bool
+	newBlockTxsSince       uint64
+	newBlockTxsSubscribers int
+	newBlockTxsExpires     time.Time
+}
+
+func (c *wsJournalCoverage) active(now time.Time) bool {
+	return c.subscribers > 0 || now.Before(c.expires)
+}
+
+func (c *wsJournalCoverage) newBlockTxsActive(now time.Time) bool {
+	return c.newBlockTxs && (c.newBlockTxsSubscribers > 0 || now.Before(c.newBlockTxsExpires))
+}
+
+// wsJournal assigns the sequence numbers to the websocket notifications of the new blocks and of the subscribed addresses
+// and keeps the last of them, so that the clients can resubscribe after a reconnect and receive the missed notifications.
+// The notifications of an address are journaled while it is subscribed and for the retention period after its last
+// subscriber left, so that they are journaled also when there is no subscriber during the reconnect.
+type wsJournal struct {
+	mux sync.Mutex
+	// events is a ring buffer of the last notifications ordered by the sequence number
+	events []db.WsJournalEvent
+	start  int
+	count  int
+	// lastSeq is the sequence number of the last notification, firstSeq the first one which can be replayed
+	lastSeq   uint64
+	firstSeq  uint64
+	retention time.Duration
+	coverage  map[string]*wsJournalCoverage
+}
+
+func newWsJournal(size int, retention time.Duration) *wsJournal {
+	return &wsJournal{
+		events:    make([]db.WsJournalEvent, size),
+		firstSeq:  1,
+		retention: retention,
+		coverage:  make(map[string]*wsJournalCoverage),
+	}
+}
+
+// record journals the notification and returns its sequence number, 0 if it cannot be journaled
+func (j *wsJournal) record(kind byte, height uint32, addrDesc string, data interface{}) uint64 {
+	b, err := json.Marshal(data)
+	if err != nil {
+		glog.Error("wsJournal marshal error ", err)
+		return 0
+	}
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	if j.count == len(j.events) {
+		j.firstSeq = j.events[j.start].Seq + 1
+		j.events[j.start] = db.WsJournalEvent{}
+		j.start = (j.start + 1) % len(j.events)
+		j.count--
+	}
+	j.lastSeq++
+	j.events[(j.start+j.count)%len(j.events)] = db.WsJournalEvent{
+		Seq:      j.lastSeq,
+		Height:   height,
+		Kind:     kind,
+		AddrDesc: bchain.AddressDescriptor(addrDesc),
+		Data:     b,
+	}
+	j.count++
+	if kind == wsJournalNewBlock {
+		j.expire(time.Now())
+	}
+	return j.lastSeq
+}
+
+// expire stops the journaling of the addresses whose retention period passed, the lock must be held
+func (j *wsJournal) expire(now time.Time) {
+	for ad, c := range j.coverage {
+		if !c.active(now) {
+			delete(j.coverage, ad)
+		} else if c.newBlockTxs && !c.newBlockTxsActive(now) {
+			c.newBlockTxs = false
+		}
+	}
+}
+
+// covers returns true if the notifications of the address are journaled, the confirmed ones if newBlockTx is set
+func (j *wsJournal) covers(addrDesc string, newBlockTx bool) bool {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	c := j.coverage[addrDesc]
+	if c == nil {
+		return false
+	}
+	if newBlockTx {
+		return c.newBlockTxsActive(time.Now())
+	}
+	return c.active(time.Now())
+}
+
+// coversNewBlockTxs returns true if the confirmed notifications of any address are journaled
+func (j *wsJournal) coversNewBlockTxs() bool {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	now := time.Now()
+	for _, c := range j.coverage {
+		if c.newBlockTxsActive(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// subscribe starts or continues the journaling of the address for a new subscriber
+func (j *wsJournal) subscribe(addrDesc string, newBlockTxs bool) {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	now := time.Now()
+	c := j.coverage[addrDesc]
+	if c == nil || !c.active(now) {
+		c = &wsJournalCoverage{since: j.lastSeq}
+		j.coverage[addrDesc] = c
+	}
+	c.subscribers++
+	if newBlockTxs {
+		if !c.newBlockTxsActive(now) {
+			c.newBlockTxs = true
+			c.newBlockTxsSince = j.lastSeq
+		}
+		c.newBlockTxsSubscribers++
+	}
+}
+
+// unsubscribe starts the retention period of the address if its last subscriber left
+func (j *wsJournal) unsubscribe(addrDesc string, newBlockTxs bool) {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	c := j.coverage[addrDesc]
+	if c == nil {
+		return
+	}
+	expires := time.Now().Add(j.retention)
+	if c.subscribers--; c.subscribers == 0 {
+		c.expires = expires
+	}
+	if newBlockTxs {
+		if c.newBlockTxsSubscribers--; c.newBlockTxsSubscribers == 0 {
+			c.newBlockTxsExpires = expires
+		}
+	}
+}
+
+// sequence returns the sequence number of the last notification
+func (j *wsJournal) sequence() uint64 {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	return j.lastSeq
+}
+
+// replay returns the journaled notifications following the position of the client which match the filter and the last
+// sequence number. The position is the sequence number sinceSequence or, if it is zero, the new block notification of
+// the block at sinceHeight. It returns false if some notifications following the position are not journaled for the
+// addresses, the client must resync then.
+func (j *wsJournal) replay(sinceSequence uint64, sinceHeight uint32, addrDescs []string, newBlockTxs bool, match func(e *db.WsJournalEvent) bool) ([]db.WsJournalEvent, uint64, bool) {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	since := sinceSequence
+	if since == 0 {
+		var found bool
+		if since, found = j.sequenceAtHeight(sinceHeight); !found {
+			return nil, j.lastSeq, false
+		}
+	}
+	if since > j.lastSeq || since+1 < j.firstSeq {
+		return nil, j.lastSeq, false
+	}
+	for _, ad := range addrDescs {
+		c := j.coverage[ad]
+		if c == nil || c.since > since || (newBlockTxs && (!c.newBlockTxs || c.newBlockTxsSince > since)) {
+			return nil, j.lastSeq, false
+		}
+	}
+	var events []db.WsJournalEvent
+	for i := 0; i < j.count; i++ {
+		e := &j.events[(j.start+i)%len(j.events)]
+		if e.Seq > since && match(e) {
+			events = append(events, *e)
+		}
+	}
+	return events, j.lastSeq, true
+}
+
+// sequenceAtHeight returns the sequence number of the last new block notification of a block at the height or lower,
+// the lock must be held
+func (j *wsJournal) sequenceAtHeight(height uint32) (uint64, bool) {
+	for i := j.count - 1; i >= 0; i-- {
+		e := &j.events[(j.start+i)%len(j.events)]
+		if e.Kind == wsJournalNewBlock && e.Height <= height {
+			return e.Seq, true
+		}
+	}
+	return 0, false
+}
+
+// persisted returns the journal to be stored on shutdown. Only the block notifications are stored, the notifications
+// of the addresses cannot be replayed after the restart because the mempool is not monitored while Blockbook is stopped.
+func (j *wsJournal) persisted(bestHash string) *db.WsJournal {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	p := &db.WsJournal{LastSeq: j.lastSeq, BestHash: bestHash}
+	for i := 0; i < j.count; i++ {
+		if e := &j.events[(j.start+i)%len(j.events)]; e.Kind == wsJournalNewBlock || e.Kind == wsJournalBlockDisconnected {
+			p.Events = append(p.Events, *e)
+		}
+	}
+	return p
+}
+
+// restore continues the journal stored on shutdown. The stored new block notifications can be replayed only if the best
+// block did not change while Blockbook was stopped. Without the stored journal (the first start or a crash) the numbering
+// starts from the time in microseconds, so that the clients holding the numbers from before the crash get the resync marker.
+func (j *wsJournal) restore(p *db.WsJournal, bestHash string) {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	if p == nil {
+		j.lastSeq = uint64(time.Now().UnixMicro())
+		j.firstSeq = j.lastSeq + 1
+		return
+	}
+	// skip one sequence number to mark the restart, nothing of the addresses is journaled across it
+	j.lastSeq = p.LastSeq + 1
+	j.firstSeq = j.lastSeq + 1
+	if p.BestHash != bestHash {
+		return
+	}
+	events := p.Events
+	if len(events) > len(j.events) {
+		events = events[len(events)-len(j.events):]
+	}
+	j.start = 0
+	j.count = copy(j.events, events)
+	if j.count > 0 {
+		j.firstSeq = j.events[0].Seq
+	}
+}
@@ -0,0 +1,186 @@
+//go:build unittest
+
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/db"
+	"github.com/trezor/blockbook/tests/dbtestdata"
+)
+
+func journalSeqs(events []db.WsJournalEvent) []uint64 {
+	seqs := make([]uint64, len(events))
+	for i := range events {
+		seqs[i] = events[i].Seq
+	}
+	return seqs
+}
+
+func equalSeqs(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWsJournalReplay(t *testing.T) {
+	j := newWsJournal(4, time.Minute)
+	all := func(e *db.WsJournalEvent) bool { return true }
+	j.record(wsJournalNewBlock, 100, "", map[string]int{"height": 100}) // 1
+	j.subscribe("a", false)
+	j.record(wsJournalAddress, 0, "a", "tx1")                           // 2
+	j.record(wsJournalNewBlock, 101, "", map[string]int{"height": 101}) // 3
+
+	events, last, ok := j.replay(1, 0, []string{"a"}, false, all)
+	if !ok || last != 3 || !equalSeqs(journalSeqs(events), []uint64{2, 3}) {
+		t.Errorf("replay(1) = %v, %d, %v", journalSeqs(events), last, ok)
+	}
+	if string(events[0].Data) != `"tx1"` {
+		t.Errorf("replayed data %s", events[0].Data)
+	}
+	// the position given by the height of the last notified block
+	events, _, ok = j.replay(0, 100, []string{"a"}, false, all)
+	if !ok || !equalSeqs(journalSeqs(events), []uint64{2, 3}) {
+		t.Errorf("replay(height 100) = %v, %v", journalSeqs(events), ok)
+	}
+	if _, _, ok = j.replay(0, 99, nil, false, all); ok {
+		t.Error("replay(height 99) did not require resync")
+	}
+	// the confirmed notifications of the address are not journaled
+	if _, _, ok = j.replay(1, 0, []string{"a"}, true, all); ok {
+		t.Error("replay(1) of the confirmed notifications did not require resync")
+	}
+	if _, _, ok = j.replay(1, 0, []string{"b"}, false, all); ok {
+		t.Error("replay(1) of not journaled address did not require resync")
+	}
+	// the address was not journaled before its subscription
+	j.subscribe("b", false)
+	if _, _, ok = j.replay(2, 0, []string{"b"}, false, all); ok {
+		t.Error("replay(2) of the address subscribed later did not require resync")
+	}
+	if _, _, ok = j.replay(4, 0, nil, false, all); ok {
+		t.Error("replay of the future sequence number did not require resync")
+	}
+
+	// the ring drops the oldest notifications
+	j.record(wsJournalAddress, 0, "a", "tx2")                           // 4
+	j.record(wsJournalNewBlock, 102, "", map[string]int{"height": 102}) // 5
+	j.record(wsJournalAddress, 0, "a", "tx3")                           // 6
+	if _, _, ok = j.replay(1, 0, nil, false, all); ok {
+		t.Error("replay of the dropped notification did not require resync")
+	}
+	events, _, ok = j.replay(2, 0, nil, false, func(e *db.WsJournalEvent) bool { return e.Kind == wsJournalNewBlock })
+	if !ok || !equalSeqs(journalSeqs(events), []uint64{3, 5}) {
+		t.Errorf("replay(2) of new blocks = %v, %v", journalSeqs(events), ok)
+	}
+}
+
+func TestWsJournalCoverage(t *testing.T) {
+	j := newWsJournal(10, time.Minute)
+	j.subscribe("a", true)
+	if !j.covers("a", false) || !j.covers("a", true) || !j.coversNewBlockTxs() {
+		t.Fatal("subscribed address is not journaled")
+	}
+	j.unsubscribe("a", true)
+	if !j.covers("a", false) || !j.covers("a", true) {
+		t.Fatal("address is not journaled in the retention period")
+	}
+	j.coverage["a"].expires = time.Now().Add(-time.Second)
+	j.coverage["a"].newBlockTxsExpires = time.Now().Add(-time.Second)
+	if j.covers("a", false) || j.covers("a", true) || j.coversNewBlockTxs() {
+		t.Fatal("address is journaled after the retention period")
+	}
+	j.record(wsJournalNewBlock, 100, "", nil)
+	if len(j.coverage) != 0 {
+		t.Errorf("expired coverage was not removed, %v", j.coverage)
+	}
+	// the resubscribed address is journaled from the resubscription
+	j.record(wsJournalNewBlock, 101, "", nil)
+	j.subscribe("a", false)
+	if _, _, ok := j.replay(0, 101, []string{"a"}, false, func(e *db.WsJournalEvent) bool { return true }); !ok {
+		t.Error("replay from the resubscription required resync")
+	}
+	if _, _, ok := j.replay(0, 100, []string{"a"}, false, func(e *db.WsJournalEvent) bool { return true }); ok {
+		t.Error("replay from before the resubscription did not require resync")
+	}
+}
+
+func TestWsJournalRestore(t *testing.T) {
+	j := newWsJournal(10, time.Minute)
+	j.record(wsJournalNewBlock, 100, "", nil)
+	j.subscribe("a", false)
+	j.record(wsJournalAddress, 0, "a", nil)
+	j.record(wsJournalNewBlock, 101, "", nil)
+	p := j.persisted("hash101")
+	if p.LastSeq != 3 || !equalSeqs(journalSeqs(p.Events), []uint64{1, 3}) {
+		t.Fatalf("persisted() = %+v", p)
+	}
+	newBlocks := func(e *db.WsJournalEvent) bool { return e.Kind == wsJournalNewBlock }
+
+	r := newWsJournal(10, time.Minute)
+	r.restore(p, "hash101")
+	events, last, ok := r.replay(0, 100, nil, false, newBlocks)
+	if !ok || last != 4 || !equalSeqs(journalSeqs(events), []uint64{3}) {
+		t.Errorf("replay(height 100) after restore = %v, %d, %v", journalSeqs(events), last, ok)
+	}
+	r.subscribe("a", false)
+	if _, _, ok = r.replay(3, 0, []string{"a"}, false, newBlocks); ok {
+		t.Error("replay of the address across the restart did not require resync")
+	}
+
+	r = newWsJournal(10, time.Minute)
+	r.restore(p, "hash102")
+	if _, _, ok = r.replay(3, 0, nil, false, newBlocks); ok {
+		t.Error("replay after the best block changed did not require resync")
+	}
+
+	r = newWsJournal(10, time.Minute)
+	r.restore(nil, "hash101")
+	if _, _, ok = r.replay(3, 0, nil, false, newBlocks); ok {
+		t.Error("replay without the stored journal did not require resync")
+	}
+}
+
+func TestSendOnNewTxAddrJournalsWithoutSubscribers(t *testing.T) {
+	parser, _ := setupChain(t)
+	s := &WebsocketServer{
+		chainParser:          parser,
+		addressSubscriptions: make(map[string]map[*websocketChannel]*addressDetails),
+		journal:              newWsJournal(10, time.Minute),
+	}
+	addrDesc, err := parser.GetAddrDescFromAddress(dbtestdata.Addr1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stringAddrDesc := string(addrDesc)
+	s.journal.record(wsJournalNewBlock, 100, "", nil)
+	s.journal.subscribe(stringAddrDesc, false)
+	s.journal.unsubscribe(stringAddrDesc, false)
+
+	s.sendOnNewTxAddr(stringAddrDesc, &api.Tx{Txid: "mempool-tx"}, false)
+	s.sendOnNewTxAddr(stringAddrDesc, &api.Tx{Txid: "new-block-tx"}, true)
+
+	events, _, ok := s.journal.replay(1, 0, []string{stringAddrDesc}, false, func(e *db.WsJournalEvent) bool { return true })
+	if !ok || len(events) != 1 || events[0].Kind != wsJournalAddress {
+		t.Fatalf("replay() = %+v, %v, want the mempool notification", events, ok)
+	}
+	var data struct {
+		Address string  `json:"address"`
+		Tx      *api.Tx `json:"tx"`
+	}
+	if err := json.Unmarshal(events[0].Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Address != dbtestdata.Addr1 || data.Tx.Txid != "mempool-tx" {
+		t.Errorf("journaled notification %s", events[0].Data)
+	}
+}
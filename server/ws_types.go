@@ -9,14 +9,17 @@ import (
 // WsReq represents a generic WebSocket request with an ID, method, and raw parameters.
 type WsReq struct {
 	ID     string          `json:"id" ts_doc:"Unique request identifier."`
-	Method string          `json:"method" ts_type:"'getAccountInfo' | 'getContractInfo' | 'getInfo' | 'getBlockHash'| 'getBlock' | 'getAccountUtxo' | 'getBalanceHistory' | 'getTransaction' | 'getTransactionSpecific' | 'estimateFee' | 'sendTransaction' | 'subscribeNewBlock' | 'unsubscribeNewBlock' | 'subscribeNewTransaction' | 'unsubscribeNewTransaction' | 'subscribeAddresses' | 'unsubscribeAddresses' | 'subscribeFiatRates' | 'unsubscribeFiatRates' | 'ping' | 'getCurrentFiatRates' | 'getFiatRatesForTimestamps' | 'getFiatRatesTickersList' | 'getMempoolFilters'" ts_doc:"Requested method name."`
+	Method string          `json:"method" ts_type:"'getAccountInfo' | 'getContractInfo' | 'getInfo' | 'getBlockHash'| 'getBlock' | 'getAccountUtxo' | 'getBalanceHistory' | 'getTransaction' | 'getTransactionSpecific' | 'estimateFee' | 'sendTransaction' | 'subscribeNewBlock' | 'unsubscribeNewBlock' | 'subscribeNewTransaction' | 'unsubscribeNewTransaction' | 'subscribeAddresses' | 'unsubscribeAddresses' | 'subscribeFiatRates' | 'unsubscribeFiatRates' | 'subscribeMempoolBlocks' | 'unsubscribeMempoolBlocks' | 'ping' | 'getCurrentFiatRates' | 'getFiatRatesForTimestamps' | 'getFiatRatesTickersList' | 'getMempoolFilters' | 'simulateTransaction'" ts_doc:"Requested method name."`
 	Params json.RawMessage `json:"params" ts_type:"any" ts_doc:"Parameters for the requested method in raw JSON format."`
+	// work counts the work of the request charged to the cost budget of the client, nil if it is not charged
+	work *api.RequestWork
 }
 
 // WsRes represents a generic WebSocket response with an ID and arbitrary data.
 type WsRes struct {
 	ID   string      `json:"id" ts_doc:"Corresponding request identifier."`
 	Data interface{} `json:"data" ts_doc:"Payload of the response, structure depends on the request."`
+	Seq  uint64      `json:"seq,omitempty" ts_doc:"Sequence number of the subscription notification, present if the notification journal is enabled."`
 	// release, if non-nil, is invoked exactly once when this response leaves the out pipeline
 	release func()
 }
@@ -35,12 +38,26 @@ type WsAccountInfoReq struct {
 	Protocols         []string `json:"protocols,omitempty" ts_doc:"Optional protocol enrichments to include. Supported values currently include 'erc4626'."`
 	PageSize          int      `json:"pageSize,omitempty" ts_doc:"Number of items per page, if paging is used."`
 	Page              int      `json:"page,omitempty" ts_doc:"Requested page index, if paging is used."`
+	Cursor            string   `json:"cursor,omitempty" ts_doc:"Cursor returned as nextCursor or prevCursor by the previous request, used instead of page."`
 	FromHeight        int      `json:"from,omitempty" ts_doc:"Starting block height for transaction filtering."`
 	ToHeight          int      `json:"to,omitempty" ts_doc:"Ending block height for transaction filtering."`
 	ContractFilter    string   `json:"contractFilter,omitempty" ts_doc:"Filter by specific contract address (for token data)."`
 	SecondaryCurrency string   `json:"secondaryCurrency,omitempty" ts_doc:"Currency code to convert values into (e.g. 'USD')."`
 	Gap               int      `json:"gap,omitempty" ts_doc:"Gap limit for XPUB scanning, if relevant."`
 	ConfirmedNonce    bool     `json:"confirmedNonce,omitempty" ts_doc:"If true, additionally return the confirmed nonce for Ethereum-like addresses (extra backend call)."`
+	Diagnostics       bool     `json:"diagnostics,omitempty" ts_doc:"If true, additionally return the diagnostics of stuck pending transactions for Ethereum-like addresses (extra backend calls)."`
+	Delegation        bool     `json:"delegation,omitempty" ts_doc:"If true, additionally return the EIP-7702 delegation of Ethereum-like addresses (extra backend call)."`
+}
+
+// WsAccountsInfoReq carries parameters for the 'getAccountsInfo' method.
+type WsAccountsInfoReq struct {
+	Descriptors       []string `json:"descriptors" ts_doc:"Addresses or XPUB descriptors to query."`
+	Details           string   `json:"details,omitempty" ts_type:"'basic' | 'tokens' | 'tokenBalances'" ts_doc:"Level of detail to retrieve about the accounts."`
+	Tokens            string   `json:"tokens,omitempty" ts_type:"'derived' | 'used' | 'nonzero'" ts_doc:"Which tokens to include in the account info."`
+	Protocols         []string `json:"protocols,omitempty" ts_doc:"Optional protocol enrichments to include. Supported values currently include 'erc4626'."`
+	ContractFilter    string   `json:"contractFilter,omitempty" ts_doc:"Filter by specific contract address (for token data)."`
+	SecondaryCurrency string   `json:"secondaryCurrency,omitempty" ts_doc:"Currency code to convert values into (e.g. 'USD')."`
+	Gap               int      `json:"gap,omitempty" ts_doc:"Gap limit for XPUB scanning, if relevant."`
 }
 
 // WsContractInfoReq carries parameters for the 'getContractInfo' method.
@@ -106,7 +123,8 @@ type WsBalanceHistoryReq struct {
 
 // WsTransactionReq requests details for a specific transaction by its txid.
 type WsTransactionReq struct {
-	Txid string `json:"txid" ts_doc:"Transaction ID to retrieve details for."`
+	Txid           string `json:"txid" ts_doc:"Transaction ID to retrieve details for."`
+	MempoolPackage bool   `json:"mempoolPackage,omitempty" ts_doc:"Include the ancestor and descendant package data of an unconfirmed transaction (Bitcoin-type)."`
 }
 
 // WsMempoolFiltersReq requests mempool filters for scripts of a specific type, after a given timestamp.
@@ -139,7 +157,7 @@ type WsTransactionSpecificReq struct {
 // WsEstimateFeeReq requests an estimation of transaction fees for a set of blocks or with specific parameters.
 type WsEstimateFeeReq struct {
 	Blocks   []int                  `json:"blocks,omitempty" ts_doc:"Block confirmations targets for which fees should be estimated."`
-	Specific map[string]interface{} `json:"specific,omitempty" ts_type:"{conservative?: boolean; txsize?: number; from?: string; to?: string; data?: string; value?: string;}" ts_doc:"Additional chain-specific parameters (e.g. for Ethereum)."`
+	Specific map[string]interface{} `json:"specific,omitempty" ts_type:"{conservative?: boolean; txsize?: number; provider?: 'mempool'; from?: string; to?: string; data?: string; value?: string;}" ts_doc:"Additional chain-specific parameters (e.g. for Ethereum)."`
 }
 
 // WsEstimateFeeRes is returned in response to a fee estimation request.
@@ -147,7 +165,59 @@ type WsEstimateFeeRes struct {
 	FeePerTx   string           `json:"feePerTx,omitempty" ts_doc:"Estimated total fee per transaction, if relevant."`
 	FeePerUnit string           `json:"feePerUnit,omitempty" ts_doc:"Estimated fee per unit (sat/byte, Wei/gas, etc.)."`
 	FeeLimit   string           `json:"feeLimit,omitempty" ts_doc:"Max fee limit for blockchains like Ethereum."`
+	L1Fee      string           `json:"l1Fee,omitempty" ts_doc:"Estimated L1 data fee on rollup networks, included in feePerTx."`
 	Eip1559    *api.Eip1559Fees `json:"eip1559,omitempty"`
+	Confidence *WsFeeConfidence `json:"confidence,omitempty" ts_doc:"Fees per unit for the confidence levels, returned by the built-in mempool fee estimator."`
+}
+
+// WsMempoolBlocks is pushed to subscribeMempoolBlocks subscribers after the mempool resync.
+type WsMempoolBlocks struct {
+	Blocks    *api.MempoolBlocks       `json:"blocks" ts_doc:"Next blocks projected from the mempool."`
+	Histogram *api.MempoolFeeHistogram `json:"histogram" ts_doc:"Histogram of the effective fee rates of the mempool transactions."`
+}
+
+// WsTxReplaced describes a mempool transaction replaced by another one.
+type WsTxReplaced struct {
+	Txid       string `json:"txid" ts_doc:"Replaced mempool transaction."`
+	ReplacedBy string `json:"replacedBy" ts_doc:"Transaction which replaced it (RBF or the same nonce)."`
+}
+
+// WsAddressTxReplaced is sent to subscribeAddresses subscribers when a mempool transaction of the address is replaced.
+type WsAddressTxReplaced struct {
+	Address    string        `json:"address" ts_doc:"Subscribed address involved in the replaced transaction."`
+	TxReplaced *WsTxReplaced `json:"txReplaced"`
+}
+
+// WsDoubleSpend describes a mempool transaction paying the address conflicted by a transaction which does not pay it.
+type WsDoubleSpend struct {
+	Txid            string `json:"txid" ts_doc:"Conflicted transaction paying the address."`
+	ConflictingTxid string `json:"conflictingTxid" ts_doc:"Transaction spending the same inputs, in mempool or confirmed."`
+}
+
+// WsAddressDoubleSpend is sent to subscribeAddresses subscribers when an unconfirmed payment to the address is double spent.
+type WsAddressDoubleSpend struct {
+	Address     string         `json:"address" ts_doc:"Subscribed address paid by the conflicted transaction."`
+	DoubleSpend *WsDoubleSpend `json:"doubleSpend"`
+}
+
+// WsTxDropped describes a transaction which left the mempool without being confirmed.
+type WsTxDropped struct {
+	Txid            string `json:"txid" ts_doc:"Dropped transaction."`
+	ConflictingTxid string `json:"conflictingTxid,omitempty" ts_doc:"Confirmed transaction spending the same inputs, if known."`
+}
+
+// WsAddressTxDropped is sent to subscribeAddresses subscribers when a mempool transaction of the address is dropped.
+type WsAddressTxDropped struct {
+	Address   string       `json:"address" ts_doc:"Subscribed address involved in the dropped transaction."`
+	TxDropped *WsTxDropped `json:"txDropped"`
+}
+
+// WsFeeConfidence holds the fees per unit with low, medium and high confidence of the confirmation in the requested number of blocks.
+type WsFeeConfidence struct {
+	Blocks int    `json:"blocks" ts_doc:"Confirmation target the fees were estimated for, can differ from the requested one."`
+	Low    string `json:"low" ts_doc:"Fee per unit which is likely to confirm if the mempool does not grow."`
+	Medium string `json:"medium" ts_doc:"Fee per unit which is expected to confirm."`
+	High   string `json:"high" ts_doc:"Fee per unit which is expected to confirm even if the mempool grows or the recent blocks were expensive."`
 }
 
 // EthereumGasData carries EVM block-level gas figures used by the frontend to deterministically
@@ -165,6 +235,31 @@ type WsNewBlock struct {
 	EVMData *EthereumGasData `json:"evmData" ts_doc:"EVM gas data for the EIP-1559 base-fee projection; null on non-EVM chains."`
 }
 
+// WsDisconnectedBlock describes a block disconnected by a reorg.
+type WsDisconnectedBlock struct {
+	Height uint32   `json:"height" ts_doc:"Height of the disconnected block."`
+	Hash   string   `json:"hash" ts_doc:"Hash of the disconnected block."`
+	Txids  []string `json:"txids" ts_doc:"Transactions of the disconnected block, they lost their confirmation."`
+}
+
+// WsBlockDisconnected is pushed to subscribeNewBlock subscribers when a reorg disconnects blocks, before the blocks of the new chain are announced.
+type WsBlockDisconnected struct {
+	BlockDisconnected []WsDisconnectedBlock `json:"blockDisconnected" ts_doc:"Disconnected blocks ordered from the highest."`
+}
+
+// WsTxUnconfirmed describes a transaction which lost its confirmation in a reorg.
+type WsTxUnconfirmed struct {
+	Txid   string `json:"txid" ts_doc:"Transaction of the disconnected block."`
+	Height uint32 `json:"height" ts_doc:"Height of the disconnected block."`
+	Hash   string `json:"hash" ts_doc:"Hash of the disconnected block."`
+}
+
+// WsAddressTxUnconfirmed is sent to subscribeAddresses subscribers when a confirmed transaction of the address loses its confirmation in a reorg.
+type WsAddressTxUnconfirmed struct {
+	Address       string           `json:"address" ts_doc:"Subscribed address involved in the transaction."`
+	TxUnconfirmed *WsTxUnconfirmed `json:"txUnconfirmed"`
+}
+
 // WsLongTermFeeRateRes is returned in response to a long term fee rate request.
 type WsLongTermFeeRateRes struct {
 	FeePerUnit string `json:"feePerUnit" ts_doc:"Long term fee rate (in sat/kByte)."`
@@ -179,8 +274,24 @@ type WsSendTransactionReq struct {
 
 // WsSubscribeAddressesReq is used to subscribe to updates on a list of addresses.
 type WsSubscribeAddressesReq struct {
-	Addresses   []string `json:"addresses" ts_doc:"List of addresses to subscribe for updates (e.g., new transactions)."`
-	NewBlockTxs bool     `json:"newBlockTxs,omitempty" ts_doc:"If true, also publish confirmed transactions for subscribed addresses when new blocks are connected."`
+	Addresses     []string `json:"addresses" ts_doc:"List of addresses to subscribe for updates (e.g., new transactions)."`
+	NewBlockTxs   bool     `json:"newBlockTxs,omitempty" ts_doc:"If true, also publish confirmed transactions for subscribed addresses when new blocks are connected."`
+	SinceSequence uint64   `json:"sinceSequence,omitempty" ts_doc:"Sequence number of the last notification received before the reconnect, the missed notifications are replayed."`
+	SinceHeight   uint32   `json:"sinceHeight,omitempty" ts_doc:"Height of the last block notified before the reconnect, used if sinceSequence is not set."`
+}
+
+// WsSubscribeNewBlockReq carries the optional parameters of the 'subscribeNewBlock' method.
+type WsSubscribeNewBlockReq struct {
+	SinceSequence uint64 `json:"sinceSequence,omitempty" ts_doc:"Sequence number of the last notification received before the reconnect, the missed notifications are replayed."`
+	SinceHeight   uint32 `json:"sinceHeight,omitempty" ts_doc:"Height of the last block notified before the reconnect, used if sinceSequence is not set."`
+}
+
+// WsSubscriptionRes is the response to subscribeNewBlock and subscribeAddresses if the notification journal is enabled.
+type WsSubscriptionRes struct {
+	Subscribed bool   `json:"subscribed" ts_doc:"True if the subscription is active."`
+	Sequence   uint64 `json:"sequence" ts_doc:"Sequence number of the last notification at the time of the subscription."`
+	Replayed   int    `json:"replayed,omitempty" ts_doc:"Number of the missed notifications which follow this response."`
+	Resync     bool   `json:"resync,omitempty" ts_doc:"True if the missed notifications are no longer available, the client must reload the state of the subscribed addresses or blocks."`
 }
 
 // WsSubscribeFiatRatesReq subscribes to updates of fiat rates for a specific currency or set of tokens.
@@ -208,6 +319,12 @@ type WsFiatRatesTickersListReq struct {
 	Token     string `json:"token,omitempty" ts_doc:"Token symbol or ID if asking for token-specific fiat rates."`
 }
 
+// WsSimulateTransactionReq is used to simulate an unsigned transaction on an Ethereum-like backend.
+type WsSimulateTransactionReq struct {
+	Tx    map[string]interface{} `json:"tx" ts_type:"{ from: string; to?: string; value?: string; data?: string; gas?: string; gasPrice?: string; maxFeePerGas?: string; maxPriorityFeePerGas?: string; stateOverrides?: Record<string, unknown> }" ts_doc:"Transaction to simulate, the numeric fields are hex encoded."`
+	Block string                 `json:"block,omitempty" ts_doc:"Block to simulate against: latest (default) or pending."`
+}
+
 // WsRpcCallReq is used for raw RPC calls (for example, on an Ethereum-like backend).
 type WsRpcCallReq struct {
 	From string `json:"from,omitempty" ts_doc:"Address from which the RPC call is originated (if relevant)."`
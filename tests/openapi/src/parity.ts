@@ -58,12 +58,28 @@ const _EthereumInternalTransfer: Compat<Bb.EthereumInternalTransfer, Schemas["Et
 const _EthereumParsedInputParam: Compat<Bb.EthereumParsedInputParam, Schemas["EthereumParsedInputParam"], "EthereumParsedInputParam"> = true;
 const _EthereumParsedInputData: Compat<Bb.EthereumParsedInputData, Schemas["EthereumParsedInputData"], "EthereumParsedInputData"> = true;
 const _EthereumSpecific: Compat<Bb.EthereumSpecific, Schemas["EthereumSpecific"], "EthereumSpecific"> = true;
+const _EthereumUserOperation: Compat<Bb.EthereumUserOperation, Schemas["EthereumUserOperation"], "EthereumUserOperation"> = true;
+const _EthereumAuthorization: Compat<Bb.EthereumAuthorization, Schemas["EthereumAuthorization"], "EthereumAuthorization"> = true;
+const _EthereumWithdrawal: Compat<Bb.EthereumWithdrawal, Schemas["EthereumWithdrawal"], "EthereumWithdrawal"> = true;
+const _EthereumNonceGap: Compat<Bb.EthereumNonceGap, Schemas["EthereumNonceGap"], "EthereumNonceGap"> = true;
+const _EthereumReplacementFees: Compat<Bb.EthereumReplacementFees, Schemas["EthereumReplacementFees"], "EthereumReplacementFees"> = true;
+const _EthereumPendingTxDiagnostics: Compat<Bb.EthereumPendingTxDiagnostics, Schemas["EthereumPendingTxDiagnostics"], "EthereumPendingTxDiagnostics"> = true;
+const _EthereumAccountDiagnostics: Compat<Bb.EthereumAccountDiagnostics, Schemas["EthereumAccountDiagnostics"], "EthereumAccountDiagnostics"> = true;
 
 const _TxChainExtraData: Compat<Bb.TxChainExtraData, Schemas["TxChainExtraData"], "TxChainExtraData"> = true;
 const _AccountChainExtraData: Compat<Bb.AccountChainExtraData, Schemas["AccountChainExtraData"], "AccountChainExtraData"> = true;
 
 const _Tx: Compat<Bb.Tx, Schemas["Tx"], "Tx"> = true;
 const _FeeStats: Compat<Bb.FeeStats, Schemas["FeeStats"], "FeeStats"> = true;
+const _MempoolBlock: Compat<Bb.MempoolBlock, Schemas["MempoolBlock"], "MempoolBlock"> = true;
+const _MempoolBlocks: Compat<Bb.MempoolBlocks, Schemas["MempoolBlocks"], "MempoolBlocks"> = true;
+const _MempoolFeeHistogramBucket: Compat<Bb.MempoolFeeHistogramBucket, Schemas["MempoolFeeHistogramBucket"], "MempoolFeeHistogramBucket"> = true;
+const _MempoolFeeHistogram: Compat<Bb.MempoolFeeHistogram, Schemas["MempoolFeeHistogram"], "MempoolFeeHistogram"> = true;
+const _MempoolPackage: Compat<Bb.MempoolPackage, Schemas["MempoolPackage"], "MempoolPackage"> = true;
+const _FeeBumpCpfpOutput: Compat<Bb.FeeBumpCpfpOutput, Schemas["FeeBumpCpfpOutput"], "FeeBumpCpfpOutput"> = true;
+const _FeeBumpCpfp: Compat<Bb.FeeBumpCpfp, Schemas["FeeBumpCpfp"], "FeeBumpCpfp"> = true;
+const _FeeBumpRbf: Compat<Bb.FeeBumpRbf, Schemas["FeeBumpRbf"], "FeeBumpRbf"> = true;
+const _FeeBump: Compat<Bb.FeeBump, Schemas["FeeBump"], "FeeBump"> = true;
 
 const _Erc4626TokenMetadata: Compat<Bb.Erc4626TokenMetadata, Schemas["Erc4626TokenMetadata"], "Erc4626TokenMetadata"> = true;
 const _Erc4626Token: Compat<Bb.Erc4626Token, Schemas["Erc4626Token"], "Erc4626Token"> = true;
@@ -74,20 +90,27 @@ const _ContractInfoResult: Compat<Bb.ContractInfoResult, Schemas["ContractInfoRe
 const _Token: Compat<Bb.Token, Schemas["Token"], "Token"> = true;
 const _StakingPool: Compat<Bb.StakingPool, Schemas["StakingPool"], "StakingPool"> = true;
 const _Address: Compat<Bb.Address, Schemas["Address"], "Address"> = true;
+const _AccountsInfoEntry: Compat<Bb.AccountsInfoEntry, Schemas["AccountsInfoEntry"], "AccountsInfoEntry"> = true;
+const _AccountsInfo: Compat<Bb.AccountsInfo, Schemas["AccountsInfo"], "AccountsInfo"> = true;
 
 const _Utxo: Compat<Bb.Utxo, Schemas["Utxo"], "Utxo"> = true;
 const _BalanceHistory: Compat<Bb.BalanceHistory, Schemas["BalanceHistory"], "BalanceHistory"> = true;
 const _Block: Compat<Bb.Block, Schemas["Block"], "Block"> = true;
 const _BlockRaw: Compat<Bb.BlockRaw, Schemas["BlockRaw"], "BlockRaw"> = true;
+const _ChainEvent: Compat<Bb.ChainEvent, Schemas["ChainEvent"], "ChainEvent"> = true;
+const _ChainEvents: Compat<Bb.ChainEvents, Schemas["ChainEvents"], "ChainEvents"> = true;
 
 const _BackendInfo: Compat<Bb.BackendInfo, Schemas["BackendInfo"], "BackendInfo"> = true;
 const _InternalStateColumn: Compat<Bb.InternalStateColumn, Schemas["InternalStateColumn"], "InternalStateColumn"> = true;
+const _FourByteSignaturesState: Compat<Bb.FourByteSignaturesState, Schemas["FourByteSignaturesState"], "FourByteSignaturesState"> = true;
 const _BlockbookInfo: Compat<Bb.BlockbookInfo, Schemas["BlockbookInfo"], "BlockbookInfo"> = true;
 const _SystemInfo: Compat<Bb.SystemInfo, Schemas["SystemInfo"], "SystemInfo"> = true;
 
 const _FiatTicker: Compat<Bb.FiatTicker, Schemas["FiatTicker"], "FiatTicker"> = true;
 const _FiatTickers: Compat<Bb.FiatTickers, Schemas["FiatTickers"], "FiatTickers"> = true;
 const _AvailableVsCurrencies: Compat<Bb.AvailableVsCurrencies, Schemas["AvailableVsCurrencies"], "AvailableVsCurrencies"> = true;
+const _SimulatedBalanceChange: Compat<Bb.SimulatedBalanceChange, Schemas["SimulatedBalanceChange"], "SimulatedBalanceChange"> = true;
+const _SimulatedTx: Compat<Bb.SimulatedTx, Schemas["SimulatedTx"], "SimulatedTx"> = true;
 
 // WebSocket envelopes: `params`/`data` are `any` in Go (typescriptify cannot
 // see through the interface{} runtime discriminator), but the YAML enumerates
@@ -97,6 +120,7 @@ const _WsReq: Compat<Bb.WsReq, Schemas["WsRequest"], "WsRequest"> = true;
 const _WsRes: Compat<Bb.WsRes, Schemas["WsResponse"], "WsResponse"> = true;
 
 const _WsAccountInfoReq: Compat<Bb.WsAccountInfoReq, Schemas["WsAccountInfoReq"], "WsAccountInfoReq"> = true;
+const _WsAccountsInfoReq: Compat<Bb.WsAccountsInfoReq, Schemas["WsAccountsInfoReq"], "WsAccountsInfoReq"> = true;
 const _WsContractInfoReq: Compat<Bb.WsContractInfoReq, Schemas["WsContractInfoReq"], "WsContractInfoReq"> = true;
 const _WsBackendInfo: Compat<Bb.WsBackendInfo, Schemas["WsBackendInfo"], "WsBackendInfo"> = true;
 const _WsInfoRes: Compat<Bb.WsInfoRes, Schemas["WsInfoRes"], "WsInfoRes"> = true;
@@ -112,11 +136,28 @@ const _WsTransactionSpecificReq: Compat<Bb.WsTransactionSpecificReq, Schemas["Ws
 const _WsEstimateFeeReq: Compat<Bb.WsEstimateFeeReq, Schemas["WsEstimateFeeReq"], "WsEstimateFeeReq"> = true;
 const _Eip1559Fee: Compat<Bb.Eip1559Fee, Schemas["Eip1559Fee"], "Eip1559Fee"> = true;
 const _Eip1559Fees: Compat<Bb.Eip1559Fees, Schemas["Eip1559Fees"], "Eip1559Fees"> = true;
+const _WsFeeConfidence: Compat<Bb.WsFeeConfidence, Schemas["WsFeeConfidence"], "WsFeeConfidence"> = true;
 const _WsEstimateFeeRes: Compat<Bb.WsEstimateFeeRes, Schemas["WsEstimateFeeRes"], "WsEstimateFeeRes"> = true;
 const _EthereumGasData: Compat<Bb.EthereumGasData, Schemas["EthereumGasData"], "EthereumGasData"> = true;
 const _WsNewBlock: Compat<Bb.WsNewBlock, Schemas["WsNewBlock"], "WsNewBlock"> = true;
+const _WsDisconnectedBlock: Compat<Bb.WsDisconnectedBlock, Schemas["WsDisconnectedBlock"], "WsDisconnectedBlock"> = true;
+const _WsBlockDisconnected: Compat<Bb.WsBlockDisconnected, Schemas["WsBlockDisconnected"], "WsBlockDisconnected"> = true;
+const _WsTxUnconfirmed: Compat<Bb.WsTxUnconfirmed, Schemas["WsTxUnconfirmed"], "WsTxUnconfirmed"> = true;
+const _WsAddressTxUnconfirmed: Compat<Bb.WsAddressTxUnconfirmed, Schemas["WsAddressTxUnconfirmed"], "WsAddressTxUnconfirmed"> = true;
+const _WsMempoolBlocks: Compat<Bb.WsMempoolBlocks, Schemas["WsMempoolBlocks"], "WsMempoolBlocks"> = true;
+const _WsTxReplaced: Compat<Bb.WsTxReplaced, Schemas["WsTxReplaced"], "WsTxReplaced"> = true;
+const _WsAddressTxReplaced: Compat<Bb.WsAddressTxReplaced, Schemas["WsAddressTxReplaced"], "WsAddressTxReplaced"> = true;
+const _WsDoubleSpend: Compat<Bb.WsDoubleSpend, Schemas["WsDoubleSpend"], "WsDoubleSpend"> = true;
+const _WsAddressDoubleSpend: Compat<Bb.WsAddressDoubleSpend, Schemas["WsAddressDoubleSpend"], "WsAddressDoubleSpend"> = true;
+const _WsTxDropped: Compat<Bb.WsTxDropped, Schemas["WsTxDropped"], "WsTxDropped"> = true;
+const _WsAddressTxDropped: Compat<Bb.WsAddressTxDropped, Schemas["WsAddressTxDropped"], "WsAddressTxDropped"> = true;
+const _DroppedTx: Compat<Bb.DroppedTx, Schemas["DroppedTx"], "DroppedTx"> = true;
+const _BroadcastTx: Compat<Bb.BroadcastTx, Schemas["BroadcastTx"], "BroadcastTx"> = true;
 const _WsSendTransactionReq: Compat<Bb.WsSendTransactionReq, Schemas["WsSendTransactionReq"], "WsSendTransactionReq"> = true;
 const _WsSubscribeAddressesReq: Compat<Bb.WsSubscribeAddressesReq, Schemas["WsSubscribeAddressesReq"], "WsSubscribeAddressesReq"> = true;
+const _WsSubscribeNewBlockReq: Compat<Bb.WsSubscribeNewBlockReq, Schemas["WsSubscribeNewBlockReq"], "WsSubscribeNewBlockReq"> = true;
+const _WsSubscriptionRes: Compat<Bb.WsSubscriptionRes, Schemas["WsSubscriptionRes"], "WsSubscriptionRes"> = true;
+const _SseSubscriptionRes: Compat<Bb.SseSubscriptionRes, Schemas["SseSubscriptionRes"], "SseSubscriptionRes"> = true;
 const _WsSubscribeFiatRatesReq: Compat<Bb.WsSubscribeFiatRatesReq, Schemas["WsSubscribeFiatRatesReq"], "WsSubscribeFiatRatesReq"> = true;
 const _WsCurrentFiatRatesReq: Compat<Bb.WsCurrentFiatRatesReq, Schemas["WsCurrentFiatRatesReq"], "WsCurrentFiatRatesReq"> = true;
 const _WsFiatRatesForTimestampsReq: Compat<Bb.WsFiatRatesForTimestampsReq, Schemas["WsFiatRatesForTimestampsReq"], "WsFiatRatesForTimestampsReq"> = true;
@@ -124,6 +165,7 @@ const _WsFiatRatesTickersListReq: Compat<Bb.WsFiatRatesTickersListReq, Schemas["
 const _WsMempoolFiltersReq: Compat<Bb.WsMempoolFiltersReq, Schemas["WsMempoolFiltersReq"], "WsMempoolFiltersReq"> = true;
 const _WsRpcCallReq: Compat<Bb.WsRpcCallReq, Schemas["WsRpcCallReq"], "WsRpcCallReq"> = true;
 const _WsRpcCallRes: Compat<Bb.WsRpcCallRes, Schemas["WsRpcCallRes"], "WsRpcCallRes"> = true;
+const _WsSimulateTransactionReq: Compat<Bb.WsSimulateTransactionReq, Schemas["WsSimulateTransactionReq"], "WsSimulateTransactionReq"> = true;
 
 const _MempoolTxidFilterEntries: Compat<Bb.MempoolTxidFilterEntries, Schemas["MempoolTxidFilterEntries"], "MempoolTxidFilterEntries"> = true;
 
@@ -141,22 +183,25 @@ const _MempoolTxidFilterEntries: Compat<Bb.MempoolTxidFilterEntries, Schemas["Me
 // type-side errors. `void` references keep tsc happy without runtime effect.
 void [
   _AddressAlias, _MultiTokenValue, _TokenTransfer, _Vin, _Vout,
-  _EthereumInternalTransfer, _EthereumParsedInputParam, _EthereumParsedInputData, _EthereumSpecific,
+  _EthereumInternalTransfer, _EthereumParsedInputParam, _EthereumParsedInputData, _EthereumSpecific, _EthereumUserOperation, _EthereumAuthorization, _EthereumWithdrawal,
+  _EthereumNonceGap, _EthereumReplacementFees, _EthereumPendingTxDiagnostics, _EthereumAccountDiagnostics,
   _TxChainExtraData, _AccountChainExtraData,
-  _Tx, _FeeStats,
+  _Tx, _FeeStats, _MempoolBlock, _MempoolBlocks, _MempoolFeeHistogramBucket, _MempoolFeeHistogram,
+  _MempoolPackage, _FeeBumpCpfpOutput, _FeeBumpCpfp, _FeeBumpRbf, _FeeBump,
   _Erc4626TokenMetadata, _Erc4626Token, _ContractInfoProtocols, _ContractInfoRates, _ContractInfoResult,
-  _Token, _StakingPool, _Address,
-  _Utxo, _BalanceHistory, _Block, _BlockRaw,
-  _BackendInfo, _InternalStateColumn, _BlockbookInfo, _SystemInfo,
-  _FiatTicker, _FiatTickers, _AvailableVsCurrencies,
+  _Token, _StakingPool, _Address, _AccountsInfoEntry, _AccountsInfo,
+  _Utxo, _BalanceHistory, _Block, _BlockRaw, _ChainEvent, _ChainEvents,
+  _BackendInfo, _InternalStateColumn, _FourByteSignaturesState, _BlockbookInfo, _SystemInfo,
+  _FiatTicker, _FiatTickers, _AvailableVsCurrencies, _SimulatedBalanceChange, _SimulatedTx,
   _WsReq, _WsRes,
-  _WsAccountInfoReq, _WsContractInfoReq, _WsBackendInfo, _WsInfoRes,
+  _WsAccountInfoReq, _WsAccountsInfoReq, _WsContractInfoReq, _WsBackendInfo, _WsInfoRes,
   _WsBlockHashReq, _WsBlockHashRes, _WsBlockReq, _WsBlockFilterReq, _WsBlockFiltersBatchReq,
   _WsAccountUtxoReq, _WsBalanceHistoryReq, _WsTransactionReq, _WsTransactionSpecificReq,
-  _WsEstimateFeeReq, _Eip1559Fee, _Eip1559Fees, _WsEstimateFeeRes,
-  _EthereumGasData, _WsNewBlock,
-  _WsSendTransactionReq, _WsSubscribeAddressesReq, _WsSubscribeFiatRatesReq,
+  _WsEstimateFeeReq, _Eip1559Fee, _Eip1559Fees, _WsFeeConfidence, _WsEstimateFeeRes,
+  _EthereumGasData, _WsNewBlock, _WsDisconnectedBlock, _WsBlockDisconnected, _WsTxUnconfirmed, _WsAddressTxUnconfirmed, _WsMempoolBlocks, _WsTxReplaced, _WsAddressTxReplaced,
+  _WsDoubleSpend, _WsAddressDoubleSpend, _WsTxDropped, _WsAddressTxDropped, _DroppedTx, _BroadcastTx,
+  _WsSendTransactionReq, _WsSubscribeAddressesReq, _WsSubscribeNewBlockReq, _WsSubscriptionRes, _SseSubscriptionRes, _WsSubscribeFiatRatesReq,
   _WsCurrentFiatRatesReq, _WsFiatRatesForTimestampsReq, _WsFiatRatesTickersListReq,
-  _WsMempoolFiltersReq, _WsRpcCallReq, _WsRpcCallRes,
+  _WsMempoolFiltersReq, _WsRpcCallReq, _WsRpcCallRes, _WsSimulateTransactionReq,
   _MempoolTxidFilterEntries,
 ];